@@ -10,13 +10,22 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/airports"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/cache"
+	cacheRepository "github.com/edinstance/distributed-aviation-system/services/flights/internal/cache/repositories/flights"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/config"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database"
+	flightRepository "github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/flights"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/flights"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/identity"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/kafka"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pubsub"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/server"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/tlsconfig"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/tracing"
 	"github.com/joho/godotenv"
 	"golang.org/x/net/http2"
@@ -58,6 +67,19 @@ func main() {
 		_ = shutdownMetrics(ctx)
 	}()
 
+	if err := airports.Init(); err != nil {
+		logger.Error("failed to load airports", "err", err)
+		os.Exit(1)
+	}
+
+	verifier, err := identity.NewVerifier(config.App)
+	if err != nil {
+		logger.Error("failed to init identity verifier", "err", err)
+		os.Exit(1)
+	}
+	middleware.SetIdentityVerifier(verifier)
+	logger.Info("Identity mode configured", "mode", config.App.IdentityMode)
+
 	pool, err := database.Init(config.App.DatabaseURL)
 	if err != nil {
 		logger.Error("Failed to initialise database", "err", err)
@@ -86,7 +108,116 @@ func main() {
 	}
 	defer kafkaPublisher.Close()
 
-	mux := server.NewMux(pool, cacheClient, kafkaPublisher)
+	relayCtx, stopRelay := context.WithCancel(ctx)
+	relayDone := make(chan struct{})
+	relay := kafka.NewOutboxRelay(outbox.NewOutboxRepository(pool), kafkaPublisher, config.App.OutboxPollInterval, config.App.OutboxBatchSize, config.App.OutboxMaxAttempts)
+	go func() {
+		defer close(relayDone)
+		relay.Run(relayCtx)
+	}()
+	defer func() {
+		stopRelay()
+		<-relayDone
+	}()
+
+	// Flight updates reach subscribers on every replica through Redis.
+	flightUpdates := pubsub.NewBroker(cacheClient)
+	updatesCtx, stopUpdates := context.WithCancel(ctx)
+	updatesDone := make(chan struct{})
+	go func() {
+		defer close(updatesDone)
+		flightUpdates.Run(updatesCtx)
+	}()
+	defer func() {
+		stopUpdates()
+		<-updatesDone
+	}()
+
+	// Every committed change is published from the outbox, so none is lost when this
+	// process stops before announcing it.
+	changeRelayCtx, stopChangeRelay := context.WithCancel(ctx)
+	changeRelayDone := make(chan struct{})
+	changeRelay := pubsub.NewChangeRelay(outbox.NewOutboxRepository(pool), flightRepository.NewFlightRepository(pool), flightUpdates, config.App.UpdatesPollInterval, config.App.OutboxBatchSize)
+	go func() {
+		defer close(changeRelayDone)
+		changeRelay.Run(changeRelayCtx)
+	}()
+	defer func() {
+		stopChangeRelay()
+		<-changeRelayDone
+	}()
+
+	backgroundRepo := flightRepository.NewFlightRepository(pool)
+	backgroundRepo.MinTurnaround = config.App.MinTurnaround
+
+	// The materializer and the aircraft events consumer never validate aircraft, so
+	// they run without an aircraft client.
+	backgroundService := flights.NewFlightsService(
+		backgroundRepo,
+		cacheRepository.NewRedisFlightRepository(cacheClient, config.App.CacheTTL),
+		nil,
+	)
+	backgroundService.ScheduleHorizon = config.App.ScheduleHorizon
+	backgroundService.Updates = flightUpdates
+
+	materializerCtx, stopMaterializer := context.WithCancel(ctx)
+	materializerDone := make(chan struct{})
+	materializer := flights.NewScheduleMaterializer(backgroundService, config.App.ScheduleInterval)
+	go func() {
+		defer close(materializerDone)
+		materializer.Run(materializerCtx)
+	}()
+	defer func() {
+		stopMaterializer()
+		<-materializerDone
+	}()
+
+	backfillerCtx, stopBackfiller := context.WithCancel(ctx)
+	backfillerDone := make(chan struct{})
+	backfiller := flights.NewAirlineBackfiller(backgroundService, config.App.BackfillInterval)
+	go func() {
+		defer close(backfillerDone)
+		backfiller.Run(backfillerCtx)
+	}()
+	defer func() {
+		stopBackfiller()
+		<-backfillerDone
+	}()
+
+	aircraftClient, err := server.NewAircraftClient()
+	if err != nil {
+		logger.Error("Failed to create aircraft client", "err", err)
+		os.Exit(1)
+	}
+	defer func() { _ = aircraftClient.Close() }()
+
+	aircraftConsumer, err := kafka.NewAircraftEventsConsumer(
+		config.App.KafkaBrokerURL,
+		config.App.KafkaSchemaRegistryURL,
+		config.App.KafkaConsumerGroup,
+		config.App.KafkaAircraftTopic,
+		config.App.KafkaAircraftDLQTopic,
+		backgroundService,
+		aircraftClient,
+	)
+	if err != nil {
+		logger.Error("Failed to initialise Kafka consumer", "err", err)
+		os.Exit(1)
+	}
+	defer aircraftConsumer.Close()
+
+	consumerCtx, stopConsumer := context.WithCancel(ctx)
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		aircraftConsumer.Run(consumerCtx)
+	}()
+	defer func() {
+		stopConsumer()
+		<-consumerDone
+	}()
+
+	mux := server.NewMux(pool, cacheClient, aircraftClient, flightUpdates)
 
 	port := config.App.Port
 	if port == "" {
@@ -102,11 +233,29 @@ func main() {
 		IdleTimeout:  120 * time.Second,
 	}
 
-	logger.Info("FlightsService listening", "addr", addr)
+	// Plaintext h2c stays the default for local docker-compose; with a certificate
+	// configured, HTTP/2 is negotiated over TLS instead.
+	if config.App.TLSEnabled() {
+		tlsConfig, err := tlsconfig.Server(config.App.TLSCertFile, config.App.TLSKeyFile, config.App.TLSReloadInterval)
+		if err != nil {
+			logger.Error("Failed to load TLS certificate", "err", err)
+			os.Exit(1)
+		}
+		srv.Handler = mux
+		srv.TLSConfig = tlsConfig
+	}
+
+	logger.Info("FlightsService listening", "addr", addr, "tls", srv.TLSConfig != nil)
 	logger.Debug("Environment", "env", config.App.Environment)
 
 	go func() {
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if srv.TLSConfig != nil {
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Error("Server error", "err", err)
 		}
 	}()
@@ -0,0 +1,129 @@
+// Package airports holds the reference data for the airports flights may operate
+// between. The dataset is embedded in the binary and parsed once, at startup.
+package airports
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	// Embedded so airport time zones resolve in images without a zoneinfo database.
+	_ "time/tzdata"
+)
+
+//go:embed airports.csv
+var dataset string
+
+// Airport is an airport from the embedded dataset. Country is an ISO 3166-1 alpha-2
+// code and TimeZone an IANA zone name.
+type Airport struct {
+	IATA      string
+	ICAO      string
+	Name      string
+	City      string
+	Country   string
+	Latitude  float64
+	Longitude float64
+	TimeZone  string
+
+	location *time.Location
+}
+
+// LocalTime returns t in the airport's time zone.
+func (a *Airport) LocalTime(t time.Time) time.Time {
+	return t.In(a.location)
+}
+
+var load = sync.OnceValues(func() (map[string]*Airport, error) {
+	return parse(strings.NewReader(dataset))
+})
+
+// Init parses the embedded dataset, so a malformed dataset stops the service at
+// startup rather than failing validation later.
+func Init() error {
+	_, err := load()
+	return err
+}
+
+// Lookup returns the airport with the normalised IATA code, if there is one.
+func Lookup(code string) (*Airport, bool) {
+	byCode, err := load()
+	if err != nil {
+		return nil, false
+	}
+	airport, ok := byCode[code]
+	return airport, ok
+}
+
+// LocalTime returns t in the time zone of the airport with the IATA code, or false if
+// the code is not a known airport.
+func LocalTime(code string, t time.Time) (time.Time, bool) {
+	airport, ok := Lookup(code)
+	if !ok {
+		return time.Time{}, false
+	}
+	return airport.LocalTime(t), true
+}
+
+// parse reads a dataset whose header is
+// iata,icao,name,city,country,latitude,longitude,time_zone.
+func parse(r io.Reader) (map[string]*Airport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 8
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read airports: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("read airports: dataset is empty")
+	}
+
+	byCode := make(map[string]*Airport, len(records)-1)
+	for i, record := range records[1:] {
+		line := i + 2
+		airport, err := parseAirport(record)
+		if err != nil {
+			return nil, fmt.Errorf("read airports: line %d: %w", line, err)
+		}
+		if _, ok := byCode[airport.IATA]; ok {
+			return nil, fmt.Errorf("read airports: line %d: duplicate IATA code %s", line, airport.IATA)
+		}
+		byCode[airport.IATA] = airport
+	}
+	return byCode, nil
+}
+
+func parseAirport(record []string) (*Airport, error) {
+	latitude, err := strconv.ParseFloat(record[5], 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return nil, fmt.Errorf("invalid latitude %q", record[5])
+	}
+	longitude, err := strconv.ParseFloat(record[6], 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return nil, fmt.Errorf("invalid longitude %q", record[6])
+	}
+	location, err := time.LoadLocation(record[7])
+	if err != nil || record[7] == "" || record[7] == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", record[7])
+	}
+	if len(record[0]) != 3 || strings.ToUpper(record[0]) != record[0] {
+		return nil, fmt.Errorf("invalid IATA code %q", record[0])
+	}
+
+	return &Airport{
+		IATA:      record[0],
+		ICAO:      record[1],
+		Name:      record[2],
+		City:      record[3],
+		Country:   record[4],
+		Latitude:  latitude,
+		Longitude: longitude,
+		TimeZone:  record[7],
+		location:  location,
+	}, nil
+}
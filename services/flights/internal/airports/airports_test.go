@@ -0,0 +1,78 @@
+package airports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	require.NoError(t, Init())
+}
+
+func TestLookup(t *testing.T) {
+	airport, ok := Lookup("LHR")
+
+	require.True(t, ok)
+	assert.Equal(t, "EGLL", airport.ICAO)
+	assert.Equal(t, "London Heathrow Airport", airport.Name)
+	assert.Equal(t, "London", airport.City)
+	assert.Equal(t, "GB", airport.Country)
+	assert.InDelta(t, 51.47, airport.Latitude, 0.01)
+	assert.InDelta(t, -0.46, airport.Longitude, 0.01)
+	assert.Equal(t, "Europe/London", airport.TimeZone)
+
+	_, ok = Lookup("ZZZ")
+	assert.False(t, ok)
+
+	_, ok = Lookup("lhr")
+	assert.False(t, ok)
+}
+
+func TestLocalTime(t *testing.T) {
+	departure := time.Date(2025, 4, 1, 7, 25, 0, 0, time.UTC)
+
+	local, ok := LocalTime("LHR", departure)
+	require.True(t, ok)
+	assert.Equal(t, "2025-04-01T08:25:00+01:00", local.Format(time.RFC3339))
+
+	local, ok = LocalTime("JFK", departure)
+	require.True(t, ok)
+	assert.Equal(t, "2025-04-01T03:25:00-04:00", local.Format(time.RFC3339))
+
+	_, ok = LocalTime("ZZZ", departure)
+	assert.False(t, ok)
+}
+
+func TestParse(t *testing.T) {
+	const header = "iata,icao,name,city,country,latitude,longitude,time_zone\n"
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty", input: "", wantErr: "dataset is empty"},
+		{name: "wrong field count", input: header + "LHR,EGLL\n", wantErr: "wrong number of fields"},
+		{name: "invalid latitude", input: header + "LHR,EGLL,Heathrow,London,GB,91,0,Europe/London\n", wantErr: "line 2: invalid latitude"},
+		{name: "invalid longitude", input: header + "LHR,EGLL,Heathrow,London,GB,51,x,Europe/London\n", wantErr: "invalid longitude"},
+		{name: "unknown time zone", input: header + "LHR,EGLL,Heathrow,London,GB,51,0,Europe/Nowhere\n", wantErr: "unknown time zone"},
+		{name: "invalid code", input: header + "lhr,EGLL,Heathrow,London,GB,51,0,Europe/London\n", wantErr: "invalid IATA code"},
+		{
+			name:    "duplicate code",
+			input:   header + "LHR,EGLL,Heathrow,London,GB,51,0,Europe/London\nLHR,EGLL,Heathrow,London,GB,51,0,Europe/London\n",
+			wantErr: "line 3: duplicate IATA code LHR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
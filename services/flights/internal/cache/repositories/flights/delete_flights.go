@@ -0,0 +1,40 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DeleteFlights evicts the cached copies of the given flights of orgID with a single DEL.
+func (r *flightCache) DeleteFlights(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "cache.delete_flights")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cache.operation", "del"),
+		attribute.Int("cache.keys", len(ids)),
+	)
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = flightKey(orgID, id)
+	}
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("cache.result", "error"))
+		return fmt.Errorf("error deleting data from the cache: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("cache.result", "success"))
+	return nil
+}
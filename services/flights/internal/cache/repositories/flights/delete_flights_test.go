@@ -0,0 +1,49 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFlightCacheDeleteFlights(t *testing.T) {
+	orgID := uuid.New()
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	keys := []string{flightKey(orgID, ids[0]), flightKey(orgID, ids[1])}
+
+	t.Run("deletes every key in one call", func(t *testing.T) {
+		mockClient := new(MockRedisClient)
+		cache := &flightCache{client: mockClient, ttl: time.Minute}
+		mockClient.On("Del", mock.Anything, keys).Return(nil).Once()
+
+		err := cache.DeleteFlights(context.Background(), orgID, ids)
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("no ids skips redis", func(t *testing.T) {
+		mockClient := new(MockRedisClient)
+		cache := &flightCache{client: mockClient, ttl: time.Minute}
+
+		err := cache.DeleteFlights(context.Background(), orgID, nil)
+
+		assert.NoError(t, err)
+		mockClient.AssertNotCalled(t, "Del", mock.Anything, mock.Anything)
+	})
+
+	t.Run("redis error", func(t *testing.T) {
+		mockClient := new(MockRedisClient)
+		cache := &flightCache{client: mockClient, ttl: time.Minute}
+		mockClient.On("Del", mock.Anything, keys).Return(errors.New("connection refused")).Once()
+
+		err := cache.DeleteFlights(context.Background(), orgID, ids)
+
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
@@ -13,12 +13,12 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func (r *flightCache) GetFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+func (r *flightCache) GetFlight(ctx context.Context, orgID uuid.UUID, id uuid.UUID) (*models.Flight, error) {
 	tracer := otel.Tracer("flights-service")
 	ctx, span := tracer.Start(ctx, "cache.get_flight")
 	defer span.End()
 
-	key := fmt.Sprintf("flight:%s", id.String())
+	key := flightKey(orgID, id)
 	span.SetAttributes(
 		attribute.String("cache.operation", "get"),
 		attribute.String("cache.key", key),
@@ -20,16 +20,17 @@ func TestFlightCacheGetFlight(t *testing.T) {
 	cache := &flightCache{client: mockClient, ttl: time.Hour}
 
 	flight := &models.Flight{
-		ID:            uuid.New(),
-		Number:        "AA123",
-		Origin:        "LAX",
-		Destination:   "JFK",
-		DepartureTime: time.Now().UTC(),
-		ArrivalTime:   time.Now().Add(5 * time.Hour).UTC(),
-		Status:        models.FlightStatusScheduled,
+		ID:             uuid.New(),
+		OrganizationID: uuid.New(),
+		Number:         "AA123",
+		Origin:         "LAX",
+		Destination:    "JFK",
+		DepartureTime:  time.Now().UTC(),
+		ArrivalTime:    time.Now().Add(5 * time.Hour).UTC(),
+		Status:         models.FlightStatusScheduled,
 	}
 	flightJSON, _ := json.Marshal(flight)
-	key := "flight:" + flight.ID.String()
+	key := "flight:" + flight.OrganizationID.String() + ":" + flight.ID.String()
 
 	tests := []struct {
 		name        string
@@ -77,7 +78,7 @@ func TestFlightCacheGetFlight(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMock()
 
-			got, err := cache.GetFlight(ctx, flight.ID)
+			got, err := cache.GetFlight(ctx, flight.OrganizationID, flight.ID)
 
 			if tc.expectErr {
 				assert.Error(t, err)
@@ -0,0 +1,64 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetFlights looks up several flights with a single MGET. Only hits are returned;
+// entries that cannot be decoded are treated as misses so the caller reloads them.
+func (r *flightCache) GetFlights(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "cache.get_flights")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cache.operation", "mget"),
+		attribute.Int("cache.keys", len(ids)),
+	)
+
+	flights := make(map[uuid.UUID]*models.Flight, len(ids))
+	if len(ids) == 0 {
+		return flights, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = flightKey(orgID, id)
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("cache.result", "error"))
+		return nil, fmt.Errorf("error getting data from the cache: %w", err)
+	}
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var flight models.Flight
+		if err := json.Unmarshal([]byte(raw), &flight); err != nil {
+			logger.WarnContext(ctx, "Discarding unreadable cached flight", "key", keys[i], "err", err)
+			continue
+		}
+		flights[ids[i]] = &flight
+	}
+
+	span.SetAttributes(
+		attribute.String("cache.result", "success"),
+		attribute.Int("cache.hits", len(flights)),
+	)
+
+	return flights, nil
+}
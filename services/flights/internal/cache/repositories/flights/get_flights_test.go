@@ -0,0 +1,67 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightCacheGetFlights(t *testing.T) {
+	ctx := context.Background()
+
+	hit := &models.Flight{ID: uuid.New(), Number: "AA123", Status: models.FlightStatusScheduled}
+	hitJSON, _ := json.Marshal(hit)
+	missID := uuid.New()
+	corruptID := uuid.New()
+
+	orgID := uuid.New()
+	prefix := "flight:" + orgID.String() + ":"
+
+	ids := []uuid.UUID{hit.ID, missID, corruptID}
+	keys := []string{prefix + hit.ID.String(), prefix + missID.String(), prefix + corruptID.String()}
+
+	t.Run("returns hits only", func(t *testing.T) {
+		mockClient := new(MockRedisClient)
+		cache := &flightCache{client: mockClient, ttl: time.Hour}
+		mockClient.On("MGet", mock.Anything, keys).
+			Return([]interface{}{string(hitJSON), nil, "{not json"}, nil).Once()
+
+		flights, err := cache.GetFlights(ctx, orgID, ids)
+
+		require.NoError(t, err)
+		assert.Len(t, flights, 1)
+		assert.Equal(t, "AA123", flights[hit.ID].Number)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("redis error", func(t *testing.T) {
+		mockClient := new(MockRedisClient)
+		cache := &flightCache{client: mockClient, ttl: time.Hour}
+		mockClient.On("MGet", mock.Anything, keys).
+			Return(nil, errors.New("connection refused")).Once()
+
+		flights, err := cache.GetFlights(ctx, orgID, ids)
+
+		assert.Error(t, err)
+		assert.Nil(t, flights)
+	})
+
+	t.Run("no ids skips redis", func(t *testing.T) {
+		mockClient := new(MockRedisClient)
+		cache := &flightCache{client: mockClient, ttl: time.Hour}
+
+		flights, err := cache.GetFlights(ctx, orgID, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, flights)
+		mockClient.AssertNotCalled(t, "MGet")
+	})
+}
@@ -2,6 +2,7 @@ package flights
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
@@ -10,14 +11,25 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// FlightCacheRepository caches flights per organization, so a lookup made on behalf of
+// one organization can never return another organization's flight.
 type FlightCacheRepository interface {
-	GetFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+	GetFlight(ctx context.Context, orgID uuid.UUID, id uuid.UUID) (*models.Flight, error)
+	GetFlights(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error)
 	SetFlight(ctx context.Context, flight *models.Flight) error
+	DeleteFlights(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error
 }
 
 type redisClient interface {
 	Get(ctx context.Context, key string) *redis.StringCmd
+	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+// flightKey is the cache key of a flight, namespaced by the organization that owns it.
+func flightKey(orgID uuid.UUID, id uuid.UUID) string {
+	return fmt.Sprintf("flight:%s:%s", orgID.String(), id.String())
 }
 
 type flightCache struct {
@@ -39,10 +51,18 @@ func NewNoopFlightRepository() FlightCacheRepository {
 	return &noopFlightCache{}
 }
 
-func (n *noopFlightCache) GetFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+func (n *noopFlightCache) GetFlight(ctx context.Context, orgID uuid.UUID, id uuid.UUID) (*models.Flight, error) {
 	return nil, nil
 }
 
+func (n *noopFlightCache) GetFlights(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+	return map[uuid.UUID]*models.Flight{}, nil
+}
+
 func (n *noopFlightCache) SetFlight(ctx context.Context, flight *models.Flight) error {
 	return nil
 }
+
+func (n *noopFlightCache) DeleteFlights(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+	return nil
+}
@@ -15,7 +15,7 @@ func (r *flightCache) SetFlight(ctx context.Context, flight *models.Flight) erro
 	ctx, span := tracer.Start(ctx, "cache.set_flight")
 	defer span.End()
 
-	key := fmt.Sprintf("flight:%s", flight.ID.String())
+	key := flightKey(flight.OrganizationID, flight.ID)
 	span.SetAttributes(
 		attribute.String("cache.operation", "set"),
 		attribute.String("cache.key", key),
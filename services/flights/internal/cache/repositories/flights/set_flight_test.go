@@ -19,14 +19,15 @@ func TestFlightCache_SetFlight(t *testing.T) {
 	cache := &flightCache{client: mockClient, ttl: time.Hour}
 
 	flight := &models.Flight{
-		ID:          uuid.New(),
-		Number:      "AA123",
-		Origin:      "LAX",
-		Destination: "JFK",
-		Status:      models.FlightStatusScheduled,
+		ID:             uuid.New(),
+		Number:         "AA123",
+		Origin:         "LAX",
+		Destination:    "JFK",
+		Status:         models.FlightStatusScheduled,
+		OrganizationID: uuid.New(),
 	}
 
-	key := "flight:" + flight.ID.String()
+	key := "flight:" + flight.OrganizationID.String() + ":" + flight.ID.String()
 
 	serialized, _ := json.Marshal(flight)
 
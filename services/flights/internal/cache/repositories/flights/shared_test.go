@@ -26,3 +26,14 @@ func (m *MockRedisClient) Set(ctx context.Context, key string, value interface{}
 	args := m.Called(ctx, key, value, exp)
 	return redis.NewStatusResult("", args.Error(0))
 }
+
+func (m *MockRedisClient) MGet(ctx context.Context, keys ...string) *redis.SliceCmd {
+	args := m.Called(ctx, keys)
+	val, _ := args.Get(0).([]interface{})
+	return redis.NewSliceResult(val, args.Error(1))
+}
+
+func (m *MockRedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	args := m.Called(ctx, keys)
+	return redis.NewIntResult(int64(len(keys)), args.Error(0))
+}
@@ -0,0 +1,79 @@
+package aircraft_client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// maxCachedAircraft bounds the aircraft cache; it is emptied when full.
+const maxCachedAircraft = 10_000
+
+type cachedAircraft struct {
+	aircraft  models.Aircraft
+	expiresAt time.Time
+}
+
+// aircraftCache keeps aircraft found by the aircraft service for a short TTL, so a
+// burst of writes for the same tail costs one lookup. Each replica has its own; the
+// replica that consumes a grounded or retired event drops the aircraft at once, while
+// the others may take up to the TTL to see the change.
+type aircraftCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]cachedAircraft
+}
+
+func newAircraftCache(ttl time.Duration) *aircraftCache {
+	return &aircraftCache{ttl: ttl, now: time.Now, entries: make(map[uuid.UUID]cachedAircraft)}
+}
+
+// get returns a copy of the cached aircraft with id, or nil if there is none or it
+// has expired.
+func (c *aircraftCache) get(ctx context.Context, id uuid.UUID) *models.Aircraft {
+	c.mu.Lock()
+	entry, ok := c.entries[id]
+	if ok && !c.now().Before(entry.expiresAt) {
+		delete(c.entries, id)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	result := "miss"
+	if ok {
+		result = "hit"
+	}
+	metrics.AircraftCacheLookups.Add(ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+
+	if !ok {
+		return nil
+	}
+	aircraft := entry.aircraft
+	return &aircraft
+}
+
+// delete drops the cached aircraft with id, if any.
+func (c *aircraftCache) delete(id uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, id)
+}
+
+func (c *aircraftCache) set(id uuid.UUID, aircraft *models.Aircraft) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedAircraft {
+		clear(c.entries)
+	}
+	c.entries[id] = cachedAircraft{aircraft: *aircraft, expiresAt: c.now().Add(c.ttl)}
+}
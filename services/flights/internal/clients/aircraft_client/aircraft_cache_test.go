@@ -0,0 +1,98 @@
+package aircraft_client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	aircraftv1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/aircraft/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+type countingAircraftServiceClient struct {
+	resp  *aircraftv1.GetAircraftByIdResponse
+	calls int
+}
+
+func (m *countingAircraftServiceClient) GetAircraftById(ctx context.Context, in *aircraftv1.GetAircraftByIdRequest, opts ...grpc.CallOption) (*aircraftv1.GetAircraftByIdResponse, error) {
+	m.calls++
+	return m.resp, nil
+}
+
+func TestAircraftCacheExpires(t *testing.T) {
+	if err := metrics.InitInstruments(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	cache := newAircraftCache(30 * time.Second)
+	cache.now = func() time.Time { return now }
+
+	id := uuid.New()
+	cache.set(id, &models.Aircraft{ID: id, Status: models.AircraftStatusAvailable})
+
+	cached := cache.get(context.Background(), id)
+	if cached == nil || cached.ID != id {
+		t.Fatalf("expected cached aircraft %s, got %+v", id, cached)
+	}
+
+	// Callers get a copy, so changing it does not change the cache.
+	cached.Status = models.AircraftStatusGrounded
+	if again := cache.get(context.Background(), id); again.Status != models.AircraftStatusAvailable {
+		t.Errorf("expected cached status AVAILABLE, got %s", again.Status)
+	}
+
+	now = now.Add(30 * time.Second)
+	if expired := cache.get(context.Background(), id); expired != nil {
+		t.Errorf("expected expired aircraft to be dropped, got %+v", expired)
+	}
+}
+
+func TestGetAircraftUsesCache(t *testing.T) {
+	if err := metrics.InitInstruments(); err != nil {
+		t.Fatal(err)
+	}
+
+	id := uuid.New()
+	service := &countingAircraftServiceClient{resp: &aircraftv1.GetAircraftByIdResponse{Aircraft: &aircraftv1.Aircraft{Id: id.String(), Capacity: 180}}}
+	c := &AircraftClient{client: service, cache: newAircraftCache(time.Minute)}
+
+	for i := 0; i < 3; i++ {
+		aircraft, err := c.GetAircraft(context.Background(), id)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		if aircraft.ID != id {
+			t.Fatalf("expected aircraft %s, got %s", id, aircraft.ID)
+		}
+	}
+
+	if service.calls != 1 {
+		t.Errorf("expected one call to the aircraft service, got %d", service.calls)
+	}
+}
+
+func TestInvalidateDropsCachedAircraft(t *testing.T) {
+	if err := metrics.InitInstruments(); err != nil {
+		t.Fatal(err)
+	}
+
+	id := uuid.New()
+	service := &countingAircraftServiceClient{resp: &aircraftv1.GetAircraftByIdResponse{Aircraft: &aircraftv1.Aircraft{Id: id.String(), Capacity: 180}}}
+	c := &AircraftClient{client: service, cache: newAircraftCache(time.Minute)}
+
+	if _, err := c.GetAircraft(context.Background(), id); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	c.Invalidate(id)
+	if _, err := c.GetAircraft(context.Background(), id); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if service.calls != 2 {
+		t.Errorf("expected the aircraft service to be asked again after invalidation, got %d calls", service.calls)
+	}
+}
@@ -2,32 +2,74 @@ package aircraft_client
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"time"
 
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/clients/resilience"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
 	aircraftv1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/aircraft/v1"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-type AircraftValidator interface {
-	ValidateAircraftExists(ctx context.Context, aircraftID uuid.UUID) error
+// AircraftLookup fetches aircraft from the aircraft service, leaving it to the caller
+// to decide whether an aircraft may be assigned to a flight.
+type AircraftLookup interface {
+	GetAircraft(ctx context.Context, aircraftID uuid.UUID) (*models.Aircraft, error)
+	// Invalidate forgets what is known about an aircraft, so the next lookup asks the
+	// aircraft service; it is called when the aircraft is known to have changed.
+	Invalidate(aircraftID uuid.UUID)
+}
+
+// DefaultCacheTTL is how long an AircraftClient caches aircraft when Options does not say.
+const DefaultCacheTTL = 30 * time.Second
+
+// Options configures an AircraftClient. Zero fields take their defaults.
+type Options struct {
+	// CacheTTL is how long a found aircraft is served from the local cache.
+	CacheTTL time.Duration
+	Retry    resilience.RetryPolicy
+	Breaker  resilience.BreakerSettings
+	// TLS secures the connection, e.g. with a client certificate for mutual TLS. If
+	// nil the connection is plaintext.
+	TLS *tls.Config
 }
 
 type AircraftClient struct {
 	conn   *grpc.ClientConn
 	client aircraftv1.AircraftServiceClient
+	cache  *aircraftCache
 }
 
-func NewAircraftClient(address string) (*AircraftClient, error) {
+// NewAircraftClient returns a client of the aircraft service at address. Lookups are
+// cached, retried on transient failures and failed fast by a circuit breaker while the
+// service is unhealthy; each attempt is still recorded by the outbound gRPC metrics.
+func NewAircraftClient(address string, options Options) (*AircraftClient, error) {
+	if options.CacheTTL <= 0 {
+		options.CacheTTL = DefaultCacheTTL
+	}
+	breaker := resilience.NewCircuitBreaker("aircraft-service", options.Breaker)
+
+	transportCredentials := insecure.NewCredentials()
+	if options.TLS != nil {
+		transportCredentials = credentials.NewTLS(options.TLS)
+	}
+
 	conn, err := grpc.NewClient(
 		address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transportCredentials),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
-		grpc.WithUnaryInterceptor(metrics.OutboundGrpcUnaryClientInterceptor()),
+		grpc.WithChainUnaryInterceptor(
+			breaker.UnaryClientInterceptor(),
+			resilience.RetryUnaryClientInterceptor(options.Retry, aircraftv1.AircraftService_GetAircraftById_FullMethodName),
+			metrics.OutboundGrpcUnaryClientInterceptor(),
+		),
 	)
 
 	if err != nil {
@@ -39,6 +81,7 @@ func NewAircraftClient(address string) (*AircraftClient, error) {
 	return &AircraftClient{
 		conn:   conn,
 		client: client,
+		cache:  newAircraftCache(options.CacheTTL),
 	}, nil
 }
 
@@ -0,0 +1,133 @@
+package aircraft_client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	aircraftv1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/aircraft/v1"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetAircraft fetches an aircraft from the aircraft service, or from the local cache if
+// it was found recently. It returns an error matching exceptions.ErrAircraftNotFound if
+// the aircraft does not exist, and one matching exceptions.ErrDownstreamClientDown if
+// the service could not be asked, including while its circuit breaker is open.
+func (c *AircraftClient) GetAircraft(ctx context.Context, aircraftID uuid.UUID) (*models.Aircraft, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "aircraft_client.get_aircraft")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("aircraft.id", aircraftID.String()),
+		attribute.String("client.service", "aircraft-service"),
+		attribute.String("grpc.method", "GetAircraftById"),
+	)
+
+	if c.cache != nil {
+		if aircraft := c.cache.get(ctx, aircraftID); aircraft != nil {
+			span.SetAttributes(
+				attribute.String("client.result", "success"),
+				attribute.String("cache.result", "hit"),
+			)
+			return aircraft, nil
+		}
+		span.SetAttributes(attribute.String("cache.result", "miss"))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req := &aircraftv1.GetAircraftByIdRequest{
+		Id: aircraftID.String(),
+	}
+
+	resp, err := c.client.GetAircraftById(ctx, req)
+
+	if err != nil {
+		span.RecordError(err)
+		if s, ok := status.FromError(err); ok {
+			span.SetAttributes(attribute.String("grpc.status_code", s.Code().String()))
+			if s.Code() == codes.NotFound {
+				span.SetAttributes(attribute.String("client.result", "not_found"))
+				logger.InfoContext(ctx, "Aircraft not found", "aircraft_id", aircraftID)
+				return nil, exceptions.AircraftNotFound(aircraftID)
+			}
+			span.SetAttributes(attribute.String("client.result", "grpc_error"))
+			logger.ErrorContext(ctx, "Downstream aircraft service error", "aircraft_id", aircraftID, "err", s.Message())
+			return nil, exceptions.ErrDownstreamClientDown
+		}
+		span.SetAttributes(attribute.String("client.result", "error"))
+		return nil, fmt.Errorf("%w: %v", exceptions.ErrDownstreamClientDown, err)
+	}
+
+	if resp.Aircraft == nil {
+		span.SetAttributes(attribute.String("client.result", "nil_aircraft"))
+		logger.InfoContext(ctx, "Aircraft not found (nil response)", "aircraft_id", aircraftID)
+		return nil, exceptions.AircraftNotFound(aircraftID)
+	}
+
+	aircraft := toModelAircraft(aircraftID, resp.Aircraft)
+	if c.cache != nil {
+		c.cache.set(aircraftID, aircraft)
+	}
+
+	span.SetAttributes(
+		attribute.String("client.result", "success"),
+		attribute.String("aircraft.model", aircraft.Model),
+		attribute.String("aircraft.status", string(aircraft.Status)),
+	)
+
+	return aircraft, nil
+}
+
+// Invalidate drops the cached aircraft with aircraftID, so its next lookup sees its
+// current status.
+func (c *AircraftClient) Invalidate(aircraftID uuid.UUID) {
+	if c.cache != nil {
+		c.cache.delete(aircraftID)
+	}
+}
+
+// toModelAircraft converts the aircraft service's Aircraft to a models.Aircraft. The
+// requested id is used if the response's id does not parse.
+func toModelAircraft(id uuid.UUID, a *aircraftv1.Aircraft) *models.Aircraft {
+	if parsed, err := uuid.Parse(a.GetId()); err == nil {
+		id = parsed
+	}
+
+	return &models.Aircraft{
+		ID:                id,
+		Registration:      a.GetRegistration(),
+		Manufacturer:      a.GetManufacturer(),
+		Model:             a.GetModel(),
+		YearOfManufacture: a.GetYearOfManufacture(),
+		Capacity:          a.GetCapacity(),
+		Status:            toModelAircraftStatus(a.GetStatus()),
+		Airline:           a.GetAirline(),
+	}
+}
+
+func toModelAircraftStatus(s aircraftv1.AircraftStatus) models.AircraftStatus {
+	switch s {
+	case aircraftv1.AircraftStatus_AIRCRAFT_STATUS_AVAILABLE:
+		return models.AircraftStatusAvailable
+	case aircraftv1.AircraftStatus_AIRCRAFT_STATUS_IN_SERVICE:
+		return models.AircraftStatusInService
+	case aircraftv1.AircraftStatus_AIRCRAFT_STATUS_MAINTENANCE:
+		return models.AircraftStatusMaintenance
+	case aircraftv1.AircraftStatus_AIRCRAFT_STATUS_GROUNDED:
+		return models.AircraftStatusGrounded
+	default:
+		return models.AircraftStatusUnspecified
+	}
+}
+
+var _ AircraftLookup = (*AircraftClient)(nil)
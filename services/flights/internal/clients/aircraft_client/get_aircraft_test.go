@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
 	aircraftv1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/aircraft/v1"
 	"github.com/google/uuid"
@@ -24,39 +25,62 @@ func (m *mockAircraftServiceClient) GetAircraftById(ctx context.Context, in *air
 // ensure mock satisfies interface at compile time
 var _ aircraftv1.AircraftServiceClient = (*mockAircraftServiceClient)(nil)
 
-func TestValidateAircraftExistsSuccess(t *testing.T) {
+func TestGetAircraftSuccess(t *testing.T) {
 	id := uuid.New()
-	c := &AircraftClient{client: &mockAircraftServiceClient{resp: &aircraftv1.GetAircraftByIdResponse{Aircraft: &aircraftv1.Aircraft{Id: id.String()}}}}
+	c := &AircraftClient{client: &mockAircraftServiceClient{resp: &aircraftv1.GetAircraftByIdResponse{Aircraft: &aircraftv1.Aircraft{
+		Id:           id.String(),
+		Registration: "G-XWBA",
+		Model:        "A350-1000",
+		Capacity:     331,
+		Status:       aircraftv1.AircraftStatus_AIRCRAFT_STATUS_MAINTENANCE,
+		Airline:      "British Airways",
+	}}}}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	if err := c.ValidateAircraftExists(ctx, id); err != nil {
+	aircraft, err := c.GetAircraft(ctx, id)
+	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
+	if aircraft.ID != id {
+		t.Errorf("expected id %s, got %s", id, aircraft.ID)
+	}
+	if aircraft.Registration != "G-XWBA" || aircraft.Model != "A350-1000" {
+		t.Errorf("unexpected aircraft %+v", aircraft)
+	}
+	if aircraft.Capacity != 331 {
+		t.Errorf("expected capacity 331, got %d", aircraft.Capacity)
+	}
+	if aircraft.Status != models.AircraftStatusMaintenance {
+		t.Errorf("expected status MAINTENANCE, got %s", aircraft.Status)
+	}
+	if aircraft.Airline != "British Airways" {
+		t.Errorf("expected airline British Airways, got %q", aircraft.Airline)
+	}
 }
 
-func TestValidateAircraftExistsNotFound(t *testing.T) {
+func TestGetAircraftNotFound(t *testing.T) {
 	id := uuid.New()
 	c := &AircraftClient{client: &mockAircraftServiceClient{resp: &aircraftv1.GetAircraftByIdResponse{Aircraft: nil}}}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	err := c.ValidateAircraftExists(ctx, id)
+	_, err := c.GetAircraft(ctx, id)
 	if !errors.Is(err, exceptions.ErrAircraftNotFound) {
 		t.Fatalf("expected ErrAircraftNotFound, got %v", err)
 	}
 }
 
-func TestValidateAircraftExistsDownstreamError(t *testing.T) {
+func TestGetAircraftDownstreamError(t *testing.T) {
 	id := uuid.New()
 	c := &AircraftClient{client: &mockAircraftServiceClient{err: errors.New("downstream error")}}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	err := c.ValidateAircraftExists(ctx, id)
+	_, err := c.GetAircraft(ctx, id)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
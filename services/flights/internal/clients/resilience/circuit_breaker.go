@@ -0,0 +1,182 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every call through.
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen lets a single probe through to test whether the server recovered.
+	BreakerHalfOpen
+	// BreakerOpen fails every call without making it.
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half_open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerSettings configures a CircuitBreaker. Zero fields take the values of
+// DefaultBreakerSettings.
+type BreakerSettings struct {
+	// FailureThreshold is how many calls in a row must fail to open the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting a probe through.
+	OpenTimeout time.Duration
+}
+
+// DefaultBreakerSettings opens after five failures in a row and probes every 30 seconds.
+var DefaultBreakerSettings = BreakerSettings{
+	FailureThreshold: 5,
+	OpenTimeout:      30 * time.Second,
+}
+
+// CircuitBreaker fails calls to an unhealthy server fast instead of letting each wait
+// for its own timeout. Only failures that point at the server or the network count;
+// an answer such as NOT_FOUND shows the server is healthy.
+type CircuitBreaker struct {
+	name     string
+	settings BreakerSettings
+	now      func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker. The name identifies it in logs
+// and metrics.
+func NewCircuitBreaker(name string, settings BreakerSettings) *CircuitBreaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = DefaultBreakerSettings.FailureThreshold
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = DefaultBreakerSettings.OpenTimeout
+	}
+	return &CircuitBreaker{name: name, settings: settings, now: time.Now}
+}
+
+// State returns the breaker's current state.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// UnaryClientInterceptor fails calls with UNAVAILABLE while the breaker is open and
+// records the outcome of the calls it lets through. The state each call saw is
+// recorded on the caller's span as circuit_breaker.state.
+func (b *CircuitBreaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		state, allowed := b.allow(ctx)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("circuit_breaker.state", state.String()))
+		if !allowed {
+			metrics.CircuitBreakerRejections.Add(ctx, 1, metric.WithAttributes(attribute.String("breaker", b.name)))
+			return status.Errorf(codes.Unavailable, "circuit breaker %s is open", b.name)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(ctx, err)
+		return err
+	}
+}
+
+// allow reports whether a call may be made, moving an open breaker whose timeout has
+// passed to half-open and admitting one probe there.
+func (b *CircuitBreaker) allow(ctx context.Context) (BreakerState, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.transition(ctx, BreakerHalfOpen)
+	}
+
+	switch b.state {
+	case BreakerOpen:
+		return b.state, false
+	case BreakerHalfOpen:
+		if b.probing {
+			return b.state, false
+		}
+		b.probing = true
+		return b.state, true
+	default:
+		return b.state, true
+	}
+}
+
+// record updates the breaker with the outcome of a call it allowed.
+func (b *CircuitBreaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+
+	if !serverFailure(err) {
+		b.failures = 0
+		if b.state != BreakerClosed {
+			b.transition(ctx, BreakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.settings.FailureThreshold {
+		b.openedAt = b.now()
+		if b.state != BreakerOpen {
+			b.transition(ctx, BreakerOpen)
+		}
+	}
+}
+
+// transition moves the breaker to state. The caller must hold b.mu.
+func (b *CircuitBreaker) transition(ctx context.Context, state BreakerState) {
+	logger.WarnContext(ctx, "Circuit breaker changed state", "breaker", b.name, "from", b.state.String(), "to", state.String(), "failures", b.failures)
+	b.state = state
+	metrics.CircuitBreakerState.Record(ctx, int64(state), metric.WithAttributes(attribute.String("breaker", b.name)))
+}
+
+// serverFailure reports whether err suggests the server or the network is unhealthy.
+func serverFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,84 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("aircraft-service", BreakerSettings{FailureThreshold: 2, OpenTimeout: 30 * time.Second})
+	breaker.now = func() time.Time { return now }
+	interceptor := breaker.UnaryClientInterceptor()
+
+	calls := 0
+	var result error
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return result
+	}
+	call := func() error {
+		return interceptor(context.Background(), lookupMethod, nil, nil, nil, invoker)
+	}
+
+	// Answers from a healthy server do not count as failures.
+	result = status.Error(codes.NotFound, "no such aircraft")
+	require.Error(t, call())
+	assert.Equal(t, BreakerClosed, breaker.State())
+
+	result = status.Error(codes.Unavailable, "connection refused")
+	require.Error(t, call())
+	assert.Equal(t, BreakerClosed, breaker.State())
+	require.Error(t, call())
+	assert.Equal(t, BreakerOpen, breaker.State())
+
+	// Open: calls fail fast without reaching the server.
+	calls = 0
+	err := call()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, err.Error(), "circuit breaker aircraft-service is open")
+	assert.Zero(t, calls)
+
+	// After the timeout a failed probe opens it again.
+	now = now.Add(30 * time.Second)
+	require.Error(t, call())
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, BreakerOpen, breaker.State())
+
+	// A successful probe closes it.
+	now = now.Add(30 * time.Second)
+	result = nil
+	require.NoError(t, call())
+	assert.Equal(t, BreakerClosed, breaker.State())
+	require.NoError(t, call())
+	assert.Equal(t, 3, calls)
+}
+
+func TestCircuitBreakerAdmitsOneProbe(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("aircraft-service", BreakerSettings{FailureThreshold: 1, OpenTimeout: time.Second})
+	breaker.now = func() time.Time { return now }
+
+	breaker.record(context.Background(), status.Error(codes.Unavailable, "down"))
+	require.Equal(t, BreakerOpen, breaker.State())
+
+	now = now.Add(time.Second)
+	state, allowed := breaker.allow(context.Background())
+	assert.Equal(t, BreakerHalfOpen, state)
+	assert.True(t, allowed)
+
+	_, allowed = breaker.allow(context.Background())
+	assert.False(t, allowed)
+}
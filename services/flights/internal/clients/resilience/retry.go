@@ -0,0 +1,131 @@
+package resilience
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures RetryUnaryClientInterceptor. Zero fields take the values of
+// DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the most times a call is made, including the first.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, doubled for each retry after.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff.
+	MaxDelay time.Duration
+	// AttemptTimeout bounds each attempt, so one that hangs can be retried while the
+	// call's own deadline allows.
+	AttemptTimeout time.Duration
+}
+
+// DefaultRetryPolicy makes up to three attempts of two seconds each.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	BaseDelay:      100 * time.Millisecond,
+	MaxDelay:       time.Second,
+	AttemptTimeout: 2 * time.Second,
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+	if p.AttemptTimeout <= 0 {
+		p.AttemptTimeout = DefaultRetryPolicy.AttemptTimeout
+	}
+	return p
+}
+
+// backoff returns the full jitter delay before retry n, counting from 1: a random
+// duration up to BaseDelay doubled n-1 times, capped at MaxDelay.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	ceiling := p.MaxDelay
+	if shift := n - 1; shift < 32 {
+		if d := p.BaseDelay << shift; d > 0 && d < ceiling {
+			ceiling = d
+		}
+	}
+	return rand.N(ceiling + 1)
+}
+
+// RetryUnaryClientInterceptor retries calls to the given methods, which must be
+// idempotent, when they fail with a code that suggests the next attempt may succeed.
+// Calls to any other method pass straight through. The number of attempts is recorded
+// on the caller's span as rpc.retry.attempts.
+func RetryUnaryClientInterceptor(policy RetryPolicy, idempotentMethods ...string) grpc.UnaryClientInterceptor {
+	policy = policy.withDefaults()
+	idempotent := make(map[string]bool, len(idempotentMethods))
+	for _, method := range idempotentMethods {
+		idempotent[method] = true
+	}
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if !idempotent[method] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		span := trace.SpanFromContext(ctx)
+		for attempt := 1; ; attempt++ {
+			attemptCtx, cancel := context.WithTimeout(ctx, policy.AttemptTimeout)
+			err := invoker(attemptCtx, method, req, reply, cc, opts...)
+			cancel()
+
+			if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+				span.SetAttributes(attribute.Int("rpc.retry.attempts", attempt))
+				return err
+			}
+
+			code := status.Code(err)
+			metrics.GrpcClientRetries.Add(ctx, 1,
+				metric.WithAttributes(
+					attribute.String("method", method),
+					attribute.String("status", code.String()),
+				),
+			)
+			logger.WarnContext(ctx, "Retrying outbound gRPC call", "method", method, "attempt", attempt, "status", code.String())
+
+			timer := time.NewTimer(policy.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				span.SetAttributes(attribute.Int("rpc.retry.attempts", attempt))
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// retryable reports whether a call that failed with err is worth making again.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const lookupMethod = "/aircraft.v1.AircraftService/GetAircraftById"
+
+var fastRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	BaseDelay:      time.Millisecond,
+	MaxDelay:       2 * time.Millisecond,
+	AttemptTimeout: time.Second,
+}
+
+// failingInvoker fails with each of errs in turn, then succeeds, counting its calls.
+func failingInvoker(calls *int, errs ...error) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestRetryUnaryClientInterceptor(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+	interceptor := RetryUnaryClientInterceptor(fastRetryPolicy, lookupMethod)
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		calls := 0
+		err := interceptor(context.Background(), lookupMethod, nil, nil, nil, failingInvoker(&calls, unavailable, unavailable))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		calls := 0
+		err := interceptor(context.Background(), lookupMethod, nil, nil, nil, failingInvoker(&calls, unavailable, unavailable, unavailable, unavailable))
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry permanent failures", func(t *testing.T) {
+		calls := 0
+		err := interceptor(context.Background(), lookupMethod, nil, nil, nil, failingInvoker(&calls, status.Error(codes.NotFound, "no such aircraft")))
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("does not retry other methods", func(t *testing.T) {
+		calls := 0
+		err := interceptor(context.Background(), "/aircraft.v1.AircraftService/CreateAircraft", nil, nil, nil, failingInvoker(&calls, unavailable))
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops when the call is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls++
+			cancel()
+			return unavailable
+		}
+
+		err := interceptor(ctx, lookupMethod, nil, nil, nil, invoker)
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+
+	for i := 0; i < 100; i++ {
+		assert.LessOrEqual(t, policy.backoff(1), 100*time.Millisecond)
+		assert.LessOrEqual(t, policy.backoff(2), 200*time.Millisecond)
+		assert.LessOrEqual(t, policy.backoff(5), 300*time.Millisecond)
+		assert.LessOrEqual(t, policy.backoff(64), 300*time.Millisecond)
+	}
+}
@@ -12,18 +12,53 @@ type Config struct {
 	LogLevel               string
 	DatabaseURL            string
 	AircraftServiceGrpcUrl string
+	AircraftCacheTTL       time.Duration
+	AircraftTLSCAFile      string
+	AircraftTLSCertFile    string
+	AircraftTLSKeyFile     string
+	AircraftTLSServerName  string
+	TLSCertFile            string
+	TLSKeyFile             string
+	TLSReloadInterval      time.Duration
 	CacheURL               string
 	CacheTTL               time.Duration
 	OtlpGrpcUrl            string
 	KafkaBrokerURL         string
 	KafkaSchemaRegistryURL string
 	KafkaFlightsTopic      string
+	KafkaAircraftTopic     string
+	KafkaAircraftDLQTopic  string
+	KafkaConsumerGroup     string
+	OutboxPollInterval     time.Duration
+	OutboxBatchSize        int
+	OutboxMaxAttempts      int32
+	UpdatesPollInterval    time.Duration
+	ScheduleInterval       time.Duration
+	ScheduleHorizon        time.Duration
+	BackfillInterval       time.Duration
+	MinTurnaround          time.Duration
+	IdentityMode           string
+	IdentityJWKSURL        string
+	IdentityHMACKeys       string
+	IdentityIssuer         string
+	IdentityKeyCacheTTL    time.Duration
 }
 
 var App Config
 
+// TLSEnabled reports whether the listener should serve TLS.
+func (c Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" || c.TLSKeyFile != ""
+}
+
+// AircraftTLSEnabled reports whether the aircraft client should connect over TLS.
+func (c Config) AircraftTLSEnabled() bool {
+	return c.AircraftTLSCAFile != "" || c.AircraftTLSCertFile != "" || c.AircraftTLSKeyFile != ""
+}
+
 // Init initialises the package-level App configuration from environment variables.
 // PORT and ENVIRONMENT default to "8081" and "development" respectively if unset; DATABASE_URL is mandatory and the process will exit if it is missing or empty.
+// TLS is off unless TLS_CERT_FILE and TLS_KEY_FILE are set, and the aircraft client uses TLS only when one of the AIRCRAFT_TLS_* files is set.
 func Init() {
 	App = Config{
 		Port:                   getEnv("PORT", "8081"),
@@ -31,12 +66,36 @@ func Init() {
 		LogLevel:               getEnvNoFallback("LOG_LEVEL"),
 		DatabaseURL:            mustGetEnv("DATABASE_URL"),
 		AircraftServiceGrpcUrl: mustGetEnv("AIRCRAFT_SERVICE_GRPC_URL"),
+		AircraftCacheTTL:       getEnvDuration("AIRCRAFT_CACHE_TTL", 30*time.Second),
+		AircraftTLSCAFile:      getEnvNoFallback("AIRCRAFT_TLS_CA_FILE"),
+		AircraftTLSCertFile:    getEnvNoFallback("AIRCRAFT_TLS_CERT_FILE"),
+		AircraftTLSKeyFile:     getEnvNoFallback("AIRCRAFT_TLS_KEY_FILE"),
+		AircraftTLSServerName:  getEnvNoFallback("AIRCRAFT_TLS_SERVER_NAME"),
+		TLSCertFile:            getEnvNoFallback("TLS_CERT_FILE"),
+		TLSKeyFile:             getEnvNoFallback("TLS_KEY_FILE"),
+		TLSReloadInterval:      getEnvDuration("TLS_RELOAD_INTERVAL", 30*time.Second),
 		CacheURL:               mustGetEnv("CACHE_URL"),
 		CacheTTL:               15 * time.Minute,
 		OtlpGrpcUrl:            mustGetEnv("OTLP_GRPC_URL"),
 		KafkaBrokerURL:         getEnv("KAFKA_BROKER_URL", "localhost:9092"),
 		KafkaSchemaRegistryURL: getEnv("KAFKA_SCHEMA_REGISTRY_URL", "http://localhost:8081"),
 		KafkaFlightsTopic:      getEnv("KAFKA_FLIGHTS_TOPIC", "flights"),
+		KafkaAircraftTopic:     getEnv("KAFKA_AIRCRAFT_TOPIC", "aircraft"),
+		KafkaAircraftDLQTopic:  getEnv("KAFKA_AIRCRAFT_DLQ_TOPIC", "aircraft-dlq"),
+		KafkaConsumerGroup:     getEnv("KAFKA_CONSUMER_GROUP", "flights-service"),
+		OutboxPollInterval:     time.Second,
+		OutboxBatchSize:        100,
+		OutboxMaxAttempts:      10,
+		UpdatesPollInterval:    500 * time.Millisecond,
+		ScheduleInterval:       15 * time.Minute,
+		ScheduleHorizon:        90 * 24 * time.Hour,
+		BackfillInterval:       15 * time.Minute,
+		MinTurnaround:          getEnvDuration("MIN_TURNAROUND", 45*time.Minute),
+		IdentityMode:           getEnv("IDENTITY_MODE", "headers"),
+		IdentityJWKSURL:        getEnvNoFallback("IDENTITY_JWKS_URL"),
+		IdentityHMACKeys:       getEnvNoFallback("IDENTITY_HMAC_KEYS"),
+		IdentityIssuer:         getEnvNoFallback("IDENTITY_ISSUER"),
+		IdentityKeyCacheTTL:    10 * time.Minute,
 	}
 }
 
@@ -58,6 +117,22 @@ func getEnvNoFallback(key string) string {
 	return ""
 }
 
+// getEnvDuration returns the environment variable named by key parsed with
+// time.ParseDuration, or fallback if it is unset or empty. An invalid or negative
+// value is logged and terminates the process, as mustGetEnv does.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		fmt.Printf("ERROR: environment variable %q must be a non-negative duration, got %q\n\n", key, value)
+		os.Exit(1)
+	}
+	return duration
+}
+
 // mustGetEnv returns the value of the environment variable named by key.
 // If the variable is unset or empty it logs an error and terminates the process
 // with exit status 1. The function does not return on error.
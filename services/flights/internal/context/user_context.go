@@ -1,6 +1,19 @@
 package context
 
-import "github.com/google/uuid"
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RoleDispatcher may create, cancel and delete flights within their organization.
+	RoleDispatcher = "DISPATCHER"
+	// RoleAdmin has every dispatcher permission within their organization.
+	RoleAdmin = "ADMIN"
+	// RolePlatformAdmin lets a caller read flights across every organization.
+	RolePlatformAdmin = "PLATFORM_ADMIN"
+)
 
 type UserContext struct {
 	UserID  uuid.UUID
@@ -8,3 +21,24 @@ type UserContext struct {
 	OrgName string
 	Roles   string
 }
+
+// HasRole reports whether role appears in the comma-separated Roles list.
+// Roles are compared case-insensitively.
+func (u *UserContext) HasRole(role string) bool {
+	for _, r := range strings.Split(u.Roles, ",") {
+		if strings.EqualFold(strings.TrimSpace(r), role) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyRole reports whether the user holds at least one of roles.
+func (u *UserContext) HasAnyRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
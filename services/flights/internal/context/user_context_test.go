@@ -0,0 +1,36 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserContextHasRole(t *testing.T) {
+	tests := []struct {
+		roles    string
+		role     string
+		expected bool
+	}{
+		{roles: "PLATFORM_ADMIN", role: RolePlatformAdmin, expected: true},
+		{roles: "DISPATCHER, platform_admin", role: RolePlatformAdmin, expected: true},
+		{roles: "DISPATCHER,ADMIN", role: RolePlatformAdmin, expected: false},
+		{roles: "", role: RolePlatformAdmin, expected: false},
+		{roles: "PLATFORM_ADMINISTRATOR", role: RolePlatformAdmin, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.roles, func(t *testing.T) {
+			user := &UserContext{Roles: tt.roles}
+			assert.Equal(t, tt.expected, user.HasRole(tt.role))
+		})
+	}
+}
+
+func TestUserContextHasAnyRole(t *testing.T) {
+	user := &UserContext{Roles: "VIEWER,dispatcher"}
+
+	assert.True(t, user.HasAnyRole(RoleDispatcher, RoleAdmin))
+	assert.False(t, user.HasAnyRole(RoleAdmin, RolePlatformAdmin))
+	assert.False(t, user.HasAnyRole())
+}
@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+type AircraftStatus string
+
+const (
+	AircraftStatusAvailable   AircraftStatus = "AVAILABLE"
+	AircraftStatusInService   AircraftStatus = "IN_SERVICE"
+	AircraftStatusMaintenance AircraftStatus = "MAINTENANCE"
+	AircraftStatusGrounded    AircraftStatus = "GROUNDED"
+	AircraftStatusUnspecified AircraftStatus = "UNSPECIFIED"
+)
+
+// Aircraft is an aircraft as owned by the aircraft service. Airline is the name of the
+// organization that registered it.
+type Aircraft struct {
+	ID                uuid.UUID
+	Registration      string
+	Manufacturer      string
+	Model             string
+	YearOfManufacture int32
+	Capacity          int32
+	Status            AircraftStatus
+	Airline           string
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleFormat names the file format of a bulk schedule import.
+type ScheduleFormat string
+
+const (
+	ScheduleFormatCSV  ScheduleFormat = "CSV"
+	ScheduleFormatSSIM ScheduleFormat = "SSIM"
+)
+
+// BulkCreateOptions configures a bulk schedule import.
+type BulkCreateOptions struct {
+	Format ScheduleFormat
+	// AircraftID is used for rows that do not name an aircraft. SSIM files only carry
+	// an aircraft type, so it is required for them.
+	AircraftID *uuid.UUID
+	// DryRun validates every row without storing any flights.
+	DryRun bool
+}
+
+// BulkCreateResult reports the outcome of one flight in a bulk import. Line is the line
+// of the file the flight came from; an SSIM line produces one result per operating day.
+type BulkCreateResult struct {
+	Line          int32      `json:"line"`
+	Number        string     `json:"number"`
+	DepartureTime *time.Time `json:"departureTime"`
+	// FlightID is set once the flight has been stored, so it is nil on a dry run.
+	FlightID *uuid.UUID `json:"flightId"`
+	// Error is set when the flight was rejected.
+	Error *string `json:"error"`
+}
+
+// BulkCreateReport summarises a bulk import, with one result per flight in file order.
+type BulkCreateReport struct {
+	DryRun       bool                `json:"dryRun"`
+	CreatedCount int32               `json:"createdCount"`
+	FailedCount  int32               `json:"failedCount"`
+	Results      []*BulkCreateResult `json:"results"`
+}
@@ -0,0 +1,60 @@
+package converters
+
+import (
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProtoAircraftConflict converts an AircraftConflictError to the v1 AircraftConflict
+// error detail. The position and minimum turnaround are only set for the kinds they
+// apply to.
+func ToProtoAircraftConflict(e *exceptions.AircraftConflictError) *v1.AircraftConflict {
+	conflict := &v1.AircraftConflict{
+		Kind:                    toProtoAircraftConflictKind(e.Kind),
+		AircraftId:              e.AircraftID.String(),
+		ConflictingFlightId:     e.FlightID.String(),
+		ConflictingFlightNumber: e.FlightNumber,
+		DepartureTime:           timestamppb.New(e.DepartureTime),
+		ArrivalTime:             timestamppb.New(e.ArrivalTime),
+		AircraftPosition:        e.Destination,
+		RequiredPosition:        e.Origin,
+	}
+	if e.Kind == exceptions.AircraftConflictTurnaround {
+		conflict.MinTurnaround = durationpb.New(e.MinTurnaround)
+	}
+	return conflict
+}
+
+func toProtoAircraftConflictKind(kind exceptions.AircraftConflictKind) v1.AircraftConflictKind {
+	switch kind {
+	case exceptions.AircraftConflictOverlap:
+		return v1.AircraftConflictKind_AIRCRAFT_CONFLICT_KIND_OVERLAP
+	case exceptions.AircraftConflictTurnaround:
+		return v1.AircraftConflictKind_AIRCRAFT_CONFLICT_KIND_TURNAROUND
+	case exceptions.AircraftConflictPosition:
+		return v1.AircraftConflictKind_AIRCRAFT_CONFLICT_KIND_POSITION
+	default:
+		return v1.AircraftConflictKind_AIRCRAFT_CONFLICT_KIND_UNSPECIFIED
+	}
+}
+
+// ToConnectError wraps err in a connect error with the code from
+// exceptions.MapErrorToGrpcCode. An aircraft conflict also carries an
+// AircraftConflict detail, so clients can tell which flight it clashes with.
+func ToConnectError(err error) *connect.Error {
+	connectErr := connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+
+	var conflict *exceptions.AircraftConflictError
+	if errors.As(err, &conflict) {
+		if detail, detailErr := connect.NewErrorDetail(ToProtoAircraftConflict(conflict)); detailErr == nil {
+			connectErr.AddDetail(detail)
+		}
+	}
+
+	return connectErr
+}
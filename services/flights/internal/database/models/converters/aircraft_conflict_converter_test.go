@@ -0,0 +1,101 @@
+package converters
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToProtoAircraftConflict(testHelper *testing.T) {
+	departure := time.Date(2025, 4, 1, 7, 25, 0, 0, time.UTC)
+	conflict := &exceptions.AircraftConflictError{
+		Kind:          exceptions.AircraftConflictTurnaround,
+		AircraftID:    uuid.New(),
+		FlightID:      uuid.New(),
+		FlightNumber:  "BA175",
+		DepartureTime: departure,
+		ArrivalTime:   departure.Add(8 * time.Hour),
+		MinTurnaround: 45 * time.Minute,
+	}
+
+	result := ToProtoAircraftConflict(conflict)
+
+	assert.Equal(testHelper, v1.AircraftConflictKind_AIRCRAFT_CONFLICT_KIND_TURNAROUND, result.GetKind())
+	assert.Equal(testHelper, conflict.AircraftID.String(), result.GetAircraftId())
+	assert.Equal(testHelper, conflict.FlightID.String(), result.GetConflictingFlightId())
+	assert.Equal(testHelper, "BA175", result.GetConflictingFlightNumber())
+	assert.Equal(testHelper, departure, result.GetDepartureTime().AsTime())
+	assert.Equal(testHelper, departure.Add(8*time.Hour), result.GetArrivalTime().AsTime())
+	assert.Equal(testHelper, 45*time.Minute, result.GetMinTurnaround().AsDuration())
+	assert.Empty(testHelper, result.GetAircraftPosition())
+}
+
+func TestToProtoAircraftConflictPosition(testHelper *testing.T) {
+	conflict := &exceptions.AircraftConflictError{
+		Kind:        exceptions.AircraftConflictPosition,
+		FlightID:    uuid.New(),
+		Destination: "JFK",
+	}
+
+	result := ToProtoAircraftConflict(conflict)
+
+	assert.Equal(testHelper, v1.AircraftConflictKind_AIRCRAFT_CONFLICT_KIND_POSITION, result.GetKind())
+	assert.Equal(testHelper, "JFK", result.GetAircraftPosition())
+	assert.Empty(testHelper, result.GetRequiredPosition())
+	assert.Nil(testHelper, result.GetMinTurnaround())
+}
+
+func TestToProtoAircraftConflictRequiredPosition(testHelper *testing.T) {
+	conflict := &exceptions.AircraftConflictError{
+		Kind:     exceptions.AircraftConflictPosition,
+		FlightID: uuid.New(),
+		Origin:   "LHR",
+	}
+
+	result := ToProtoAircraftConflict(conflict)
+
+	assert.Equal(testHelper, "LHR", result.GetRequiredPosition())
+	assert.Empty(testHelper, result.GetAircraftPosition())
+}
+
+func TestToConnectError(testHelper *testing.T) {
+	testHelper.Run("Aircraft Conflict Carries Detail", func(testHelper *testing.T) {
+		conflict := &exceptions.AircraftConflictError{
+			Kind:         exceptions.AircraftConflictOverlap,
+			FlightID:     uuid.New(),
+			FlightNumber: "BA175",
+		}
+
+		connectErr := ToConnectError(fmt.Errorf("flight BA117: %w", conflict))
+
+		assert.Equal(testHelper, connect.CodeFailedPrecondition, connectErr.Code())
+		require.Len(testHelper, connectErr.Details(), 1)
+		value, err := connectErr.Details()[0].Value()
+		require.NoError(testHelper, err)
+		detail, ok := value.(*v1.AircraftConflict)
+		require.True(testHelper, ok)
+		assert.Equal(testHelper, v1.AircraftConflictKind_AIRCRAFT_CONFLICT_KIND_OVERLAP, detail.GetKind())
+		assert.Equal(testHelper, conflict.FlightID.String(), detail.GetConflictingFlightId())
+	})
+
+	testHelper.Run("Other Errors Have No Detail", func(testHelper *testing.T) {
+		connectErr := ToConnectError(exceptions.ErrVersionConflict)
+
+		assert.Equal(testHelper, connect.CodeAborted, connectErr.Code())
+		assert.Empty(testHelper, connectErr.Details())
+	})
+
+	testHelper.Run("Unknown Errors Are Internal", func(testHelper *testing.T) {
+		connectErr := ToConnectError(errors.New("boom"))
+
+		assert.Equal(testHelper, connect.CodeInternal, connectErr.Code())
+	})
+}
@@ -0,0 +1,53 @@
+package converters
+
+import (
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProtoBulkCreateReport converts a models.BulkCreateReport to its v1 protobuf
+// representation. A nil report converts to nil.
+func ToProtoBulkCreateReport(report *models.BulkCreateReport) *v1.BulkCreateFlightsResponse {
+	if report == nil {
+		return nil
+	}
+
+	result := &v1.BulkCreateFlightsResponse{
+		DryRun:       report.DryRun,
+		CreatedCount: report.CreatedCount,
+		FailedCount:  report.FailedCount,
+		Results:      make([]*v1.BulkCreateFlightResult, 0, len(report.Results)),
+	}
+	for _, row := range report.Results {
+		protoRow := &v1.BulkCreateFlightResult{
+			Line:   row.Line,
+			Number: row.Number,
+		}
+		if row.DepartureTime != nil {
+			protoRow.DepartureTime = timestamppb.New(*row.DepartureTime)
+		}
+		if row.FlightID != nil {
+			protoRow.FlightId = row.FlightID.String()
+		}
+		if row.Error != nil {
+			protoRow.Error = *row.Error
+		}
+		result.Results = append(result.Results, protoRow)
+	}
+	return result
+}
+
+// FromProtoScheduleFormat converts a v1.ScheduleFormat to the corresponding
+// models.ScheduleFormat. It reports false for SCHEDULE_FORMAT_UNSPECIFIED and unknown
+// values.
+func FromProtoScheduleFormat(p v1.ScheduleFormat) (models.ScheduleFormat, bool) {
+	switch p {
+	case v1.ScheduleFormat_SCHEDULE_FORMAT_CSV:
+		return models.ScheduleFormatCSV, true
+	case v1.ScheduleFormat_SCHEDULE_FORMAT_SSIM:
+		return models.ScheduleFormatSSIM, true
+	default:
+		return "", false
+	}
+}
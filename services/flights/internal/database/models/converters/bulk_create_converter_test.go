@@ -0,0 +1,67 @@
+package converters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToProtoBulkCreateReport(testHelper *testing.T) {
+	testHelper.Run("Nil", func(testHelper *testing.T) {
+		assert.Nil(testHelper, ToProtoBulkCreateReport(nil))
+	})
+
+	testHelper.Run("Maps Results", func(testHelper *testing.T) {
+		flightID := uuid.New()
+		departure := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+		message := "invalid input: line 3: invalid aircraft ID"
+		report := &models.BulkCreateReport{
+			DryRun:       false,
+			CreatedCount: 1,
+			FailedCount:  1,
+			Results: []*models.BulkCreateResult{
+				{Line: 2, Number: "BA123", DepartureTime: &departure, FlightID: &flightID},
+				{Line: 3, Number: "BA124", Error: &message},
+			},
+		}
+
+		result := ToProtoBulkCreateReport(report)
+
+		assert.Equal(testHelper, int32(1), result.CreatedCount)
+		assert.Equal(testHelper, int32(1), result.FailedCount)
+		require.Len(testHelper, result.Results, 2)
+		assert.Equal(testHelper, int32(2), result.Results[0].Line)
+		assert.Equal(testHelper, flightID.String(), result.Results[0].FlightId)
+		assert.True(testHelper, result.Results[0].DepartureTime.AsTime().Equal(departure))
+		assert.Empty(testHelper, result.Results[0].Error)
+		assert.Nil(testHelper, result.Results[1].DepartureTime)
+		assert.Empty(testHelper, result.Results[1].FlightId)
+		assert.Equal(testHelper, message, result.Results[1].Error)
+	})
+}
+
+func TestFromProtoScheduleFormat(testHelper *testing.T) {
+	tests := []struct {
+		name     string
+		input    v1.ScheduleFormat
+		expected models.ScheduleFormat
+		ok       bool
+	}{
+		{"CSV", v1.ScheduleFormat_SCHEDULE_FORMAT_CSV, models.ScheduleFormatCSV, true},
+		{"SSIM", v1.ScheduleFormat_SCHEDULE_FORMAT_SSIM, models.ScheduleFormatSSIM, true},
+		{"Unspecified", v1.ScheduleFormat_SCHEDULE_FORMAT_UNSPECIFIED, "", false},
+	}
+
+	for _, tt := range tests {
+		testHelper.Run(tt.name, func(testHelper *testing.T) {
+			format, ok := FromProtoScheduleFormat(tt.input)
+			assert.Equal(testHelper, tt.expected, format)
+			assert.Equal(testHelper, tt.ok, ok)
+		})
+	}
+}
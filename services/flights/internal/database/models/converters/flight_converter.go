@@ -0,0 +1,97 @@
+package converters
+
+import (
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/airports"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProtoFlight converts a models.Flight to its v1 protobuf representation.
+// A nil flight converts to nil. The airports and local times are filled in when
+// the origin and destination are known airports. A flight without an aircraft has an
+// empty aircraft ID.
+func ToProtoFlight(flight *models.Flight) *v1.Flight {
+	if flight == nil {
+		return nil
+	}
+
+	result := &v1.Flight{
+		Id:            flight.ID.String(),
+		Number:        flight.Number,
+		Origin:        flight.Origin,
+		Destination:   flight.Destination,
+		DepartureTime: timestamppb.New(flight.DepartureTime),
+		ArrivalTime:   timestamppb.New(flight.ArrivalTime),
+		Status:        ToProtoStatus(flight.Status),
+		AircraftId:    aircraftID(flight.AircraftID),
+		Airline:       flight.Airline,
+		Version:       flight.Version,
+		DelayMinutes:  flight.DelayMinutes(),
+	}
+	if flight.DeletedAt != nil {
+		result.DeletedAt = timestamppb.New(*flight.DeletedAt)
+	}
+	if flight.EstimatedDepartureTime != nil {
+		result.EstimatedDepartureTime = timestamppb.New(*flight.EstimatedDepartureTime)
+	}
+	if flight.EstimatedArrivalTime != nil {
+		result.EstimatedArrivalTime = timestamppb.New(*flight.EstimatedArrivalTime)
+	}
+	if flight.ActualDepartureTime != nil {
+		result.ActualDepartureTime = timestamppb.New(*flight.ActualDepartureTime)
+	}
+	if flight.ActualArrivalTime != nil {
+		result.ActualArrivalTime = timestamppb.New(*flight.ActualArrivalTime)
+	}
+	if flight.ActualTakeoffTime != nil {
+		result.ActualTakeoffTime = timestamppb.New(*flight.ActualTakeoffTime)
+	}
+	if flight.ActualLandingTime != nil {
+		result.ActualLandingTime = timestamppb.New(*flight.ActualLandingTime)
+	}
+	if blockTime := flight.BlockTime(); blockTime != nil {
+		result.BlockTime = durationpb.New(*blockTime)
+	}
+	if airborneTime := flight.AirborneTime(); airborneTime != nil {
+		result.AirborneTime = durationpb.New(*airborneTime)
+	}
+	if flight.DelayCode != nil {
+		result.DelayCode = *flight.DelayCode
+	}
+	if origin, ok := airports.Lookup(flight.Origin); ok {
+		result.OriginAirport = ToProtoAirport(origin)
+		result.DepartureLocalTime = origin.LocalTime(flight.DepartureTime).Format(time.RFC3339)
+	}
+	if destination, ok := airports.Lookup(flight.Destination); ok {
+		result.DestinationAirport = ToProtoAirport(destination)
+		result.ArrivalLocalTime = destination.LocalTime(flight.ArrivalTime).Format(time.RFC3339)
+	}
+	return result
+}
+
+// ToProtoAirport converts an airports.Airport to its v1 protobuf representation.
+func ToProtoAirport(airport *airports.Airport) *v1.Airport {
+	return &v1.Airport{
+		Iata:      airport.IATA,
+		Icao:      airport.ICAO,
+		Name:      airport.Name,
+		City:      airport.City,
+		Country:   airport.Country,
+		Latitude:  airport.Latitude,
+		Longitude: airport.Longitude,
+		TimeZone:  airport.TimeZone,
+	}
+}
+
+// aircraftID returns the string form of id, or "" when no aircraft is assigned.
+func aircraftID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
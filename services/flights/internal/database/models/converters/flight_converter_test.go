@@ -0,0 +1,137 @@
+package converters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToProtoFlight(testHelper *testing.T) {
+	flight := &models.Flight{
+		ID:            uuid.New(),
+		Number:        "BA117",
+		Origin:        "LHR",
+		Destination:   "JFK",
+		DepartureTime: time.Date(2025, 4, 1, 8, 25, 0, 0, time.UTC),
+		ArrivalTime:   time.Date(2025, 4, 1, 16, 10, 0, 0, time.UTC),
+		Status:        models.FlightStatusDelayed,
+		AircraftID:    uuid.New(),
+		Airline:       "British Airways",
+		Version:       2,
+	}
+
+	result := ToProtoFlight(flight)
+
+	require.NotNil(testHelper, result)
+	assert.Equal(testHelper, flight.ID.String(), result.Id)
+	assert.Equal(testHelper, flight.Number, result.Number)
+	assert.Equal(testHelper, flight.Origin, result.Origin)
+	assert.Equal(testHelper, flight.Destination, result.Destination)
+	assert.True(testHelper, flight.DepartureTime.Equal(result.DepartureTime.AsTime()))
+	assert.True(testHelper, flight.ArrivalTime.Equal(result.ArrivalTime.AsTime()))
+	assert.Equal(testHelper, v1.FlightStatus_FLIGHT_STATUS_DELAYED, result.Status)
+	assert.Equal(testHelper, flight.AircraftID.String(), result.AircraftId)
+	assert.Equal(testHelper, flight.Airline, result.Airline)
+	assert.Equal(testHelper, flight.Version, result.Version)
+	assert.Nil(testHelper, result.DeletedAt)
+	assert.Nil(testHelper, result.EstimatedDepartureTime)
+	assert.Empty(testHelper, result.DelayCode)
+	assert.Zero(testHelper, result.DelayMinutes)
+	require.NotNil(testHelper, result.OriginAirport)
+	assert.Equal(testHelper, "EGLL", result.OriginAirport.Icao)
+	assert.Equal(testHelper, "Europe/London", result.OriginAirport.TimeZone)
+	require.NotNil(testHelper, result.DestinationAirport)
+	assert.Equal(testHelper, "KJFK", result.DestinationAirport.Icao)
+	assert.Equal(testHelper, "2025-04-01T09:25:00+01:00", result.DepartureLocalTime)
+	assert.Equal(testHelper, "2025-04-01T12:10:00-04:00", result.ArrivalLocalTime)
+}
+
+func TestToProtoFlightUnknownAirports(testHelper *testing.T) {
+	result := ToProtoFlight(&models.Flight{ID: uuid.New(), Origin: "ZZZ", Destination: "ZZY"})
+
+	assert.Nil(testHelper, result.OriginAirport)
+	assert.Nil(testHelper, result.DestinationAirport)
+	assert.Empty(testHelper, result.DepartureLocalTime)
+	assert.Empty(testHelper, result.ArrivalLocalTime)
+}
+
+func TestToProtoFlightWithoutAircraft(testHelper *testing.T) {
+	result := ToProtoFlight(&models.Flight{ID: uuid.New(), AircraftID: uuid.Nil})
+
+	assert.Empty(testHelper, result.AircraftId)
+}
+
+func TestToProtoFlightDeleted(testHelper *testing.T) {
+	deletedAt := time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC)
+
+	result := ToProtoFlight(&models.Flight{ID: uuid.New(), DeletedAt: &deletedAt})
+
+	require.NotNil(testHelper, result.DeletedAt)
+	assert.True(testHelper, deletedAt.Equal(result.DeletedAt.AsTime()))
+}
+
+func TestToProtoFlightDelayed(testHelper *testing.T) {
+	departure := time.Date(2025, 4, 1, 8, 25, 0, 0, time.UTC)
+	estimatedDeparture := departure.Add(95 * time.Minute)
+	estimatedArrival := estimatedDeparture.Add(8 * time.Hour)
+	code := "41"
+
+	result := ToProtoFlight(&models.Flight{
+		ID:                     uuid.New(),
+		DepartureTime:          departure,
+		EstimatedDepartureTime: &estimatedDeparture,
+		EstimatedArrivalTime:   &estimatedArrival,
+		DelayCode:              &code,
+	})
+
+	require.NotNil(testHelper, result.EstimatedDepartureTime)
+	assert.True(testHelper, estimatedDeparture.Equal(result.EstimatedDepartureTime.AsTime()))
+	require.NotNil(testHelper, result.EstimatedArrivalTime)
+	assert.True(testHelper, estimatedArrival.Equal(result.EstimatedArrivalTime.AsTime()))
+	assert.Nil(testHelper, result.ActualDepartureTime)
+	assert.Equal(testHelper, code, result.DelayCode)
+	assert.Equal(testHelper, int32(95), result.DelayMinutes)
+}
+
+func TestToProtoFlightMovements(testHelper *testing.T) {
+	out := time.Date(2025, 4, 1, 8, 30, 0, 0, time.UTC)
+	off := out.Add(18 * time.Minute)
+	on := off.Add(7 * time.Hour)
+	in := on.Add(9 * time.Minute)
+
+	result := ToProtoFlight(&models.Flight{
+		ID:                  uuid.New(),
+		ActualDepartureTime: &out,
+		ActualTakeoffTime:   &off,
+		ActualLandingTime:   &on,
+		ActualArrivalTime:   &in,
+	})
+
+	require.NotNil(testHelper, result.ActualTakeoffTime)
+	assert.True(testHelper, off.Equal(result.ActualTakeoffTime.AsTime()))
+	require.NotNil(testHelper, result.ActualLandingTime)
+	assert.True(testHelper, on.Equal(result.ActualLandingTime.AsTime()))
+	require.NotNil(testHelper, result.BlockTime)
+	assert.Equal(testHelper, 7*time.Hour+27*time.Minute, result.BlockTime.AsDuration())
+	require.NotNil(testHelper, result.AirborneTime)
+	assert.Equal(testHelper, 7*time.Hour, result.AirborneTime.AsDuration())
+}
+
+func TestToProtoFlightDepartedOnly(testHelper *testing.T) {
+	out := time.Date(2025, 4, 1, 8, 30, 0, 0, time.UTC)
+
+	result := ToProtoFlight(&models.Flight{ID: uuid.New(), ActualDepartureTime: &out})
+
+	assert.Nil(testHelper, result.ActualTakeoffTime)
+	assert.Nil(testHelper, result.BlockTime)
+	assert.Nil(testHelper, result.AirborneTime)
+}
+
+func TestToProtoFlightNil(testHelper *testing.T) {
+	assert.Nil(testHelper, ToProtoFlight(nil))
+}
@@ -0,0 +1,37 @@
+package converters
+
+import (
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+)
+
+// ToProtoFlightEvent converts a models.FlightEvent to its v1 protobuf representation.
+// A nil event converts to nil.
+func ToProtoFlightEvent(event *models.FlightEvent) *v1.FlightEvent {
+	if event == nil {
+		return nil
+	}
+
+	return &v1.FlightEvent{
+		Sequence: event.Sequence,
+		Type:     ToProtoFlightEventType(event.Type),
+		Flight:   ToProtoFlight(event.Flight),
+	}
+}
+
+// ToProtoFlightEventType converts a models.FlightEventType to the v1 protobuf
+// FlightEventType, returning FLIGHT_EVENT_TYPE_UNSPECIFIED for unknown values.
+func ToProtoFlightEventType(t models.FlightEventType) v1.FlightEventType {
+	switch t {
+	case models.FlightEventSnapshot:
+		return v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT
+	case models.FlightEventSnapshotComplete:
+		return v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT_COMPLETE
+	case models.FlightEventUpdated:
+		return v1.FlightEventType_FLIGHT_EVENT_TYPE_UPDATED
+	case models.FlightEventDeleted:
+		return v1.FlightEventType_FLIGHT_EVENT_TYPE_DELETED
+	default:
+		return v1.FlightEventType_FLIGHT_EVENT_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,52 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToProtoFlightEvent(testHelper *testing.T) {
+	testHelper.Run("Nil", func(testHelper *testing.T) {
+		assert.Nil(testHelper, ToProtoFlightEvent(nil))
+	})
+
+	testHelper.Run("Maps Fields", func(testHelper *testing.T) {
+		flight := &models.Flight{ID: uuid.New(), Number: "BA117"}
+
+		result := ToProtoFlightEvent(&models.FlightEvent{Type: models.FlightEventUpdated, Sequence: 42, Flight: flight})
+
+		assert.Equal(testHelper, uint64(42), result.Sequence)
+		assert.Equal(testHelper, v1.FlightEventType_FLIGHT_EVENT_TYPE_UPDATED, result.Type)
+		assert.Equal(testHelper, flight.ID.String(), result.Flight.Id)
+	})
+
+	testHelper.Run("Snapshot Complete Has No Flight", func(testHelper *testing.T) {
+		result := ToProtoFlightEvent(&models.FlightEvent{Type: models.FlightEventSnapshotComplete, Sequence: 7})
+
+		assert.Nil(testHelper, result.Flight)
+		assert.Equal(testHelper, v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT_COMPLETE, result.Type)
+	})
+}
+
+func TestToProtoFlightEventType(testHelper *testing.T) {
+	tests := []struct {
+		input    models.FlightEventType
+		expected v1.FlightEventType
+	}{
+		{models.FlightEventSnapshot, v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT},
+		{models.FlightEventSnapshotComplete, v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT_COMPLETE},
+		{models.FlightEventUpdated, v1.FlightEventType_FLIGHT_EVENT_TYPE_UPDATED},
+		{models.FlightEventDeleted, v1.FlightEventType_FLIGHT_EVENT_TYPE_DELETED},
+		{"UNKNOWN", v1.FlightEventType_FLIGHT_EVENT_TYPE_UNSPECIFIED},
+	}
+
+	for _, tt := range tests {
+		testHelper.Run(string(tt.input), func(testHelper *testing.T) {
+			assert.Equal(testHelper, tt.expected, ToProtoFlightEventType(tt.input))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package converters
+
+import (
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProtoFlightHistoryEntry converts a models.FlightHistoryEntry to its v1 protobuf
+// representation. A nil entry converts to nil.
+func ToProtoFlightHistoryEntry(entry *models.FlightHistoryEntry) *v1.FlightHistoryEntry {
+	if entry == nil {
+		return nil
+	}
+
+	return &v1.FlightHistoryEntry{
+		Id:        entry.ID.String(),
+		FlightId:  entry.FlightID.String(),
+		Operation: ToProtoHistoryOperation(entry.Operation),
+		ActorId:   entry.ActorID.String(),
+		Before:    string(entry.Before),
+		After:     string(entry.After),
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+}
+
+// ToProtoHistoryOperation converts a models.FlightHistoryOperation to the v1 protobuf
+// FlightHistoryOperation, returning FLIGHT_HISTORY_OPERATION_UNSPECIFIED for unknown values.
+func ToProtoHistoryOperation(op models.FlightHistoryOperation) v1.FlightHistoryOperation {
+	switch op {
+	case models.FlightHistoryOperationCreated:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_CREATED
+	case models.FlightHistoryOperationUpdated:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_UPDATED
+	case models.FlightHistoryOperationStatusChanged:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_STATUS_CHANGED
+	case models.FlightHistoryOperationDeleted:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_DELETED
+	case models.FlightHistoryOperationRestored:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_RESTORED
+	case models.FlightHistoryOperationAirlineBackfilled:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_AIRLINE_BACKFILLED
+	case models.FlightHistoryOperationDelayed:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_DELAYED
+	case models.FlightHistoryOperationMovementRecorded:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_MOVEMENT_RECORDED
+	default:
+		return v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_UNSPECIFIED
+	}
+}
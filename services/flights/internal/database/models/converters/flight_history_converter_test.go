@@ -0,0 +1,75 @@
+package converters
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToProtoFlightHistoryEntry(testHelper *testing.T) {
+	testHelper.Run("Nil", func(testHelper *testing.T) {
+		assert.Nil(testHelper, ToProtoFlightHistoryEntry(nil))
+	})
+
+	testHelper.Run("Maps Fields", func(testHelper *testing.T) {
+		entry := &models.FlightHistoryEntry{
+			ID:        uuid.New(),
+			FlightID:  uuid.New(),
+			Operation: models.FlightHistoryOperationStatusChanged,
+			ActorID:   uuid.New(),
+			Before:    json.RawMessage(`{"status":"SCHEDULED"}`),
+			After:     json.RawMessage(`{"status":"DELAYED"}`),
+			CreatedAt: time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC),
+		}
+
+		result := ToProtoFlightHistoryEntry(entry)
+
+		assert.Equal(testHelper, entry.ID.String(), result.Id)
+		assert.Equal(testHelper, entry.FlightID.String(), result.FlightId)
+		assert.Equal(testHelper, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_STATUS_CHANGED, result.Operation)
+		assert.Equal(testHelper, entry.ActorID.String(), result.ActorId)
+		assert.Equal(testHelper, `{"status":"SCHEDULED"}`, result.Before)
+		assert.Equal(testHelper, `{"status":"DELAYED"}`, result.After)
+		assert.True(testHelper, result.CreatedAt.AsTime().Equal(entry.CreatedAt))
+	})
+
+	testHelper.Run("Created Has No Before", func(testHelper *testing.T) {
+		entry := &models.FlightHistoryEntry{
+			Operation: models.FlightHistoryOperationCreated,
+			After:     json.RawMessage(`{}`),
+		}
+
+		result := ToProtoFlightHistoryEntry(entry)
+
+		assert.Empty(testHelper, result.Before)
+		assert.Equal(testHelper, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_CREATED, result.Operation)
+	})
+}
+
+func TestToProtoHistoryOperation(testHelper *testing.T) {
+	tests := []struct {
+		input    models.FlightHistoryOperation
+		expected v1.FlightHistoryOperation
+	}{
+		{models.FlightHistoryOperationCreated, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_CREATED},
+		{models.FlightHistoryOperationUpdated, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_UPDATED},
+		{models.FlightHistoryOperationStatusChanged, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_STATUS_CHANGED},
+		{models.FlightHistoryOperationDeleted, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_DELETED},
+		{models.FlightHistoryOperationRestored, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_RESTORED},
+		{models.FlightHistoryOperationAirlineBackfilled, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_AIRLINE_BACKFILLED},
+		{models.FlightHistoryOperationDelayed, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_DELAYED},
+		{models.FlightHistoryOperationMovementRecorded, v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_MOVEMENT_RECORDED},
+		{"UNKNOWN", v1.FlightHistoryOperation_FLIGHT_HISTORY_OPERATION_UNSPECIFIED},
+	}
+
+	for _, tt := range tests {
+		testHelper.Run(string(tt.input), func(testHelper *testing.T) {
+			assert.Equal(testHelper, tt.expected, ToProtoHistoryOperation(tt.input))
+		})
+	}
+}
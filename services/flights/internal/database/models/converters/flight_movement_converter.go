@@ -0,0 +1,24 @@
+package converters
+
+import (
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+)
+
+// FromProtoMovement converts a v1.FlightMovement to the corresponding
+// models.FlightMovement. It reports false for FLIGHT_MOVEMENT_UNSPECIFIED and unknown
+// values.
+func FromProtoMovement(p v1.FlightMovement) (models.FlightMovement, bool) {
+	switch p {
+	case v1.FlightMovement_FLIGHT_MOVEMENT_OUT:
+		return models.FlightMovementOut, true
+	case v1.FlightMovement_FLIGHT_MOVEMENT_OFF:
+		return models.FlightMovementOff, true
+	case v1.FlightMovement_FLIGHT_MOVEMENT_ON:
+		return models.FlightMovementOn, true
+	case v1.FlightMovement_FLIGHT_MOVEMENT_IN:
+		return models.FlightMovementIn, true
+	default:
+		return "", false
+	}
+}
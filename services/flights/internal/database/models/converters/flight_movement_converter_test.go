@@ -0,0 +1,33 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromProtoMovement(testHelper *testing.T) {
+	tests := []struct {
+		name     string
+		input    v1.FlightMovement
+		expected models.FlightMovement
+		ok       bool
+	}{
+		{"Out", v1.FlightMovement_FLIGHT_MOVEMENT_OUT, models.FlightMovementOut, true},
+		{"Off", v1.FlightMovement_FLIGHT_MOVEMENT_OFF, models.FlightMovementOff, true},
+		{"On", v1.FlightMovement_FLIGHT_MOVEMENT_ON, models.FlightMovementOn, true},
+		{"In", v1.FlightMovement_FLIGHT_MOVEMENT_IN, models.FlightMovementIn, true},
+		{"Unspecified", v1.FlightMovement_FLIGHT_MOVEMENT_UNSPECIFIED, "", false},
+		{"Unknown", v1.FlightMovement(999), "", false},
+	}
+
+	for _, tt := range tests {
+		testHelper.Run(tt.name, func(testHelper *testing.T) {
+			movement, ok := FromProtoMovement(tt.input)
+			assert.Equal(testHelper, tt.expected, movement)
+			assert.Equal(testHelper, tt.ok, ok)
+		})
+	}
+}
@@ -6,21 +6,87 @@ import (
 	"github.com/google/uuid"
 )
 
+// DefaultAirline is the placeholder airline of flights stored before the airline was
+// persisted; such flights are backfilled with the airline of their organization.
+const DefaultAirline = "System"
+
+// Flight is a single operation of a flight number. DepartureTime and ArrivalTime are
+// the schedule; the estimated times are set once a delay is reported. The actual
+// departure, takeoff, landing and arrival times are the Out, Off, On and In (OOOI)
+// movements, set as operations record them. DelayCode is the IATA (AHM 730) code of
+// the most recent delay.
 type Flight struct {
-	ID             uuid.UUID    `db:"id" json:"id"`
-	Number         string       `db:"number" json:"number"`
-	Origin         string       `db:"origin" json:"origin"`
-	Destination    string       `db:"destination" json:"destination"`
-	DepartureTime  time.Time    `db:"departure_time" json:"departure_time"`
-	ArrivalTime    time.Time    `db:"arrival_time" json:"arrival_time"`
-	Status         FlightStatus `db:"status" json:"status"`
-	AircraftID     uuid.UUID    `db:"aircraft_id" json:"aircraft_id"`
-	CreatedBy      uuid.UUID    `db:"created_by" json:"-"`
-	LastUpdatedBy  uuid.UUID    `db:"last_updated_by" json:"-"`
-	OrganizationID uuid.UUID    `db:"organization_id" json:"-"`
-	Airline        string       `db:"airline" json:"airline"`
-	CreatedAt      time.Time    `db:"created_at" json:"-"`
-	UpdatedAt      time.Time    `db:"updated_at" json:"-"`
+	ID                     uuid.UUID    `db:"id" json:"id"`
+	Number                 string       `db:"number" json:"number"`
+	Origin                 string       `db:"origin" json:"origin"`
+	Destination            string       `db:"destination" json:"destination"`
+	DepartureTime          time.Time    `db:"departure_time" json:"departure_time"`
+	ArrivalTime            time.Time    `db:"arrival_time" json:"arrival_time"`
+	Status                 FlightStatus `db:"status" json:"status"`
+	AircraftID             uuid.UUID    `db:"aircraft_id" json:"aircraft_id"`
+	CreatedBy              uuid.UUID    `db:"created_by" json:"created_by"`
+	LastUpdatedBy          uuid.UUID    `db:"last_updated_by" json:"last_updated_by"`
+	OrganizationID         uuid.UUID    `db:"organization_id" json:"organization_id"`
+	Airline                string       `db:"airline" json:"airline"`
+	CreatedAt              time.Time    `db:"created_at" json:"-"`
+	UpdatedAt              time.Time    `db:"updated_at" json:"-"`
+	Version                int32        `db:"version" json:"version"`
+	DeletedAt              *time.Time   `db:"deleted_at" json:"deleted_at,omitempty"`
+	EstimatedDepartureTime *time.Time   `db:"estimated_departure_time" json:"estimated_departure_time,omitempty"`
+	EstimatedArrivalTime   *time.Time   `db:"estimated_arrival_time" json:"estimated_arrival_time,omitempty"`
+	ActualDepartureTime    *time.Time   `db:"actual_departure_time" json:"actual_departure_time,omitempty"`
+	ActualArrivalTime      *time.Time   `db:"actual_arrival_time" json:"actual_arrival_time,omitempty"`
+	DelayCode              *string      `db:"delay_code" json:"delay_code,omitempty"`
+	ActualTakeoffTime      *time.Time   `db:"actual_takeoff_time" json:"actual_takeoff_time,omitempty"`
+	ActualLandingTime      *time.Time   `db:"actual_landing_time" json:"actual_landing_time,omitempty"`
 }
 
 func (Flight) IsEntity() {}
+
+// ExpectedDepartureTime returns the estimated departure time, or the scheduled one
+// when no delay has been reported.
+func (f *Flight) ExpectedDepartureTime() time.Time {
+	if f.EstimatedDepartureTime != nil {
+		return *f.EstimatedDepartureTime
+	}
+	return f.DepartureTime
+}
+
+// ExpectedArrivalTime returns the estimated arrival time, or the scheduled one when no
+// delay has been reported.
+func (f *Flight) ExpectedArrivalTime() time.Time {
+	if f.EstimatedArrivalTime != nil {
+		return *f.EstimatedArrivalTime
+	}
+	return f.ArrivalTime
+}
+
+// DelayMinutes returns how many minutes after the scheduled departure the flight is
+// expected to leave, rounded up, or zero when it is not delayed.
+func (f *Flight) DelayMinutes() int32 {
+	delay := f.ExpectedDepartureTime().Sub(f.DepartureTime)
+	if delay <= 0 {
+		return 0
+	}
+	return int32((delay + time.Minute - 1) / time.Minute)
+}
+
+// BlockTime returns the time from leaving the gate to reaching it, or nil until both
+// have been recorded.
+func (f *Flight) BlockTime() *time.Duration {
+	return elapsed(f.ActualDepartureTime, f.ActualArrivalTime)
+}
+
+// AirborneTime returns the time from takeoff to landing, or nil until both have been
+// recorded.
+func (f *Flight) AirborneTime() *time.Duration {
+	return elapsed(f.ActualTakeoffTime, f.ActualLandingTime)
+}
+
+func elapsed(from, to *time.Time) *time.Duration {
+	if from == nil || to == nil {
+		return nil
+	}
+	d := to.Sub(*from)
+	return &d
+}
@@ -0,0 +1,9 @@
+package models
+
+// FlightChange is the state of a flight after a write, numbered in the order the
+// writes were published. Sequences are shared by every organization and have no
+// gaps, so a subscriber can resume after the last change it saw.
+type FlightChange struct {
+	Sequence uint64  `json:"sequence"`
+	Flight   *Flight `json:"flight"`
+}
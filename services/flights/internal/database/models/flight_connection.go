@@ -0,0 +1,21 @@
+package models
+
+// FlightConnection is a Relay style page of flights.
+type FlightConnection struct {
+	Edges    []*FlightEdge `json:"edges"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+// FlightEdge pairs a flight with the opaque cursor that points at it.
+type FlightEdge struct {
+	Cursor string  `json:"cursor"`
+	Node   *Flight `json:"node"`
+}
+
+// PageInfo describes the position of a page within the full result set.
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DelayReport asks for a flight to be recorded as leaving later than scheduled.
+type DelayReport struct {
+	FlightID uuid.UUID
+	// Code is an IATA (AHM 730) delay code, either numeric such as "93" or alphabetic
+	// such as "RA".
+	Code                   string
+	EstimatedDepartureTime time.Time
+	// EstimatedArrivalTime defaults to the estimated departure plus the scheduled
+	// block time.
+	EstimatedArrivalTime *time.Time
+	Remarks              *string
+}
+
+// FlightDelay records one delay to a flight, either reported directly or carried
+// forward from the late arrival of the previous flight of its aircraft. Minutes is the
+// delay to the departure against the schedule.
+type FlightDelay struct {
+	ID                     uuid.UUID  `db:"id" json:"id"`
+	FlightID               uuid.UUID  `db:"flight_id" json:"flight_id"`
+	Code                   string     `db:"code" json:"code"`
+	Minutes                int32      `db:"minutes" json:"minutes"`
+	EstimatedDepartureTime time.Time  `db:"estimated_departure_time" json:"estimated_departure_time"`
+	EstimatedArrivalTime   time.Time  `db:"estimated_arrival_time" json:"estimated_arrival_time"`
+	Remarks                *string    `db:"remarks" json:"remarks,omitempty"`
+	CausedByFlightID       *uuid.UUID `db:"caused_by_flight_id" json:"caused_by_flight_id,omitempty"`
+	ReportedBy             uuid.UUID  `db:"reported_by" json:"reported_by"`
+	CreatedAt              time.Time  `db:"created_at" json:"created_at"`
+}
+
+// DelayResult is the outcome of a DelayReport: the delayed flight and the later flights
+// of its aircraft that are delayed in turn, in departure order.
+type DelayResult struct {
+	Flight         *Flight   `json:"flight"`
+	KnockOnFlights []*Flight `json:"knockOnFlights"`
+}
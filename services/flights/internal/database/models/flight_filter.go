@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightFilter narrows a flight listing. Nil fields are not applied.
+type FlightFilter struct {
+	Origin        *string
+	Destination   *string
+	DepartureFrom *time.Time
+	DepartureTo   *time.Time
+	Status        *FlightStatus
+	Airline       *string
+	AircraftID    *uuid.UUID
+	// OrganizationID restricts the listing to one tenant. It is set by the service from
+	// the caller's context, never from client input.
+	OrganizationID *uuid.UUID
+	// IncludeDeleted also lists deleted flights. The service only allows it for admins.
+	IncludeDeleted bool
+}
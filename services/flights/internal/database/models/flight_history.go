@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightHistoryOperation names the kind of write a FlightHistoryEntry records.
+type FlightHistoryOperation string
+
+const (
+	FlightHistoryOperationCreated           FlightHistoryOperation = "CREATED"
+	FlightHistoryOperationUpdated           FlightHistoryOperation = "UPDATED"
+	FlightHistoryOperationStatusChanged     FlightHistoryOperation = "STATUS_CHANGED"
+	FlightHistoryOperationDeleted           FlightHistoryOperation = "DELETED"
+	FlightHistoryOperationRestored          FlightHistoryOperation = "RESTORED"
+	FlightHistoryOperationAirlineBackfilled FlightHistoryOperation = "AIRLINE_BACKFILLED"
+	FlightHistoryOperationDelayed           FlightHistoryOperation = "DELAYED"
+	FlightHistoryOperationMovementRecorded  FlightHistoryOperation = "MOVEMENT_RECORDED"
+)
+
+// FlightHistoryEntry is an immutable record of one write to a flight. Before and
+// After hold the JSON encoding of the flight either side of the write; Before is
+// empty for CREATED.
+type FlightHistoryEntry struct {
+	ID             uuid.UUID              `db:"id" json:"id"`
+	FlightID       uuid.UUID              `db:"flight_id" json:"flight_id"`
+	OrganizationID uuid.UUID              `db:"organization_id" json:"organization_id"`
+	Operation      FlightHistoryOperation `db:"operation" json:"operation"`
+	ActorID        uuid.UUID              `db:"actor_id" json:"actor_id"`
+	Before         json.RawMessage        `db:"before" json:"before,omitempty"`
+	After          json.RawMessage        `db:"after" json:"after"`
+	CreatedAt      time.Time              `db:"created_at" json:"created_at"`
+}
+
+// FlightHistoryConnection is a Relay style page of history entries, oldest first.
+type FlightHistoryConnection struct {
+	Edges    []*FlightHistoryEdge `json:"edges"`
+	PageInfo *PageInfo            `json:"pageInfo"`
+}
+
+// FlightHistoryEdge pairs a history entry with the opaque cursor that points at it.
+type FlightHistoryEdge struct {
+	Cursor string              `json:"cursor"`
+	Node   *FlightHistoryEntry `json:"node"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightMovement is one of the Out, Off, On and In (OOOI) times operations record for
+// a flight.
+type FlightMovement string
+
+const (
+	// FlightMovementOut is the flight leaving the gate.
+	FlightMovementOut FlightMovement = "OUT"
+	// FlightMovementOff is the flight taking off.
+	FlightMovementOff FlightMovement = "OFF"
+	// FlightMovementOn is the flight landing.
+	FlightMovementOn FlightMovement = "ON"
+	// FlightMovementIn is the flight reaching the gate.
+	FlightMovementIn FlightMovement = "IN"
+)
+
+// FlightMovements lists the movements in the order a flight makes them.
+var FlightMovements = []FlightMovement{FlightMovementOut, FlightMovementOff, FlightMovementOn, FlightMovementIn}
+
+// MovementRecord is the recording of one movement of a flight.
+type MovementRecord struct {
+	FlightID   uuid.UUID      `json:"flight_id"`
+	Movement   FlightMovement `json:"movement"`
+	Time       time.Time      `json:"time"`
+	RecordedBy uuid.UUID      `json:"recorded_by"`
+}
+
+// MovementTime returns the recorded time of movement, or nil if it has not been
+// recorded.
+func (f *Flight) MovementTime(movement FlightMovement) *time.Time {
+	switch movement {
+	case FlightMovementOut:
+		return f.ActualDepartureTime
+	case FlightMovementOff:
+		return f.ActualTakeoffTime
+	case FlightMovementOn:
+		return f.ActualLandingTime
+	case FlightMovementIn:
+		return f.ActualArrivalTime
+	default:
+		return nil
+	}
+}
+
+// SetMovementTime records at as the time of movement.
+func (f *Flight) SetMovementTime(movement FlightMovement, at time.Time) {
+	switch movement {
+	case FlightMovementOut:
+		f.ActualDepartureTime = &at
+	case FlightMovementOff:
+		f.ActualTakeoffTime = &at
+	case FlightMovementOn:
+		f.ActualLandingTime = &at
+	case FlightMovementIn:
+		f.ActualArrivalTime = &at
+	}
+}
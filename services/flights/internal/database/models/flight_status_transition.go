@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightStatusTransition records a single status change made to a flight.
+type FlightStatusTransition struct {
+	ID         uuid.UUID    `db:"id" json:"id"`
+	FlightID   uuid.UUID    `db:"flight_id" json:"flight_id"`
+	FromStatus FlightStatus `db:"from_status" json:"from_status"`
+	ToStatus   FlightStatus `db:"to_status" json:"to_status"`
+	Reason     *string      `db:"reason" json:"reason,omitempty"`
+	ChangedBy  uuid.UUID    `db:"changed_by" json:"changed_by"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+}
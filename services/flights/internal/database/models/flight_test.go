@@ -30,3 +30,26 @@ func TestFlightJSONSerialization(testHelper *testing.T) {
 	assert.NoError(testHelper, err)
 	assert.Equal(testHelper, flight.Number, decoded.Number)
 }
+
+func TestFlightMovementTimes(t *testing.T) {
+	out := time.Date(2025, 4, 1, 9, 5, 0, 0, time.UTC)
+	var flight Flight
+
+	assert.Nil(t, flight.BlockTime())
+	assert.Nil(t, flight.AirborneTime())
+
+	flight.SetMovementTime(FlightMovementOut, out)
+	flight.SetMovementTime(FlightMovementOff, out.Add(15*time.Minute))
+	flight.SetMovementTime(FlightMovementOn, out.Add(7*time.Hour))
+	assert.Nil(t, flight.BlockTime())
+	assert.Equal(t, 6*time.Hour+45*time.Minute, *flight.AirborneTime())
+
+	flight.SetMovementTime(FlightMovementIn, out.Add(7*time.Hour+10*time.Minute))
+	assert.Equal(t, 7*time.Hour+10*time.Minute, *flight.BlockTime())
+
+	assert.Equal(t, flight.ActualDepartureTime, flight.MovementTime(FlightMovementOut))
+	assert.Equal(t, flight.ActualTakeoffTime, flight.MovementTime(FlightMovementOff))
+	assert.Equal(t, flight.ActualLandingTime, flight.MovementTime(FlightMovementOn))
+	assert.Equal(t, flight.ActualArrivalTime, flight.MovementTime(FlightMovementIn))
+	assert.Nil(t, flight.MovementTime("TAXI"))
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightUpdate describes a partial change to a flight. Nil fields keep their stored
+// value. Version must match the stored version for the change to be applied.
+type FlightUpdate struct {
+	Number        *string
+	Origin        *string
+	Destination   *string
+	DepartureTime *time.Time
+	ArrivalTime   *time.Time
+	AircraftID    *uuid.UUID
+	Version       int32
+}
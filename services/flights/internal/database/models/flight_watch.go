@@ -0,0 +1,34 @@
+package models
+
+import "github.com/google/uuid"
+
+// FlightWatch selects the flights a watch follows. Nil and empty fields are not
+// applied; the others must all match.
+type FlightWatch struct {
+	FlightIDs   []uuid.UUID
+	Origin      *string
+	Destination *string
+	Airline     *string
+	// AfterSequence resumes an earlier watch after the last change it received, in
+	// place of the snapshot a new watch starts with.
+	AfterSequence *uint64
+}
+
+type FlightEventType string
+
+const (
+	// FlightEventSnapshot carries a flight that matched when the watch started.
+	FlightEventSnapshot FlightEventType = "SNAPSHOT"
+	// FlightEventSnapshotComplete follows the last snapshot event and has no flight.
+	FlightEventSnapshotComplete FlightEventType = "SNAPSHOT_COMPLETE"
+	FlightEventUpdated          FlightEventType = "UPDATED"
+	FlightEventDeleted          FlightEventType = "DELETED"
+)
+
+// FlightEvent is one message of a flight watch. Sequence is the point to resume
+// after; it is zero on snapshot events, which cannot be resumed part way through.
+type FlightEvent struct {
+	Type     FlightEventType
+	Sequence uint64
+	Flight   *Flight
+}
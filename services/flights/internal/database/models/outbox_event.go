@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types written to the outbox. Each maps to an Avro record of the same name on the flights topic.
+const (
+	EventTypeFlightCreated          = "FlightCreated"
+	EventTypeFlightUpdated          = "FlightUpdated"
+	EventTypeFlightStatusChanged    = "FlightStatusChanged"
+	EventTypeFlightCancelled        = "FlightCancelled"
+	EventTypeFlightDelayed          = "FlightDelayed"
+	EventTypeFlightMovementRecorded = "FlightMovementRecorded"
+	EventTypeFlightDeleted          = "FlightDeleted"
+)
+
+// OutboxEvent is a domain event stored alongside the write that produced it and
+// later relayed to Kafka. AggregateID is used as the message key, so events for
+// the same flight are delivered in ID order.
+type OutboxEvent struct {
+	ID           int64
+	AggregateID  uuid.UUID
+	EventType    string
+	Payload      []byte
+	TraceContext map[string]string
+	Attempts     int32
+	CreatedAt    time.Time
+}
+
+// OutboxResult reports the outcome of relaying a single OutboxEvent. A nil Err
+// marks the event as published; otherwise it is retried after NextAttemptAt, or
+// parked as dead when Dead is set.
+type OutboxResult struct {
+	ID            int64
+	Err           error
+	NextAttemptAt time.Time
+	Dead          bool
+}
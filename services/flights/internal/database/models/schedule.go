@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Weekdays is a set of days of the week, with bit n set for time.Weekday n.
+type Weekdays int16
+
+// AllWeekdays operates every day.
+const AllWeekdays Weekdays = 1<<7 - 1
+
+// WeekdaysOf returns the set holding days.
+func WeekdaysOf(days ...time.Weekday) Weekdays {
+	var set Weekdays
+	for _, day := range days {
+		set |= 1 << day
+	}
+	return set
+}
+
+// Has reports whether day is in the set.
+func (w Weekdays) Has(day time.Weekday) bool {
+	return w&(1<<day) != 0
+}
+
+// Days returns the days in the set, starting from Monday.
+func (w Weekdays) Days() []time.Weekday {
+	days := make([]time.Weekday, 0, 7)
+	for i := 1; i <= 7; i++ {
+		if day := time.Weekday(i % 7); w.Has(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// Schedule is a recurring flight pattern from which individual flights are
+// materialized. The departure is a wall clock time in TimeZone, so instances keep the
+// same local time across daylight saving changes. StartDate and EndDate are inclusive
+// calendar dates held at midnight UTC. AircraftID is uuid.Nil once the aircraft has
+// been released, and the schedule's flights are then materialized without one.
+type Schedule struct {
+	ID                 uuid.UUID `db:"id"`
+	Number             string    `db:"number"`
+	Origin             string    `db:"origin"`
+	Destination        string    `db:"destination"`
+	DepartureLocalTime string    `db:"departure_local_time"`
+	TimeZone           string    `db:"time_zone"`
+	DurationMinutes    int32     `db:"duration_minutes"`
+	DaysOfWeek         Weekdays  `db:"days_of_week"`
+	StartDate          time.Time `db:"start_date"`
+	EndDate            time.Time `db:"end_date"`
+	AircraftID         uuid.UUID `db:"aircraft_id"`
+	OrganizationID     uuid.UUID `db:"organization_id"`
+	Airline            string    `db:"airline"`
+	CreatedBy          uuid.UUID `db:"created_by"`
+	LastUpdatedBy      uuid.UUID `db:"last_updated_by"`
+	Version            int32     `db:"version"`
+	// MaterializedThrough is the last operating day flights have been generated for,
+	// or nil before the first run.
+	MaterializedThrough *time.Time `db:"materialized_through"`
+	CreatedAt           time.Time  `db:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"`
+}
+
+// ScheduleInput describes a new schedule. DepartureLocalTime is HH:MM and TimeZone an
+// IANA zone name such as Europe/London.
+type ScheduleInput struct {
+	Number             string
+	Origin             string
+	Destination        string
+	DepartureLocalTime string
+	TimeZone           string
+	DurationMinutes    int32
+	DaysOfWeek         Weekdays
+	StartDate          time.Time
+	EndDate            time.Time
+	AircraftID         uuid.UUID
+}
+
+// ScheduleUpdate describes a partial change to a schedule. Nil fields keep their
+// stored value. Version must match the stored version for the change to be applied.
+type ScheduleUpdate struct {
+	Number             *string
+	Origin             *string
+	Destination        *string
+	DepartureLocalTime *string
+	TimeZone           *string
+	DurationMinutes    *int32
+	DaysOfWeek         *Weekdays
+	StartDate          *time.Time
+	EndDate            *time.Time
+	AircraftID         *uuid.UUID
+	Version            int32
+}
+
+// ScheduledFlight is a flight materialized from a schedule. ScheduleDate is the
+// operating day it was generated for and ScheduleVersion the version of the schedule
+// it reflects.
+type ScheduledFlight struct {
+	Flight          *Flight
+	ScheduleID      uuid.UUID
+	ScheduleDate    time.Time
+	ScheduleVersion int32
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekdays(t *testing.T) {
+	weekdays := WeekdaysOf(time.Sunday, time.Monday, time.Friday)
+
+	assert.True(t, weekdays.Has(time.Sunday))
+	assert.True(t, weekdays.Has(time.Friday))
+	assert.False(t, weekdays.Has(time.Saturday))
+	assert.Equal(t, []time.Weekday{time.Monday, time.Friday, time.Sunday}, weekdays.Days())
+	assert.Len(t, AllWeekdays.Days(), 7)
+	assert.Empty(t, Weekdays(0).Days())
+}
@@ -0,0 +1,94 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// BackfillAirline gives every flight still carrying models.DefaultAirline the airline
+// of its organization's most recently created flight that has one, and returns the ids
+// of the flights it changed by organization. Organizations without such a flight are
+// left until they have one. Each changed flight moves to its next version, and its
+// history, attributed to actorID, and a FlightUpdated event carrying traceContext are
+// written by the same statement.
+func (flightRepository *FlightRepository) BackfillAirline(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.backfill_airline")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+	)
+
+	// The history rows and event payloads encode the flight the way the application
+	// does, without the timestamps; the before state differs only in its airline and
+	// version.
+	const query = `
+        WITH airlines AS (
+            SELECT DISTINCT ON (organization_id) organization_id, airline
+            FROM flights
+            WHERE airline <> $1
+            ORDER BY organization_id, created_at DESC, id DESC
+        ), backfilled AS (
+            UPDATE flights
+            SET airline = airlines.airline, version = flights.version + 1
+            FROM airlines
+            WHERE flights.organization_id = airlines.organization_id
+              AND flights.airline = $1
+            RETURNING flights.id, flights.organization_id, flights.version,
+                jsonb_strip_nulls(to_jsonb(flights.*) - 'created_at' - 'updated_at') AS after
+        ), history AS (
+            INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after)
+            SELECT id, organization_id, $2, $3,
+                jsonb_set(jsonb_set(after, '{airline}', to_jsonb($1::text)), '{version}', to_jsonb(version - 1)),
+                after
+            FROM backfilled
+        ), events AS (
+            INSERT INTO outbox (aggregate_id, event_type, payload, trace_context)
+            SELECT id, $4, after, $5
+            FROM backfilled
+        )
+        SELECT organization_id, id FROM backfilled
+    `
+
+	rows, err := flightRepository.pool.Query(ctx, query, models.DefaultAirline,
+		models.FlightHistoryOperationAirlineBackfilled, actorID, models.EventTypeFlightUpdated, traceContext)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("backfill airline: %w", err)
+	}
+	defer rows.Close()
+
+	backfilled := make(map[uuid.UUID][]uuid.UUID)
+	count := 0
+	for rows.Next() {
+		var orgID, id uuid.UUID
+		if err := rows.Scan(&orgID, &id); err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "scan_error"))
+			return nil, fmt.Errorf("backfill airline: %w", err)
+		}
+		backfilled[orgID] = append(backfilled[orgID], id)
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("backfill airline: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", count),
+	)
+
+	return backfilled, nil
+}
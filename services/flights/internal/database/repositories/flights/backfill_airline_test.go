@@ -0,0 +1,59 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightRepositoryBackfillAirline(t *testing.T) {
+	backfillSQL := regexp.QuoteMeta(`WITH airlines AS ( SELECT DISTINCT ON (organization_id) organization_id, airline FROM flights WHERE airline <> $1 ORDER BY organization_id, created_at DESC, id DESC ), backfilled AS ( UPDATE flights SET airline = airlines.airline, version = flights.version + 1 FROM airlines WHERE flights.organization_id = airlines.organization_id AND flights.airline = $1 RETURNING flights.id, flights.organization_id, flights.version, jsonb_strip_nulls(to_jsonb(flights.*) - 'created_at' - 'updated_at') AS after ), history AS ( INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after) SELECT id, organization_id, $2, $3, jsonb_set(jsonb_set(after, '{airline}', to_jsonb($1::text)), '{version}', to_jsonb(version - 1)), after FROM backfilled ), events AS ( INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) SELECT id, $4, after, $5 FROM backfilled ) SELECT organization_id, id FROM backfilled`)
+	actorID := uuid.New()
+	trace := map[string]string{"traceparent": "00-abc-def-01"}
+
+	t.Run("returns the backfilled flight ids by organization", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		otherOrgID := uuid.New()
+		ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+		mock.ExpectQuery(backfillSQL).
+			WithArgs(models.DefaultAirline, models.FlightHistoryOperationAirlineBackfilled, actorID, models.EventTypeFlightUpdated, trace).
+			WillReturnRows(pgxmock.NewRows([]string{"organization_id", "id"}).
+				AddRow(testOrgID, ids[0]).
+				AddRow(otherOrgID, ids[1]).
+				AddRow(testOrgID, ids[2]))
+
+		repo := &FlightRepository{pool: mock}
+		backfilled, err := repo.BackfillAirline(context.Background(), actorID, trace)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID][]uuid.UUID{testOrgID: {ids[0], ids[2]}, otherOrgID: {ids[1]}}, backfilled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(backfillSQL).
+			WithArgs(models.DefaultAirline, models.FlightHistoryOperationAirlineBackfilled, actorID, models.EventTypeFlightUpdated, trace).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		backfilled, err := repo.BackfillAirline(context.Background(), actorID, trace)
+
+		assert.ErrorContains(t, err, "backfill airline")
+		assert.Nil(t, backfilled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
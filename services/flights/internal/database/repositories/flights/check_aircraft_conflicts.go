@@ -0,0 +1,152 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// lockAircraft takes a transaction-scoped advisory lock on each aircraft, in a fixed
+// order so that concurrent writers cannot deadlock. Writes that check an aircraft's
+// flights hold it until they commit, so two of them cannot both pass the checks and
+// then double-book the aircraft.
+func lockAircraft(ctx context.Context, tx pgx.Tx, aircraftIDs ...uuid.UUID) error {
+	ids := slices.Clone(aircraftIDs)
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+
+	for _, id := range slices.Compact(ids) {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1::text, 0))`, id); err != nil {
+			return fmt.Errorf("lock aircraft %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// checkAircraftConflicts applies checkAircraftAvailability and checkAircraftPosition to f.
+func (flightRepository *FlightRepository) checkAircraftConflicts(ctx context.Context, tx pgx.Tx, f *models.Flight) error {
+	if err := flightRepository.checkAircraftAvailability(ctx, tx, f); err != nil {
+		return err
+	}
+	return checkAircraftPosition(ctx, tx, f)
+}
+
+// checkAircraftAvailability returns an *exceptions.AircraftConflictError if another
+// flight of f's aircraft overlaps f, or leaves less than MinTurnaround on the ground
+// before or after it. Deleted and cancelled flights, and f itself, are ignored, as is a
+// cancelled f. The caller must hold the aircraft's lock.
+func (flightRepository *FlightRepository) checkAircraftAvailability(ctx context.Context, tx pgx.Tx, f *models.Flight) error {
+	if f.Status == models.FlightStatusCancelled {
+		return nil
+	}
+
+	const query = `
+        SELECT id, number, departure_time, arrival_time
+        FROM flights
+        WHERE aircraft_id = $1 AND id <> $2 AND deleted_at IS NULL AND status <> $3
+          AND departure_time < $4 AND arrival_time > $5
+        ORDER BY departure_time, id
+        LIMIT 1
+    `
+
+	turnaround := flightRepository.MinTurnaround
+	var clash exceptions.AircraftConflictError
+	err := tx.QueryRow(
+		ctx,
+		query,
+		f.AircraftID,
+		f.ID,
+		models.FlightStatusCancelled,
+		f.ArrivalTime.Add(turnaround),
+		f.DepartureTime.Add(-turnaround),
+	).Scan(&clash.FlightID, &clash.FlightNumber, &clash.DepartureTime, &clash.ArrivalTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check aircraft %s availability: %w", f.AircraftID, err)
+	}
+
+	clash.AircraftID = f.AircraftID
+	if clash.DepartureTime.Before(f.ArrivalTime) && clash.ArrivalTime.After(f.DepartureTime) {
+		clash.Kind = exceptions.AircraftConflictOverlap
+	} else {
+		clash.Kind = exceptions.AircraftConflictTurnaround
+		clash.MinTurnaround = turnaround
+	}
+	return &clash
+}
+
+// checkAircraftPosition returns an *exceptions.AircraftConflictError if the last flight
+// of f's aircraft to depart before f does not end at f's origin, or the first flight to
+// depart after f does not start at f's destination. The same flights are ignored as by
+// checkAircraftAvailability. The caller must hold the aircraft's lock.
+func checkAircraftPosition(ctx context.Context, tx pgx.Tx, f *models.Flight) error {
+	if f.Status == models.FlightStatusCancelled {
+		return nil
+	}
+
+	const previousQuery = `
+        SELECT id, number, destination, departure_time, arrival_time
+        FROM flights
+        WHERE aircraft_id = $1 AND id <> $2 AND deleted_at IS NULL AND status <> $3
+          AND departure_time < $4
+        ORDER BY departure_time DESC, id DESC
+        LIMIT 1
+    `
+
+	var previous exceptions.AircraftConflictError
+	err := tx.QueryRow(
+		ctx,
+		previousQuery,
+		f.AircraftID,
+		f.ID,
+		models.FlightStatusCancelled,
+		f.DepartureTime,
+	).Scan(&previous.FlightID, &previous.FlightNumber, &previous.Destination, &previous.DepartureTime, &previous.ArrivalTime)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("check aircraft %s position: %w", f.AircraftID, err)
+	}
+	if err == nil && previous.Destination != f.Origin {
+		previous.Kind = exceptions.AircraftConflictPosition
+		previous.AircraftID = f.AircraftID
+		return &previous
+	}
+
+	const nextQuery = `
+        SELECT id, number, origin, departure_time, arrival_time
+        FROM flights
+        WHERE aircraft_id = $1 AND id <> $2 AND deleted_at IS NULL AND status <> $3
+          AND departure_time > $4
+        ORDER BY departure_time ASC, id ASC
+        LIMIT 1
+    `
+
+	var next exceptions.AircraftConflictError
+	err = tx.QueryRow(
+		ctx,
+		nextQuery,
+		f.AircraftID,
+		f.ID,
+		models.FlightStatusCancelled,
+		f.DepartureTime,
+	).Scan(&next.FlightID, &next.FlightNumber, &next.Origin, &next.DepartureTime, &next.ArrivalTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check aircraft %s position: %w", f.AircraftID, err)
+	}
+
+	if next.Origin == f.Destination {
+		return nil
+	}
+	next.Kind = exceptions.AircraftConflictPosition
+	next.AircraftID = f.AircraftID
+	return &next
+}
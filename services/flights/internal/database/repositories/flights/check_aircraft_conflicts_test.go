@@ -0,0 +1,260 @@
+package flights
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+var (
+	lockAircraftSQL         = regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtextextended($1::text, 0))`)
+	aircraftAvailabilitySQL = regexp.QuoteMeta(`SELECT id, number, departure_time, arrival_time FROM flights WHERE aircraft_id = $1 AND id <> $2 AND deleted_at IS NULL AND status <> $3 AND departure_time < $4 AND arrival_time > $5 ORDER BY departure_time, id LIMIT 1`)
+	aircraftPositionSQL     = regexp.QuoteMeta(`SELECT id, number, destination, departure_time, arrival_time FROM flights WHERE aircraft_id = $1 AND id <> $2 AND deleted_at IS NULL AND status <> $3 AND departure_time < $4 ORDER BY departure_time DESC, id DESC LIMIT 1`)
+	aircraftNextFlightSQL   = regexp.QuoteMeta(`SELECT id, number, origin, departure_time, arrival_time FROM flights WHERE aircraft_id = $1 AND id <> $2 AND deleted_at IS NULL AND status <> $3 AND departure_time > $4 ORDER BY departure_time ASC, id ASC LIMIT 1`)
+)
+
+// expectAircraftLock expects lockAircraft to lock each of ids, in the order given.
+func expectAircraftLock(mock pgxmock.PgxPoolIface, ids ...uuid.UUID) {
+	for _, id := range ids {
+		mock.ExpectExec(lockAircraftSQL).WithArgs(id).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	}
+}
+
+// expectAircraftAvailable expects checkAircraftAvailability to find nothing clashing with f.
+func expectAircraftAvailable(mock pgxmock.PgxPoolIface, f *models.Flight, turnaround time.Duration) {
+	if f.Status == models.FlightStatusCancelled {
+		return
+	}
+	mock.ExpectQuery(aircraftAvailabilitySQL).
+		WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.ArrivalTime.Add(turnaround), f.DepartureTime.Add(-turnaround)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "number", "departure_time", "arrival_time"}))
+}
+
+// expectNoAircraftConflicts expects checkAircraftConflicts to pass f, with no earlier
+// or later flight of its aircraft.
+func expectNoAircraftConflicts(mock pgxmock.PgxPoolIface, f *models.Flight, turnaround time.Duration) {
+	if f.Status == models.FlightStatusCancelled {
+		return
+	}
+	expectAircraftAvailable(mock, f, turnaround)
+	mock.ExpectQuery(aircraftPositionSQL).
+		WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.DepartureTime).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "number", "destination", "departure_time", "arrival_time"}))
+	expectNoNextFlight(mock, f)
+}
+
+// expectNoNextFlight expects checkAircraftPosition to find no later flight of f's aircraft.
+func expectNoNextFlight(mock pgxmock.PgxPoolIface, f *models.Flight) {
+	mock.ExpectQuery(aircraftNextFlightSQL).
+		WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.DepartureTime).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "number", "origin", "departure_time", "arrival_time"}))
+}
+
+func TestLockAircraft(testHelper *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(testHelper, err)
+	defer mock.Close()
+
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	mock.ExpectBegin()
+	expectAircraftLock(mock, first, second)
+
+	tx, err := mock.Begin(context.Background())
+	require.NoError(testHelper, err)
+
+	require.NoError(testHelper, lockAircraft(context.Background(), tx, second, first, second))
+	assert.NoError(testHelper, mock.ExpectationsWereMet())
+}
+
+func TestCheckAircraftConflicts(testHelper *testing.T) {
+	turnaround := 45 * time.Minute
+	clashID := uuid.New()
+
+	newFlight := func() *models.Flight {
+		return &models.Flight{
+			ID:            uuid.New(),
+			Number:        "BA117",
+			Origin:        "LHR",
+			Destination:   "JFK",
+			DepartureTime: time.Date(2025, 4, 1, 7, 25, 0, 0, time.UTC),
+			ArrivalTime:   time.Date(2025, 4, 1, 15, 25, 0, 0, time.UTC),
+			Status:        models.FlightStatusScheduled,
+			AircraftID:    uuid.New(),
+		}
+	}
+
+	cases := []struct {
+		name   string
+		modify func(*models.Flight)
+		setup  func(mock pgxmock.PgxPoolIface, f *models.Flight)
+		check  func(testHelper *testing.T, f *models.Flight, err error)
+	}{
+		{
+			name: "NoConflicts",
+			setup: func(mock pgxmock.PgxPoolIface, f *models.Flight) {
+				expectNoAircraftConflicts(mock, f, turnaround)
+			},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				assert.NoError(testHelper, err)
+			},
+		},
+		{
+			name: "Overlap",
+			setup: func(mock pgxmock.PgxPoolIface, f *models.Flight) {
+				mock.ExpectQuery(aircraftAvailabilitySQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.ArrivalTime.Add(turnaround), f.DepartureTime.Add(-turnaround)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "number", "departure_time", "arrival_time"}).
+						AddRow(clashID, "BA175", f.DepartureTime.Add(2*time.Hour), f.ArrivalTime.Add(2*time.Hour)))
+			},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				var conflict *exceptions.AircraftConflictError
+				require.ErrorAs(testHelper, err, &conflict)
+				assert.Equal(testHelper, exceptions.AircraftConflictOverlap, conflict.Kind)
+				assert.Equal(testHelper, clashID, conflict.FlightID)
+				assert.Equal(testHelper, "BA175", conflict.FlightNumber)
+				assert.Equal(testHelper, f.AircraftID, conflict.AircraftID)
+			},
+		},
+		{
+			name: "Turnaround",
+			setup: func(mock pgxmock.PgxPoolIface, f *models.Flight) {
+				mock.ExpectQuery(aircraftAvailabilitySQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.ArrivalTime.Add(turnaround), f.DepartureTime.Add(-turnaround)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "number", "departure_time", "arrival_time"}).
+						AddRow(clashID, "BA112", f.ArrivalTime.Add(30*time.Minute), f.ArrivalTime.Add(8*time.Hour)))
+			},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				var conflict *exceptions.AircraftConflictError
+				require.ErrorAs(testHelper, err, &conflict)
+				assert.Equal(testHelper, exceptions.AircraftConflictTurnaround, conflict.Kind)
+				assert.Equal(testHelper, clashID, conflict.FlightID)
+				assert.Equal(testHelper, turnaround, conflict.MinTurnaround)
+			},
+		},
+		{
+			name: "PreviousFlightEndsElsewhere",
+			setup: func(mock pgxmock.PgxPoolIface, f *models.Flight) {
+				expectAircraftAvailable(mock, f, turnaround)
+				mock.ExpectQuery(aircraftPositionSQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.DepartureTime).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "number", "destination", "departure_time", "arrival_time"}).
+						AddRow(clashID, "BA178", "JFK", f.DepartureTime.Add(-10*time.Hour), f.DepartureTime.Add(-3*time.Hour)))
+			},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				var conflict *exceptions.AircraftConflictError
+				require.ErrorAs(testHelper, err, &conflict)
+				assert.Equal(testHelper, exceptions.AircraftConflictPosition, conflict.Kind)
+				assert.Equal(testHelper, clashID, conflict.FlightID)
+				assert.Equal(testHelper, "JFK", conflict.Destination)
+			},
+		},
+		{
+			name: "PreviousFlightEndsAtOrigin",
+			setup: func(mock pgxmock.PgxPoolIface, f *models.Flight) {
+				expectAircraftAvailable(mock, f, turnaround)
+				mock.ExpectQuery(aircraftPositionSQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.DepartureTime).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "number", "destination", "departure_time", "arrival_time"}).
+						AddRow(clashID, "BA112", "LHR", f.DepartureTime.Add(-10*time.Hour), f.DepartureTime.Add(-3*time.Hour)))
+				expectNoNextFlight(mock, f)
+			},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				assert.NoError(testHelper, err)
+			},
+		},
+		{
+			name: "NextFlightStartsElsewhere",
+			setup: func(mock pgxmock.PgxPoolIface, f *models.Flight) {
+				expectAircraftAvailable(mock, f, turnaround)
+				mock.ExpectQuery(aircraftPositionSQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.DepartureTime).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "number", "destination", "departure_time", "arrival_time"}))
+				mock.ExpectQuery(aircraftNextFlightSQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.DepartureTime).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "number", "origin", "departure_time", "arrival_time"}).
+						AddRow(clashID, "BA119", "LHR", f.ArrivalTime.Add(3*time.Hour), f.ArrivalTime.Add(11*time.Hour)))
+			},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				var conflict *exceptions.AircraftConflictError
+				require.ErrorAs(testHelper, err, &conflict)
+				assert.Equal(testHelper, exceptions.AircraftConflictPosition, conflict.Kind)
+				assert.Equal(testHelper, clashID, conflict.FlightID)
+				assert.Equal(testHelper, "BA119", conflict.FlightNumber)
+				assert.Equal(testHelper, "LHR", conflict.Origin)
+				assert.Empty(testHelper, conflict.Destination)
+				assert.Equal(testHelper, f.AircraftID, conflict.AircraftID)
+			},
+		},
+		{
+			name: "NextFlightStartsAtDestination",
+			setup: func(mock pgxmock.PgxPoolIface, f *models.Flight) {
+				expectAircraftAvailable(mock, f, turnaround)
+				mock.ExpectQuery(aircraftPositionSQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.DepartureTime).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "number", "destination", "departure_time", "arrival_time"}).
+						AddRow(uuid.New(), "BA112", "LHR", f.DepartureTime.Add(-10*time.Hour), f.DepartureTime.Add(-3*time.Hour)))
+				mock.ExpectQuery(aircraftNextFlightSQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.DepartureTime).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "number", "origin", "departure_time", "arrival_time"}).
+						AddRow(clashID, "BA112", "JFK", f.ArrivalTime.Add(3*time.Hour), f.ArrivalTime.Add(11*time.Hour)))
+			},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				assert.NoError(testHelper, err)
+			},
+		},
+		{
+			name:   "CancelledFlightIsNotChecked",
+			modify: func(f *models.Flight) { f.Status = models.FlightStatusCancelled },
+			setup:  func(mock pgxmock.PgxPoolIface, f *models.Flight) {},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				assert.NoError(testHelper, err)
+			},
+		},
+		{
+			name: "DatabaseError",
+			setup: func(mock pgxmock.PgxPoolIface, f *models.Flight) {
+				mock.ExpectQuery(aircraftAvailabilitySQL).
+					WithArgs(f.AircraftID, f.ID, models.FlightStatusCancelled, f.ArrivalTime.Add(turnaround), f.DepartureTime.Add(-turnaround)).
+					WillReturnError(context.DeadlineExceeded)
+			},
+			check: func(testHelper *testing.T, f *models.Flight, err error) {
+				require.ErrorIs(testHelper, err, context.DeadlineExceeded)
+				assert.NotErrorIs(testHelper, err, exceptions.ErrAircraftConflict)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		testHelper.Run(tc.name, func(testHelper *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(testHelper, err)
+			defer mock.Close()
+
+			flight := newFlight()
+			if tc.modify != nil {
+				tc.modify(flight)
+			}
+
+			mock.ExpectBegin()
+			tc.setup(mock, flight)
+
+			tx, err := mock.Begin(context.Background())
+			require.NoError(testHelper, err)
+
+			repo := &FlightRepository{pool: mock, MinTurnaround: turnaround}
+			tc.check(testHelper, flight, repo.checkAircraftConflicts(context.Background(), tx, flight))
+			assert.NoError(testHelper, mock.ExpectationsWereMet())
+		})
+	}
+}
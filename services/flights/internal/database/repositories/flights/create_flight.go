@@ -4,9 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -14,7 +15,10 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func (flightRepository *FlightRepository) CreateFlight(ctx context.Context, f *models.Flight) error {
+// CreateFlight inserts f and writes history and events to the outbox in the same
+// transaction, so the events are published if and only if the flight is stored. An
+// *exceptions.AircraftConflictError is returned if f's aircraft cannot operate it.
+func (flightRepository *FlightRepository) CreateFlight(ctx context.Context, f *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
 	tracer := otel.Tracer("flights-service")
 	ctx, span := tracer.Start(ctx, "db.create_flight")
 	defer span.End()
@@ -26,19 +30,48 @@ func (flightRepository *FlightRepository) CreateFlight(ctx context.Context, f *m
 		attribute.String("flight.number", f.Number),
 		attribute.String("flight.origin", f.Origin),
 		attribute.String("flight.destination", f.Destination),
+		attribute.String("flight.airline", f.Airline),
 	)
 
 	const query = `
         INSERT INTO flights (
             id, number, origin, destination,
             departure_time, arrival_time, status, aircraft_id,
-            created_by, last_updated_by, organization_id
+            created_by, last_updated_by, organization_id, airline
         )
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-        RETURNING created_at, updated_at
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+        RETURNING created_at, updated_at, version
     `
 
-	err := flightRepository.pool.QueryRow(
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create flight %s: begin: %w", f.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := lockAircraft(ctx, tx, f.AircraftID); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create flight %s: %w", f.ID, err)
+	}
+
+	if err := flightRepository.checkAircraftConflicts(ctx, tx, f); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, exceptions.ErrAircraftConflict) {
+			span.SetAttributes(attribute.String("db.result", "aircraft_conflict"))
+			return err
+		}
+		logger.Error("Error checking aircraft conflicts", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create flight %s: %w", f.ID, err)
+	}
+
+	err = tx.QueryRow(
 		ctx,
 		query,
 		f.ID,
@@ -52,7 +85,8 @@ func (flightRepository *FlightRepository) CreateFlight(ctx context.Context, f *m
 		f.CreatedBy,
 		f.LastUpdatedBy,
 		f.OrganizationID,
-	).Scan(&f.CreatedAt, &f.UpdatedAt)
+		f.Airline,
+	).Scan(&f.CreatedAt, &f.UpdatedAt, &f.Version)
 
 	if err != nil {
 		span.RecordError(err)
@@ -67,11 +101,7 @@ func (flightRepository *FlightRepository) CreateFlight(ctx context.Context, f *m
 
 			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_flight_instance" {
 				span.SetAttributes(attribute.String("db.result", "duplicate"))
-				return fmt.Errorf(
-					"flight with number %s at %s already exists",
-					f.Number,
-					f.DepartureTime.Format(time.RFC3339),
-				)
+				return duplicateFlightError(f)
 			} else {
 				logger.Error("Error saving flight to db", "id", f.ID, "code", pgErr.Code, "constraint", pgErr.ConstraintName, "error", err)
 				span.SetAttributes(attribute.String("db.result", "postgres_error"))
@@ -84,6 +114,26 @@ func (flightRepository *FlightRepository) CreateFlight(ctx context.Context, f *m
 		return fmt.Errorf("create flight %s: %w", f.ID, err)
 	}
 
+	if err := insertFlightHistory(ctx, tx, history, f); err != nil {
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create flight %s: commit: %w", f.ID, err)
+	}
+
 	span.SetAttributes(attribute.String("db.result", "success"))
 	return nil
 }
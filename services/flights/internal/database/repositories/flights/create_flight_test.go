@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
 )
 
 func TestFlightRepositoryCreateFlight(testHelper *testing.T) {
@@ -35,6 +36,7 @@ func TestFlightRepositoryCreateFlight(testHelper *testing.T) {
 				require.NoError(testHelper, err)
 				assert.Equal(testHelper, createdAt, flight.CreatedAt)
 				assert.Equal(testHelper, updatedAt, flight.UpdatedAt)
+				assert.Equal(testHelper, int32(1), flight.Version)
 			},
 		},
 		{
@@ -101,34 +103,46 @@ func TestFlightRepositoryCreateFlight(testHelper *testing.T) {
 				CreatedBy:      uuid.New(),
 				LastUpdatedBy:  uuid.New(),
 				OrganizationID: uuid.New(),
+				Airline:        "American Airlines",
 			}
 
-			expectedSQL := `INSERT INTO flights ( id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_by, last_updated_by, organization_id ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING created_at, updated_at`
+			expectedSQL := `INSERT INTO flights ( id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_by, last_updated_by, organization_id, airline ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING created_at, updated_at, version`
 			createdAt := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
 			updatedAt := createdAt
 
 			expectedSQL = regexp.QuoteMeta(expectedSQL)
-			expect := mock.ExpectQuery(expectedSQL).WithArgs(
-				flight.ID,
-				flight.Number,
-				flight.Origin,
-				flight.Destination,
-				flight.DepartureTime,
-				flight.ArrivalTime,
-				flight.Status,
-				flight.AircraftID,
-				flight.CreatedBy,
-				flight.LastUpdatedBy,
-				flight.OrganizationID,
-			)
-
-			if tc.returnRows {
-				expect.WillReturnRows(
-					pgxmock.NewRows([]string{"created_at", "updated_at"}).
-						AddRow(createdAt, updatedAt),
-				)
+			if tc.name == "ContextCancelled" {
+				// A cancelled context is rejected before any statement is sent.
+				mock.ExpectBegin().WillReturnError(tc.mockErr)
 			} else {
-				expect.WillReturnError(tc.mockErr)
+				mock.ExpectBegin()
+				expectAircraftLock(mock, flight.AircraftID)
+				expectNoAircraftConflicts(mock, flight, 0)
+				expect := mock.ExpectQuery(expectedSQL).WithArgs(
+					flight.ID,
+					flight.Number,
+					flight.Origin,
+					flight.Destination,
+					flight.DepartureTime,
+					flight.ArrivalTime,
+					flight.Status,
+					flight.AircraftID,
+					flight.CreatedBy,
+					flight.LastUpdatedBy,
+					flight.OrganizationID,
+					flight.Airline,
+				)
+
+				if tc.returnRows {
+					expect.WillReturnRows(
+						pgxmock.NewRows([]string{"created_at", "updated_at", "version"}).
+							AddRow(createdAt, updatedAt, int32(1)),
+					)
+					mock.ExpectCommit()
+				} else {
+					expect.WillReturnError(tc.mockErr)
+					mock.ExpectRollback()
+				}
 			}
 
 			repo := &FlightRepository{pool: mock}
@@ -139,7 +153,7 @@ func TestFlightRepositoryCreateFlight(testHelper *testing.T) {
 				cancel()
 			}
 
-			err = repo.CreateFlight(ctx, flight)
+			err = repo.CreateFlight(ctx, flight, nil)
 			tc.assertChecks(testHelper, flight, err, createdAt, updatedAt)
 
 			assert.NoError(testHelper, mock.ExpectationsWereMet())
@@ -176,14 +190,18 @@ func TestFlightRepositoryCreateFlightAllFlightStatuses(testHelper *testing.T) {
 				CreatedBy:      uuid.New(),
 				LastUpdatedBy:  uuid.New(),
 				OrganizationID: uuid.New(),
+				Airline:        "American Airlines",
 			}
 
-			expectedSQL := `INSERT INTO flights ( id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_by, last_updated_by, organization_id ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING created_at, updated_at`
+			expectedSQL := `INSERT INTO flights ( id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_by, last_updated_by, organization_id, airline ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING created_at, updated_at, version`
 			createdAt := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
 			updatedAt := createdAt
 
 			expectedSQL = regexp.QuoteMeta(expectedSQL)
 
+			mock.ExpectBegin()
+			expectAircraftLock(mock, flight.AircraftID)
+			expectNoAircraftConflicts(mock, flight, 0)
 			mock.ExpectQuery(expectedSQL).
 				WithArgs(
 					flight.ID,
@@ -197,14 +215,16 @@ func TestFlightRepositoryCreateFlightAllFlightStatuses(testHelper *testing.T) {
 					flight.CreatedBy,
 					flight.LastUpdatedBy,
 					flight.OrganizationID,
+					flight.Airline,
 				).
-				WillReturnRows(pgxmock.NewRows([]string{"created_at", "updated_at"}).
-					AddRow(createdAt, updatedAt))
+				WillReturnRows(pgxmock.NewRows([]string{"created_at", "updated_at", "version"}).
+					AddRow(createdAt, updatedAt, int32(1)))
+			mock.ExpectCommit()
 
 			repo := &FlightRepository{pool: mock}
 			ctx := context.Background()
 
-			err = repo.CreateFlight(ctx, flight)
+			err = repo.CreateFlight(ctx, flight, nil)
 
 			assert.NoError(testHelper, err)
 			assert.NotZero(testHelper, flight.CreatedAt)
@@ -215,3 +235,113 @@ func TestFlightRepositoryCreateFlightAllFlightStatuses(testHelper *testing.T) {
 		})
 	}
 }
+
+func TestFlightRepositoryCreateFlightWritesOutbox(testHelper *testing.T) {
+	insertFlightSQL := regexp.QuoteMeta(`INSERT INTO flights ( id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_by, last_updated_by, organization_id, airline ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING created_at, updated_at, version`)
+	insertOutboxSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	createdAt := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
+
+	newFixtures := func() (*models.Flight, *models.OutboxEvent) {
+		flight := &models.Flight{
+			ID:            uuid.New(),
+			Number:        "AA123",
+			DepartureTime: time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC),
+		}
+		event := &models.OutboxEvent{
+			AggregateID:  flight.ID,
+			EventType:    models.EventTypeFlightCreated,
+			Payload:      []byte(`{"id":"` + flight.ID.String() + `"}`),
+			TraceContext: map[string]string{"traceparent": "00-abc-def-01"},
+		}
+		return flight, event
+	}
+
+	testHelper.Run("Success", func(testHelper *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(testHelper, err)
+		defer mock.Close()
+
+		flight, event := newFixtures()
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, flight.AircraftID)
+		expectNoAircraftConflicts(mock, flight, 0)
+		mock.ExpectQuery(insertFlightSQL).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at", "updated_at", "version"}).AddRow(createdAt, createdAt, int32(1)))
+		mock.ExpectQuery(insertOutboxSQL).
+			WithArgs(flight.ID, models.EventTypeFlightCreated, event.Payload, event.TraceContext).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(42), createdAt))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateFlight(context.Background(), flight, nil, event)
+
+		require.NoError(testHelper, err)
+		assert.Equal(testHelper, int64(42), event.ID)
+		assert.Equal(testHelper, createdAt, event.CreatedAt)
+		assert.NoError(testHelper, mock.ExpectationsWereMet())
+	})
+
+	testHelper.Run("OutboxErrorRollsBackFlight", func(testHelper *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(testHelper, err)
+		defer mock.Close()
+
+		flight, event := newFixtures()
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, flight.AircraftID)
+		expectNoAircraftConflicts(mock, flight, 0)
+		mock.ExpectQuery(insertFlightSQL).
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at", "updated_at", "version"}).AddRow(createdAt, createdAt, int32(1)))
+		mock.ExpectQuery(insertOutboxSQL).
+			WithArgs(flight.ID, models.EventTypeFlightCreated, event.Payload, event.TraceContext).
+			WillReturnError(fmt.Errorf("outbox unavailable"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateFlight(context.Background(), flight, nil, event)
+
+		require.Error(testHelper, err)
+		assert.Contains(testHelper, err.Error(), "insert outbox event")
+		assert.NoError(testHelper, mock.ExpectationsWereMet())
+	})
+}
+
+func TestFlightRepositoryCreateFlightAircraftConflict(testHelper *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(testHelper, err)
+	defer mock.Close()
+
+	turnaround := 45 * time.Minute
+	flight := &models.Flight{
+		ID:            uuid.New(),
+		Number:        "BA117",
+		Origin:        "LHR",
+		Destination:   "JFK",
+		DepartureTime: time.Date(2025, 4, 1, 7, 25, 0, 0, time.UTC),
+		ArrivalTime:   time.Date(2025, 4, 1, 15, 25, 0, 0, time.UTC),
+		Status:        models.FlightStatusScheduled,
+		AircraftID:    uuid.New(),
+	}
+	clashID := uuid.New()
+
+	mock.ExpectBegin()
+	expectAircraftLock(mock, flight.AircraftID)
+	mock.ExpectQuery(aircraftAvailabilitySQL).
+		WithArgs(flight.AircraftID, flight.ID, models.FlightStatusCancelled, flight.ArrivalTime.Add(turnaround), flight.DepartureTime.Add(-turnaround)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "number", "departure_time", "arrival_time"}).
+			AddRow(clashID, "BA175", flight.DepartureTime, flight.ArrivalTime))
+	mock.ExpectRollback()
+
+	repo := &FlightRepository{pool: mock, MinTurnaround: turnaround}
+	err = repo.CreateFlight(context.Background(), flight, nil)
+
+	var conflict *exceptions.AircraftConflictError
+	require.ErrorAs(testHelper, err, &conflict)
+	assert.Equal(testHelper, clashID, conflict.FlightID)
+	assert.Equal(testHelper, exceptions.AircraftConflictOverlap, conflict.Kind)
+	assert.NoError(testHelper, mock.ExpectationsWereMet())
+}
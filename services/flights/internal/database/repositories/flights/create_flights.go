@@ -0,0 +1,142 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// flightCopyColumns are the columns CreateFlights loads; the rest take their defaults.
+var flightCopyColumns = []string{
+	"id", "number", "origin", "destination",
+	"departure_time", "arrival_time", "status", "aircraft_id",
+	"created_by", "last_updated_by", "organization_id", "airline", "version",
+}
+
+// CreateFlights bulk loads flights with COPY, together with their history (paired by
+// index) and events, in one transaction. Either every flight is stored or none is, so
+// an *exceptions.AircraftConflictError for any flight fails the batch. The timestamps
+// are left to the database and are not read back.
+func (flightRepository *FlightRepository) CreateFlights(
+	ctx context.Context,
+	flights []*models.Flight,
+	history []*models.FlightHistoryEntry,
+	events []*models.OutboxEvent,
+) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.create_flights")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "copy"),
+		attribute.String("db.table", "flights"),
+		attribute.Int("db.rows", len(flights)),
+	)
+
+	if len(flights) == 0 {
+		span.SetAttributes(attribute.String("db.result", "success"))
+		return nil
+	}
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create %d flights: begin: %w", len(flights), err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	aircraftIDs := make([]uuid.UUID, len(flights))
+	for i, f := range flights {
+		aircraftIDs[i] = f.AircraftID
+	}
+	if err := lockAircraft(ctx, tx, aircraftIDs...); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create %d flights: %w", len(flights), err)
+	}
+
+	for _, f := range flights {
+		f.Version = 1
+	}
+
+	_, err = tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"flights"},
+		flightCopyColumns,
+		pgx.CopyFromSlice(len(flights), func(i int) ([]any, error) {
+			f := flights[i]
+			return []any{
+				f.ID, f.Number, f.Origin, f.Destination,
+				f.DepartureTime, f.ArrivalTime, f.Status, f.AircraftID,
+				f.CreatedBy, f.LastUpdatedBy, f.OrganizationID, f.Airline, f.Version,
+			}, nil
+		}),
+	)
+	if err != nil {
+		span.RecordError(err)
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_flight_instance" {
+			span.SetAttributes(attribute.String("db.result", "duplicate"))
+			return fmt.Errorf("%w: a flight in the batch already exists: %s", exceptions.ErrDuplicateFlight, pgErr.Detail)
+		}
+
+		logger.Error("Error copying flights to db", "count", len(flights), "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create %d flights: %w", len(flights), err)
+	}
+
+	// Checked once the whole batch is loaded, so its flights are checked against each
+	// other as well as against those already stored.
+	for _, f := range flights {
+		if err := flightRepository.checkAircraftConflicts(ctx, tx, f); err != nil {
+			span.RecordError(err)
+			if errors.Is(err, exceptions.ErrAircraftConflict) {
+				span.SetAttributes(attribute.String("db.result", "aircraft_conflict"))
+				return fmt.Errorf("flight %s at %s: %w", f.Number, f.DepartureTime.Format(time.RFC3339), err)
+			}
+			logger.Error("Error checking aircraft conflicts", "count", len(flights), "error", err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return fmt.Errorf("create %d flights: %w", len(flights), err)
+		}
+	}
+
+	if err := copyFlightHistory(ctx, tx, history, flights); err != nil {
+		logger.Error("Error writing flight history", "count", len(flights), "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create %d flights: %w", len(flights), err)
+	}
+
+	if err := outbox.CopyEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "count", len(flights), "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create %d flights: %w", len(flights), err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create %d flights: commit: %w", len(flights), err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
@@ -0,0 +1,166 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryCreateFlights(t *testing.T) {
+	historyColumns := []string{"id", "flight_id", "organization_id", "operation", "actor_id", "before", "after"}
+	outboxColumns := []string{"aggregate_id", "event_type", "payload", "trace_context"}
+
+	newBatch := func() ([]*models.Flight, []*models.FlightHistoryEntry, []*models.OutboxEvent) {
+		departure := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+		var flights []*models.Flight
+		var history []*models.FlightHistoryEntry
+		var events []*models.OutboxEvent
+		for i := 0; i < 2; i++ {
+			f := &models.Flight{
+				ID:             uuid.New(),
+				Number:         "BA123",
+				Origin:         "LHR",
+				Destination:    "JFK",
+				DepartureTime:  departure.AddDate(0, 0, i),
+				ArrivalTime:    departure.AddDate(0, 0, i).Add(8 * time.Hour),
+				Status:         models.FlightStatusScheduled,
+				OrganizationID: testOrgID,
+			}
+			flights = append(flights, f)
+			history = append(history, &models.FlightHistoryEntry{Operation: models.FlightHistoryOperationCreated})
+			events = append(events, &models.OutboxEvent{AggregateID: f.ID, EventType: models.EventTypeFlightCreated, Payload: []byte(`{}`)})
+		}
+		return flights, history, events
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flights, history, events := newBatch()
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, uuid.Nil)
+		mock.ExpectCopyFrom(pgx.Identifier{"flights"}, flightCopyColumns).WillReturnResult(2)
+		for _, f := range flights {
+			expectNoAircraftConflicts(mock, f, 0)
+		}
+		mock.ExpectCopyFrom(pgx.Identifier{"flight_history"}, historyColumns).WillReturnResult(2)
+		mock.ExpectCopyFrom(pgx.Identifier{"outbox"}, outboxColumns).WillReturnResult(2)
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateFlights(context.Background(), flights, history, events)
+
+		require.NoError(t, err)
+		for i, f := range flights {
+			assert.Equal(t, int32(1), f.Version)
+			assert.Equal(t, f.ID, history[i].FlightID)
+			assert.Equal(t, testOrgID, history[i].OrganizationID)
+			assert.NotEqual(t, uuid.Nil, history[i].ID)
+			assert.Contains(t, string(history[i].After), f.ID.String())
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Flights", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateFlights(context.Background(), nil, nil, nil)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicate Rolls Back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flights, history, events := newBatch()
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, uuid.Nil)
+		mock.ExpectCopyFrom(pgx.Identifier{"flights"}, flightCopyColumns).WillReturnError(&pgconn.PgError{
+			Code:           pgerrcode.UniqueViolation,
+			ConstraintName: "unique_flight_instance",
+			Detail:         "Key (number, departure_time) already exists.",
+		})
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateFlights(context.Background(), flights, history, events)
+
+		assert.ErrorIs(t, err, exceptions.ErrDuplicateFlight)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Outbox Error Rolls Back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flights, history, events := newBatch()
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, uuid.Nil)
+		mock.ExpectCopyFrom(pgx.Identifier{"flights"}, flightCopyColumns).WillReturnResult(2)
+		for _, f := range flights {
+			expectNoAircraftConflicts(mock, f, 0)
+		}
+		mock.ExpectCopyFrom(pgx.Identifier{"flight_history"}, historyColumns).WillReturnResult(2)
+		mock.ExpectCopyFrom(pgx.Identifier{"outbox"}, outboxColumns).WillReturnError(errors.New("copy failed"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateFlights(context.Background(), flights, history, events)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "create 2 flights")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Aircraft Conflict Rolls Back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flights, history, events := newBatch()
+		clash := flights[0]
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, uuid.Nil)
+		mock.ExpectCopyFrom(pgx.Identifier{"flights"}, flightCopyColumns).WillReturnResult(2)
+		mock.ExpectQuery(aircraftAvailabilitySQL).
+			WithArgs(clash.AircraftID, clash.ID, models.FlightStatusCancelled, clash.ArrivalTime, clash.DepartureTime).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "number", "departure_time", "arrival_time"}).
+				AddRow(uuid.New(), "BA175", clash.DepartureTime, clash.ArrivalTime))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateFlights(context.Background(), flights, history, events)
+
+		var conflict *exceptions.AircraftConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, "BA175", conflict.FlightNumber)
+		assert.Contains(t, err.Error(), "flight BA123 at 2025-01-06T10:00:00Z")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,66 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CreateSchedule inserts s and fills in its stored version and timestamps. No flights
+// are generated here; that is left to MaterializeSchedule.
+func (flightRepository *FlightRepository) CreateSchedule(ctx context.Context, s *models.Schedule) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.create_schedule")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "schedules"),
+		attribute.String("schedule.id", s.ID.String()),
+		attribute.String("flight.number", s.Number),
+	)
+
+	const query = `
+        INSERT INTO schedules (
+            id, number, origin, destination,
+            departure_local_time, time_zone, duration_minutes, days_of_week,
+            start_date, end_date, aircraft_id,
+            organization_id, airline, created_by, last_updated_by
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+        RETURNING version, created_at, updated_at
+    `
+
+	err := flightRepository.pool.QueryRow(
+		ctx,
+		query,
+		s.ID,
+		s.Number,
+		s.Origin,
+		s.Destination,
+		s.DepartureLocalTime,
+		s.TimeZone,
+		s.DurationMinutes,
+		s.DaysOfWeek,
+		s.StartDate,
+		s.EndDate,
+		s.AircraftID,
+		s.OrganizationID,
+		s.Airline,
+		s.CreatedBy,
+		s.LastUpdatedBy,
+	).Scan(&s.Version, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		logger.Error("Error inserting schedule into db", "id", s.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("create schedule %s: %w", s.ID, err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
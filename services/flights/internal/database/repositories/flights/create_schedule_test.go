@@ -0,0 +1,98 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+var scheduleColumnNames = []string{
+	"id", "number", "origin", "destination", "departure_local_time", "time_zone", "duration_minutes", "days_of_week",
+	"start_date", "end_date", "aircraft_id", "organization_id", "airline", "created_by", "last_updated_by",
+	"version", "materialized_through", "created_at", "updated_at",
+}
+
+func newTestSchedule() *models.Schedule {
+	return &models.Schedule{
+		ID:                 uuid.New(),
+		Number:             "BA117",
+		Origin:             "LHR",
+		Destination:        "JFK",
+		DepartureLocalTime: "08:25",
+		TimeZone:           "Europe/London",
+		DurationMinutes:    480,
+		DaysOfWeek:         models.WeekdaysOf(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday),
+		StartDate:          time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:            time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC),
+		AircraftID:         uuid.New(),
+		OrganizationID:     testOrgID,
+		Airline:            "British Airways",
+		CreatedBy:          uuid.New(),
+		LastUpdatedBy:      uuid.New(),
+		Version:            1,
+	}
+}
+
+func addScheduleRow(rows *pgxmock.Rows, s *models.Schedule) *pgxmock.Rows {
+	return rows.AddRow(
+		s.ID, s.Number, s.Origin, s.Destination, s.DepartureLocalTime, s.TimeZone, s.DurationMinutes, s.DaysOfWeek,
+		s.StartDate, s.EndDate, s.AircraftID, s.OrganizationID, s.Airline, s.CreatedBy, s.LastUpdatedBy,
+		s.Version, s.MaterializedThrough, s.CreatedAt, s.UpdatedAt,
+	)
+}
+
+func TestFlightRepositoryCreateSchedule(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta(`INSERT INTO schedules ( id, number, origin, destination, departure_local_time, time_zone, duration_minutes, days_of_week, start_date, end_date, aircraft_id, organization_id, airline, created_by, last_updated_by ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING version, created_at, updated_at`)
+	createdAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	expectInsert := func(mock pgxmock.PgxPoolIface, s *models.Schedule) *pgxmock.ExpectedQuery {
+		return mock.ExpectQuery(expectedSQL).WithArgs(
+			s.ID, s.Number, s.Origin, s.Destination, s.DepartureLocalTime, s.TimeZone, s.DurationMinutes, s.DaysOfWeek,
+			s.StartDate, s.EndDate, s.AircraftID, s.OrganizationID, s.Airline, s.CreatedBy, s.LastUpdatedBy,
+		)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		schedule := newTestSchedule()
+		schedule.Version = 0
+		expectInsert(mock, schedule).
+			WillReturnRows(pgxmock.NewRows([]string{"version", "created_at", "updated_at"}).AddRow(int32(1), createdAt, createdAt))
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateSchedule(context.Background(), schedule)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), schedule.Version)
+		assert.Equal(t, createdAt, schedule.CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		schedule := newTestSchedule()
+		expectInsert(mock, schedule).WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.CreateSchedule(context.Background(), schedule)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "create schedule "+schedule.ID.String())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,88 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DeleteFlight marks f as deleted by setting its deleted_at tombstone; the row itself is
+// kept so it can be restored. A flight that is missing or already deleted yields
+// ErrNotFound. The history entry and any events are written in the same transaction.
+func (flightRepository *FlightRepository) DeleteFlight(ctx context.Context, f *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.delete_flight")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+		attribute.String("flight.id", f.ID.String()),
+	)
+
+	const query = `
+        UPDATE flights
+        SET deleted_at = NOW(), last_updated_by = $2, version = version + 1
+        WHERE id = $1 AND deleted_at IS NULL
+        RETURNING deleted_at, updated_at, version
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("delete flight %s: begin: %w", f.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	err = tx.QueryRow(ctx, query, f.ID, deletedBy).Scan(&f.DeletedAt, &f.UpdatedAt, &f.Version)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "not_found"))
+			return fmt.Errorf("%w: flight with id=%s", exceptions.ErrNotFound, f.ID)
+		}
+		logger.Error("Error deleting flight in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("delete flight %s: %w", f.ID, err)
+	}
+
+	deleted := *f
+	deleted.LastUpdatedBy = deletedBy
+	if err := insertFlightHistory(ctx, tx, history, &deleted); err != nil {
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("delete flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("delete flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("delete flight %s: commit: %w", f.ID, err)
+	}
+
+	f.LastUpdatedBy = deletedBy
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
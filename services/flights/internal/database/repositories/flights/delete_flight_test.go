@@ -0,0 +1,110 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryDeleteFlight(t *testing.T) {
+	deleteSQL := regexp.QuoteMeta(`UPDATE flights SET deleted_at = NOW(), last_updated_by = $2, version = version + 1 WHERE id = $1 AND deleted_at IS NULL RETURNING deleted_at, updated_at, version`)
+	historySQL := regexp.QuoteMeta(`INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`)
+	outboxSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	deletedAt := time.Date(2024, 12, 15, 10, 5, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight := &models.Flight{ID: uuid.New(), OrganizationID: testOrgID, Version: 2}
+		deletedBy := uuid.New()
+		history := &models.FlightHistoryEntry{Operation: models.FlightHistoryOperationDeleted, ActorID: deletedBy, Before: []byte(`{}`)}
+		historyID := uuid.New()
+		event := &models.OutboxEvent{AggregateID: flight.ID, EventType: models.EventTypeFlightDeleted, Payload: []byte(`{}`)}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(deleteSQL).
+			WithArgs(flight.ID, deletedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"deleted_at", "updated_at", "version"}).AddRow(&deletedAt, deletedAt, int32(3)))
+		mock.ExpectQuery(historySQL).
+			WithArgs(flight.ID, testOrgID, models.FlightHistoryOperationDeleted, deletedBy, history.Before, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(historyID, deletedAt))
+		mock.ExpectQuery(outboxSQL).
+			WithArgs(flight.ID, models.EventTypeFlightDeleted, event.Payload, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(9), deletedAt))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.DeleteFlight(context.Background(), flight, deletedBy, history, event)
+
+		require.NoError(t, err)
+		require.NotNil(t, flight.DeletedAt)
+		assert.Equal(t, deletedAt, *flight.DeletedAt)
+		assert.Equal(t, int32(3), flight.Version)
+		assert.Equal(t, deletedBy, flight.LastUpdatedBy)
+
+		assert.Equal(t, historyID, history.ID)
+		assert.Equal(t, flight.ID, history.FlightID)
+		var after models.Flight
+		require.NoError(t, json.Unmarshal(history.After, &after))
+		require.NotNil(t, after.DeletedAt)
+		assert.Equal(t, int32(3), after.Version)
+		assert.Equal(t, deletedBy, after.LastUpdatedBy)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already Deleted", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight := &models.Flight{ID: uuid.New(), Version: 2}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(deleteSQL).
+			WithArgs(flight.ID, pgxmock.AnyArg()).
+			WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.DeleteFlight(context.Background(), flight, uuid.New(), nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+		assert.Nil(t, flight.DeletedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight := &models.Flight{ID: uuid.New()}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(deleteSQL).
+			WithArgs(flight.ID, pgxmock.AnyArg()).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.DeleteFlight(context.Background(), flight, uuid.New(), nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection reset")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,75 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// FindFlightInstances returns the live flights that share a number and departure time
+// with any of candidates, so a bulk import can report duplicates row by row before
+// loading. The lookup is not scoped to an organization because flight instances are
+// unique across all of them.
+func (flightRepository *FlightRepository) FindFlightInstances(ctx context.Context, candidates []*models.Flight) ([]*models.Flight, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.find_flight_instances")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "flights"),
+		attribute.Int("db.candidates", len(candidates)),
+	)
+
+	numbers := make([]string, len(candidates))
+	departures := make([]time.Time, len(candidates))
+	for i, candidate := range candidates {
+		numbers[i] = candidate.Number
+		departures[i] = candidate.DepartureTime
+	}
+
+	const query = `
+        SELECT ` + flightColumns + `
+        FROM flights
+        WHERE (number, departure_time) IN (
+            SELECT * FROM unnest($1::text[], $2::timestamptz[])
+        )
+          AND deleted_at IS NULL
+    `
+
+	rows, err := flightRepository.pool.Query(ctx, query, numbers, departures)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("find flight instances: %w", err)
+	}
+	defer rows.Close()
+
+	var flights []*models.Flight
+	for rows.Next() {
+		flight, err := scanFlight(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "scan_error"))
+			return nil, fmt.Errorf("find flight instances: %w", err)
+		}
+		flights = append(flights, flight)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("find flight instances: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(flights)),
+	)
+
+	return flights, nil
+}
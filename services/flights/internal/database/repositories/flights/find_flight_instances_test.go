@@ -0,0 +1,65 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+func TestFlightRepositoryFindFlightInstances(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta("SELECT " + flightColumns + " FROM flights WHERE (number, departure_time) IN ( SELECT * FROM unnest($1::text[], $2::timestamptz[]) ) AND deleted_at IS NULL")
+	departure := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	candidates := []*models.Flight{
+		{Number: "BA123", DepartureTime: departure},
+		{Number: "BA125", DepartureTime: departure},
+	}
+	numbers := []string{"BA123", "BA125"}
+	departures := []time.Time{departure, departure}
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		existingID := uuid.New()
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(numbers, departures).
+			WillReturnRows(pgxmock.NewRows(listColumns).
+				AddRow(existingID, "BA123", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusScheduled, uuid.New(), departure, departure, int32(1), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.FindFlightInstances(context.Background(), candidates)
+
+		require.NoError(t, err)
+		require.Len(t, flights, 1)
+		assert.Equal(t, existingID, flights[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(numbers, departures).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.FindFlightInstances(context.Background(), candidates)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "find flight instances")
+		assert.Nil(t, flights)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -13,7 +13,10 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func (flightRepository *FlightRepository) GetFlightByID(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+// GetFlightByID loads a single flight. When orgID is set a flight belonging to another
+// organization is reported as not found; a nil orgID reads across organizations. Deleted
+// flights are reported as not found unless includeDeleted is set.
+func (flightRepository *FlightRepository) GetFlightByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
 	tracer := otel.Tracer("flights-service")
 	ctx, span := tracer.Start(ctx, "db.get_flight_by_id")
 	defer span.End()
@@ -22,27 +25,19 @@ func (flightRepository *FlightRepository) GetFlightByID(ctx context.Context, id
 		attribute.String("db.operation", "select"),
 		attribute.String("db.table", "flights"),
 		attribute.String("flight.id", id.String()),
+		attribute.Bool("db.org_scoped", orgID != nil),
+		attribute.Bool("db.include_deleted", includeDeleted),
 	)
 
 	const query = `
-        SELECT id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_at, updated_at
+        SELECT ` + flightColumns + `
         FROM flights
         WHERE id = $1
+          AND ($2::uuid IS NULL OR organization_id = $2)
+          AND ($3 OR deleted_at IS NULL)
     `
 
-	var flight models.Flight
-	err := flightRepository.pool.QueryRow(ctx, query, id).Scan(
-		&flight.ID,
-		&flight.Number,
-		&flight.Origin,
-		&flight.Destination,
-		&flight.DepartureTime,
-		&flight.ArrivalTime,
-		&flight.Status,
-		&flight.AircraftID,
-		&flight.CreatedAt,
-		&flight.UpdatedAt,
-	)
+	flight, err := scanFlight(flightRepository.pool.QueryRow(ctx, query, id, orgID, includeDeleted))
 
 	if err != nil {
 		span.RecordError(err)
@@ -59,5 +54,5 @@ func (flightRepository *FlightRepository) GetFlightByID(ctx context.Context, id
 		attribute.String("flight.number", flight.Number),
 	)
 
-	return &flight, nil
+	return flight, nil
 }
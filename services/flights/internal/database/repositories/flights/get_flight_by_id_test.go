@@ -34,6 +34,10 @@ func TestFlightRepositoryGetFlightByID(t *testing.T) {
 				assert.Equal(t, "AA123", flight.Number)
 				assert.Equal(t, createdAt, flight.CreatedAt)
 				assert.Equal(t, updatedAt, flight.UpdatedAt)
+				assert.NotEqual(t, uuid.Nil, flight.OrganizationID)
+				assert.Equal(t, "British Airways", flight.Airline)
+				assert.NotEqual(t, uuid.Nil, flight.CreatedBy)
+				assert.NotEqual(t, uuid.Nil, flight.LastUpdatedBy)
 			},
 		},
 		{
@@ -79,21 +83,25 @@ func TestFlightRepositoryGetFlightByID(t *testing.T) {
 			defer mock.Close()
 
 			flightID := uuid.New()
+			orgID := uuid.New()
 			expectedSQL := `
-				SELECT id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_at, updated_at
+				SELECT id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_at, updated_at, version, organization_id, airline, created_by, last_updated_by, deleted_at, estimated_departure_time, estimated_arrival_time, actual_departure_time, actual_arrival_time, delay_code, actual_takeoff_time, actual_landing_time
 				FROM flights
 				WHERE id = $1
+				  AND ($2::uuid IS NULL OR organization_id = $2)
+				  AND ($3 OR deleted_at IS NULL)
 			`
 			createdAt := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
 			updatedAt := createdAt
 
 			expect := mock.ExpectQuery(regexp.QuoteMeta(expectedSQL)).
-				WithArgs(flightID)
+				WithArgs(flightID, &orgID, false)
 
 			if tc.returnRows {
 				expect.WillReturnRows(
 					pgxmock.NewRows([]string{
-						"id", "number", "origin", "destination", "departure_time", "arrival_time", "status", "aircraft_id", "created_at", "updated_at",
+						"id", "number", "origin", "destination", "departure_time", "arrival_time", "status", "aircraft_id", "created_at", "updated_at", "version", "organization_id", "airline", "created_by", "last_updated_by", "deleted_at",
+						"estimated_departure_time", "estimated_arrival_time", "actual_departure_time", "actual_arrival_time", "delay_code", "actual_takeoff_time", "actual_landing_time",
 					}).AddRow(
 						flightID,
 						"AA123",
@@ -105,6 +113,19 @@ func TestFlightRepositoryGetFlightByID(t *testing.T) {
 						uuid.New(),
 						createdAt,
 						updatedAt,
+						int32(1),
+						orgID,
+						"British Airways",
+						uuid.New(),
+						uuid.New(),
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					),
 				)
 			} else {
@@ -119,7 +140,7 @@ func TestFlightRepositoryGetFlightByID(t *testing.T) {
 				cancel()
 			}
 
-			flight, err := repo.GetFlightByID(ctx, flightID)
+			flight, err := repo.GetFlightByID(ctx, flightID, &orgID, false)
 			tc.assertChecks(t, flight, err, createdAt, updatedAt, flightID)
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -0,0 +1,64 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetFlightStates loads the stored state of every flight in ids, across every
+// organization and including deleted flights, so a change can be announced whatever
+// it was. Unknown ids are omitted, and rows come back in no particular order.
+func (flightRepository *FlightRepository) GetFlightStates(ctx context.Context, ids []uuid.UUID) ([]*models.Flight, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.get_flight_states")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "flights"),
+		attribute.Int("db.ids", len(ids)),
+	)
+
+	const query = `
+        SELECT ` + flightColumns + `
+        FROM flights
+        WHERE id = ANY($1)
+    `
+
+	rows, err := flightRepository.pool.Query(ctx, query, ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("get flight states: %w", err)
+	}
+	defer rows.Close()
+
+	flights := make([]*models.Flight, 0, len(ids))
+	for rows.Next() {
+		flight, err := scanFlight(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "scan_error"))
+			return nil, fmt.Errorf("get flight states: %w", err)
+		}
+		flights = append(flights, flight)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("get flight states: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(flights)),
+	)
+
+	return flights, nil
+}
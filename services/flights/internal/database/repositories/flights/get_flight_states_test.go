@@ -0,0 +1,64 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+func TestFlightRepositoryGetFlightStates(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta("SELECT " + flightColumns + " FROM flights WHERE id = ANY($1)")
+	departure := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	liveID, deletedID := uuid.New(), uuid.New()
+	ids := []uuid.UUID{liveID, deletedID}
+
+	t.Run("Includes Deleted Flights", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		deletedAt := departure.Add(-time.Hour)
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(ids).
+			WillReturnRows(pgxmock.NewRows(listColumns).
+				AddRow(liveID, "BA117", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusScheduled, uuid.New(), departure, departure, int32(1), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil).
+				AddRow(deletedID, "BA119", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusScheduled, uuid.New(), departure, departure, int32(3), uuid.New(), "Other Airline", uuid.New(), uuid.New(), &deletedAt, nil, nil, nil, nil, nil, nil, nil))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.GetFlightStates(context.Background(), ids)
+
+		require.NoError(t, err)
+		require.Len(t, flights, 2)
+		assert.Equal(t, liveID, flights[0].ID)
+		assert.Equal(t, deletedID, flights[1].ID)
+		require.NotNil(t, flights[1].DeletedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(ids).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.GetFlightStates(context.Background(), ids)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "get flight states")
+		assert.Nil(t, flights)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,68 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetFlightsByIDs loads every flight in ids with a single query. Unknown ids are
+// omitted from the result rather than reported as errors, and rows come back in
+// no particular order. Deleted flights are always omitted, and a non-nil orgID also
+// omits flights of other organizations.
+func (flightRepository *FlightRepository) GetFlightsByIDs(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.get_flights_by_ids")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "flights"),
+		attribute.Int("db.ids", len(ids)),
+		attribute.Bool("db.org_scoped", orgID != nil),
+	)
+
+	const query = `
+        SELECT ` + flightColumns + `
+        FROM flights
+        WHERE id = ANY($1)
+          AND ($2::uuid IS NULL OR organization_id = $2)
+          AND deleted_at IS NULL
+    `
+
+	rows, err := flightRepository.pool.Query(ctx, query, ids, orgID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("get flights by ids: %w", err)
+	}
+	defer rows.Close()
+
+	flights := make([]*models.Flight, 0, len(ids))
+	for rows.Next() {
+		flight, err := scanFlight(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "scan_error"))
+			return nil, fmt.Errorf("get flights by ids: %w", err)
+		}
+		flights = append(flights, flight)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("get flights by ids: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(flights)),
+	)
+
+	return flights, nil
+}
@@ -0,0 +1,62 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+func TestFlightRepositoryGetFlightsByIDs(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta("SELECT " + flightColumns + " FROM flights WHERE id = ANY($1) AND ($2::uuid IS NULL OR organization_id = $2) AND deleted_at IS NULL")
+	departure := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	firstID, secondID, missingID := uuid.New(), uuid.New(), uuid.New()
+	ids := []uuid.UUID{firstID, secondID, missingID}
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(ids, &testOrgID).
+			WillReturnRows(pgxmock.NewRows(listColumns).
+				AddRow(secondID, "BA119", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusScheduled, uuid.New(), departure, departure, int32(1), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil).
+				AddRow(firstID, "BA117", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusDelayed, uuid.New(), departure, departure, int32(2), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.GetFlightsByIDs(context.Background(), ids, &testOrgID)
+
+		require.NoError(t, err)
+		require.Len(t, flights, 2)
+		assert.Equal(t, secondID, flights[0].ID)
+		assert.Equal(t, firstID, flights[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(ids, (*uuid.UUID)(nil)).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.GetFlightsByIDs(context.Background(), ids, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "get flights by ids")
+		assert.Nil(t, flights)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,50 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetScheduleByID loads a single schedule. When orgID is set a schedule belonging to
+// another organization is reported as not found; a nil orgID reads across organizations.
+func (flightRepository *FlightRepository) GetScheduleByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID) (*models.Schedule, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.get_schedule_by_id")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "schedules"),
+		attribute.String("schedule.id", id.String()),
+		attribute.Bool("db.org_scoped", orgID != nil),
+	)
+
+	const query = `
+        SELECT ` + scheduleColumns + `
+        FROM schedules
+        WHERE id = $1
+          AND ($2::uuid IS NULL OR organization_id = $2)
+    `
+
+	schedule, err := scanSchedule(flightRepository.pool.QueryRow(ctx, query, id, orgID))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "not_found"))
+			return nil, fmt.Errorf("%w: schedule with id=%s", exceptions.ErrNotFound, id)
+		}
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("get schedule %s: %w", id, err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return schedule, nil
+}
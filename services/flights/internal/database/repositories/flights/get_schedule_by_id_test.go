@@ -0,0 +1,76 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryGetScheduleByID(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta("SELECT " + scheduleColumns + " FROM schedules WHERE id = $1 AND ($2::uuid IS NULL OR organization_id = $2)")
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		stored := newTestSchedule()
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(stored.ID, &testOrgID).
+			WillReturnRows(addScheduleRow(pgxmock.NewRows(scheduleColumnNames), stored))
+
+		repo := &FlightRepository{pool: mock}
+		schedule, err := repo.GetScheduleByID(context.Background(), stored.ID, &testOrgID)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, schedule)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		id := uuid.New()
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(id, &testOrgID).
+			WillReturnError(pgx.ErrNoRows)
+
+		repo := &FlightRepository{pool: mock}
+		schedule, err := repo.GetScheduleByID(context.Background(), id, &testOrgID)
+
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+		assert.Nil(t, schedule)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		id := uuid.New()
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(id, (*uuid.UUID)(nil)).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		schedule, err := repo.GetScheduleByID(context.Background(), id, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "get schedule "+id.String())
+		assert.NotErrorIs(t, err, exceptions.ErrNotFound)
+		assert.Nil(t, schedule)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,89 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// insertFlightHistory completes entry with the state of f after the write and stores it
+// using tx, which is expected to be the transaction that performed the write. A nil
+// entry records nothing.
+func insertFlightHistory(ctx context.Context, tx pgx.Tx, entry *models.FlightHistoryEntry, f *models.Flight) error {
+	if entry == nil {
+		return nil
+	}
+
+	after, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("encode flight history for %s: %w", f.ID, err)
+	}
+
+	entry.FlightID = f.ID
+	entry.OrganizationID = f.OrganizationID
+	entry.After = after
+
+	const query = `
+        INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at
+    `
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		entry.FlightID,
+		entry.OrganizationID,
+		entry.Operation,
+		entry.ActorID,
+		entry.Before,
+		entry.After,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert flight history %s for %s: %w", entry.Operation, f.ID, err)
+	}
+
+	return nil
+}
+
+// copyFlightHistory completes entries, pairing entries[i] with flights[i] as
+// insertFlightHistory does, and stores them with a single COPY using tx.
+func copyFlightHistory(ctx context.Context, tx pgx.Tx, entries []*models.FlightHistoryEntry, flights []*models.Flight) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if len(entries) != len(flights) {
+		return fmt.Errorf("copy flight history: %d entries for %d flights", len(entries), len(flights))
+	}
+
+	for i, entry := range entries {
+		after, err := json.Marshal(flights[i])
+		if err != nil {
+			return fmt.Errorf("encode flight history for %s: %w", flights[i].ID, err)
+		}
+
+		entry.ID = uuid.New()
+		entry.FlightID = flights[i].ID
+		entry.OrganizationID = flights[i].OrganizationID
+		entry.After = after
+	}
+
+	_, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"flight_history"},
+		[]string{"id", "flight_id", "organization_id", "operation", "actor_id", "before", "after"},
+		pgx.CopyFromSlice(len(entries), func(i int) ([]any, error) {
+			entry := entries[i]
+			return []any{entry.ID, entry.FlightID, entry.OrganizationID, entry.Operation, entry.ActorID, entry.Before, entry.After}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("copy %d flight history entries: %w", len(entries), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListActiveSchedules returns up to limit schedules, across every organization, that
+// still operate on or after the calendar date from. Schedules are ordered by ID; pass
+// the last ID of a page as after to read the next one.
+func (flightRepository *FlightRepository) ListActiveSchedules(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.list_active_schedules")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "schedules"),
+		attribute.Int("db.limit", limit),
+	)
+
+	const query = `
+        SELECT ` + scheduleColumns + `
+        FROM schedules
+        WHERE end_date >= $1
+          AND ($2::uuid IS NULL OR id > $2)
+        ORDER BY id
+        LIMIT $3
+    `
+
+	rows, err := flightRepository.pool.Query(ctx, query, from, after, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list active schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := make([]*models.Schedule, 0, limit)
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return nil, fmt.Errorf("list active schedules: scan: %w", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list active schedules: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(schedules)),
+	)
+	return schedules, nil
+}
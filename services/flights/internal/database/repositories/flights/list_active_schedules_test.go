@@ -0,0 +1,57 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightRepositoryListActiveSchedules(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta("SELECT " + scheduleColumns + " FROM schedules WHERE end_date >= $1 AND ($2::uuid IS NULL OR id > $2) ORDER BY id LIMIT $3")
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		after := uuid.New()
+		first, second := newTestSchedule(), newTestSchedule()
+		rows := addScheduleRow(addScheduleRow(pgxmock.NewRows(scheduleColumnNames), first), second)
+		mock.ExpectQuery(expectedSQL).WithArgs(from, &after, 50).WillReturnRows(rows)
+
+		repo := &FlightRepository{pool: mock}
+		schedules, err := repo.ListActiveSchedules(context.Background(), from, 50, &after)
+
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+		assert.Equal(t, first.ID, schedules[0].ID)
+		assert.Equal(t, second.ID, schedules[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(from, (*uuid.UUID)(nil), 50).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		schedules, err := repo.ListActiveSchedules(context.Background(), from, 50, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "list active schedules")
+		assert.Nil(t, schedules)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
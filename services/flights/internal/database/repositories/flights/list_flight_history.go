@@ -0,0 +1,92 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListFlightHistory returns up to limit history entries of the flight, oldest first.
+// When after is set only entries strictly after that cursor position are returned. A
+// non-nil orgID restricts the entries to that organization.
+func (flightRepository *FlightRepository) ListFlightHistory(
+	ctx context.Context,
+	flightID uuid.UUID,
+	orgID *uuid.UUID,
+	limit int,
+	after *pagination.Cursor,
+) ([]*models.FlightHistoryEntry, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.list_flight_history")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "flight_history"),
+		attribute.String("flight.id", flightID.String()),
+		attribute.Int("db.limit", limit),
+		attribute.Bool("db.paginated", after != nil),
+	)
+
+	const query = `
+        SELECT id, flight_id, organization_id, operation, actor_id, before, after, created_at
+        FROM flight_history
+        WHERE flight_id = $1
+          AND ($2::uuid IS NULL OR organization_id = $2)
+          AND ($3::timestamptz IS NULL OR (created_at, id) > ($3, $4))
+        ORDER BY created_at, id
+        LIMIT $5
+    `
+
+	var afterTime, afterID any
+	if after != nil {
+		afterTime, afterID = after.Time, after.ID
+	}
+
+	rows, err := flightRepository.pool.Query(ctx, query, flightID, orgID, afterTime, afterID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list flight history %s: %w", flightID, err)
+	}
+	defer rows.Close()
+
+	entries := make([]*models.FlightHistoryEntry, 0, limit)
+	for rows.Next() {
+		var entry models.FlightHistoryEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.FlightID,
+			&entry.OrganizationID,
+			&entry.Operation,
+			&entry.ActorID,
+			&entry.Before,
+			&entry.After,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "scan_error"))
+			return nil, fmt.Errorf("list flight history %s: %w", flightID, err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list flight history %s: %w", flightID, err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(entries)),
+	)
+
+	return entries, nil
+}
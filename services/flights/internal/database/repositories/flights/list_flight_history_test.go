@@ -0,0 +1,84 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+)
+
+func TestFlightRepositoryListFlightHistory(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta(`SELECT id, flight_id, organization_id, operation, actor_id, before, after, created_at FROM flight_history WHERE flight_id = $1 AND ($2::uuid IS NULL OR organization_id = $2) AND ($3::timestamptz IS NULL OR (created_at, id) > ($3, $4)) ORDER BY created_at, id LIMIT $5`)
+	historyColumns := []string{"id", "flight_id", "organization_id", "operation", "actor_id", "before", "after", "created_at"}
+	flightID := uuid.New()
+	actorID := uuid.New()
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("first page", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		created, updated := uuid.New(), uuid.New()
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(flightID, &testOrgID, nil, nil, 21).
+			WillReturnRows(pgxmock.NewRows(historyColumns).
+				AddRow(created, flightID, testOrgID, models.FlightHistoryOperationCreated, actorID, []byte(nil), []byte(`{"number":"BA117"}`), createdAt).
+				AddRow(updated, flightID, testOrgID, models.FlightHistoryOperationUpdated, actorID, []byte(`{"number":"BA117"}`), []byte(`{"number":"BA118"}`), createdAt.Add(time.Minute)))
+
+		repo := &FlightRepository{pool: mock}
+		entries, err := repo.ListFlightHistory(context.Background(), flightID, &testOrgID, 21, nil)
+
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, created, entries[0].ID)
+		assert.Equal(t, models.FlightHistoryOperationCreated, entries[0].Operation)
+		assert.Nil(t, entries[0].Before)
+		assert.JSONEq(t, `{"number":"BA118"}`, string(entries[1].After))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("after cursor", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		cursor := &pagination.Cursor{Time: createdAt, ID: uuid.New()}
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(flightID, (*uuid.UUID)(nil), cursor.Time, cursor.ID, 5).
+			WillReturnRows(pgxmock.NewRows(historyColumns))
+
+		repo := &FlightRepository{pool: mock}
+		entries, err := repo.ListFlightHistory(context.Background(), flightID, nil, 5, cursor)
+
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(flightID, &testOrgID, nil, nil, 21).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		entries, err := repo.ListFlightHistory(context.Background(), flightID, &testOrgID, 21, nil)
+
+		assert.ErrorContains(t, err, "list flight history "+flightID.String())
+		assert.Nil(t, entries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,129 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListFlights returns up to limit flights matching filter, ordered by departure time and id.
+// When after is set only flights strictly after that cursor position are returned.
+func (flightRepository *FlightRepository) ListFlights(
+	ctx context.Context,
+	filter models.FlightFilter,
+	limit int,
+	after *pagination.Cursor,
+) ([]*models.Flight, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.list_flights")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "flights"),
+		attribute.Int("db.limit", limit),
+		attribute.Bool("db.paginated", after != nil),
+	)
+
+	query, args := buildListFlightsQuery(filter, limit, after)
+
+	rows, err := flightRepository.pool.Query(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list flights: %w", err)
+	}
+	defer rows.Close()
+
+	flights := make([]*models.Flight, 0, limit)
+	for rows.Next() {
+		flight, err := scanFlight(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "scan_error"))
+			return nil, fmt.Errorf("list flights: %w", err)
+		}
+		flights = append(flights, flight)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list flights: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(flights)),
+	)
+
+	return flights, nil
+}
+
+// buildListFlightsQuery assembles the keyset paginated listing query. Route and
+// departure predicates line up with idx_flights_route_departure and the aircraft
+// predicate with idx_aircraft_id.
+func buildListFlightsQuery(filter models.FlightFilter, limit int, after *pagination.Cursor) (string, []any) {
+	conditions, args := flightConditions(filter, after, nil)
+
+	var query strings.Builder
+	query.WriteString("SELECT " + flightColumns + " FROM flights")
+	if len(conditions) > 0 {
+		query.WriteString(" WHERE " + strings.Join(conditions, " AND "))
+	}
+
+	args = append(args, limit)
+	query.WriteString(fmt.Sprintf(" ORDER BY departure_time, id LIMIT $%d", len(args)))
+
+	return query.String(), args
+}
+
+// flightConditions returns the WHERE predicates for filter and the keyset cursor,
+// numbering placeholders after the arguments already in args.
+func flightConditions(filter models.FlightFilter, after *pagination.Cursor, args []any) ([]string, []any) {
+	var conditions []string
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.Origin != nil {
+		addCondition("origin = $%d", *filter.Origin)
+	}
+	if filter.Destination != nil {
+		addCondition("destination = $%d", *filter.Destination)
+	}
+	if filter.DepartureFrom != nil {
+		addCondition("departure_time >= $%d", *filter.DepartureFrom)
+	}
+	if filter.DepartureTo != nil {
+		addCondition("departure_time < $%d", *filter.DepartureTo)
+	}
+	if filter.Status != nil {
+		addCondition("status = $%d", *filter.Status)
+	}
+	if filter.Airline != nil {
+		addCondition("airline = $%d", *filter.Airline)
+	}
+	if filter.AircraftID != nil {
+		addCondition("aircraft_id = $%d", *filter.AircraftID)
+	}
+	if filter.OrganizationID != nil {
+		addCondition("organization_id = $%d", *filter.OrganizationID)
+	}
+	if after != nil {
+		args = append(args, after.Time, after.ID)
+		conditions = append(conditions, fmt.Sprintf("(departure_time, id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	return conditions, args
+}
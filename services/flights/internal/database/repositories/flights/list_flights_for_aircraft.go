@@ -0,0 +1,90 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListFlightsForAircraft returns up to limit flights for each aircraft in aircraftIDs,
+// applying the same filter and keyset cursor to every aircraft. Results are grouped
+// by aircraft and ordered by departure_time, id within each group.
+func (flightRepository *FlightRepository) ListFlightsForAircraft(
+	ctx context.Context,
+	aircraftIDs []uuid.UUID,
+	filter models.FlightFilter,
+	limit int,
+	after *pagination.Cursor,
+) ([]*models.Flight, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.list_flights_for_aircraft")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "flights"),
+		attribute.Int("db.aircraft", len(aircraftIDs)),
+		attribute.Int("db.limit", limit),
+		attribute.Bool("db.paginated", after != nil),
+	)
+
+	query, args := buildAircraftFlightsQuery(aircraftIDs, filter, limit, after)
+
+	rows, err := flightRepository.pool.Query(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list flights for aircraft: %w", err)
+	}
+	defer rows.Close()
+
+	var flights []*models.Flight
+	for rows.Next() {
+		flight, err := scanFlight(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "scan_error"))
+			return nil, fmt.Errorf("list flights for aircraft: %w", err)
+		}
+		flights = append(flights, flight)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list flights for aircraft: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(flights)),
+	)
+
+	return flights, nil
+}
+
+// buildAircraftFlightsQuery runs the paginated listing once per aircraft through a
+// LATERAL join, so each page is an idx_aircraft_id lookup and one aircraft with many
+// flights cannot crowd the others out of the result.
+func buildAircraftFlightsQuery(aircraftIDs []uuid.UUID, filter models.FlightFilter, limit int, after *pagination.Cursor) (string, []any) {
+	filter.AircraftID = nil
+	conditions, args := flightConditions(filter, after, []any{aircraftIDs})
+	conditions = append([]string{"aircraft_id = a.requested_aircraft_id"}, conditions...)
+
+	args = append(args, limit)
+
+	var query strings.Builder
+	query.WriteString("SELECT " + flightColumns + " FROM unnest($1::uuid[]) WITH ORDINALITY AS a(requested_aircraft_id, position)")
+	query.WriteString(" CROSS JOIN LATERAL (SELECT " + flightColumns + " FROM flights")
+	query.WriteString(" WHERE " + strings.Join(conditions, " AND "))
+	query.WriteString(fmt.Sprintf(" ORDER BY departure_time, id LIMIT $%d) AS f", len(args)))
+	query.WriteString(" ORDER BY a.position, f.departure_time, f.id")
+
+	return query.String(), args
+}
@@ -0,0 +1,85 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+)
+
+func TestBuildAircraftFlightsQuery(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := models.FlightStatusScheduled
+	cursor := &pagination.Cursor{Time: from.Add(time.Hour), ID: uuid.New()}
+	ignoredAircraft := uuid.New()
+
+	query, args := buildAircraftFlightsQuery(ids, models.FlightFilter{
+		DepartureFrom: &from,
+		Status:        &status,
+		AircraftID:    &ignoredAircraft,
+	}, 21, cursor)
+
+	assert.Equal(t,
+		"SELECT "+flightColumns+" FROM unnest($1::uuid[]) WITH ORDINALITY AS a(requested_aircraft_id, position)"+
+			" CROSS JOIN LATERAL (SELECT "+flightColumns+" FROM flights"+
+			" WHERE aircraft_id = a.requested_aircraft_id AND deleted_at IS NULL AND departure_time >= $2 AND status = $3 AND (departure_time, id) > ($4, $5)"+
+			" ORDER BY departure_time, id LIMIT $6) AS f"+
+			" ORDER BY a.position, f.departure_time, f.id",
+		query)
+	assert.Equal(t, []any{ids, from, status, cursor.Time, cursor.ID, 21}, args)
+}
+
+func TestFlightRepositoryListFlightsForAircraft(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	expectedSQL := regexp.QuoteMeta("SELECT " + flightColumns + " FROM unnest($1::uuid[]) WITH ORDINALITY AS a(requested_aircraft_id, position)")
+	departure := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(ids, 5).
+			WillReturnRows(pgxmock.NewRows(listColumns).
+				AddRow(uuid.New(), "BA117", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusScheduled, ids[0], departure, departure, int32(1), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil).
+				AddRow(uuid.New(), "BA118", "JFK", "LHR", departure.Add(10*time.Hour), departure.Add(17*time.Hour), models.FlightStatusScheduled, ids[1], departure, departure, int32(1), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.ListFlightsForAircraft(context.Background(), ids, models.FlightFilter{}, 5, nil)
+
+		require.NoError(t, err)
+		require.Len(t, flights, 2)
+		assert.Equal(t, ids[0], flights[0].AircraftID)
+		assert.Equal(t, ids[1], flights[1].AircraftID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(ids, 5).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.ListFlightsForAircraft(context.Background(), ids, models.FlightFilter{}, 5, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "list flights for aircraft")
+		assert.Nil(t, flights)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
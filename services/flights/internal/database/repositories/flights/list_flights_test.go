@@ -0,0 +1,149 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var listColumns = []string{
+	"id", "number", "origin", "destination", "departure_time", "arrival_time", "status", "aircraft_id", "created_at", "updated_at", "version", "organization_id", "airline", "created_by", "last_updated_by", "deleted_at",
+	"estimated_departure_time", "estimated_arrival_time", "actual_departure_time", "actual_arrival_time", "delay_code", "actual_takeoff_time", "actual_landing_time",
+}
+
+var testOrgID = uuid.New()
+
+func TestBuildListFlightsQuery(t *testing.T) {
+	origin := "LHR"
+	destination := "JFK"
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	status := models.FlightStatusScheduled
+	airline := "British Airways"
+	aircraftID := uuid.New()
+	cursor := &pagination.Cursor{Time: from, ID: uuid.New()}
+
+	tests := []struct {
+		name          string
+		filter        models.FlightFilter
+		after         *pagination.Cursor
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		{
+			name:          "no filter",
+			expectedQuery: "SELECT " + flightColumns + " FROM flights WHERE deleted_at IS NULL ORDER BY departure_time, id LIMIT $1",
+			expectedArgs:  []any{11},
+		},
+		{
+			name:          "including deleted",
+			filter:        models.FlightFilter{IncludeDeleted: true},
+			expectedQuery: "SELECT " + flightColumns + " FROM flights ORDER BY departure_time, id LIMIT $1",
+			expectedArgs:  []any{11},
+		},
+		{
+			name:   "route and window",
+			filter: models.FlightFilter{Origin: &origin, Destination: &destination, DepartureFrom: &from, DepartureTo: &to},
+			expectedQuery: "SELECT " + flightColumns + " FROM flights WHERE deleted_at IS NULL AND origin = $1 AND destination = $2 AND departure_time >= $3 AND departure_time < $4" +
+				" ORDER BY departure_time, id LIMIT $5",
+			expectedArgs: []any{origin, destination, from, to, 11},
+		},
+		{
+			name:   "status airline aircraft and cursor",
+			filter: models.FlightFilter{Status: &status, Airline: &airline, AircraftID: &aircraftID},
+			after:  cursor,
+			expectedQuery: "SELECT " + flightColumns + " FROM flights WHERE deleted_at IS NULL AND status = $1 AND airline = $2 AND aircraft_id = $3 AND (departure_time, id) > ($4, $5)" +
+				" ORDER BY departure_time, id LIMIT $6",
+			expectedArgs: []any{status, airline, aircraftID, cursor.Time, cursor.ID, 11},
+		},
+		{
+			name:          "organization scoped",
+			filter:        models.FlightFilter{Origin: &origin, OrganizationID: &testOrgID},
+			expectedQuery: "SELECT " + flightColumns + " FROM flights WHERE deleted_at IS NULL AND origin = $1 AND organization_id = $2 ORDER BY departure_time, id LIMIT $3",
+			expectedArgs:  []any{origin, testOrgID, 11},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args := buildListFlightsQuery(tc.filter, 11, tc.after)
+
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
+
+func TestFlightRepositoryListFlights(t *testing.T) {
+	origin := "LHR"
+	filter := models.FlightFilter{Origin: &origin}
+	expectedSQL := regexp.QuoteMeta("SELECT " + flightColumns + " FROM flights WHERE deleted_at IS NULL AND origin = $1 ORDER BY departure_time, id LIMIT $2")
+	departure := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		firstID, secondID := uuid.New(), uuid.New()
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(origin, 3).
+			WillReturnRows(pgxmock.NewRows(listColumns).
+				AddRow(firstID, "BA117", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusScheduled, uuid.New(), departure, departure, int32(1), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil).
+				AddRow(secondID, "BA119", "LHR", "JFK", departure.Add(time.Hour), departure.Add(9*time.Hour), models.FlightStatusDelayed, uuid.New(), departure, departure, int32(1), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.ListFlights(context.Background(), filter, 3, nil)
+
+		require.NoError(t, err)
+		require.Len(t, flights, 2)
+		assert.Equal(t, firstID, flights[0].ID)
+		assert.Equal(t, secondID, flights[1].ID)
+		assert.Equal(t, models.FlightStatusDelayed, flights[1].Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(origin, 3).
+			WillReturnRows(pgxmock.NewRows(listColumns))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.ListFlights(context.Background(), filter, 3, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, flights)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(origin, 3).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.ListFlights(context.Background(), filter, 3, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "list flights")
+		assert.Nil(t, flights)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
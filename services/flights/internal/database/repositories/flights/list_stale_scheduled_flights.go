@@ -0,0 +1,72 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListStaleScheduledFlights returns the live flights of a schedule that were
+// materialized from a version older than scheduleVersion and are still scheduled to
+// depart after departingAfter, ordered by departure. Flights that have been delayed,
+// cancelled or have departed are left out, as operations have taken them over from the
+// schedule.
+func (flightRepository *FlightRepository) ListStaleScheduledFlights(ctx context.Context, scheduleID uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.list_stale_scheduled_flights")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "flights"),
+		attribute.String("schedule.id", scheduleID.String()),
+		attribute.Int("schedule.version", int(scheduleVersion)),
+	)
+
+	const query = `
+        SELECT ` + flightColumns + `, schedule_id, schedule_date, schedule_version
+        FROM flights
+        WHERE schedule_id = $1
+          AND schedule_version < $2
+          AND departure_time > $3
+          AND status = $4
+          AND deleted_at IS NULL
+        ORDER BY departure_time, id
+    `
+
+	rows, err := flightRepository.pool.Query(ctx, query, scheduleID, scheduleVersion, departingAfter, models.FlightStatusScheduled)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list stale flights of schedule %s: %w", scheduleID, err)
+	}
+	defer rows.Close()
+
+	var instances []*models.ScheduledFlight
+	for rows.Next() {
+		instance := &models.ScheduledFlight{Flight: &models.Flight{}}
+		targets := append(flightScanTargets(instance.Flight), &instance.ScheduleID, &instance.ScheduleDate, &instance.ScheduleVersion)
+		if err := rows.Scan(targets...); err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return nil, fmt.Errorf("list stale flights of schedule %s: scan: %w", scheduleID, err)
+		}
+		instances = append(instances, instance)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list stale flights of schedule %s: %w", scheduleID, err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(instances)),
+	)
+	return instances, nil
+}
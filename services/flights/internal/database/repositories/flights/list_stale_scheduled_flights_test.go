@@ -0,0 +1,66 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+func TestFlightRepositoryListStaleScheduledFlights(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta("SELECT " + flightColumns + ", schedule_id, schedule_date, schedule_version FROM flights WHERE schedule_id = $1 AND schedule_version < $2 AND departure_time > $3 AND status = $4 AND deleted_at IS NULL ORDER BY departure_time, id")
+	now := time.Date(2025, 4, 1, 6, 0, 0, 0, time.UTC)
+	scheduleID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flightID := uuid.New()
+		departure := time.Date(2025, 4, 2, 7, 25, 0, 0, time.UTC)
+		day := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+		columns := append(append([]string{}, listColumns...), "schedule_id", "schedule_date", "schedule_version")
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(scheduleID, int32(3), now, models.FlightStatusScheduled).
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow(flightID, "BA117", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusScheduled, uuid.New(), now, now, int32(1), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil, scheduleID, day, int32(2)))
+
+		repo := &FlightRepository{pool: mock}
+		instances, err := repo.ListStaleScheduledFlights(context.Background(), scheduleID, 3, now)
+
+		require.NoError(t, err)
+		require.Len(t, instances, 1)
+		assert.Equal(t, flightID, instances[0].Flight.ID)
+		assert.Equal(t, scheduleID, instances[0].ScheduleID)
+		assert.Equal(t, day, instances[0].ScheduleDate)
+		assert.Equal(t, int32(2), instances[0].ScheduleVersion)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(scheduleID, int32(3), now, models.FlightStatusScheduled).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		instances, err := repo.ListStaleScheduledFlights(context.Background(), scheduleID, 3, now)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "list stale flights of schedule "+scheduleID.String())
+		assert.Nil(t, instances)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
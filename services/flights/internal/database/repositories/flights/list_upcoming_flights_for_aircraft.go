@@ -0,0 +1,69 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListUpcomingFlightsForAircraft returns up to limit live flights of aircraftID, across
+// every organization, that are scheduled or delayed and depart after departingAfter,
+// ordered by departure.
+func (flightRepository *FlightRepository) ListUpcomingFlightsForAircraft(ctx context.Context, aircraftID uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.list_upcoming_flights_for_aircraft")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "flights"),
+		attribute.String("aircraft.id", aircraftID.String()),
+		attribute.Int("db.limit", limit),
+	)
+
+	const query = `
+        SELECT ` + flightColumns + `
+        FROM flights
+        WHERE aircraft_id = $1
+          AND departure_time > $2
+          AND status IN ($3, $4)
+          AND deleted_at IS NULL
+        ORDER BY departure_time, id
+        LIMIT $5
+    `
+
+	rows, err := flightRepository.pool.Query(ctx, query, aircraftID, departingAfter, models.FlightStatusScheduled, models.FlightStatusDelayed, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list upcoming flights of aircraft %s: %w", aircraftID, err)
+	}
+	defer rows.Close()
+
+	var flights []*models.Flight
+	for rows.Next() {
+		flight, err := scanFlight(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return nil, fmt.Errorf("list upcoming flights of aircraft %s: scan: %w", aircraftID, err)
+		}
+		flights = append(flights, flight)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("list upcoming flights of aircraft %s: %w", aircraftID, err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(flights)),
+	)
+	return flights, nil
+}
@@ -0,0 +1,63 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+func TestFlightRepositoryListUpcomingFlightsForAircraft(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta("SELECT " + flightColumns + " FROM flights WHERE aircraft_id = $1 AND departure_time > $2 AND status IN ($3, $4) AND deleted_at IS NULL ORDER BY departure_time, id LIMIT $5")
+	now := time.Date(2025, 4, 1, 6, 0, 0, 0, time.UTC)
+	aircraftID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flightID := uuid.New()
+		departure := time.Date(2025, 4, 2, 7, 25, 0, 0, time.UTC)
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(aircraftID, now, models.FlightStatusScheduled, models.FlightStatusDelayed, 50).
+			WillReturnRows(pgxmock.NewRows(listColumns).
+				AddRow(flightID, "BA117", "LHR", "JFK", departure, departure.Add(8*time.Hour), models.FlightStatusDelayed, aircraftID, now, now, int32(4), testOrgID, "British Airways", uuid.New(), uuid.New(), nil, nil, nil, nil, nil, nil, nil, nil))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.ListUpcomingFlightsForAircraft(context.Background(), aircraftID, now, 50)
+
+		require.NoError(t, err)
+		require.Len(t, flights, 1)
+		assert.Equal(t, flightID, flights[0].ID)
+		assert.Equal(t, aircraftID, flights[0].AircraftID)
+		assert.Equal(t, int32(4), flights[0].Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(aircraftID, now, models.FlightStatusScheduled, models.FlightStatusDelayed, 50).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		flights, err := repo.ListUpcomingFlightsForAircraft(context.Background(), aircraftID, now, 50)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "list upcoming flights of aircraft "+aircraftID.String())
+		assert.Nil(t, flights)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,196 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MaterializeSchedule inserts the flights of s's operating days, skipping every
+// instance that conflicts with an existing flight: one already materialized for that
+// day, even if since deleted, or any live flight with the same number and departure
+// time under unique_flight_instance. Running it again with the same instances is
+// therefore a no-op. An instance whose aircraft is not available, as checked by
+// checkAircraftAvailability, is skipped too. The history (paired by index) and events of the inserted flights
+// are written in the same transaction, and the schedule's materialized_through is
+// advanced to through. It returns how many flights were inserted.
+func (flightRepository *FlightRepository) MaterializeSchedule(
+	ctx context.Context,
+	s *models.Schedule,
+	instances []*models.ScheduledFlight,
+	history []*models.FlightHistoryEntry,
+	events []*models.OutboxEvent,
+	through time.Time,
+) (int, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.materialize_schedule")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "flights"),
+		attribute.String("schedule.id", s.ID.String()),
+		attribute.Int("db.rows", len(instances)),
+	)
+
+	if len(history) != len(instances) || len(events) != len(instances) {
+		err := fmt.Errorf("materialize schedule %s: %d history entries and %d events for %d flights",
+			s.ID, len(history), len(events), len(instances))
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return 0, err
+	}
+
+	const insertQuery = `
+        INSERT INTO flights (
+            id, number, origin, destination,
+            departure_time, arrival_time, status, aircraft_id,
+            created_by, last_updated_by, organization_id, airline,
+            schedule_id, schedule_date, schedule_version
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+        ON CONFLICT DO NOTHING
+        RETURNING created_at, updated_at, version
+    `
+
+	const advanceQuery = `
+        UPDATE schedules
+        SET materialized_through = $2
+        WHERE id = $1 AND (materialized_through IS NULL OR materialized_through < $2)
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return 0, fmt.Errorf("materialize schedule %s: begin: %w", s.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := lockAircraft(ctx, tx, s.AircraftID); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return 0, fmt.Errorf("materialize schedule %s: %w", s.ID, err)
+	}
+
+	var created []*models.OutboxEvent
+	for i, instance := range instances {
+		f := instance.Flight
+		inserted, err := flightRepository.insertScheduledFlight(ctx, tx, insertQuery, instance)
+		if err != nil {
+			logger.Error("Error inserting scheduled flight into db", "schedule_id", s.ID, "id", f.ID, "error", err)
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return 0, fmt.Errorf("materialize schedule %s: insert flight %s: %w", s.ID, f.ID, err)
+		}
+		if !inserted {
+			continue
+		}
+
+		if err := insertFlightHistory(ctx, tx, history[i], f); err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return 0, fmt.Errorf("materialize schedule %s: %w", s.ID, err)
+		}
+		created = append(created, events[i])
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, created); err != nil {
+		logger.Error("Error writing scheduled flight events to outbox", "schedule_id", s.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return 0, fmt.Errorf("materialize schedule %s: %w", s.ID, err)
+	}
+
+	if _, err := tx.Exec(ctx, advanceQuery, s.ID, through); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return 0, fmt.Errorf("materialize schedule %s: advance horizon: %w", s.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return 0, fmt.Errorf("materialize schedule %s: commit: %w", s.ID, err)
+	}
+
+	if s.MaterializedThrough == nil || s.MaterializedThrough.Before(through) {
+		s.MaterializedThrough = &through
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows_inserted", len(created)),
+	)
+	return len(created), nil
+}
+
+// insertScheduledFlight inserts instance with insertQuery under a savepoint and keeps it
+// only if the aircraft is available, reporting whether it was kept. Only availability is
+// checked, not position, since a schedule's return leg often belongs to another schedule
+// that is materialized separately; a clash is logged and the day is tried again on the
+// next run.
+func (flightRepository *FlightRepository) insertScheduledFlight(ctx context.Context, tx pgx.Tx, insertQuery string, instance *models.ScheduledFlight) (bool, error) {
+	f := instance.Flight
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("savepoint: %w", err)
+	}
+	defer func() {
+		_ = savepoint.Rollback(ctx)
+	}()
+
+	err = savepoint.QueryRow(
+		ctx,
+		insertQuery,
+		f.ID,
+		f.Number,
+		f.Origin,
+		f.Destination,
+		f.DepartureTime,
+		f.ArrivalTime,
+		f.Status,
+		nullableID(f.AircraftID),
+		f.CreatedBy,
+		f.LastUpdatedBy,
+		f.OrganizationID,
+		f.Airline,
+		instance.ScheduleID,
+		instance.ScheduleDate,
+		instance.ScheduleVersion,
+	).Scan(&f.CreatedAt, &f.UpdatedAt, &f.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = flightRepository.checkAircraftAvailability(ctx, savepoint, f)
+	var conflict *exceptions.AircraftConflictError
+	if errors.As(err, &conflict) {
+		logger.Warn("Skipping scheduled flight the aircraft cannot operate", "schedule_id", instance.ScheduleID, "schedule_date", instance.ScheduleDate.Format(time.DateOnly), "conflict", conflict.Error())
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := savepoint.Commit(ctx); err != nil {
+		return false, fmt.Errorf("release savepoint: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,174 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+func TestFlightRepositoryMaterializeSchedule(t *testing.T) {
+	insertSQL := regexp.QuoteMeta(`INSERT INTO flights ( id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_by, last_updated_by, organization_id, airline, schedule_id, schedule_date, schedule_version ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) ON CONFLICT DO NOTHING RETURNING created_at, updated_at, version`)
+	historySQL := regexp.QuoteMeta(`INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`)
+	outboxSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	advanceSQL := regexp.QuoteMeta(`UPDATE schedules SET materialized_through = $2 WHERE id = $1 AND (materialized_through IS NULL OR materialized_through < $2)`)
+	createdAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	through := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+
+	newInstances := func(schedule *models.Schedule) ([]*models.ScheduledFlight, []*models.FlightHistoryEntry, []*models.OutboxEvent) {
+		var instances []*models.ScheduledFlight
+		var history []*models.FlightHistoryEntry
+		var events []*models.OutboxEvent
+		for _, day := range []time.Time{schedule.StartDate, schedule.StartDate.AddDate(0, 0, 1)} {
+			departure := day.Add(7*time.Hour + 25*time.Minute)
+			flight := &models.Flight{
+				ID:             uuid.New(),
+				Number:         schedule.Number,
+				Origin:         schedule.Origin,
+				Destination:    schedule.Destination,
+				DepartureTime:  departure,
+				ArrivalTime:    departure.Add(8 * time.Hour),
+				Status:         models.FlightStatusScheduled,
+				AircraftID:     schedule.AircraftID,
+				CreatedBy:      schedule.LastUpdatedBy,
+				LastUpdatedBy:  schedule.LastUpdatedBy,
+				OrganizationID: schedule.OrganizationID,
+				Airline:        schedule.Airline,
+			}
+			instances = append(instances, &models.ScheduledFlight{Flight: flight, ScheduleID: schedule.ID, ScheduleDate: day, ScheduleVersion: schedule.Version})
+			history = append(history, &models.FlightHistoryEntry{Operation: models.FlightHistoryOperationCreated, ActorID: schedule.LastUpdatedBy})
+			events = append(events, &models.OutboxEvent{AggregateID: flight.ID, EventType: models.EventTypeFlightCreated, Payload: []byte(`{}`)})
+		}
+		return instances, history, events
+	}
+
+	expectInsert := func(mock pgxmock.PgxPoolIface, instance *models.ScheduledFlight) *pgxmock.ExpectedQuery {
+		f := instance.Flight
+		return mock.ExpectQuery(insertSQL).WithArgs(
+			f.ID, f.Number, f.Origin, f.Destination, f.DepartureTime, f.ArrivalTime, f.Status, &f.AircraftID,
+			f.CreatedBy, f.LastUpdatedBy, f.OrganizationID, f.Airline,
+			instance.ScheduleID, instance.ScheduleDate, instance.ScheduleVersion,
+		)
+	}
+
+	t.Run("Skips Existing Instances", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		schedule := newTestSchedule()
+		instances, history, events := newInstances(schedule)
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, schedule.AircraftID)
+		mock.ExpectBegin()
+		expectInsert(mock, instances[0]).WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		expectInsert(mock, instances[1]).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at", "updated_at", "version"}).AddRow(createdAt, createdAt, int32(1)))
+		expectAircraftAvailable(mock, instances[1].Flight, 0)
+		mock.ExpectCommit()
+		mock.ExpectQuery(historySQL).
+			WithArgs(instances[1].Flight.ID, testOrgID, models.FlightHistoryOperationCreated, schedule.LastUpdatedBy, json.RawMessage(nil), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), createdAt))
+		mock.ExpectQuery(outboxSQL).
+			WithArgs(instances[1].Flight.ID, models.EventTypeFlightCreated, []byte(`{}`), map[string]string(nil)).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(1), createdAt))
+		mock.ExpectExec(advanceSQL).WithArgs(schedule.ID, through).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		created, err := repo.MaterializeSchedule(context.Background(), schedule, instances, history, events, through)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, int32(1), instances[1].Flight.Version)
+		assert.Equal(t, instances[1].Flight.ID, history[1].FlightID)
+		require.NotNil(t, schedule.MaterializedThrough)
+		assert.Equal(t, through, *schedule.MaterializedThrough)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Insert Error Rolls Back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		schedule := newTestSchedule()
+		instances, history, events := newInstances(schedule)
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, schedule.AircraftID)
+		mock.ExpectBegin()
+		expectInsert(mock, instances[0]).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		created, err := repo.MaterializeSchedule(context.Background(), schedule, instances, history, events, through)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "materialize schedule "+schedule.ID.String())
+		assert.Zero(t, created)
+		assert.Nil(t, schedule.MaterializedThrough)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Skips Instances The Aircraft Cannot Operate", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		schedule := newTestSchedule()
+		instances, history, events := newInstances(schedule)
+		clash := instances[0].Flight
+
+		mock.ExpectBegin()
+		expectAircraftLock(mock, schedule.AircraftID)
+		for _, instance := range instances {
+			mock.ExpectBegin()
+			expectInsert(mock, instance).
+				WillReturnRows(pgxmock.NewRows([]string{"created_at", "updated_at", "version"}).AddRow(createdAt, createdAt, int32(1)))
+			mock.ExpectQuery(aircraftAvailabilitySQL).
+				WithArgs(instance.Flight.AircraftID, instance.Flight.ID, models.FlightStatusCancelled, instance.Flight.ArrivalTime, instance.Flight.DepartureTime).
+				WillReturnRows(pgxmock.NewRows([]string{"id", "number", "departure_time", "arrival_time"}).
+					AddRow(uuid.New(), "BA175", clash.DepartureTime, clash.ArrivalTime))
+			mock.ExpectRollback()
+		}
+		mock.ExpectExec(advanceSQL).WithArgs(schedule.ID, through).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		created, err := repo.MaterializeSchedule(context.Background(), schedule, instances, history, events, through)
+
+		require.NoError(t, err)
+		assert.Zero(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Mismatched History", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		schedule := newTestSchedule()
+		instances, history, events := newInstances(schedule)
+
+		repo := &FlightRepository{pool: mock}
+		_, err = repo.MaterializeSchedule(context.Background(), schedule, instances, history[:1], events, through)
+
+		require.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,116 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RecordMovement writes f's status and movement times, provided the stored row is
+// still at expectedVersion. transition, when set, records the status the movement
+// advanced the flight to. A missing, deleted or newer row yields ErrVersionConflict.
+// On success f is refreshed with the stored timestamps and version. The history entry
+// and any events are written in the same transaction.
+func (flightRepository *FlightRepository) RecordMovement(
+	ctx context.Context,
+	f *models.Flight,
+	expectedVersion int32,
+	transition *models.FlightStatusTransition,
+	history *models.FlightHistoryEntry,
+	events ...*models.OutboxEvent,
+) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.record_movement")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+		attribute.String("flight.id", f.ID.String()),
+		attribute.Int("flight.version", int(expectedVersion)),
+		attribute.String("flight.status", string(f.Status)),
+	)
+
+	const updateQuery = `
+        UPDATE flights
+        SET status = $2, actual_departure_time = $3, actual_takeoff_time = $4,
+            actual_landing_time = $5, actual_arrival_time = $6, last_updated_by = $7,
+            version = version + 1
+        WHERE id = $1 AND version = $8 AND deleted_at IS NULL
+        RETURNING updated_at, version
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("record movement of flight %s: begin: %w", f.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	err = tx.QueryRow(
+		ctx,
+		updateQuery,
+		f.ID,
+		f.Status,
+		f.ActualDepartureTime,
+		f.ActualTakeoffTime,
+		f.ActualLandingTime,
+		f.ActualArrivalTime,
+		f.LastUpdatedBy,
+		expectedVersion,
+	).Scan(&f.UpdatedAt, &f.Version)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "version_conflict"))
+			return fmt.Errorf("%w: id=%s version=%d", exceptions.ErrVersionConflict, f.ID, expectedVersion)
+		}
+		logger.Error("Error writing flight movement in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("record movement of flight %s: %w", f.ID, err)
+	}
+
+	if transition != nil {
+		if err := insertStatusTransition(ctx, tx, transition); err != nil {
+			logger.Error("Error recording flight status transition in db", "id", f.ID, "error", err)
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return err
+		}
+	}
+
+	if err := insertFlightHistory(ctx, tx, history, f); err != nil {
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("record movement of flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("record movement of flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("record movement of flight %s: commit: %w", f.ID, err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
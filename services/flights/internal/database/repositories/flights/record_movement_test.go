@@ -0,0 +1,149 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryRecordMovement(t *testing.T) {
+	updateSQL := regexp.QuoteMeta(`UPDATE flights SET status = $2, actual_departure_time = $3, actual_takeoff_time = $4, actual_landing_time = $5, actual_arrival_time = $6, last_updated_by = $7, version = version + 1 WHERE id = $1 AND version = $8 AND deleted_at IS NULL RETURNING updated_at, version`)
+	transitionSQL := regexp.QuoteMeta(`INSERT INTO flight_status_transitions ( id, flight_id, from_status, to_status, reason, changed_by ) VALUES ($1, $2, $3, $4, $5, $6) RETURNING created_at`)
+	historySQL := regexp.QuoteMeta(`INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`)
+	outboxSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	updatedAt := time.Date(2024, 12, 15, 12, 20, 0, 0, time.UTC)
+	out := time.Date(2024, 12, 15, 12, 5, 0, 0, time.UTC)
+	off := out.Add(15 * time.Minute)
+
+	newFlight := func() *models.Flight {
+		return &models.Flight{
+			ID:                  uuid.New(),
+			Status:              models.FlightStatusInProgress,
+			OrganizationID:      testOrgID,
+			LastUpdatedBy:       uuid.New(),
+			Version:             4,
+			ActualDepartureTime: &out,
+			ActualTakeoffTime:   &off,
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight := newFlight()
+		transition := &models.FlightStatusTransition{
+			ID:         uuid.New(),
+			FlightID:   flight.ID,
+			FromStatus: models.FlightStatusDeparted,
+			ToStatus:   models.FlightStatusInProgress,
+			ChangedBy:  flight.LastUpdatedBy,
+		}
+		history := &models.FlightHistoryEntry{Operation: models.FlightHistoryOperationMovementRecorded, ActorID: flight.LastUpdatedBy, Before: []byte(`{}`)}
+		event := &models.OutboxEvent{AggregateID: flight.ID, EventType: models.EventTypeFlightMovementRecorded, Payload: []byte(`{}`)}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, models.FlightStatusInProgress, &out, &off, (*time.Time)(nil), (*time.Time)(nil), flight.LastUpdatedBy, int32(4)).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(5)))
+		mock.ExpectQuery(transitionSQL).
+			WithArgs(transition.ID, flight.ID, models.FlightStatusDeparted, models.FlightStatusInProgress, (*string)(nil), flight.LastUpdatedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(updatedAt))
+		mock.ExpectQuery(historySQL).
+			WithArgs(flight.ID, testOrgID, models.FlightHistoryOperationMovementRecorded, flight.LastUpdatedBy, history.Before, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), updatedAt))
+		mock.ExpectQuery(outboxSQL).
+			WithArgs(flight.ID, models.EventTypeFlightMovementRecorded, event.Payload, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(11), updatedAt))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.RecordMovement(context.Background(), flight, 4, transition, history, event)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(5), flight.Version)
+		assert.Equal(t, updatedAt, flight.UpdatedAt)
+		assert.Equal(t, updatedAt, transition.CreatedAt)
+
+		var after models.Flight
+		require.NoError(t, json.Unmarshal(history.After, &after))
+		assert.Equal(t, models.FlightStatusInProgress, after.Status)
+		require.NotNil(t, after.ActualTakeoffTime)
+		assert.True(t, off.Equal(*after.ActualTakeoffTime))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Correction Records No Transition", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight := newFlight()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, models.FlightStatusInProgress, &out, &off, (*time.Time)(nil), (*time.Time)(nil), flight.LastUpdatedBy, int32(4)).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(5)))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.RecordMovement(context.Background(), flight, 4, nil, nil)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Version Conflict", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight := newFlight()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, models.FlightStatusInProgress, &out, &off, (*time.Time)(nil), (*time.Time)(nil), flight.LastUpdatedBy, int32(4)).
+			WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.RecordMovement(context.Background(), flight, 4, nil, nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrVersionConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Update Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight := newFlight()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, models.FlightStatusInProgress, &out, &off, (*time.Time)(nil), (*time.Time)(nil), flight.LastUpdatedBy, int32(4)).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.RecordMovement(context.Background(), flight, 4, nil, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "record movement of flight")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,145 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ReportDelay writes f's status, estimates and delay code and stores delay, provided
+// the stored row is still at expectedVersion. transition, when set, records the move
+// to DELAYED. A missing, deleted or newer row yields ErrVersionConflict. On success f
+// is refreshed with the stored timestamps and version. The history entry and any
+// events are written in the same transaction.
+func (flightRepository *FlightRepository) ReportDelay(
+	ctx context.Context,
+	f *models.Flight,
+	expectedVersion int32,
+	delay *models.FlightDelay,
+	transition *models.FlightStatusTransition,
+	history *models.FlightHistoryEntry,
+	events ...*models.OutboxEvent,
+) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.report_delay")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+		attribute.String("flight.id", f.ID.String()),
+		attribute.Int("flight.version", int(expectedVersion)),
+		attribute.String("flight.delay.code", delay.Code),
+		attribute.Int("flight.delay.minutes", int(delay.Minutes)),
+	)
+
+	const updateQuery = `
+        UPDATE flights
+        SET status = $2, estimated_departure_time = $3, estimated_arrival_time = $4,
+            delay_code = $5, last_updated_by = $6, version = version + 1
+        WHERE id = $1 AND version = $7 AND deleted_at IS NULL
+        RETURNING updated_at, version
+    `
+
+	const insertQuery = `
+        INSERT INTO flight_delays (
+            id, flight_id, code, minutes, estimated_departure_time, estimated_arrival_time,
+            remarks, caused_by_flight_id, reported_by
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING created_at
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("report delay to flight %s: begin: %w", f.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	err = tx.QueryRow(
+		ctx,
+		updateQuery,
+		f.ID,
+		f.Status,
+		f.EstimatedDepartureTime,
+		f.EstimatedArrivalTime,
+		f.DelayCode,
+		f.LastUpdatedBy,
+		expectedVersion,
+	).Scan(&f.UpdatedAt, &f.Version)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "version_conflict"))
+			return fmt.Errorf("%w: id=%s version=%d", exceptions.ErrVersionConflict, f.ID, expectedVersion)
+		}
+		logger.Error("Error writing flight delay in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("report delay to flight %s: %w", f.ID, err)
+	}
+
+	err = tx.QueryRow(
+		ctx,
+		insertQuery,
+		delay.ID,
+		delay.FlightID,
+		delay.Code,
+		delay.Minutes,
+		delay.EstimatedDepartureTime,
+		delay.EstimatedArrivalTime,
+		delay.Remarks,
+		delay.CausedByFlightID,
+		delay.ReportedBy,
+	).Scan(&delay.CreatedAt)
+	if err != nil {
+		logger.Error("Error recording flight delay in db", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("record delay for flight %s: %w", f.ID, err)
+	}
+
+	if transition != nil {
+		if err := insertStatusTransition(ctx, tx, transition); err != nil {
+			logger.Error("Error recording flight status transition in db", "id", f.ID, "error", err)
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return err
+		}
+	}
+
+	if err := insertFlightHistory(ctx, tx, history, f); err != nil {
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("report delay to flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("report delay to flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("report delay to flight %s: commit: %w", f.ID, err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
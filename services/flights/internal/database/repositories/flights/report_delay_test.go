@@ -0,0 +1,177 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryReportDelay(t *testing.T) {
+	updateSQL := regexp.QuoteMeta(`UPDATE flights SET status = $2, estimated_departure_time = $3, estimated_arrival_time = $4, delay_code = $5, last_updated_by = $6, version = version + 1 WHERE id = $1 AND version = $7 AND deleted_at IS NULL RETURNING updated_at, version`)
+	delaySQL := regexp.QuoteMeta(`INSERT INTO flight_delays ( id, flight_id, code, minutes, estimated_departure_time, estimated_arrival_time, remarks, caused_by_flight_id, reported_by ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING created_at`)
+	transitionSQL := regexp.QuoteMeta(`INSERT INTO flight_status_transitions ( id, flight_id, from_status, to_status, reason, changed_by ) VALUES ($1, $2, $3, $4, $5, $6) RETURNING created_at`)
+	historySQL := regexp.QuoteMeta(`INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`)
+	outboxSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	updatedAt := time.Date(2024, 12, 15, 10, 5, 0, 0, time.UTC)
+	departure := time.Date(2024, 12, 15, 12, 0, 0, 0, time.UTC)
+	estimatedDeparture := departure.Add(45 * time.Minute)
+	estimatedArrival := estimatedDeparture.Add(8 * time.Hour)
+	code := "41"
+
+	newFixtures := func() (*models.Flight, *models.FlightDelay) {
+		actor := uuid.New()
+		flight := &models.Flight{
+			ID:                     uuid.New(),
+			DepartureTime:          departure,
+			Status:                 models.FlightStatusDelayed,
+			OrganizationID:         testOrgID,
+			LastUpdatedBy:          actor,
+			Version:                2,
+			EstimatedDepartureTime: &estimatedDeparture,
+			EstimatedArrivalTime:   &estimatedArrival,
+			DelayCode:              &code,
+		}
+		delay := &models.FlightDelay{
+			ID:                     uuid.New(),
+			FlightID:               flight.ID,
+			Code:                   code,
+			Minutes:                45,
+			EstimatedDepartureTime: estimatedDeparture,
+			EstimatedArrivalTime:   estimatedArrival,
+			ReportedBy:             actor,
+		}
+		return flight, delay
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, delay := newFixtures()
+		transition := &models.FlightStatusTransition{
+			ID:         uuid.New(),
+			FlightID:   flight.ID,
+			FromStatus: models.FlightStatusScheduled,
+			ToStatus:   models.FlightStatusDelayed,
+			ChangedBy:  delay.ReportedBy,
+		}
+		history := &models.FlightHistoryEntry{Operation: models.FlightHistoryOperationDelayed, ActorID: delay.ReportedBy, Before: []byte(`{}`)}
+		event := &models.OutboxEvent{AggregateID: flight.ID, EventType: models.EventTypeFlightDelayed, Payload: []byte(`{}`)}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, models.FlightStatusDelayed, &estimatedDeparture, &estimatedArrival, &code, delay.ReportedBy, int32(2)).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(3)))
+		mock.ExpectQuery(delaySQL).
+			WithArgs(delay.ID, flight.ID, code, int32(45), estimatedDeparture, estimatedArrival, (*string)(nil), (*uuid.UUID)(nil), delay.ReportedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(updatedAt))
+		mock.ExpectQuery(transitionSQL).
+			WithArgs(transition.ID, flight.ID, models.FlightStatusScheduled, models.FlightStatusDelayed, (*string)(nil), delay.ReportedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(updatedAt))
+		mock.ExpectQuery(historySQL).
+			WithArgs(flight.ID, testOrgID, models.FlightHistoryOperationDelayed, delay.ReportedBy, history.Before, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), updatedAt))
+		mock.ExpectQuery(outboxSQL).
+			WithArgs(flight.ID, models.EventTypeFlightDelayed, event.Payload, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(9), updatedAt))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.ReportDelay(context.Background(), flight, 2, delay, transition, history, event)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), flight.Version)
+		assert.Equal(t, updatedAt, flight.UpdatedAt)
+		assert.Equal(t, updatedAt, delay.CreatedAt)
+		assert.Equal(t, updatedAt, transition.CreatedAt)
+
+		var after models.Flight
+		require.NoError(t, json.Unmarshal(history.After, &after))
+		assert.Equal(t, models.FlightStatusDelayed, after.Status)
+		assert.Equal(t, code, *after.DelayCode)
+		assert.Equal(t, int32(3), after.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already Delayed Records No Transition", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, delay := newFixtures()
+		cause := uuid.New()
+		delay.CausedByFlightID = &cause
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, models.FlightStatusDelayed, &estimatedDeparture, &estimatedArrival, &code, delay.ReportedBy, int32(2)).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(3)))
+		mock.ExpectQuery(delaySQL).
+			WithArgs(delay.ID, flight.ID, code, int32(45), estimatedDeparture, estimatedArrival, (*string)(nil), &cause, delay.ReportedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(updatedAt))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.ReportDelay(context.Background(), flight, 2, delay, nil, nil)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Version Conflict", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, delay := newFixtures()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, models.FlightStatusDelayed, &estimatedDeparture, &estimatedArrival, &code, delay.ReportedBy, int32(2)).
+			WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.ReportDelay(context.Background(), flight, 2, delay, nil, nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrVersionConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Delay Insert Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, delay := newFixtures()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, models.FlightStatusDelayed, &estimatedDeparture, &estimatedArrival, &code, delay.ReportedBy, int32(2)).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(3)))
+		mock.ExpectQuery(delaySQL).
+			WithArgs(delay.ID, flight.ID, code, int32(45), estimatedDeparture, estimatedArrival, (*string)(nil), (*uuid.UUID)(nil), delay.ReportedBy).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.ReportDelay(context.Background(), flight, 2, delay, nil, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "record delay for flight")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
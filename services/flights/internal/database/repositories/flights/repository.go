@@ -2,17 +2,27 @@ package flights
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type DB interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 type FlightRepository struct {
 	pool DB
+	// MinTurnaround is the least time an aircraft must spend on the ground between
+	// two flights.
+	MinTurnaround time.Duration
 }
 
 // NewFlightRepository returns a new FlightRepository backed by the provided *pgxpool.Pool.
@@ -20,3 +30,98 @@ type FlightRepository struct {
 func NewFlightRepository(pool *pgxpool.Pool) *FlightRepository {
 	return &FlightRepository{pool: pool}
 }
+
+// flightColumns is the column list every flight read selects, in the order scanFlight expects.
+const flightColumns = `id, number, origin, destination, departure_time, arrival_time, status, aircraft_id, created_at, updated_at, version, organization_id, airline, created_by, last_updated_by, deleted_at, estimated_departure_time, estimated_arrival_time, actual_departure_time, actual_arrival_time, delay_code, actual_takeoff_time, actual_landing_time`
+
+// scanFlight reads a single row selected with flightColumns into a Flight.
+func scanFlight(row pgx.Row) (*models.Flight, error) {
+	var flight models.Flight
+	if err := row.Scan(flightScanTargets(&flight)...); err != nil {
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// flightScanTargets returns the destinations for the columns of flightColumns, so
+// queries that select extra columns after them can append their own.
+func flightScanTargets(flight *models.Flight) []any {
+	return []any{
+		&flight.ID,
+		&flight.Number,
+		&flight.Origin,
+		&flight.Destination,
+		&flight.DepartureTime,
+		&flight.ArrivalTime,
+		&flight.Status,
+		&flight.AircraftID,
+		&flight.CreatedAt,
+		&flight.UpdatedAt,
+		&flight.Version,
+		&flight.OrganizationID,
+		&flight.Airline,
+		&flight.CreatedBy,
+		&flight.LastUpdatedBy,
+		&flight.DeletedAt,
+		&flight.EstimatedDepartureTime,
+		&flight.EstimatedArrivalTime,
+		&flight.ActualDepartureTime,
+		&flight.ActualArrivalTime,
+		&flight.DelayCode,
+		&flight.ActualTakeoffTime,
+		&flight.ActualLandingTime,
+	}
+}
+
+// nullableID returns nil for uuid.Nil, so that an unset ID is stored as NULL.
+func nullableID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// scheduleColumns is the column list every schedule read selects, in the order
+// scanSchedule expects. The departure time is read back as HH:MM.
+const scheduleColumns = `id, number, origin, destination, to_char(departure_local_time, 'HH24:MI'), time_zone, duration_minutes, days_of_week, start_date, end_date, aircraft_id, organization_id, airline, created_by, last_updated_by, version, materialized_through, created_at, updated_at`
+
+// scanSchedule reads a single row selected with scheduleColumns into a Schedule.
+func scanSchedule(row pgx.Row) (*models.Schedule, error) {
+	var schedule models.Schedule
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.Number,
+		&schedule.Origin,
+		&schedule.Destination,
+		&schedule.DepartureLocalTime,
+		&schedule.TimeZone,
+		&schedule.DurationMinutes,
+		&schedule.DaysOfWeek,
+		&schedule.StartDate,
+		&schedule.EndDate,
+		&schedule.AircraftID,
+		&schedule.OrganizationID,
+		&schedule.Airline,
+		&schedule.CreatedBy,
+		&schedule.LastUpdatedBy,
+		&schedule.Version,
+		&schedule.MaterializedThrough,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// duplicateFlightError reports that another live flight already has f's number and
+// departure time.
+func duplicateFlightError(f *models.Flight) error {
+	return fmt.Errorf(
+		"%w: flight with number %s at %s already exists",
+		exceptions.ErrDuplicateFlight,
+		f.Number,
+		f.DepartureTime.Format(time.RFC3339),
+	)
+}
@@ -0,0 +1,119 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RestoreFlight clears the deleted_at tombstone of f. A flight that is missing or not
+// deleted yields ErrNotFound, and ErrDuplicateFlight is returned if a live flight has
+// since taken the same number and departure time. An *exceptions.AircraftConflictError
+// is returned if f's aircraft has since been given a clashing flight. The history entry and any events are
+// written in the same transaction.
+func (flightRepository *FlightRepository) RestoreFlight(ctx context.Context, f *models.Flight, restoredBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.restore_flight")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+		attribute.String("flight.id", f.ID.String()),
+	)
+
+	const query = `
+        UPDATE flights
+        SET deleted_at = NULL, last_updated_by = $2, version = version + 1
+        WHERE id = $1 AND deleted_at IS NOT NULL
+        RETURNING updated_at, version
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("restore flight %s: begin: %w", f.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := lockAircraft(ctx, tx, f.AircraftID); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("restore flight %s: %w", f.ID, err)
+	}
+
+	if err := flightRepository.checkAircraftConflicts(ctx, tx, f); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, exceptions.ErrAircraftConflict) {
+			span.SetAttributes(attribute.String("db.result", "aircraft_conflict"))
+			return err
+		}
+		logger.Error("Error checking aircraft conflicts", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("restore flight %s: %w", f.ID, err)
+	}
+
+	err = tx.QueryRow(ctx, query, f.ID, restoredBy).Scan(&f.UpdatedAt, &f.Version)
+	if err != nil {
+		span.RecordError(err)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "not_found"))
+			return fmt.Errorf("%w: deleted flight with id=%s", exceptions.ErrNotFound, f.ID)
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_flight_instance" {
+			span.SetAttributes(attribute.String("db.result", "duplicate"))
+			return duplicateFlightError(f)
+		}
+
+		logger.Error("Error restoring flight in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("restore flight %s: %w", f.ID, err)
+	}
+
+	restored := *f
+	restored.DeletedAt = nil
+	restored.LastUpdatedBy = restoredBy
+	if err := insertFlightHistory(ctx, tx, history, &restored); err != nil {
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("restore flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("restore flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("restore flight %s: commit: %w", f.ID, err)
+	}
+
+	f.DeletedAt = nil
+	f.LastUpdatedBy = restoredBy
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
@@ -0,0 +1,89 @@
+package flights
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryRestoreFlight(t *testing.T) {
+	restoreSQL := regexp.QuoteMeta(`UPDATE flights SET deleted_at = NULL, last_updated_by = $2, version = version + 1 WHERE id = $1 AND deleted_at IS NOT NULL RETURNING updated_at, version`)
+	updatedAt := time.Date(2024, 12, 15, 10, 5, 0, 0, time.UTC)
+	deletedAt := updatedAt.Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		mockErr      error
+		assertChecks func(t *testing.T, flight *models.Flight, err error)
+	}{
+		{
+			name: "Success",
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				require.NoError(t, err)
+				assert.Nil(t, flight.DeletedAt)
+				assert.Equal(t, int32(4), flight.Version)
+				assert.Equal(t, updatedAt, flight.UpdatedAt)
+			},
+		},
+		{
+			name:    "Not Deleted",
+			mockErr: pgx.ErrNoRows,
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				assert.ErrorIs(t, err, exceptions.ErrNotFound)
+				assert.NotNil(t, flight.DeletedAt)
+			},
+		},
+		{
+			name: "Number Taken By Live Flight",
+			mockErr: &pgconn.PgError{
+				Code:           pgerrcode.UniqueViolation,
+				ConstraintName: "unique_flight_instance",
+			},
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				assert.ErrorIs(t, err, exceptions.ErrDuplicateFlight)
+				assert.NotNil(t, flight.DeletedAt)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			flight := &models.Flight{ID: uuid.New(), Number: "BA117", DepartureTime: deletedAt, Version: 3, DeletedAt: &deletedAt}
+			restoredBy := uuid.New()
+
+			mock.ExpectBegin()
+			expectAircraftLock(mock, flight.AircraftID)
+			expectNoAircraftConflicts(mock, flight, 0)
+			expect := mock.ExpectQuery(restoreSQL).WithArgs(flight.ID, restoredBy)
+			if tc.mockErr != nil {
+				expect.WillReturnError(tc.mockErr)
+				mock.ExpectRollback()
+			} else {
+				expect.WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(4)))
+				mock.ExpectCommit()
+			}
+
+			repo := &FlightRepository{pool: mock}
+			err = repo.RestoreFlight(context.Background(), flight, restoredBy, nil)
+
+			tc.assertChecks(t, flight, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TransitionFlightStatus moves f to transition.ToStatus and records the transition in the
+// same transaction. The update only applies while the flight is still in
+// transition.FromStatus; if another writer changed it first ErrVersionConflict is returned.
+// The history entry and any events are written in the same transaction.
+func (flightRepository *FlightRepository) TransitionFlightStatus(
+	ctx context.Context,
+	f *models.Flight,
+	transition *models.FlightStatusTransition,
+	history *models.FlightHistoryEntry,
+	events ...*models.OutboxEvent,
+) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.transition_flight_status")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+		attribute.String("flight.id", f.ID.String()),
+		attribute.String("flight.status.from", string(transition.FromStatus)),
+		attribute.String("flight.status.to", string(transition.ToStatus)),
+	)
+
+	const updateQuery = `
+        UPDATE flights
+        SET status = $2, last_updated_by = $3, version = version + 1
+        WHERE id = $1 AND status = $4 AND deleted_at IS NULL
+        RETURNING updated_at, version
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("begin status transition for flight %s: %w", f.ID, err)
+	}
+
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	err = tx.QueryRow(
+		ctx,
+		updateQuery,
+		f.ID,
+		transition.ToStatus,
+		transition.ChangedBy,
+		transition.FromStatus,
+	).Scan(&f.UpdatedAt, &f.Version)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "version_conflict"))
+			return fmt.Errorf("%w: flight %s is no longer %s", exceptions.ErrVersionConflict, f.ID, transition.FromStatus)
+		}
+		logger.Error("Error updating flight status in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("transition flight %s: %w", f.ID, err)
+	}
+
+	if err := insertStatusTransition(ctx, tx, transition); err != nil {
+		span.RecordError(err)
+		logger.Error("Error recording flight status transition in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return err
+	}
+
+	transitioned := *f
+	transitioned.Status = transition.ToStatus
+	transitioned.LastUpdatedBy = transition.ChangedBy
+	if err := insertFlightHistory(ctx, tx, history, &transitioned); err != nil {
+		span.RecordError(err)
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("transition flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		span.RecordError(err)
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("transition flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("commit status transition for flight %s: %w", f.ID, err)
+	}
+
+	f.Status = transition.ToStatus
+	f.LastUpdatedBy = transition.ChangedBy
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
+
+// insertStatusTransition stores transition using tx, which is expected to be the
+// transaction that changed the flight's status, and sets its creation time.
+func insertStatusTransition(ctx context.Context, tx pgx.Tx, transition *models.FlightStatusTransition) error {
+	const query = `
+        INSERT INTO flight_status_transitions (
+            id, flight_id, from_status, to_status, reason, changed_by
+        )
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at
+    `
+
+	err := tx.QueryRow(
+		ctx,
+		query,
+		transition.ID,
+		transition.FlightID,
+		transition.FromStatus,
+		transition.ToStatus,
+		transition.Reason,
+		transition.ChangedBy,
+	).Scan(&transition.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("record status transition for flight %s: %w", transition.FlightID, err)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryTransitionFlightStatus(t *testing.T) {
+	updateSQL := regexp.QuoteMeta(`UPDATE flights SET status = $2, last_updated_by = $3, version = version + 1 WHERE id = $1 AND status = $4 AND deleted_at IS NULL RETURNING updated_at, version`)
+	insertSQL := regexp.QuoteMeta(`INSERT INTO flight_status_transitions ( id, flight_id, from_status, to_status, reason, changed_by ) VALUES ($1, $2, $3, $4, $5, $6) RETURNING created_at`)
+	historySQL := regexp.QuoteMeta(`INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`)
+	outboxSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	updatedAt := time.Date(2024, 12, 15, 10, 5, 0, 0, time.UTC)
+	reason := "weather"
+
+	newFixtures := func() (*models.Flight, *models.FlightStatusTransition) {
+		flight := &models.Flight{ID: uuid.New(), Status: models.FlightStatusScheduled, Version: 1}
+		transition := &models.FlightStatusTransition{
+			ID:         uuid.New(),
+			FlightID:   flight.ID,
+			FromStatus: models.FlightStatusScheduled,
+			ToStatus:   models.FlightStatusDelayed,
+			Reason:     &reason,
+			ChangedBy:  uuid.New(),
+		}
+		return flight, transition
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, transition := newFixtures()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, transition.ToStatus, transition.ChangedBy, transition.FromStatus).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(2)))
+		mock.ExpectQuery(insertSQL).
+			WithArgs(transition.ID, flight.ID, transition.FromStatus, transition.ToStatus, &reason, transition.ChangedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(updatedAt))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.TransitionFlightStatus(context.Background(), flight, transition, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.FlightStatusDelayed, flight.Status)
+		assert.Equal(t, int32(2), flight.Version)
+		assert.Equal(t, transition.ChangedBy, flight.LastUpdatedBy)
+		assert.Equal(t, updatedAt, transition.CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Status Changed Concurrently", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, transition := newFixtures()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, transition.ToStatus, transition.ChangedBy, transition.FromStatus).
+			WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.TransitionFlightStatus(context.Background(), flight, transition, nil)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, exceptions.ErrVersionConflict)
+		assert.Equal(t, models.FlightStatusScheduled, flight.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Insert Error Rolls Back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, transition := newFixtures()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, transition.ToStatus, transition.ChangedBy, transition.FromStatus).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(2)))
+		mock.ExpectQuery(insertSQL).
+			WithArgs(transition.ID, flight.ID, transition.FromStatus, transition.ToStatus, &reason, transition.ChangedBy).
+			WillReturnError(errors.New("insert failed"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.TransitionFlightStatus(context.Background(), flight, transition, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "record status transition")
+		assert.Equal(t, models.FlightStatusScheduled, flight.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Writes Outbox Events", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, transition := newFixtures()
+		event := &models.OutboxEvent{
+			AggregateID: flight.ID,
+			EventType:   models.EventTypeFlightStatusChanged,
+			Payload:     []byte(`{}`),
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, transition.ToStatus, transition.ChangedBy, transition.FromStatus).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(2)))
+		mock.ExpectQuery(insertSQL).
+			WithArgs(transition.ID, flight.ID, transition.FromStatus, transition.ToStatus, &reason, transition.ChangedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(updatedAt))
+		mock.ExpectQuery(outboxSQL).
+			WithArgs(flight.ID, models.EventTypeFlightStatusChanged, event.Payload, event.TraceContext).
+			WillReturnError(errors.New("outbox unavailable"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.TransitionFlightStatus(context.Background(), flight, transition, nil, event)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insert outbox event")
+		assert.Equal(t, models.FlightStatusScheduled, flight.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Writes History With New Status", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, transition := newFixtures()
+		flight.OrganizationID = testOrgID
+		history := &models.FlightHistoryEntry{Operation: models.FlightHistoryOperationStatusChanged, ActorID: transition.ChangedBy}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, transition.ToStatus, transition.ChangedBy, transition.FromStatus).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(2)))
+		mock.ExpectQuery(insertSQL).
+			WithArgs(transition.ID, flight.ID, transition.FromStatus, transition.ToStatus, &reason, transition.ChangedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(updatedAt))
+		mock.ExpectQuery(historySQL).
+			WithArgs(flight.ID, testOrgID, models.FlightHistoryOperationStatusChanged, transition.ChangedBy, history.Before, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), updatedAt))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.TransitionFlightStatus(context.Background(), flight, transition, history)
+		require.NoError(t, err)
+
+		var after models.Flight
+		require.NoError(t, json.Unmarshal(history.After, &after))
+		assert.Equal(t, models.FlightStatusDelayed, after.Status)
+		assert.Equal(t, int32(2), after.Version)
+		assert.Equal(t, transition.ChangedBy, after.LastUpdatedBy)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("History Error Rolls Back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, transition := newFixtures()
+		history := &models.FlightHistoryEntry{Operation: models.FlightHistoryOperationStatusChanged, ActorID: transition.ChangedBy}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(updateSQL).
+			WithArgs(flight.ID, transition.ToStatus, transition.ChangedBy, transition.FromStatus).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(2)))
+		mock.ExpectQuery(insertSQL).
+			WithArgs(transition.ID, flight.ID, transition.FromStatus, transition.ToStatus, &reason, transition.ChangedBy).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(updatedAt))
+		mock.ExpectQuery(historySQL).
+			WithArgs(flight.ID, pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnError(errors.New("history unavailable"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.TransitionFlightStatus(context.Background(), flight, transition, history)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insert flight history STATUS_CHANGED")
+		assert.Equal(t, models.FlightStatusScheduled, flight.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Begin Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight, transition := newFixtures()
+
+		mock.ExpectBegin().WillReturnError(errors.New("pool closed"))
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.TransitionFlightStatus(context.Background(), flight, transition, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "begin status transition")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,89 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// UnassignAircraft clears f's aircraft, provided the stored row is still at
+// expectedVersion and still assigned to f.AircraftID. A missing, deleted, reassigned or
+// newer row yields ErrVersionConflict. No aircraft checks are needed, as the flight is
+// left without one. On success f.AircraftID is uuid.Nil. The history entry and any
+// events are written in the same transaction.
+func (flightRepository *FlightRepository) UnassignAircraft(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.unassign_aircraft")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+		attribute.String("flight.id", f.ID.String()),
+		attribute.String("aircraft.id", f.AircraftID.String()),
+		attribute.Int("flight.version", int(expectedVersion)),
+	)
+
+	const query = `
+        UPDATE flights
+        SET aircraft_id = NULL, last_updated_by = $3, version = version + 1
+        WHERE id = $1 AND aircraft_id = $2 AND version = $4 AND deleted_at IS NULL
+        RETURNING updated_at, version
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("unassign aircraft from flight %s: begin: %w", f.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	err = tx.QueryRow(ctx, query, f.ID, f.AircraftID, f.LastUpdatedBy, expectedVersion).Scan(&f.UpdatedAt, &f.Version)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "version_conflict"))
+			return fmt.Errorf("%w: id=%s version=%d", exceptions.ErrVersionConflict, f.ID, expectedVersion)
+		}
+		logger.Error("Error unassigning aircraft in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("unassign aircraft from flight %s: %w", f.ID, err)
+	}
+	f.AircraftID = uuid.Nil
+
+	if err := insertFlightHistory(ctx, tx, history, f); err != nil {
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("unassign aircraft from flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("unassign aircraft from flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("unassign aircraft from flight %s: commit: %w", f.ID, err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
@@ -0,0 +1,107 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryUnassignAircraft(t *testing.T) {
+	unassignSQL := regexp.QuoteMeta(`UPDATE flights SET aircraft_id = NULL, last_updated_by = $3, version = version + 1 WHERE id = $1 AND aircraft_id = $2 AND version = $4 AND deleted_at IS NULL RETURNING updated_at, version`)
+	historySQL := regexp.QuoteMeta(`INSERT INTO flight_history (flight_id, organization_id, operation, actor_id, before, after) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`)
+	outboxSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	updatedAt := time.Date(2024, 12, 15, 10, 5, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		aircraftID := uuid.New()
+		actor := uuid.New()
+		flight := &models.Flight{ID: uuid.New(), AircraftID: aircraftID, OrganizationID: testOrgID, LastUpdatedBy: actor, Version: 2}
+		history := &models.FlightHistoryEntry{Operation: models.FlightHistoryOperationUpdated, ActorID: actor, Before: []byte(`{}`)}
+		event := &models.OutboxEvent{AggregateID: flight.ID, EventType: models.EventTypeFlightUpdated, Payload: []byte(`{}`)}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(unassignSQL).
+			WithArgs(flight.ID, aircraftID, actor, int32(2)).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at", "version"}).AddRow(updatedAt, int32(3)))
+		mock.ExpectQuery(historySQL).
+			WithArgs(flight.ID, testOrgID, models.FlightHistoryOperationUpdated, actor, history.Before, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), updatedAt))
+		mock.ExpectQuery(outboxSQL).
+			WithArgs(flight.ID, models.EventTypeFlightUpdated, event.Payload, pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(9), updatedAt))
+		mock.ExpectCommit()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.UnassignAircraft(context.Background(), flight, 2, history, event)
+
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Nil, flight.AircraftID)
+		assert.Equal(t, int32(3), flight.Version)
+		assert.Equal(t, updatedAt, flight.UpdatedAt)
+
+		var after models.Flight
+		require.NoError(t, json.Unmarshal(history.After, &after))
+		assert.Equal(t, uuid.Nil, after.AircraftID)
+		assert.Equal(t, int32(3), after.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Version Conflict", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		aircraftID := uuid.New()
+		flight := &models.Flight{ID: uuid.New(), AircraftID: aircraftID, Version: 2}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(unassignSQL).
+			WithArgs(flight.ID, aircraftID, pgxmock.AnyArg(), int32(2)).
+			WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.UnassignAircraft(context.Background(), flight, 2, nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrVersionConflict)
+		assert.Equal(t, aircraftID, flight.AircraftID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		flight := &models.Flight{ID: uuid.New(), AircraftID: uuid.New()}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(unassignSQL).
+			WithArgs(flight.ID, flight.AircraftID, pgxmock.AnyArg(), int32(0)).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := &FlightRepository{pool: mock}
+		err = repo.UnassignAircraft(context.Background(), flight, 0, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection reset")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,68 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// UnassignScheduleAircraft clears aircraftID from every schedule, across every
+// organization, that still operates on or after the calendar date from, attributing
+// the change to actorID. Each schedule's version is incremented, so its materialized
+// flights are brought in line without the aircraft, and flights materialized from it
+// later are left without one until it is given another. It returns the IDs of the
+// schedules changed.
+func (flightRepository *FlightRepository) UnassignScheduleAircraft(ctx context.Context, aircraftID, actorID uuid.UUID, from time.Time) ([]uuid.UUID, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.unassign_schedule_aircraft")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "schedules"),
+		attribute.String("aircraft.id", aircraftID.String()),
+	)
+
+	const query = `
+        UPDATE schedules
+        SET aircraft_id = NULL, last_updated_by = $2, version = version + 1
+        WHERE aircraft_id = $1 AND end_date >= $3
+        RETURNING id
+    `
+
+	rows, err := flightRepository.pool.Query(ctx, query, aircraftID, actorID, from)
+	if err != nil {
+		logger.Error("Error unassigning aircraft from schedules in db", "aircraft_id", aircraftID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("unassign aircraft %s from schedules: %w", aircraftID, err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return nil, fmt.Errorf("unassign aircraft %s from schedules: scan: %w", aircraftID, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return nil, fmt.Errorf("unassign aircraft %s from schedules: %w", aircraftID, err)
+	}
+
+	span.SetAttributes(
+		attribute.String("db.result", "success"),
+		attribute.Int("db.rows", len(ids)),
+	)
+	return ids, nil
+}
@@ -0,0 +1,56 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightRepositoryUnassignScheduleAircraft(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta(`UPDATE schedules SET aircraft_id = NULL, last_updated_by = $2, version = version + 1 WHERE aircraft_id = $1 AND end_date >= $3 RETURNING id`)
+	from := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	aircraftID := uuid.New()
+	actorID := uuid.New()
+
+	t.Run("returns the changed schedule ids", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		ids := []uuid.UUID{uuid.New(), uuid.New()}
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(aircraftID, actorID, from).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(ids[0]).AddRow(ids[1]))
+
+		repo := &FlightRepository{pool: mock}
+		unassigned, err := repo.UnassignScheduleAircraft(context.Background(), aircraftID, actorID, from)
+
+		require.NoError(t, err)
+		assert.Equal(t, ids, unassigned)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(expectedSQL).
+			WithArgs(aircraftID, actorID, from).
+			WillReturnError(errors.New("connection reset"))
+
+		repo := &FlightRepository{pool: mock}
+		unassigned, err := repo.UnassignScheduleAircraft(context.Background(), aircraftID, actorID, from)
+
+		assert.ErrorContains(t, err, "unassign aircraft "+aircraftID.String()+" from schedules")
+		assert.Nil(t, unassigned)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,148 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// UpdateFlight writes the mutable fields of f, provided the stored row is still at
+// expectedVersion. On success the version is incremented and f is refreshed with the
+// stored status, timestamps and version. A missing, deleted or newer row yields
+// ErrVersionConflict, and an *exceptions.AircraftConflictError is returned if f's
+// aircraft cannot operate it. A flight without an aircraft is stored with none and
+// has nothing to conflict with.
+// The history entry and any events are written in the same transaction.
+func (flightRepository *FlightRepository) UpdateFlight(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.update_flight")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+		attribute.String("flight.id", f.ID.String()),
+		attribute.String("flight.number", f.Number),
+		attribute.Int("flight.version", int(expectedVersion)),
+	)
+
+	const query = `
+        UPDATE flights
+        SET number = $2, origin = $3, destination = $4,
+            departure_time = $5, arrival_time = $6, aircraft_id = $7,
+            last_updated_by = $8, version = version + 1
+        WHERE id = $1 AND version = $9 AND deleted_at IS NULL
+        RETURNING status, created_at, updated_at, version
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update flight %s: begin: %w", f.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	hasAircraft := f.AircraftID != uuid.Nil
+	if hasAircraft {
+		if err := lockAircraft(ctx, tx, f.AircraftID); err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return fmt.Errorf("update flight %s: %w", f.ID, err)
+		}
+	}
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		f.ID,
+		f.Number,
+		f.Origin,
+		f.Destination,
+		f.DepartureTime,
+		f.ArrivalTime,
+		nullableID(f.AircraftID),
+		f.LastUpdatedBy,
+		expectedVersion,
+	).Scan(&f.Status, &f.CreatedAt, &f.UpdatedAt, &f.Version)
+
+	if err != nil {
+		span.RecordError(err)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "version_conflict"))
+			return fmt.Errorf("%w: id=%s version=%d", exceptions.ErrVersionConflict, f.ID, expectedVersion)
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			span.SetAttributes(
+				attribute.String("db.error.code", pgErr.Code),
+				attribute.String("db.error.constraint", pgErr.ConstraintName),
+			)
+
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_flight_instance" {
+				span.SetAttributes(attribute.String("db.result", "duplicate"))
+				return duplicateFlightError(f)
+			}
+
+			logger.Error("Error updating flight in db", "id", f.ID, "code", pgErr.Code, "constraint", pgErr.ConstraintName, "error", err)
+			span.SetAttributes(attribute.String("db.result", "postgres_error"))
+			return fmt.Errorf("postgres error [%s]: %w", pgErr.Code, err)
+		}
+
+		logger.Error("Error updating flight in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update flight %s: %w", f.ID, err)
+	}
+
+	if hasAircraft {
+		if err := flightRepository.checkAircraftConflicts(ctx, tx, f); err != nil {
+			span.RecordError(err)
+			if errors.Is(err, exceptions.ErrAircraftConflict) {
+				span.SetAttributes(attribute.String("db.result", "aircraft_conflict"))
+				return err
+			}
+			logger.Error("Error checking aircraft conflicts", "id", f.ID, "error", err)
+			span.SetAttributes(attribute.String("db.result", "error"))
+			return fmt.Errorf("update flight %s: %w", f.ID, err)
+		}
+	}
+
+	if err := insertFlightHistory(ctx, tx, history, f); err != nil {
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update flight %s: commit: %w", f.ID, err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
@@ -0,0 +1,228 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryUpdateFlight(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta(`UPDATE flights SET number = $2, origin = $3, destination = $4, departure_time = $5, arrival_time = $6, aircraft_id = $7, last_updated_by = $8, version = version + 1 WHERE id = $1 AND version = $9 AND deleted_at IS NULL RETURNING status, created_at, updated_at, version`)
+	createdAt := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	cases := []struct {
+		name         string
+		mockErr      error
+		assertChecks func(t *testing.T, flight *models.Flight, err error)
+	}{
+		{
+			name: "Success",
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, int32(3), flight.Version)
+				assert.Equal(t, updatedAt, flight.UpdatedAt)
+				assert.Equal(t, models.FlightStatusDelayed, flight.Status)
+			},
+		},
+		{
+			name:    "Stale Version",
+			mockErr: pgx.ErrNoRows,
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, exceptions.ErrVersionConflict)
+				assert.Equal(t, int32(2), flight.Version)
+			},
+		},
+		{
+			name: "Unique Constraint Violation",
+			mockErr: &pgconn.PgError{
+				Code:           pgerrcode.UniqueViolation,
+				ConstraintName: "unique_flight_instance",
+			},
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "already exists")
+			},
+		},
+		{
+			name:    "Database Error",
+			mockErr: errors.New("connection reset"),
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "update flight")
+				assert.NotErrorIs(t, err, exceptions.ErrVersionConflict)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			flight := &models.Flight{
+				ID:            uuid.New(),
+				Number:        "AA123",
+				Origin:        "LAX",
+				Destination:   "SFO",
+				DepartureTime: time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC),
+				ArrivalTime:   time.Date(2024, 12, 15, 12, 0, 0, 0, time.UTC),
+				AircraftID:    uuid.New(),
+				LastUpdatedBy: uuid.New(),
+				Version:       2,
+			}
+
+			mock.ExpectBegin()
+			expectAircraftLock(mock, flight.AircraftID)
+			expect := mock.ExpectQuery(expectedSQL).WithArgs(
+				flight.ID,
+				flight.Number,
+				flight.Origin,
+				flight.Destination,
+				flight.DepartureTime,
+				flight.ArrivalTime,
+				&flight.AircraftID,
+				flight.LastUpdatedBy,
+				int32(2),
+			)
+
+			if tc.mockErr == nil {
+				expect.WillReturnRows(
+					pgxmock.NewRows([]string{"status", "created_at", "updated_at", "version"}).
+						AddRow(models.FlightStatusDelayed, createdAt, updatedAt, int32(3)),
+				)
+				expectNoAircraftConflicts(mock, flight, 0)
+				mock.ExpectCommit()
+			} else {
+				expect.WillReturnError(tc.mockErr)
+				mock.ExpectRollback()
+			}
+
+			repo := &FlightRepository{pool: mock}
+			err = repo.UpdateFlight(context.Background(), flight, 2, nil)
+			tc.assertChecks(t, flight, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFlightRepositoryUpdateFlightWritesOutbox(t *testing.T) {
+	updateSQL := regexp.QuoteMeta(`UPDATE flights SET number = $2, origin = $3, destination = $4, departure_time = $5, arrival_time = $6, aircraft_id = $7, last_updated_by = $8, version = version + 1 WHERE id = $1 AND version = $9 AND deleted_at IS NULL RETURNING status, created_at, updated_at, version`)
+	outboxSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	updatedAt := time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	flight := &models.Flight{ID: uuid.New(), Number: "AA123", AircraftID: uuid.New(), Version: 2}
+	event := &models.OutboxEvent{
+		AggregateID: flight.ID,
+		EventType:   models.EventTypeFlightUpdated,
+		Payload:     []byte(`{}`),
+	}
+
+	mock.ExpectBegin()
+	expectAircraftLock(mock, flight.AircraftID)
+	mock.ExpectQuery(updateSQL).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), int32(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"status", "created_at", "updated_at", "version"}).
+			AddRow(models.FlightStatusScheduled, updatedAt, updatedAt, int32(3)))
+	expectNoAircraftConflicts(mock, flight, 0)
+	mock.ExpectQuery(outboxSQL).
+		WithArgs(flight.ID, models.EventTypeFlightUpdated, event.Payload, event.TraceContext).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(7), updatedAt))
+	mock.ExpectCommit()
+
+	repo := &FlightRepository{pool: mock}
+	err = repo.UpdateFlight(context.Background(), flight, 2, nil, event)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), event.ID)
+	assert.Equal(t, int32(3), flight.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFlightRepositoryUpdateFlightAircraftConflict(t *testing.T) {
+	updateSQL := regexp.QuoteMeta(`UPDATE flights SET number = $2, origin = $3, destination = $4, departure_time = $5, arrival_time = $6, aircraft_id = $7, last_updated_by = $8, version = version + 1 WHERE id = $1 AND version = $9 AND deleted_at IS NULL RETURNING status, created_at, updated_at, version`)
+	updatedAt := time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	flight := &models.Flight{
+		ID:            uuid.New(),
+		Number:        "AA123",
+		Origin:        "LAX",
+		Destination:   "SFO",
+		DepartureTime: time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC),
+		ArrivalTime:   time.Date(2024, 12, 15, 12, 0, 0, 0, time.UTC),
+		AircraftID:    uuid.New(),
+		Version:       2,
+	}
+
+	mock.ExpectBegin()
+	expectAircraftLock(mock, flight.AircraftID)
+	mock.ExpectQuery(updateSQL).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), int32(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"status", "created_at", "updated_at", "version"}).
+			AddRow(models.FlightStatusScheduled, updatedAt, updatedAt, int32(3)))
+	expectAircraftAvailable(mock, flight, 0)
+	mock.ExpectQuery(aircraftPositionSQL).
+		WithArgs(flight.AircraftID, flight.ID, models.FlightStatusCancelled, flight.DepartureTime).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "number", "destination", "departure_time", "arrival_time"}).
+			AddRow(uuid.New(), "AA100", "JFK", flight.DepartureTime.Add(-8*time.Hour), flight.DepartureTime.Add(-3*time.Hour)))
+	mock.ExpectRollback()
+
+	repo := &FlightRepository{pool: mock}
+	err = repo.UpdateFlight(context.Background(), flight, 2, nil)
+
+	var conflict *exceptions.AircraftConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, exceptions.AircraftConflictPosition, conflict.Kind)
+	assert.Equal(t, "JFK", conflict.Destination)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFlightRepositoryUpdateFlightWithoutAircraft(t *testing.T) {
+	updateSQL := regexp.QuoteMeta(`UPDATE flights SET number = $2, origin = $3, destination = $4, departure_time = $5, arrival_time = $6, aircraft_id = $7, last_updated_by = $8, version = version + 1 WHERE id = $1 AND version = $9 AND deleted_at IS NULL RETURNING status, created_at, updated_at, version`)
+	updatedAt := time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC)
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	flight := &models.Flight{ID: uuid.New(), Number: "AA124", Version: 2}
+
+	// Neither the aircraft lock nor the conflict checks are made.
+	mock.ExpectBegin()
+	mock.ExpectQuery(updateSQL).
+		WithArgs(flight.ID, pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), (*uuid.UUID)(nil), pgxmock.AnyArg(), int32(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"status", "created_at", "updated_at", "version"}).
+			AddRow(models.FlightStatusScheduled, updatedAt, updatedAt, int32(3)))
+	mock.ExpectCommit()
+
+	repo := &FlightRepository{pool: mock}
+	err = repo.UpdateFlight(context.Background(), flight, 2, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), flight.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
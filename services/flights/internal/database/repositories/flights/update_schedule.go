@@ -0,0 +1,72 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// UpdateSchedule writes the mutable fields of s, provided the stored row is still at
+// expectedVersion. On success the version is incremented, which marks the schedule's
+// materialized flights as out of date, and s is refreshed with the stored version and
+// timestamps. A missing or newer row yields ErrVersionConflict.
+func (flightRepository *FlightRepository) UpdateSchedule(ctx context.Context, s *models.Schedule, expectedVersion int32) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.update_schedule")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "schedules"),
+		attribute.String("schedule.id", s.ID.String()),
+		attribute.Int("schedule.version", int(expectedVersion)),
+	)
+
+	const query = `
+        UPDATE schedules
+        SET number = $2, origin = $3, destination = $4,
+            departure_local_time = $5, time_zone = $6, duration_minutes = $7, days_of_week = $8,
+            start_date = $9, end_date = $10, aircraft_id = $11,
+            last_updated_by = $12, version = version + 1
+        WHERE id = $1 AND version = $13
+        RETURNING version, materialized_through, created_at, updated_at
+    `
+
+	err := flightRepository.pool.QueryRow(
+		ctx,
+		query,
+		s.ID,
+		s.Number,
+		s.Origin,
+		s.Destination,
+		s.DepartureLocalTime,
+		s.TimeZone,
+		s.DurationMinutes,
+		s.DaysOfWeek,
+		s.StartDate,
+		s.EndDate,
+		nullableID(s.AircraftID),
+		s.LastUpdatedBy,
+		expectedVersion,
+	).Scan(&s.Version, &s.MaterializedThrough, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "version_conflict"))
+			return fmt.Errorf("%w: schedule id=%s version=%d", exceptions.ErrVersionConflict, s.ID, expectedVersion)
+		}
+		logger.Error("Error updating schedule in db", "id", s.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update schedule %s: %w", s.ID, err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
@@ -0,0 +1,106 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryUpdateSchedule(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta(`UPDATE schedules SET number = $2, origin = $3, destination = $4, departure_local_time = $5, time_zone = $6, duration_minutes = $7, days_of_week = $8, start_date = $9, end_date = $10, aircraft_id = $11, last_updated_by = $12, version = version + 1 WHERE id = $1 AND version = $13 RETURNING version, materialized_through, created_at, updated_at`)
+	updatedAt := time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC)
+	through := time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name         string
+		mockErr      error
+		assertChecks func(t *testing.T, schedule *models.Schedule, err error)
+	}{
+		{
+			name: "Success",
+			assertChecks: func(t *testing.T, schedule *models.Schedule, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, int32(3), schedule.Version)
+				assert.Equal(t, updatedAt, schedule.UpdatedAt)
+				require.NotNil(t, schedule.MaterializedThrough)
+				assert.Equal(t, through, *schedule.MaterializedThrough)
+			},
+		},
+		{
+			name:    "Stale Version",
+			mockErr: pgx.ErrNoRows,
+			assertChecks: func(t *testing.T, schedule *models.Schedule, err error) {
+				assert.ErrorIs(t, err, exceptions.ErrVersionConflict)
+				assert.Equal(t, int32(2), schedule.Version)
+			},
+		},
+		{
+			name:    "Database Error",
+			mockErr: errors.New("connection reset"),
+			assertChecks: func(t *testing.T, schedule *models.Schedule, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "update schedule")
+				assert.NotErrorIs(t, err, exceptions.ErrVersionConflict)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			schedule := newTestSchedule()
+			schedule.Version = 2
+			expect := mock.ExpectQuery(expectedSQL).WithArgs(
+				schedule.ID, schedule.Number, schedule.Origin, schedule.Destination,
+				schedule.DepartureLocalTime, schedule.TimeZone, schedule.DurationMinutes, schedule.DaysOfWeek,
+				schedule.StartDate, schedule.EndDate, &schedule.AircraftID, schedule.LastUpdatedBy, int32(2),
+			)
+			if tc.mockErr == nil {
+				expect.WillReturnRows(pgxmock.NewRows([]string{"version", "materialized_through", "created_at", "updated_at"}).
+					AddRow(int32(3), &through, updatedAt, updatedAt))
+			} else {
+				expect.WillReturnError(tc.mockErr)
+			}
+
+			repo := &FlightRepository{pool: mock}
+			err = repo.UpdateSchedule(context.Background(), schedule, 2)
+			tc.assertChecks(t, schedule, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Without Aircraft Stores Null", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		schedule := newTestSchedule()
+		schedule.Version = 2
+		schedule.AircraftID = uuid.Nil
+		mock.ExpectQuery(expectedSQL).WithArgs(
+			schedule.ID, schedule.Number, schedule.Origin, schedule.Destination,
+			schedule.DepartureLocalTime, schedule.TimeZone, schedule.DurationMinutes, schedule.DaysOfWeek,
+			schedule.StartDate, schedule.EndDate, (*uuid.UUID)(nil), schedule.LastUpdatedBy, int32(2),
+		).WillReturnRows(pgxmock.NewRows([]string{"version", "materialized_through", "created_at", "updated_at"}).
+			AddRow(int32(3), &through, updatedAt, updatedAt))
+
+		repo := &FlightRepository{pool: mock}
+		require.NoError(t, repo.UpdateSchedule(context.Background(), schedule, 2))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,134 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/outbox"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// UpdateScheduledFlight brings a materialized flight in line with its schedule at
+// instance.ScheduleVersion, as UpdateFlight does for an edit, provided the stored row is
+// still at expectedVersion and has not departed. A missing, deleted, departed or newer
+// row yields ErrVersionConflict. As with MaterializeSchedule, only the aircraft's
+// availability is checked, yielding an *exceptions.AircraftConflictError.
+// The history entry and any events are written in the same transaction.
+func (flightRepository *FlightRepository) UpdateScheduledFlight(ctx context.Context, instance *models.ScheduledFlight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.update_scheduled_flight")
+	defer span.End()
+
+	f := instance.Flight
+	span.SetAttributes(
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "flights"),
+		attribute.String("flight.id", f.ID.String()),
+		attribute.String("schedule.id", instance.ScheduleID.String()),
+		attribute.Int("flight.version", int(expectedVersion)),
+		attribute.Int("schedule.version", int(instance.ScheduleVersion)),
+	)
+
+	const query = `
+        UPDATE flights
+        SET number = $2, origin = $3, destination = $4,
+            departure_time = $5, arrival_time = $6, aircraft_id = $7,
+            last_updated_by = $8, schedule_version = $9, version = version + 1
+        WHERE id = $1 AND version = $10 AND status = $11 AND deleted_at IS NULL
+        RETURNING status, created_at, updated_at, version
+    `
+
+	tx, err := flightRepository.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update scheduled flight %s: begin: %w", f.ID, err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := lockAircraft(ctx, tx, f.AircraftID); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update scheduled flight %s: %w", f.ID, err)
+	}
+
+	err = tx.QueryRow(
+		ctx,
+		query,
+		f.ID,
+		f.Number,
+		f.Origin,
+		f.Destination,
+		f.DepartureTime,
+		f.ArrivalTime,
+		nullableID(f.AircraftID),
+		f.LastUpdatedBy,
+		instance.ScheduleVersion,
+		expectedVersion,
+		models.FlightStatusScheduled,
+	).Scan(&f.Status, &f.CreatedAt, &f.UpdatedAt, &f.Version)
+
+	if err != nil {
+		span.RecordError(err)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetAttributes(attribute.String("db.result", "version_conflict"))
+			return fmt.Errorf("%w: id=%s version=%d", exceptions.ErrVersionConflict, f.ID, expectedVersion)
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_flight_instance" {
+			span.SetAttributes(attribute.String("db.result", "duplicate"))
+			return duplicateFlightError(f)
+		}
+
+		logger.Error("Error updating scheduled flight in db", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update scheduled flight %s: %w", f.ID, err)
+	}
+
+	if err := flightRepository.checkAircraftAvailability(ctx, tx, f); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, exceptions.ErrAircraftConflict) {
+			span.SetAttributes(attribute.String("db.result", "aircraft_conflict"))
+			return err
+		}
+		logger.Error("Error checking aircraft conflicts", "id", f.ID, "error", err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update scheduled flight %s: %w", f.ID, err)
+	}
+
+	if err := insertFlightHistory(ctx, tx, history, f); err != nil {
+		logger.Error("Error writing flight history", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update scheduled flight %s: %w", f.ID, err)
+	}
+
+	if err := outbox.InsertEvents(ctx, tx, events); err != nil {
+		logger.Error("Error writing flight events to outbox", "id", f.ID, "error", err)
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update scheduled flight %s: %w", f.ID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("db.result", "error"))
+		return fmt.Errorf("update scheduled flight %s: commit: %w", f.ID, err)
+	}
+
+	span.SetAttributes(attribute.String("db.result", "success"))
+	return nil
+}
@@ -0,0 +1,100 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestFlightRepositoryUpdateScheduledFlight(t *testing.T) {
+	expectedSQL := regexp.QuoteMeta(`UPDATE flights SET number = $2, origin = $3, destination = $4, departure_time = $5, arrival_time = $6, aircraft_id = $7, last_updated_by = $8, schedule_version = $9, version = version + 1 WHERE id = $1 AND version = $10 AND status = $11 AND deleted_at IS NULL RETURNING status, created_at, updated_at, version`)
+	updatedAt := time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name         string
+		mockErr      error
+		assertChecks func(t *testing.T, flight *models.Flight, err error)
+	}{
+		{
+			name: "Success",
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, int32(3), flight.Version)
+				assert.Equal(t, updatedAt, flight.UpdatedAt)
+			},
+		},
+		{
+			name:    "Changed Since Read",
+			mockErr: pgx.ErrNoRows,
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				assert.ErrorIs(t, err, exceptions.ErrVersionConflict)
+				assert.Equal(t, int32(2), flight.Version)
+			},
+		},
+		{
+			name:    "Database Error",
+			mockErr: errors.New("connection reset"),
+			assertChecks: func(t *testing.T, flight *models.Flight, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "update scheduled flight")
+				assert.NotErrorIs(t, err, exceptions.ErrVersionConflict)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			departure := time.Date(2025, 4, 2, 7, 40, 0, 0, time.UTC)
+			flight := &models.Flight{
+				ID:            uuid.New(),
+				Number:        "BA117",
+				Origin:        "LHR",
+				Destination:   "JFK",
+				DepartureTime: departure,
+				ArrivalTime:   departure.Add(8 * time.Hour),
+				AircraftID:    uuid.New(),
+				LastUpdatedBy: uuid.New(),
+				Version:       2,
+			}
+			instance := &models.ScheduledFlight{Flight: flight, ScheduleID: uuid.New(), ScheduleVersion: 4}
+
+			mock.ExpectBegin()
+			expectAircraftLock(mock, flight.AircraftID)
+			expect := mock.ExpectQuery(expectedSQL).WithArgs(
+				flight.ID, flight.Number, flight.Origin, flight.Destination,
+				flight.DepartureTime, flight.ArrivalTime, &flight.AircraftID, flight.LastUpdatedBy,
+				int32(4), int32(2), models.FlightStatusScheduled,
+			)
+			if tc.mockErr == nil {
+				expect.WillReturnRows(pgxmock.NewRows([]string{"status", "created_at", "updated_at", "version"}).
+					AddRow(models.FlightStatusScheduled, updatedAt, updatedAt, int32(3)))
+				expectAircraftAvailable(mock, flight, 0)
+				mock.ExpectCommit()
+			} else {
+				expect.WillReturnError(tc.mockErr)
+				mock.ExpectRollback()
+			}
+
+			repo := &FlightRepository{pool: mock}
+			err = repo.UpdateScheduledFlight(context.Background(), instance, 2, nil)
+			tc.assertChecks(t, flight, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// Copier is the subset of pgx.Tx used to bulk load outbox rows inside another
+// repository's transaction.
+type Copier interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// CopyEvents writes events to the outbox with a single COPY using c, which is expected
+// to be the transaction that performs the write the events describe. Unlike
+// InsertEvents it does not read back the generated ID and created_at.
+func CopyEvents(ctx context.Context, c Copier, events []*models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := c.CopyFrom(
+		ctx,
+		pgx.Identifier{"outbox"},
+		[]string{"aggregate_id", "event_type", "payload", "trace_context"},
+		pgx.CopyFromSlice(len(events), func(i int) ([]any, error) {
+			event := events[i]
+			return []any{event.AggregateID, event.EventType, event.Payload, event.TraceContext}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("copy %d outbox events: %w", len(events), err)
+	}
+
+	return nil
+}
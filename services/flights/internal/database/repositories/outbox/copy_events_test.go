@@ -0,0 +1,60 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+func TestCopyEvents(t *testing.T) {
+	columns := []string{"aggregate_id", "event_type", "payload", "trace_context"}
+	events := []*models.OutboxEvent{
+		{AggregateID: uuid.New(), EventType: models.EventTypeFlightCreated, Payload: []byte(`{}`)},
+		{AggregateID: uuid.New(), EventType: models.EventTypeFlightCreated, Payload: []byte(`{}`)},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectCopyFrom(pgx.Identifier{"outbox"}, columns).WillReturnResult(2)
+
+		err = CopyEvents(context.Background(), mock, events)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Events", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		err = CopyEvents(context.Background(), mock, nil)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Copy Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectCopyFrom(pgx.Identifier{"outbox"}, columns).WillReturnError(errors.New("copy failed"))
+
+		err = CopyEvents(context.Background(), mock, events)
+
+		assert.ErrorContains(t, err, "copy 2 outbox events")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,34 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+// InsertEvents writes events to the outbox using q, which is expected to be the
+// transaction that performs the write the events describe.
+func InsertEvents(ctx context.Context, q Querier, events []*models.OutboxEvent) error {
+	const query = `
+        INSERT INTO outbox (aggregate_id, event_type, payload, trace_context)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at
+    `
+
+	for _, event := range events {
+		err := q.QueryRow(
+			ctx,
+			query,
+			event.AggregateID,
+			event.EventType,
+			event.Payload,
+			event.TraceContext,
+		).Scan(&event.ID, &event.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("insert outbox event %s for %s: %w", event.EventType, event.AggregateID, err)
+		}
+	}
+
+	return nil
+}
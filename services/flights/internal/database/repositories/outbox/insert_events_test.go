@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+func TestInsertEvents(t *testing.T) {
+	insertSQL := regexp.QuoteMeta(`INSERT INTO outbox (aggregate_id, event_type, payload, trace_context) VALUES ($1, $2, $3, $4) RETURNING id, created_at`)
+	createdAt := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		first := &models.OutboxEvent{AggregateID: uuid.New(), EventType: models.EventTypeFlightCreated, Payload: []byte(`{}`)}
+		second := &models.OutboxEvent{AggregateID: uuid.New(), EventType: models.EventTypeFlightCreated, Payload: []byte(`{}`)}
+
+		mock.ExpectQuery(insertSQL).
+			WithArgs(first.AggregateID, first.EventType, first.Payload, first.TraceContext).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(1), createdAt))
+		mock.ExpectQuery(insertSQL).
+			WithArgs(second.AggregateID, second.EventType, second.Payload, second.TraceContext).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(2), createdAt))
+
+		err = InsertEvents(context.Background(), mock, []*models.OutboxEvent{first, second})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), first.ID)
+		assert.Equal(t, int64(2), second.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Events", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		require.NoError(t, InsertEvents(context.Background(), mock, nil))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		event := &models.OutboxEvent{AggregateID: uuid.New(), EventType: models.EventTypeFlightCreated, Payload: []byte(`{}`)}
+		mock.ExpectQuery(insertSQL).
+			WithArgs(event.AggregateID, event.EventType, event.Payload, event.TraceContext).
+			WillReturnError(errors.New("connection reset"))
+
+		err = InsertEvents(context.Background(), mock, []*models.OutboxEvent{event})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insert outbox event FlightCreated")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
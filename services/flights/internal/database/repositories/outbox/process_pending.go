@@ -0,0 +1,199 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// claimLease is how long claimed events are held back from other relays while they
+// are delivered. Delivery is cut off when it expires, so a relay that dies mid-batch
+// only delays its events until the lease runs out.
+const claimLease = 2 * time.Minute
+
+// ProcessPending claims up to limit events that are due for delivery, passes them to
+// handle and records the returned results.
+//
+// Only the oldest pending event of each aggregate is claimed, and claimed rows are
+// locked with SKIP LOCKED, so concurrent relays never publish two events for the
+// same aggregate out of order. The claim pushes the events' next attempt past
+// claimLease and is committed before handle runs, so no rows stay locked while the
+// broker is reached. Dead events are never claimed and do not hold back later events.
+func (r *OutboxRepository) ProcessPending(
+	ctx context.Context,
+	limit int,
+	handle func(ctx context.Context, events []*models.OutboxEvent) []models.OutboxResult,
+) (int, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.process_outbox")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "outbox"),
+		attribute.Int("db.limit", limit),
+	)
+
+	events, err := r.claim(ctx, limit)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+	span.SetAttributes(attribute.Int("outbox.claimed", len(events)))
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	handleCtx, cancel := context.WithTimeout(ctx, claimLease)
+	results := handle(handleCtx, events)
+	cancel()
+
+	if err := r.recordResults(ctx, results); err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+func (r *OutboxRepository) claim(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	const claimQuery = `
+        SELECT o.id, o.aggregate_id, o.event_type, o.payload, o.trace_context, o.attempts, o.created_at
+        FROM outbox o
+        WHERE o.published_at IS NULL
+          AND o.dead_at IS NULL
+          AND o.next_attempt_at <= NOW()
+          AND NOT EXISTS (
+              SELECT 1 FROM outbox p
+              WHERE p.aggregate_id = o.aggregate_id
+                AND p.published_at IS NULL
+                AND p.dead_at IS NULL
+                AND p.id < o.id
+          )
+        ORDER BY o.id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    `
+	const leaseQuery = `UPDATE outbox SET next_attempt_at = NOW() + make_interval(secs => $2) WHERE id = ANY($1)`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin outbox claim: %w", err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, claimQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox events: %w", err)
+	}
+
+	var events []*models.OutboxEvent
+	ids := make([]int64, 0, limit)
+	for rows.Next() {
+		var event models.OutboxEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.AggregateID,
+			&event.EventType,
+			&event.Payload,
+			&event.TraceContext,
+			&event.Attempts,
+			&event.CreatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan outbox event: %w", err)
+		}
+		events = append(events, &event)
+		ids = append(ids, event.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("claim outbox events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	if _, err := tx.Exec(ctx, leaseQuery, ids, claimLease.Seconds()); err != nil {
+		return nil, fmt.Errorf("lease outbox events: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit outbox claim: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) recordResults(ctx context.Context, results []models.OutboxResult) error {
+	const publishedQuery = `UPDATE outbox SET published_at = NOW(), last_error = NULL WHERE id = $1`
+	const failedQuery = `UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`
+	const deadQuery = `UPDATE outbox SET attempts = attempts + 1, last_error = $2, dead_at = NOW() WHERE id = $1`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin outbox results: %w", err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	for _, result := range results {
+		switch {
+		case result.Err == nil:
+			_, err = tx.Exec(ctx, publishedQuery, result.ID)
+		case result.Dead:
+			_, err = tx.Exec(ctx, deadQuery, result.ID, result.Err.Error())
+		default:
+			logger.WarnContext(ctx, "Outbox event delivery failed", "outbox_id", result.ID, "next_attempt_at", result.NextAttemptAt, "err", result.Err)
+			_, err = tx.Exec(ctx, failedQuery, result.ID, result.Err.Error(), result.NextAttemptAt)
+		}
+		if err != nil {
+			return fmt.Errorf("record outbox result %d: %w", result.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit outbox results: %w", err)
+	}
+	return nil
+}
+
+// OldestPendingAge returns how long the oldest event still awaiting delivery has
+// been waiting, or zero when the outbox is drained. Dead events are not counted.
+func (r *OutboxRepository) OldestPendingAge(ctx context.Context) (float64, error) {
+	const query = `
+        SELECT COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(created_at)), 0)::float8
+        FROM outbox
+        WHERE published_at IS NULL
+          AND dead_at IS NULL
+    `
+
+	var seconds float64
+	if err := r.pool.QueryRow(ctx, query).Scan(&seconds); err != nil {
+		return 0, fmt.Errorf("query outbox lag: %w", err)
+	}
+	return seconds, nil
+}
+
+// DeadCount returns how many events have exhausted their delivery attempts and been
+// parked.
+func (r *OutboxRepository) DeadCount(ctx context.Context) (int64, error) {
+	const query = `SELECT COUNT(*) FROM outbox WHERE dead_at IS NOT NULL`
+
+	var count int64
+	if err := r.pool.QueryRow(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count dead outbox events: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,226 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+var (
+	claimSQL     = regexp.QuoteMeta(`SELECT o.id, o.aggregate_id, o.event_type, o.payload, o.trace_context, o.attempts, o.created_at FROM outbox o WHERE o.published_at IS NULL AND o.dead_at IS NULL AND o.next_attempt_at <= NOW() AND NOT EXISTS ( SELECT 1 FROM outbox p WHERE p.aggregate_id = o.aggregate_id AND p.published_at IS NULL AND p.dead_at IS NULL AND p.id < o.id ) ORDER BY o.id LIMIT $1 FOR UPDATE SKIP LOCKED`)
+	leaseSQL     = regexp.QuoteMeta(`UPDATE outbox SET next_attempt_at = NOW() + make_interval(secs => $2) WHERE id = ANY($1)`)
+	publishedSQL = regexp.QuoteMeta(`UPDATE outbox SET published_at = NOW(), last_error = NULL WHERE id = $1`)
+	failedSQL    = regexp.QuoteMeta(`UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`)
+	deadSQL      = regexp.QuoteMeta(`UPDATE outbox SET attempts = attempts + 1, last_error = $2, dead_at = NOW() WHERE id = $1`)
+	claimColumns = []string{"id", "aggregate_id", "event_type", "payload", "trace_context", "attempts", "created_at"}
+)
+
+func TestOutboxRepositoryProcessPending(t *testing.T) {
+	createdAt := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
+	retryAt := createdAt.Add(time.Minute)
+
+	t.Run("Records Published, Failed And Dead Events", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		trace := map[string]string{"traceparent": "00-abc-def-01"}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimSQL).WithArgs(10).
+			WillReturnRows(pgxmock.NewRows(claimColumns).
+				AddRow(int64(1), uuid.New(), models.EventTypeFlightCreated, []byte(`{}`), trace, int32(0), createdAt).
+				AddRow(int64(2), uuid.New(), models.EventTypeFlightCreated, []byte(`{}`), map[string]string(nil), int32(3), createdAt).
+				AddRow(int64(3), uuid.New(), models.EventTypeFlightCreated, []byte(`{}`), map[string]string(nil), int32(9), createdAt))
+		mock.ExpectExec(leaseSQL).WithArgs([]int64{1, 2, 3}, claimLease.Seconds()).WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(publishedSQL).WithArgs(int64(1)).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec(failedSQL).WithArgs(int64(2), "broker down", retryAt).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec(deadSQL).WithArgs(int64(3), "bad payload").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+
+		repo := &OutboxRepository{pool: mock}
+		var handled []*models.OutboxEvent
+		processed, err := repo.ProcessPending(context.Background(), 10, func(ctx context.Context, events []*models.OutboxEvent) []models.OutboxResult {
+			// Delivery is bounded by the lease.
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(claimLease), deadline, time.Second)
+
+			handled = events
+			return []models.OutboxResult{
+				{ID: 1},
+				{ID: 2, Err: errors.New("broker down"), NextAttemptAt: retryAt},
+				{ID: 3, Err: errors.New("bad payload"), Dead: true},
+			}
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, processed)
+		require.Len(t, handled, 3)
+		assert.Equal(t, trace, handled[0].TraceContext)
+		assert.Equal(t, int32(3), handled[1].Attempts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing Pending", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimSQL).WithArgs(10).WillReturnRows(pgxmock.NewRows(claimColumns))
+		mock.ExpectRollback()
+
+		repo := &OutboxRepository{pool: mock}
+		processed, err := repo.ProcessPending(context.Background(), 10, func(ctx context.Context, events []*models.OutboxEvent) []models.OutboxResult {
+			t.Fatal("handler should not be called without events")
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Zero(t, processed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Claim Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimSQL).WithArgs(10).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := &OutboxRepository{pool: mock}
+		_, err = repo.ProcessPending(context.Background(), 10, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "claim outbox events")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Lease Error Skips Delivery", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimSQL).WithArgs(10).
+			WillReturnRows(pgxmock.NewRows(claimColumns).
+				AddRow(int64(1), uuid.New(), models.EventTypeFlightCreated, []byte(`{}`), map[string]string(nil), int32(0), createdAt))
+		mock.ExpectExec(leaseSQL).WithArgs([]int64{1}, claimLease.Seconds()).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := &OutboxRepository{pool: mock}
+		_, err = repo.ProcessPending(context.Background(), 10, func(ctx context.Context, events []*models.OutboxEvent) []models.OutboxResult {
+			t.Fatal("handler should not be called when the claim fails")
+			return nil
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "lease outbox events")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Result Update Error Rolls Back", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimSQL).WithArgs(10).
+			WillReturnRows(pgxmock.NewRows(claimColumns).
+				AddRow(int64(1), uuid.New(), models.EventTypeFlightCreated, []byte(`{}`), map[string]string(nil), int32(0), createdAt))
+		mock.ExpectExec(leaseSQL).WithArgs([]int64{1}, claimLease.Seconds()).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(publishedSQL).WithArgs(int64(1)).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := &OutboxRepository{pool: mock}
+		_, err = repo.ProcessPending(context.Background(), 10, func(ctx context.Context, events []*models.OutboxEvent) []models.OutboxResult {
+			return []models.OutboxResult{{ID: 1}}
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "record outbox result 1")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutboxRepositoryOldestPendingAge(t *testing.T) {
+	lagSQL := regexp.QuoteMeta(`SELECT COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(created_at)), 0)::float8 FROM outbox WHERE published_at IS NULL AND dead_at IS NULL`)
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(lagSQL).WillReturnRows(pgxmock.NewRows([]string{"lag"}).AddRow(12.5))
+
+		repo := &OutboxRepository{pool: mock}
+		lag, err := repo.OldestPendingAge(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 12.5, lag)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(lagSQL).WillReturnError(errors.New("connection reset"))
+
+		repo := &OutboxRepository{pool: mock}
+		_, err = repo.OldestPendingAge(context.Background())
+
+		require.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutboxRepositoryDeadCount(t *testing.T) {
+	deadSQL := regexp.QuoteMeta(`SELECT COUNT(*) FROM outbox WHERE dead_at IS NOT NULL`)
+
+	t.Run("Success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(deadSQL).WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(2)))
+
+		repo := &OutboxRepository{pool: mock}
+		count, err := repo.DeadCount(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectQuery(deadSQL).WillReturnError(errors.New("connection reset"))
+
+		repo := &OutboxRepository{pool: mock}
+		_, err = repo.DeadCount(context.Background())
+
+		assert.ErrorContains(t, err, "count dead outbox events")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,114 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// broadcastTimeout bounds how long claimed events stay locked while their flights are
+// published to subscribers.
+const broadcastTimeout = 10 * time.Second
+
+// ProcessUnbroadcast claims up to limit events whose flights have not yet been
+// published to subscribers, oldest first, and passes the IDs of those flights to
+// handle. The events are marked broadcast only if handle succeeds; otherwise they are
+// claimed again on the next call, so every committed change reaches subscribers.
+//
+// This is independent of delivery to Kafka: an event is broadcast whether it has been
+// published, is pending or is dead. Claimed rows are locked with SKIP LOCKED for the
+// duration of handle, which is cut off after broadcastTimeout, so concurrent relays
+// never broadcast the same event twice. It returns the number of events claimed.
+func (r *OutboxRepository) ProcessUnbroadcast(
+	ctx context.Context,
+	limit int,
+	handle func(ctx context.Context, aggregateIDs []uuid.UUID) error,
+) (int, error) {
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "db.process_outbox_broadcast")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "outbox"),
+		attribute.Int("db.limit", limit),
+	)
+
+	const claimQuery = `
+        SELECT id, aggregate_id
+        FROM outbox
+        WHERE broadcast_at IS NULL
+        ORDER BY id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    `
+	const broadcastQuery = `UPDATE outbox SET broadcast_at = NOW() WHERE id = ANY($1)`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("begin outbox broadcast: %w", err)
+	}
+	defer func() {
+		// Rollback after a successful Commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, claimQuery, limit)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("claim unbroadcast outbox events: %w", err)
+	}
+
+	ids := make([]int64, 0, limit)
+	var aggregateIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id int64
+		var aggregateID uuid.UUID
+		if err := rows.Scan(&id, &aggregateID); err != nil {
+			rows.Close()
+			span.RecordError(err)
+			return 0, fmt.Errorf("scan unbroadcast outbox event: %w", err)
+		}
+		ids = append(ids, id)
+		if !seen[aggregateID] {
+			seen[aggregateID] = true
+			aggregateIDs = append(aggregateIDs, aggregateID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("claim unbroadcast outbox events: %w", err)
+	}
+	span.SetAttributes(attribute.Int("outbox.claimed", len(ids)))
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	handleCtx, cancel := context.WithTimeout(ctx, broadcastTimeout)
+	err = handle(handleCtx, aggregateIDs)
+	cancel()
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("broadcast outbox events: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, broadcastQuery, ids); err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("mark outbox events broadcast: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("commit outbox broadcast: %w", err)
+	}
+
+	return len(ids), nil
+}
@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	claimUnbroadcastSQL = regexp.QuoteMeta(`SELECT id, aggregate_id FROM outbox WHERE broadcast_at IS NULL ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED`)
+	broadcastSQL        = regexp.QuoteMeta(`UPDATE outbox SET broadcast_at = NOW() WHERE id = ANY($1)`)
+)
+
+func TestOutboxRepositoryProcessUnbroadcast(t *testing.T) {
+	first := uuid.New()
+	second := uuid.New()
+
+	t.Run("Marks Events Broadcast Once Handled", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimUnbroadcastSQL).WithArgs(10).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "aggregate_id"}).
+				AddRow(int64(1), first).
+				AddRow(int64(2), second).
+				AddRow(int64(3), first))
+		mock.ExpectExec(broadcastSQL).WithArgs([]int64{1, 2, 3}).WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+		mock.ExpectCommit()
+
+		repo := &OutboxRepository{pool: mock}
+		var handled []uuid.UUID
+		processed, err := repo.ProcessUnbroadcast(context.Background(), 10, func(ctx context.Context, aggregateIDs []uuid.UUID) error {
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(broadcastTimeout), deadline, time.Second)
+
+			handled = aggregateIDs
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, processed)
+		assert.Equal(t, []uuid.UUID{first, second}, handled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing To Broadcast", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimUnbroadcastSQL).WithArgs(10).WillReturnRows(pgxmock.NewRows([]string{"id", "aggregate_id"}))
+		mock.ExpectRollback()
+
+		repo := &OutboxRepository{pool: mock}
+		processed, err := repo.ProcessUnbroadcast(context.Background(), 10, func(ctx context.Context, aggregateIDs []uuid.UUID) error {
+			t.Fatal("handler should not be called without events")
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Zero(t, processed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Handler Error Leaves Events For The Next Claim", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimUnbroadcastSQL).WithArgs(10).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "aggregate_id"}).AddRow(int64(1), first))
+		mock.ExpectRollback()
+
+		repo := &OutboxRepository{pool: mock}
+		_, err = repo.ProcessUnbroadcast(context.Background(), 10, func(ctx context.Context, aggregateIDs []uuid.UUID) error {
+			return errors.New("redis down")
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "redis down")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Claim Error", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimUnbroadcastSQL).WithArgs(10).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := &OutboxRepository{pool: mock}
+		_, err = repo.ProcessUnbroadcast(context.Background(), 10, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "claim unbroadcast outbox events")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,168 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc loads the values for keys in one call. Keys missing from the returned
+// map resolve to the zero value of V; a returned error fails every key in the batch.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader coalesces the Load calls made within a short window into a single BatchFunc
+// call and memoises the results. A Loader is meant to live for one request, so the
+// memoised values never outlive the request that loaded them.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	results []*result[V]
+}
+
+// Option configures a Loader.
+type Option func(*options)
+
+type options struct {
+	wait     time.Duration
+	maxBatch int
+}
+
+// WithWait sets how long a batch stays open for further keys after the first Load.
+func WithWait(wait time.Duration) Option {
+	return func(o *options) { o.wait = wait }
+}
+
+// WithMaxBatch dispatches a batch as soon as it holds n keys.
+func WithMaxBatch(n int) Option {
+	return func(o *options) { o.maxBatch = n }
+}
+
+// New returns a Loader that resolves keys with fetch. By default a batch stays open
+// for 2ms and holds at most 100 keys.
+func New[K comparable, V any](fetch BatchFunc[K, V], opts ...Option) *Loader[K, V] {
+	o := options{wait: 2 * time.Millisecond, maxBatch: 100}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     o.wait,
+		maxBatch: o.maxBatch,
+		results:  make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value for key, joining the open batch or starting a new one.
+// The batch is fetched with the context of the Load call that opened it.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+
+	res, ok := l.results[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.results[key] = res
+
+		if l.pending == nil {
+			l.pending = &batch[K, V]{ctx: ctx}
+			go l.dispatchAfterWait(l.pending)
+		}
+		l.pending.keys = append(l.pending.keys, key)
+		l.pending.results = append(l.pending.results, res)
+
+		if len(l.pending.keys) >= l.maxBatch {
+			full := l.pending
+			l.pending = nil
+			go l.dispatch(full)
+		}
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// LoadMany loads every key, returning values in key order. The first error wins.
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	values := make([]V, len(keys))
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key K) {
+			defer wg.Done()
+			values[i], errs[i] = l.Load(ctx, key)
+		}(i, key)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func (l *Loader[K, V]) dispatchAfterWait(b *batch[K, V]) {
+	time.Sleep(l.wait)
+
+	l.mu.Lock()
+	if l.pending != b {
+		// Already dispatched because it filled up.
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+
+	l.dispatch(b)
+}
+
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	values, err := l.fetch(b.ctx, b.keys)
+
+	for i, key := range b.keys {
+		res := b.results[i]
+		if err != nil {
+			res.err = err
+		} else {
+			res.value = values[key]
+		}
+		close(res.done)
+	}
+
+	if err != nil {
+		// Failed keys are forgotten so a later Load can retry them.
+		l.mu.Lock()
+		for i, key := range b.keys {
+			if l.results[key] == b.results[i] {
+				delete(l.results, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
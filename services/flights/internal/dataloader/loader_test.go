@@ -0,0 +1,107 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *recorder) fetch(ctx context.Context, keys []int) (map[int]string, error) {
+	r.mu.Lock()
+	r.batches = append(r.batches, append([]int(nil), keys...))
+	r.mu.Unlock()
+
+	values := make(map[int]string, len(keys))
+	for _, k := range keys {
+		if k >= 0 {
+			values[k] = string(rune('a' + k))
+		}
+	}
+	return values, nil
+}
+
+func TestLoaderBatchesConcurrentLoads(t *testing.T) {
+	rec := &recorder{}
+	loader := New(rec.fetch, WithWait(20*time.Millisecond))
+
+	values, err := loader.LoadMany(context.Background(), []int{0, 1, 2, 1, -1})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "b", ""}, values)
+	require.Len(t, rec.batches, 1)
+	assert.ElementsMatch(t, []int{0, 1, 2, -1}, rec.batches[0])
+}
+
+func TestLoaderMemoisesResults(t *testing.T) {
+	rec := &recorder{}
+	loader := New(rec.fetch, WithWait(time.Millisecond))
+
+	first, err := loader.Load(context.Background(), 3)
+	require.NoError(t, err)
+	second, err := loader.Load(context.Background(), 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, "d", first)
+	assert.Equal(t, first, second)
+	assert.Len(t, rec.batches, 1)
+}
+
+func TestLoaderSplitsAtMaxBatch(t *testing.T) {
+	rec := &recorder{}
+	loader := New(rec.fetch, WithWait(20*time.Millisecond), WithMaxBatch(2))
+
+	_, err := loader.LoadMany(context.Background(), []int{0, 1, 2, 3, 4})
+
+	require.NoError(t, err)
+	total := 0
+	for _, b := range rec.batches {
+		assert.LessOrEqual(t, len(b), 2)
+		total += len(b)
+	}
+	assert.Equal(t, 5, total)
+}
+
+func TestLoaderErrorFailsBatchAndAllowsRetry(t *testing.T) {
+	var calls atomic.Int32
+	fetchErr := errors.New("db down")
+	loader := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		if calls.Add(1) == 1 {
+			return nil, fetchErr
+		}
+		return map[int]string{1: "b"}, nil
+	}, WithWait(time.Millisecond))
+
+	_, err := loader.Load(context.Background(), 1)
+	assert.ErrorIs(t, err, fetchErr)
+
+	value, err := loader.Load(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "b", value)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestLoaderHonoursCallerContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	loader := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		<-release
+		return nil, nil
+	}, WithWait(time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := loader.Load(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,21 @@
+package directives
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+)
+
+// HasRoleDirective only resolves the field when the caller holds at least one of roles.
+func HasRoleDirective(ctx context.Context, _ any, next graphql.Resolver, roles []string) (res any, err error) {
+	user := middleware.GetRequestUserContext(ctx)
+
+	if !user.HasAnyRole(roles...) {
+		return nil, fmt.Errorf("%w: requires one of %v", exceptions.ErrForbidden, roles)
+	}
+
+	return next(ctx)
+}
@@ -0,0 +1,43 @@
+package directives
+
+import (
+	"context"
+	"testing"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasRoleDirective(t *testing.T) {
+	roles := []string{userContext.RoleDispatcher, userContext.RoleAdmin}
+	next := func(ctx context.Context) (any, error) { return "resolved", nil }
+
+	tests := []struct {
+		name      string
+		userRoles string
+		allowed   bool
+	}{
+		{name: "dispatcher", userRoles: "DISPATCHER", allowed: true},
+		{name: "admin among other roles", userRoles: "VIEWER,admin", allowed: true},
+		{name: "viewer", userRoles: "VIEWER", allowed: false},
+		{name: "no roles", userRoles: "", allowed: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{Roles: tc.userRoles})
+
+			res, err := HasRoleDirective(ctx, nil, next, roles)
+
+			if tc.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, "resolved", res)
+				return
+			}
+			assert.ErrorIs(t, err, exceptions.ErrForbidden)
+			assert.Nil(t, res)
+		})
+	}
+}
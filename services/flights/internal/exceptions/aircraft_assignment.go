@@ -0,0 +1,29 @@
+package exceptions
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAircraftInMaintenance = errors.New("aircraft is in maintenance")
+	ErrAircraftGrounded      = errors.New("aircraft is grounded")
+	ErrAircraftNoCapacity    = errors.New("aircraft has no seating capacity")
+	ErrAircraftWrongAirline  = errors.New("aircraft belongs to another airline")
+)
+
+func AircraftInMaintenance(id any) error {
+	return fmt.Errorf("%w: aircraft with id=%v cannot be assigned to flights", ErrAircraftInMaintenance, id)
+}
+
+func AircraftGrounded(id any) error {
+	return fmt.Errorf("%w: aircraft with id=%v cannot be assigned to flights", ErrAircraftGrounded, id)
+}
+
+func AircraftNoCapacity(id any) error {
+	return fmt.Errorf("%w: aircraft with id=%v has no seats", ErrAircraftNoCapacity, id)
+}
+
+func AircraftWrongAirline(id any) error {
+	return fmt.Errorf("%w: aircraft with id=%v cannot be assigned to this organization's flights", ErrAircraftWrongAirline, id)
+}
@@ -0,0 +1,66 @@
+package exceptions
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrAircraftConflict = errors.New("aircraft conflict")
+
+// AircraftConflictKind is the rule a flight breaks when its aircraft cannot operate it.
+type AircraftConflictKind string
+
+const (
+	// AircraftConflictOverlap means the aircraft is already flying during the flight.
+	AircraftConflictOverlap AircraftConflictKind = "OVERLAP"
+	// AircraftConflictTurnaround means the aircraft has less than the minimum
+	// turnaround on the ground before or after the flight.
+	AircraftConflictTurnaround AircraftConflictKind = "TURNAROUND"
+	// AircraftConflictPosition means the aircraft's previous flight does not end at the
+	// flight's origin, or its next flight does not start at the flight's destination.
+	AircraftConflictPosition AircraftConflictKind = "POSITION"
+)
+
+// AircraftConflictError names the flight that stops an aircraft from operating another.
+// It matches ErrAircraftConflict with errors.Is.
+type AircraftConflictError struct {
+	Kind       AircraftConflictKind
+	AircraftID uuid.UUID
+	// The clashing flight.
+	FlightID      uuid.UUID
+	FlightNumber  string
+	DepartureTime time.Time
+	ArrivalTime   time.Time
+	// Destination is where the clashing flight leaves the aircraft. Set for POSITION
+	// when the clashing flight departs before the flight.
+	Destination string
+	// Origin is where the clashing flight needs the aircraft. Set for POSITION when the
+	// clashing flight departs after the flight.
+	Origin string
+	// MinTurnaround is the turnaround that was required. Set for TURNAROUND.
+	MinTurnaround time.Duration
+}
+
+func (e *AircraftConflictError) Error() string {
+	flight := fmt.Sprintf("flight %s (id=%s) departing %s", e.FlightNumber, e.FlightID, e.DepartureTime.UTC().Format(time.RFC3339))
+	switch e.Kind {
+	case AircraftConflictOverlap:
+		return fmt.Sprintf("%v: aircraft %s is already operating %s", ErrAircraftConflict, e.AircraftID, flight)
+	case AircraftConflictTurnaround:
+		return fmt.Sprintf("%v: aircraft %s needs at least %s on the ground around %s", ErrAircraftConflict, e.AircraftID, e.MinTurnaround, flight)
+	case AircraftConflictPosition:
+		if e.Origin != "" {
+			return fmt.Sprintf("%v: aircraft %s must be at %s for %s", ErrAircraftConflict, e.AircraftID, e.Origin, flight)
+		}
+		return fmt.Sprintf("%v: aircraft %s will be at %s after %s", ErrAircraftConflict, e.AircraftID, e.Destination, flight)
+	default:
+		return fmt.Sprintf("%v: aircraft %s clashes with %s", ErrAircraftConflict, e.AircraftID, flight)
+	}
+}
+
+func (e *AircraftConflictError) Unwrap() error {
+	return ErrAircraftConflict
+}
@@ -0,0 +1,52 @@
+package exceptions
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAircraftConflictError(testHelper *testing.T) {
+	aircraftID := uuid.MustParse("6f1c2b4e-8a55-4a43-9d1e-0c1f5b2a7e11")
+	flightID := uuid.MustParse("0b8e2d8c-3f3a-4a57-8f43-5f2c4c6f9d20")
+	base := AircraftConflictError{
+		AircraftID:    aircraftID,
+		FlightID:      flightID,
+		FlightNumber:  "BA117",
+		DepartureTime: time.Date(2025, 4, 1, 7, 25, 0, 0, time.UTC),
+		ArrivalTime:   time.Date(2025, 4, 1, 15, 25, 0, 0, time.UTC),
+	}
+	flight := "flight BA117 (id=0b8e2d8c-3f3a-4a57-8f43-5f2c4c6f9d20) departing 2025-04-01T07:25:00Z"
+
+	testCases := []struct {
+		kind     AircraftConflictKind
+		modify   func(*AircraftConflictError)
+		expected string
+	}{
+		{AircraftConflictOverlap, nil, "aircraft conflict: aircraft 6f1c2b4e-8a55-4a43-9d1e-0c1f5b2a7e11 is already operating " + flight},
+		{AircraftConflictTurnaround, func(e *AircraftConflictError) { e.MinTurnaround = 45 * time.Minute }, "aircraft conflict: aircraft 6f1c2b4e-8a55-4a43-9d1e-0c1f5b2a7e11 needs at least 45m0s on the ground around " + flight},
+		{AircraftConflictPosition, func(e *AircraftConflictError) { e.Destination = "JFK" }, "aircraft conflict: aircraft 6f1c2b4e-8a55-4a43-9d1e-0c1f5b2a7e11 will be at JFK after " + flight},
+		{AircraftConflictPosition, func(e *AircraftConflictError) { e.Origin = "LHR" }, "aircraft conflict: aircraft 6f1c2b4e-8a55-4a43-9d1e-0c1f5b2a7e11 must be at LHR for " + flight},
+	}
+
+	for _, testCase := range testCases {
+		conflict := base
+		conflict.Kind = testCase.kind
+		if testCase.modify != nil {
+			testCase.modify(&conflict)
+		}
+
+		err := fmt.Errorf("create flight: %w", &conflict)
+
+		assert.EqualError(testHelper, &conflict, testCase.expected)
+		assert.ErrorIs(testHelper, err, ErrAircraftConflict)
+
+		var target *AircraftConflictError
+		assert.True(testHelper, errors.As(err, &target))
+		assert.Equal(testHelper, flightID, target.FlightID)
+	}
+}
@@ -0,0 +1,9 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrOrganizationRequired     = errors.New("organization context required")
+	ErrForbidden                = errors.New("caller does not have a permitted role")
+	ErrInvalidIdentityAssertion = errors.New("invalid identity assertion")
+)
@@ -0,0 +1,5 @@
+package exceptions
+
+import "errors"
+
+var ErrVersionConflict = errors.New("flight has been modified since it was read")
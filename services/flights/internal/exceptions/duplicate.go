@@ -0,0 +1,5 @@
+package exceptions
+
+import "errors"
+
+var ErrDuplicateFlight = errors.New("duplicate flight")
@@ -0,0 +1,7 @@
+package exceptions
+
+import "errors"
+
+// ErrMovementOutOfOrder is returned when a movement time is recorded before the
+// movements that precede it, or is not between its neighbouring movement times.
+var ErrMovementOutOfOrder = errors.New("flight movements must be recorded in out, off, on, in order")
@@ -0,0 +1,8 @@
+package exceptions
+
+import "errors"
+
+var (
+	ErrInvalidCursor   = errors.New("invalid pagination cursor")
+	ErrInvalidPageSize = errors.New("invalid page size")
+)
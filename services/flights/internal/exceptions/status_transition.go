@@ -0,0 +1,12 @@
+package exceptions
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrIllegalStatusTransition = errors.New("illegal flight status transition")
+
+func IllegalStatusTransition(from, to any) error {
+	return fmt.Errorf("%w: %v -> %v", ErrIllegalStatusTransition, from, to)
+}
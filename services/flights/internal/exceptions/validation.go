@@ -9,13 +9,19 @@ import (
 
 var (
 	ErrInvalidIATACode          = errors.New("IATA code must be exactly 3 uppercase letters A-Z")
+	ErrUnknownAirport           = errors.New("unknown airport")
 	ErrInvalidFlightNumber      = errors.New("flight number must contain airline code (2-3 letters) followed by digits, max 10 characters")
 	ErrSameOriginAndDestination = errors.New("duplicate origin and destination code")
 	ErrInvalidTimes             = errors.New("arrival must be after departure")
 	ErrInvalidInput             = errors.New("invalid input")
 	ErrAircraftNotFound         = errors.New("aircraft not found")
+	ErrInvalidDelayCode         = errors.New("delay code must be an IATA (AHM 730) delay code")
 )
 
+func UnknownAirport(code string) error {
+	return fmt.Errorf("%w: no airport has IATA code %s", ErrUnknownAirport, code)
+}
+
 func AircraftNotFound(id any) error {
 	return fmt.Errorf("%w: aircraft with id=%v was not found", ErrAircraftNotFound, id)
 }
@@ -25,9 +31,27 @@ var errorCodeMap = map[error]connect.Code{
 	ErrInvalidTimes:             connect.CodeInvalidArgument,
 	ErrInvalidFlightNumber:      connect.CodeInvalidArgument,
 	ErrInvalidIATACode:          connect.CodeInvalidArgument,
+	ErrUnknownAirport:           connect.CodeInvalidArgument,
 	ErrSameOriginAndDestination: connect.CodeInvalidArgument,
+	ErrInvalidDelayCode:         connect.CodeInvalidArgument,
 	ErrAircraftNotFound:         connect.CodeNotFound,
+	ErrAircraftInMaintenance:    connect.CodeFailedPrecondition,
+	ErrAircraftGrounded:         connect.CodeFailedPrecondition,
+	ErrAircraftNoCapacity:       connect.CodeFailedPrecondition,
+	ErrAircraftWrongAirline:     connect.CodePermissionDenied,
 	ErrNotFound:                 connect.CodeNotFound,
+	ErrInvalidCursor:            connect.CodeInvalidArgument,
+	ErrInvalidPageSize:          connect.CodeInvalidArgument,
+	ErrResumeExpired:            connect.CodeOutOfRange,
+	ErrUpdatesInterrupted:       connect.CodeUnavailable,
+	ErrVersionConflict:          connect.CodeAborted,
+	ErrDuplicateFlight:          connect.CodeAlreadyExists,
+	ErrIllegalStatusTransition:  connect.CodeFailedPrecondition,
+	ErrMovementOutOfOrder:       connect.CodeFailedPrecondition,
+	ErrAircraftConflict:         connect.CodeFailedPrecondition,
+	ErrOrganizationRequired:     connect.CodePermissionDenied,
+	ErrForbidden:                connect.CodePermissionDenied,
+	ErrInvalidIdentityAssertion: connect.CodeUnauthenticated,
 }
 
 // MapErrorToGrpcCode returns the corresponding connect.Code for the provided error.
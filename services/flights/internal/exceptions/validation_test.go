@@ -13,10 +13,29 @@ func TestMapErrorToGrpcCode(testHelper *testing.T) {
 		expectedConnectCode connect.Code
 	}{
 		{ErrInvalidIATACode, connect.CodeInvalidArgument},
+		{UnknownAirport("ZZZ"), connect.CodeInvalidArgument},
 		{ErrInvalidFlightNumber, connect.CodeInvalidArgument},
 		{ErrInvalidTimes, connect.CodeInvalidArgument},
 		{ErrInvalidFlightNumber, connect.CodeInvalidArgument},
 		{ErrInvalidInput, connect.CodeInvalidArgument},
+		{ErrInvalidDelayCode, connect.CodeInvalidArgument},
+		{ErrInvalidCursor, connect.CodeInvalidArgument},
+		{ErrInvalidPageSize, connect.CodeInvalidArgument},
+		{ErrResumeExpired, connect.CodeOutOfRange},
+		{ErrUpdatesInterrupted, connect.CodeUnavailable},
+		{ErrVersionConflict, connect.CodeAborted},
+		{ErrDuplicateFlight, connect.CodeAlreadyExists},
+		{ErrIllegalStatusTransition, connect.CodeFailedPrecondition},
+		{ErrMovementOutOfOrder, connect.CodeFailedPrecondition},
+		{IllegalStatusTransition("ARRIVED", "SCHEDULED"), connect.CodeFailedPrecondition},
+		{&AircraftConflictError{Kind: AircraftConflictOverlap}, connect.CodeFailedPrecondition},
+		{AircraftInMaintenance("a1"), connect.CodeFailedPrecondition},
+		{AircraftGrounded("a1"), connect.CodeFailedPrecondition},
+		{AircraftNoCapacity("a1"), connect.CodeFailedPrecondition},
+		{AircraftWrongAirline("a1"), connect.CodePermissionDenied},
+		{ErrOrganizationRequired, connect.CodePermissionDenied},
+		{ErrForbidden, connect.CodePermissionDenied},
+		{ErrInvalidIdentityAssertion, connect.CodeUnauthenticated},
 		{error: error(nil), expectedConnectCode: connect.CodeInternal},
 	}
 
@@ -0,0 +1,12 @@
+package exceptions
+
+import "errors"
+
+var (
+	// ErrResumeExpired is returned when the changes after a resume point are no longer
+	// retained, so the caller has to start again from a fresh snapshot.
+	ErrResumeExpired = errors.New("resume sequence is no longer retained")
+	// ErrUpdatesInterrupted ends a watch that could not keep up with its updates or
+	// whose server is shutting down. The caller can resume where it left off.
+	ErrUpdatesInterrupted = errors.New("flight updates were interrupted")
+)
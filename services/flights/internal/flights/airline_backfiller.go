@@ -0,0 +1,46 @@
+package flights
+
+import (
+	"context"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+)
+
+// AirlineBackfiller runs BackfillAirline when it starts and then periodically, so the
+// flights of an organization that only gets a named flight later are backfilled too.
+// A flight is only backfilled once, so it is safe to run in each replica.
+type AirlineBackfiller struct {
+	service  *Service
+	interval time.Duration
+}
+
+// NewAirlineBackfiller returns an AirlineBackfiller that backfills the flights of
+// service every interval.
+func NewAirlineBackfiller(service *Service, interval time.Duration) *AirlineBackfiller {
+	return &AirlineBackfiller{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Run backfills airlines until ctx is cancelled.
+func (b *AirlineBackfiller) Run(ctx context.Context) {
+	logger.InfoContext(ctx, "Starting airline backfiller", "interval", b.interval)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.service.BackfillAirline(ctx); err != nil && ctx.Err() == nil {
+			logger.ErrorContext(ctx, "Failed to backfill flight airline", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Airline backfiller stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package flights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAirlineBackfillerRunsUntilCancelled(t *testing.T) {
+	runs := make(chan struct{}, 1)
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.BackfillAirlineFn = func(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error) {
+		select {
+		case runs <- struct{}{}:
+		default:
+		}
+		return nil, nil
+	}
+
+	backfiller := NewAirlineBackfiller(NewFlightsService(repo, cache, aircraft), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		backfiller.Run(ctx)
+	}()
+
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("backfiller did not run on start")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("backfiller did not stop")
+	}
+}
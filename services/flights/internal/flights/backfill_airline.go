@@ -0,0 +1,34 @@
+package flights
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+// BackfillAirline replaces the placeholder airline of flights stored before the airline
+// was persisted with the airline their organization's other flights carry. The change
+// is made by the system rather than a user, so its history has no actor, and each
+// flight gets a FlightUpdated event like any other edit.
+func (service *Service) BackfillAirline(ctx context.Context) error {
+	backfilled, err := service.Repo.BackfillAirline(ctx, uuid.Nil, traceContext(ctx))
+	if err != nil {
+		return err
+	}
+	if len(backfilled) == 0 {
+		return nil
+	}
+
+	for orgID, ids := range backfilled {
+		logger.InfoContext(ctx, "Backfilled flight airline", "organization_id", orgID, "flights", len(ids))
+
+		if service.Cache != nil {
+			if err := service.Cache.DeleteFlights(ctx, orgID, ids); err != nil {
+				logger.WarnContext(ctx, "Failed to evict backfilled flights from cache", "organization_id", orgID, "err", err)
+			}
+		}
+	}
+	service.notifyUpdates()
+	return nil
+}
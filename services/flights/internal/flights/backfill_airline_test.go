@@ -0,0 +1,80 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillAirline(t *testing.T) {
+	t.Run("evicts backfilled flights and notifies updates", func(t *testing.T) {
+		otherOrgID := uuid.New()
+		backfilled := map[uuid.UUID][]uuid.UUID{testOrgID: {uuid.New(), uuid.New()}, otherOrgID: {uuid.New()}}
+		repo := &FakeRepo{
+			BackfillAirlineFn: func(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error) {
+				assert.Equal(t, uuid.Nil, actorID)
+				assert.NotNil(t, traceContext)
+				return backfilled, nil
+			},
+		}
+		evicted := make(map[uuid.UUID][]uuid.UUID)
+		cache := FakeFlightsCache{
+			DeleteFlightsFn: func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+				evicted[orgID] = ids
+				return nil
+			},
+		}
+		service := NewFlightsService(repo, cache, &FakeAircraftClient{})
+		updates := &notifiedUpdates{}
+		service.Updates = updates
+
+		require.NoError(t, service.BackfillAirline(context.Background()))
+
+		assert.Equal(t, backfilled, evicted)
+		assert.Equal(t, 1, updates.notified)
+	})
+
+	t.Run("nothing to backfill", func(t *testing.T) {
+		service := NewFlightsService(&FakeRepo{}, FakeFlightsCache{
+			DeleteFlightsFn: func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+				t.Fatal("nothing should be evicted")
+				return nil
+			},
+		}, &FakeAircraftClient{})
+		updates := &notifiedUpdates{}
+		service.Updates = updates
+
+		require.NoError(t, service.BackfillAirline(context.Background()))
+
+		assert.Zero(t, updates.notified)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repoErr := errors.New("db down")
+		repo := &FakeRepo{
+			BackfillAirlineFn: func(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error) {
+				return nil, repoErr
+			},
+		}
+		service := NewFlightsService(repo, FakeFlightsCache{}, &FakeAircraftClient{})
+
+		assert.ErrorIs(t, service.BackfillAirline(context.Background()), repoErr)
+	})
+}
+
+func TestReadsDoNotBackfillAirline(t *testing.T) {
+	repo := &FakeRepo{
+		BackfillAirlineFn: func(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error) {
+			t.Fatal("a read should not backfill")
+			return nil, nil
+		},
+	}
+	service := NewFlightsService(repo, FakeFlightsCache{}, &FakeAircraftClient{})
+
+	_, err := service.GetFlightByID(orgContext(testOrgID), uuid.New(), false)
+	require.NoError(t, err)
+}
@@ -0,0 +1,226 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/schedule_import"
+	"github.com/google/uuid"
+)
+
+// bulkCreateBatchSize is the number of flights stored per transaction. A batch that
+// fails is reported row by row without affecting the batches around it.
+const bulkCreateBatchSize = 500
+
+// pendingFlight is a validated import row waiting to be stored.
+type pendingFlight struct {
+	flight *models.Flight
+	result *models.BulkCreateResult
+}
+
+// BulkCreateFlights imports a schedule file, creating a flight for every valid row. Each
+// row is validated like CreateFlight and reported individually; rows that fail do not
+// stop the rest. With options.DryRun nothing is stored. An error is only returned when
+// the file cannot be read or the aircraft service is unavailable, in which case no
+// flights have been stored.
+func (service *Service) BulkCreateFlights(
+	ctx context.Context,
+	file io.Reader,
+	options models.BulkCreateOptions,
+) (*models.BulkCreateReport, error) {
+	rows, err := schedule_import.Parse(options.Format, file)
+	if err != nil {
+		return nil, err
+	}
+
+	userContext := middleware.GetRequestUserContext(ctx)
+
+	report := &models.BulkCreateReport{
+		DryRun:  options.DryRun,
+		Results: make([]*models.BulkCreateResult, 0, len(rows)),
+	}
+	aircraftChecks := make(map[uuid.UUID]error)
+	seen := make(map[string]bool, len(rows))
+	pending := make([]pendingFlight, 0, len(rows))
+
+	for _, row := range rows {
+		result := &models.BulkCreateResult{Line: int32(row.Line), Number: row.Number}
+		if !row.DepartureTime.IsZero() {
+			departure := row.DepartureTime
+			result.DepartureTime = &departure
+		}
+		report.Results = append(report.Results, result)
+
+		if row.Err != nil {
+			failBulkResult(result, row.Err)
+			continue
+		}
+
+		number, origin, destination, err := validateFlightDetails(row.Number, row.Origin, row.Destination, row.DepartureTime, row.ArrivalTime)
+		if err != nil {
+			failBulkResult(result, err)
+			continue
+		}
+		result.Number = number
+
+		aircraftID := row.AircraftID
+		if aircraftID == uuid.Nil && options.AircraftID != nil {
+			aircraftID = *options.AircraftID
+		}
+		if aircraftID == uuid.Nil {
+			failBulkResult(result, fmt.Errorf("%w: aircraft ID is required", exceptions.ErrInvalidInput))
+			continue
+		}
+
+		checkErr, checked := aircraftChecks[aircraftID]
+		if !checked {
+			aircraft, lookupErr := service.AircraftClient.GetAircraft(ctx, aircraftID)
+			switch {
+			case errors.Is(lookupErr, exceptions.ErrAircraftNotFound):
+				checkErr = lookupErr
+			case lookupErr != nil:
+				logger.ErrorContext(ctx, "Failed to validate aircraft for import", "aircraft_id", aircraftID, "err", lookupErr)
+				return nil, lookupErr
+			default:
+				checkErr = checkAircraftAssignable(ctx, aircraft)
+			}
+			aircraftChecks[aircraftID] = checkErr
+		}
+		if checkErr != nil {
+			failBulkResult(result, checkErr)
+			continue
+		}
+
+		key := number + "@" + row.DepartureTime.UTC().Format(time.RFC3339)
+		if seen[key] {
+			failBulkResult(result, fmt.Errorf("%w: flight %s at %s appears more than once in the file",
+				exceptions.ErrDuplicateFlight, number, row.DepartureTime.Format(time.RFC3339)))
+			continue
+		}
+		seen[key] = true
+
+		pending = append(pending, pendingFlight{
+			flight: &models.Flight{
+				ID:             uuid.New(),
+				Number:         number,
+				Origin:         origin,
+				Destination:    destination,
+				DepartureTime:  row.DepartureTime,
+				ArrivalTime:    row.ArrivalTime,
+				Status:         models.FlightStatusScheduled,
+				AircraftID:     aircraftID,
+				CreatedBy:      userContext.UserID,
+				LastUpdatedBy:  userContext.UserID,
+				OrganizationID: userContext.OrgID,
+				Airline:        userContext.OrgName,
+			},
+			result: result,
+		})
+	}
+
+	for start := 0; start < len(pending); start += bulkCreateBatchSize {
+		batch := pending[start:min(start+bulkCreateBatchSize, len(pending))]
+		service.storeBulkBatch(ctx, batch, options.DryRun)
+	}
+
+	for _, result := range report.Results {
+		if result.Error != nil {
+			report.FailedCount++
+		} else {
+			report.CreatedCount++
+		}
+	}
+
+	logger.InfoContext(ctx, "Flight schedule imported",
+		"format", options.Format, "dry_run", options.DryRun,
+		"created", report.CreatedCount, "failed", report.FailedCount)
+
+	return report, nil
+}
+
+// storeBulkBatch rejects the flights in batch that already exist and stores the rest,
+// recording the outcome on each result. On a dry run the duplicate check still runs but
+// nothing is stored.
+func (service *Service) storeBulkBatch(ctx context.Context, batch []pendingFlight, dryRun bool) {
+	candidates := make([]*models.Flight, len(batch))
+	for i, p := range batch {
+		candidates[i] = p.flight
+	}
+
+	existing, err := service.Repo.FindFlightInstances(ctx, candidates)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to check imported flights for duplicates", "count", len(batch), "err", err)
+		failBulkBatch(batch, err)
+		return
+	}
+
+	taken := make(map[string]bool, len(existing))
+	for _, f := range existing {
+		taken[f.Number+"@"+f.DepartureTime.UTC().Format(time.RFC3339)] = true
+	}
+
+	accepted := batch[:0:0]
+	for _, p := range batch {
+		if taken[p.flight.Number+"@"+p.flight.DepartureTime.UTC().Format(time.RFC3339)] {
+			failBulkResult(p.result, fmt.Errorf("%w: flight with number %s at %s already exists",
+				exceptions.ErrDuplicateFlight, p.flight.Number, p.flight.DepartureTime.Format(time.RFC3339)))
+			continue
+		}
+		accepted = append(accepted, p)
+	}
+
+	if dryRun || len(accepted) == 0 {
+		return
+	}
+
+	flights := make([]*models.Flight, 0, len(accepted))
+	history := make([]*models.FlightHistoryEntry, 0, len(accepted))
+	events := make([]*models.OutboxEvent, 0, len(accepted))
+	for _, p := range accepted {
+		event, err := newOutboxEvent(ctx, models.EventTypeFlightCreated, p.flight.ID, p.flight)
+		if err != nil {
+			failBulkBatch(accepted, err)
+			return
+		}
+		entry, err := newHistoryEntry(ctx, models.FlightHistoryOperationCreated, nil)
+		if err != nil {
+			failBulkBatch(accepted, err)
+			return
+		}
+
+		flights = append(flights, p.flight)
+		history = append(history, entry)
+		events = append(events, event)
+	}
+
+	if err := service.Repo.CreateFlights(ctx, flights, history, events); err != nil {
+		logger.ErrorContext(ctx, "Failed to store imported flights", "count", len(flights), "err", err)
+		failBulkBatch(accepted, err)
+		return
+	}
+
+	service.notifyUpdates()
+
+	for _, p := range accepted {
+		id := p.flight.ID
+		p.result.FlightID = &id
+	}
+}
+
+func failBulkResult(result *models.BulkCreateResult, err error) {
+	message := err.Error()
+	result.Error = &message
+}
+
+func failBulkBatch(batch []pendingFlight, err error) {
+	for _, p := range batch {
+		failBulkResult(p.result, err)
+	}
+}
@@ -0,0 +1,234 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bulkCSVHeader = "number,origin,destination,departure_time,arrival_time,aircraft_id\n"
+
+func bulkContext() (context.Context, uuid.UUID) {
+	userID := uuid.New()
+	ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{
+		UserID:  userID,
+		OrgID:   testOrgID,
+		OrgName: "British Airways",
+	})
+	return ctx, userID
+}
+
+func TestBulkCreateFlights(t *testing.T) {
+	aircraftID := uuid.New()
+	missingAircraftID := uuid.New()
+
+	t.Run("Reports Each Row", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		ctx, userID := bulkContext()
+		existingDeparture := time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC)
+
+		var stored []*models.Flight
+		var storedHistory []*models.FlightHistoryEntry
+		var storedEvents []*models.OutboxEvent
+		repo.FindInstancesFn = func(ctx context.Context, candidates []*models.Flight) ([]*models.Flight, error) {
+			return []*models.Flight{{Number: "BA127", DepartureTime: existingDeparture}}, nil
+		}
+		repo.CreateFlightsFn = func(ctx context.Context, flights []*models.Flight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent) error {
+			stored, storedHistory, storedEvents = flights, history, events
+			return nil
+		}
+		aircraft.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+			if id == missingAircraftID {
+				return nil, exceptions.AircraftNotFound(id)
+			}
+			return testAircraft(id), nil
+		}
+
+		input := bulkCSVHeader +
+			"ba123,lhr,jfk,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z," + aircraftID.String() + "\n" +
+			"BA1,LHR,LHR,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z," + aircraftID.String() + "\n" +
+			"BA124,LHR,JFK,2025-01-07T10:00:00Z,2025-01-07T18:00:00Z,\n" +
+			"BA125,LHR,JFK,2025-01-08T10:00:00Z,2025-01-08T18:00:00Z," + missingAircraftID.String() + "\n" +
+			"BA123,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z," + aircraftID.String() + "\n" +
+			"BA127,LHR,JFK,2025-01-09T10:00:00Z,2025-01-09T18:00:00Z," + aircraftID.String() + "\n"
+
+		service := NewFlightsService(repo, cache, aircraft)
+		report, err := service.BulkCreateFlights(ctx, strings.NewReader(input), models.BulkCreateOptions{Format: models.ScheduleFormatCSV})
+
+		require.NoError(t, err)
+		assert.False(t, report.DryRun)
+		assert.Equal(t, int32(1), report.CreatedCount)
+		assert.Equal(t, int32(5), report.FailedCount)
+		require.Len(t, report.Results, 6)
+
+		created := report.Results[0]
+		assert.Equal(t, int32(2), created.Line)
+		assert.Equal(t, "BA123", created.Number)
+		assert.Nil(t, created.Error)
+		require.NotNil(t, created.FlightID)
+
+		assert.Contains(t, *report.Results[1].Error, exceptions.ErrSameOriginAndDestination.Error())
+		assert.Contains(t, *report.Results[2].Error, "aircraft ID is required")
+		assert.Contains(t, *report.Results[3].Error, exceptions.ErrAircraftNotFound.Error())
+		assert.Contains(t, *report.Results[4].Error, "more than once")
+		assert.Contains(t, *report.Results[5].Error, "already exists")
+
+		require.Len(t, stored, 1)
+		assert.Equal(t, *created.FlightID, stored[0].ID)
+		assert.Equal(t, "LHR", stored[0].Origin)
+		assert.Equal(t, aircraftID, stored[0].AircraftID)
+		assert.Equal(t, testOrgID, stored[0].OrganizationID)
+		assert.Equal(t, "British Airways", stored[0].Airline)
+		assert.Equal(t, userID, stored[0].CreatedBy)
+		require.Len(t, storedHistory, 1)
+		assert.Equal(t, models.FlightHistoryOperationCreated, storedHistory[0].Operation)
+		assert.Equal(t, userID, storedHistory[0].ActorID)
+		require.Len(t, storedEvents, 1)
+		assert.Equal(t, models.EventTypeFlightCreated, storedEvents[0].EventType)
+	})
+
+	t.Run("Dry Run Stores Nothing", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		ctx, _ := bulkContext()
+		repo.CreateFlightsFn = func(ctx context.Context, flights []*models.Flight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent) error {
+			t.Fatal("CreateFlights should not be called on a dry run")
+			return nil
+		}
+
+		input := bulkCSVHeader +
+			"BA123,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z,\n" +
+			"BA124,LHR,JFK,2025-01-07T10:00:00Z,2025-01-07T18:00:00Z,\n"
+
+		service := NewFlightsService(repo, cache, aircraft)
+		report, err := service.BulkCreateFlights(ctx, strings.NewReader(input), models.BulkCreateOptions{
+			Format:     models.ScheduleFormatCSV,
+			AircraftID: &aircraftID,
+			DryRun:     true,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, int32(2), report.CreatedCount)
+		assert.Zero(t, report.FailedCount)
+		for _, result := range report.Results {
+			assert.Nil(t, result.FlightID)
+			assert.Nil(t, result.Error)
+		}
+	})
+
+	t.Run("Rejects Rows For Unassignable Aircraft", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		ctx, _ := bulkContext()
+		groundedAircraftID := uuid.New()
+		aircraft.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+			a := testAircraft(id)
+			if id == groundedAircraftID {
+				a.Status = models.AircraftStatusGrounded
+			}
+			return a, nil
+		}
+
+		input := bulkCSVHeader +
+			"BA123,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z," + groundedAircraftID.String() + "\n" +
+			"BA124,LHR,JFK,2025-01-07T10:00:00Z,2025-01-07T18:00:00Z," + aircraftID.String() + "\n"
+
+		service := NewFlightsService(repo, cache, aircraft)
+		report, err := service.BulkCreateFlights(ctx, strings.NewReader(input), models.BulkCreateOptions{Format: models.ScheduleFormatCSV})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), report.CreatedCount)
+		assert.Equal(t, int32(1), report.FailedCount)
+		require.NotNil(t, report.Results[0].Error)
+		assert.Contains(t, *report.Results[0].Error, exceptions.ErrAircraftGrounded.Error())
+		assert.Nil(t, report.Results[1].Error)
+	})
+
+	t.Run("Checks Each Aircraft Once", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		ctx, _ := bulkContext()
+		calls := 0
+		aircraft.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+			calls++
+			return testAircraft(id), nil
+		}
+
+		input := bulkCSVHeader +
+			"BA123,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z,\n" +
+			"BA124,LHR,JFK,2025-01-07T10:00:00Z,2025-01-07T18:00:00Z,\n"
+
+		service := NewFlightsService(repo, cache, aircraft)
+		_, err := service.BulkCreateFlights(ctx, strings.NewReader(input), models.BulkCreateOptions{
+			Format:     models.ScheduleFormatCSV,
+			AircraftID: &aircraftID,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Aircraft Service Down", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		ctx, _ := bulkContext()
+		aircraft.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+			return nil, exceptions.ErrDownstreamClientDown
+		}
+
+		input := bulkCSVHeader + "BA123,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z,\n"
+
+		service := NewFlightsService(repo, cache, aircraft)
+		report, err := service.BulkCreateFlights(ctx, strings.NewReader(input), models.BulkCreateOptions{
+			Format:     models.ScheduleFormatCSV,
+			AircraftID: &aircraftID,
+		})
+
+		assert.ErrorIs(t, err, exceptions.ErrDownstreamClientDown)
+		assert.Nil(t, report)
+	})
+
+	t.Run("Store Error Fails The Batch", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		ctx, _ := bulkContext()
+		repo.CreateFlightsFn = func(ctx context.Context, flights []*models.Flight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent) error {
+			return errors.New("db failure")
+		}
+
+		input := bulkCSVHeader +
+			"BA123,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z,\n" +
+			"BA124,LHR,JFK,2025-01-07T10:00:00Z,2025-01-07T18:00:00Z,\n"
+
+		service := NewFlightsService(repo, cache, aircraft)
+		report, err := service.BulkCreateFlights(ctx, strings.NewReader(input), models.BulkCreateOptions{
+			Format:     models.ScheduleFormatCSV,
+			AircraftID: &aircraftID,
+		})
+
+		require.NoError(t, err)
+		assert.Zero(t, report.CreatedCount)
+		assert.Equal(t, int32(2), report.FailedCount)
+		for _, result := range report.Results {
+			assert.Equal(t, "db failure", *result.Error)
+			assert.Nil(t, result.FlightID)
+		}
+	})
+
+	t.Run("Unreadable File", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		ctx, _ := bulkContext()
+
+		service := NewFlightsService(repo, cache, aircraft)
+		report, err := service.BulkCreateFlights(ctx, strings.NewReader("number,origin\n"), models.BulkCreateOptions{Format: models.ScheduleFormatCSV})
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidInput)
+		assert.Nil(t, report)
+	})
+}
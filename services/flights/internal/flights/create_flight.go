@@ -2,15 +2,11 @@ package flights
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
-	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
-	"github.com/edinstance/distributed-aviation-system/services/flights/internal/validation/flight_number"
-	"github.com/edinstance/distributed-aviation-system/services/flights/internal/validation/iata_codes"
 	"github.com/google/uuid"
 )
 
@@ -24,36 +20,15 @@ func (service *Service) CreateFlight(
 	aircraftId uuid.UUID,
 ) (*models.Flight, error) {
 
-	if !arrival.After(departure) {
-		return nil, fmt.Errorf("%w: departure=%v, arrival=%v",
-			exceptions.ErrInvalidTimes, departure, arrival)
-	}
-
-	normalizedNumber, err := flight_number.ValidateAndNormalizeFlightNumber(number)
-	if err != nil {
-		return nil, err
-	}
-
-	normalizedOrigin, err := iata_codes.ValidateAndNormalizeIATACode(origin)
+	normalizedNumber, normalizedOrigin, normalizedDestination, err := validateFlightDetails(number, origin, destination, departure, arrival)
 	if err != nil {
 		return nil, err
 	}
 
-	normalizedDestination, err := iata_codes.ValidateAndNormalizeIATACode(destination)
-	if err != nil {
+	if err := service.validateAircraft(ctx, aircraftId); err != nil {
 		return nil, err
 	}
 
-	if normalizedOrigin == normalizedDestination {
-		return nil, exceptions.ErrSameOriginAndDestination
-	}
-
-	validationErr := service.AircraftClient.ValidateAircraftExists(ctx, aircraftId)
-	if validationErr != nil {
-		logger.ErrorContext(ctx, "Aircraft does not exist", "aircraft_id", aircraftId, "err", validationErr)
-		return nil, validationErr
-	}
-
 	userContext := middleware.GetRequestUserContext(ctx)
 
 	flight := &models.Flight{
@@ -71,12 +46,24 @@ func (service *Service) CreateFlight(
 		Airline:        userContext.OrgName,
 	}
 
-	if err := service.Repo.CreateFlight(ctx, flight); err != nil {
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightCreated, flight.ID, flight)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationCreated, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.Repo.CreateFlight(ctx, flight, history, event); err != nil {
 		logger.ErrorContext(ctx, "Failed to create flight in database", "flight_id", flight.ID, "err", err)
 		return nil, err
 	}
 
-	// Run post-create tasks asynchronously (cache + Kafka)
+	service.notifyUpdates()
+
+	// The FlightCreated event is published by the outbox relay; only the cache is warmed here.
 	go func(f *models.Flight) {
 		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -85,11 +72,6 @@ func (service *Service) CreateFlight(
 			logger.WarnContext(bgCtx, "Failed to cache flight",
 				"flight_id", f.ID, "err", err)
 		}
-
-		if err := service.KafkaPublisher.PublishFlightCreated(bgCtx, f); err != nil {
-			logger.WarnContext(bgCtx, "Failed to publish flight created event",
-				"flight_id", f.ID, "err", err)
-		}
 	}(flight)
 
 	logger.InfoContext(ctx, "Flight created", "flight_id", flight.ID, "number", flight.Number, "origin", flight.Origin, "destination", flight.Destination, "departure_time", flight.DepartureTime, "arrival_time", flight.ArrivalTime, "aircraft_id", flight.AircraftID)
@@ -2,6 +2,7 @@ package flights
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -12,8 +13,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func defaultTestDeps() (*FakeRepo, *FakeFlightsCache, *FakeAircraftClient, *FakeKafkaPublisher) {
-	return &FakeRepo{}, &FakeFlightsCache{}, &FakeAircraftClient{}, &FakeKafkaPublisher{}
+func defaultTestDeps() (*FakeRepo, *FakeFlightsCache, *FakeAircraftClient) {
+	return &FakeRepo{}, &FakeFlightsCache{}, &FakeAircraftClient{}
 }
 
 func TestCreateFlight(t *testing.T) {
@@ -31,7 +32,7 @@ func TestCreateFlight(t *testing.T) {
 		dest        string
 		departure   time.Time
 		arrival     time.Time
-		setup       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient, k *FakeKafkaPublisher)
+		setup       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient)
 		expectError error
 	}{
 		{
@@ -41,7 +42,7 @@ func TestCreateFlight(t *testing.T) {
 			dest:      "LHR",
 			departure: dep,
 			arrival:   arr,
-			setup:     func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient, k *FakeKafkaPublisher) {},
+			setup:     func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient) {},
 		},
 		{
 			name:        "arrival before departure",
@@ -50,7 +51,7 @@ func TestCreateFlight(t *testing.T) {
 			dest:        "LHR",
 			departure:   arr,
 			arrival:     dep,
-			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient, k *FakeKafkaPublisher) {},
+			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient) {},
 			expectError: exceptions.ErrInvalidTimes,
 		},
 		{
@@ -60,7 +61,7 @@ func TestCreateFlight(t *testing.T) {
 			dest:        "JFK",
 			departure:   dep,
 			arrival:     arr,
-			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient, k *FakeKafkaPublisher) {},
+			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient) {},
 			expectError: exceptions.ErrSameOriginAndDestination,
 		},
 		{
@@ -70,8 +71,8 @@ func TestCreateFlight(t *testing.T) {
 			dest:      "LHR",
 			departure: dep,
 			arrival:   arr,
-			setup: func(r *FakeRepo, _ *FakeFlightsCache, _ *FakeAircraftClient, _ *FakeKafkaPublisher) {
-				r.CreateFlightFn = func(ctx context.Context, f *models.Flight) error {
+			setup: func(r *FakeRepo, _ *FakeFlightsCache, _ *FakeAircraftClient) {
+				r.CreateFlightFn = func(ctx context.Context, f *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
 					return repoErr
 				}
 			},
@@ -84,7 +85,7 @@ func TestCreateFlight(t *testing.T) {
 			dest:        "LHR",
 			departure:   dep,
 			arrival:     arr,
-			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient, k *FakeKafkaPublisher) {},
+			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient) {},
 			expectError: exceptions.ErrInvalidFlightNumber,
 		},
 		{
@@ -94,7 +95,7 @@ func TestCreateFlight(t *testing.T) {
 			dest:        "LHR",
 			departure:   dep,
 			arrival:     arr,
-			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient, k *FakeKafkaPublisher) {},
+			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient) {},
 			expectError: exceptions.ErrInvalidIATACode,
 		},
 		{
@@ -104,9 +105,19 @@ func TestCreateFlight(t *testing.T) {
 			dest:        "LHR1232",
 			departure:   dep,
 			arrival:     arr,
-			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient, k *FakeKafkaPublisher) {},
+			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient) {},
 			expectError: exceptions.ErrInvalidIATACode,
 		},
+		{
+			name:        "unknown destination airport",
+			number:      "BA121",
+			origin:      "LHR",
+			dest:        "ZZZ",
+			departure:   dep,
+			arrival:     arr,
+			setup:       func(r *FakeRepo, c *FakeFlightsCache, a *FakeAircraftClient) {},
+			expectError: exceptions.ErrUnknownAirport,
+		},
 		{
 			name:      "aircraft validation error",
 			number:    "BA121",
@@ -114,9 +125,9 @@ func TestCreateFlight(t *testing.T) {
 			dest:      "LGW",
 			departure: dep,
 			arrival:   arr,
-			setup: func(_ *FakeRepo, _ *FakeFlightsCache, r *FakeAircraftClient, _ *FakeKafkaPublisher) {
-				r.ValidateAircraftExistsFn = func(ctx context.Context, id uuid.UUID) error {
-					return aircraftErr
+			setup: func(_ *FakeRepo, _ *FakeFlightsCache, r *FakeAircraftClient) {
+				r.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+					return nil, aircraftErr
 				}
 			},
 			expectError: aircraftErr,
@@ -125,10 +136,10 @@ func TestCreateFlight(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			repo, cache, aircraft, kafka := defaultTestDeps()
-			tt.setup(repo, cache, aircraft, kafka)
+			repo, cache, aircraft := defaultTestDeps()
+			tt.setup(repo, cache, aircraft)
 
-			svc := NewFlightsService(repo, cache, aircraft, kafka)
+			svc := NewFlightsService(repo, cache, aircraft)
 
 			flight, err := svc.CreateFlight(
 				context.Background(),
@@ -156,3 +167,30 @@ func TestCreateFlight(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateFlightWritesOutboxEvent(t *testing.T) {
+	repo, cache, aircraft := defaultTestDeps()
+
+	var written []*models.OutboxEvent
+	repo.CreateFlightFn = func(ctx context.Context, f *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+		written = events
+		return nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+	dep := time.Now().Add(time.Hour)
+
+	flight, err := svc.CreateFlight(context.Background(), "AA123", "JFK", "LHR", dep, dep.Add(7*time.Hour), uuid.New())
+
+	assert.NoError(t, err)
+	if assert.Len(t, written, 1) {
+		assert.Equal(t, models.EventTypeFlightCreated, written[0].EventType)
+		assert.Equal(t, flight.ID, written[0].AggregateID)
+
+		var payload models.Flight
+		assert.NoError(t, json.Unmarshal(written[0].Payload, &payload))
+		assert.Equal(t, flight.ID, payload.ID)
+		assert.Equal(t, "AA123", payload.Number)
+		assert.Equal(t, "LHR", payload.Destination)
+	}
+}
@@ -0,0 +1,61 @@
+package flights
+
+import (
+	"context"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// CreateSchedule stores a recurring schedule for the caller's organization and
+// materializes its flights up to the schedule horizon. Materialization failures are only
+// logged, since the schedule materializer retries them on its next run.
+func (service *Service) CreateSchedule(ctx context.Context, input models.ScheduleInput) (*models.Schedule, error) {
+	userContext := middleware.GetRequestUserContext(ctx)
+	if userContext.OrgID == uuid.Nil {
+		return nil, exceptions.ErrOrganizationRequired
+	}
+
+	schedule := &models.Schedule{
+		ID:                 uuid.New(),
+		Number:             input.Number,
+		Origin:             input.Origin,
+		Destination:        input.Destination,
+		DepartureLocalTime: input.DepartureLocalTime,
+		TimeZone:           input.TimeZone,
+		DurationMinutes:    input.DurationMinutes,
+		DaysOfWeek:         input.DaysOfWeek,
+		StartDate:          input.StartDate,
+		EndDate:            input.EndDate,
+		AircraftID:         input.AircraftID,
+		OrganizationID:     userContext.OrgID,
+		Airline:            userContext.OrgName,
+		CreatedBy:          userContext.UserID,
+		LastUpdatedBy:      userContext.UserID,
+	}
+
+	if _, err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	if err := service.validateAircraft(ctx, schedule.AircraftID); err != nil {
+		return nil, err
+	}
+
+	if err := service.Repo.CreateSchedule(ctx, schedule); err != nil {
+		logger.ErrorContext(ctx, "Failed to create schedule in database", "schedule_id", schedule.ID, "err", err)
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Schedule created", "schedule_id", schedule.ID, "number", schedule.Number, "origin", schedule.Origin, "destination", schedule.Destination, "departure_local_time", schedule.DepartureLocalTime, "time_zone", schedule.TimeZone, "start_date", schedule.StartDate, "end_date", schedule.EndDate)
+
+	if err := service.materializeSchedule(ctx, schedule, time.Now()); err != nil {
+		logger.WarnContext(ctx, "Failed to materialize new schedule", "schedule_id", schedule.ID, "err", err)
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,174 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var weekdaysMonToFri = models.WeekdaysOf(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+
+func TestCreateSchedule(t *testing.T) {
+	today := calendarDate(time.Now())
+	userID := uuid.New()
+	aircraftID := uuid.New()
+	repoErr := errors.New("db failure")
+
+	validInput := func() models.ScheduleInput {
+		return models.ScheduleInput{
+			Number:             "ba117",
+			Origin:             "lhr",
+			Destination:        "jfk",
+			DepartureLocalTime: "8:25",
+			TimeZone:           "Europe/London",
+			DurationMinutes:    480,
+			DaysOfWeek:         weekdaysMonToFri,
+			StartDate:          today.AddDate(0, 0, 1),
+			EndDate:            today.AddDate(0, 0, 28),
+			AircraftID:         aircraftID,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		input       func(input *models.ScheduleInput)
+		setup       func(r *FakeRepo, a *FakeAircraftClient)
+		expectError error
+	}{
+		{name: "creates schedule"},
+		{
+			name:        "unknown time zone",
+			input:       func(input *models.ScheduleInput) { input.TimeZone = "Mars/Olympus_Mons" },
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:        "invalid departure time",
+			input:       func(input *models.ScheduleInput) { input.DepartureLocalTime = "25:00" },
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:        "no operating days",
+			input:       func(input *models.ScheduleInput) { input.DaysOfWeek = 0 },
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:        "zero duration",
+			input:       func(input *models.ScheduleInput) { input.DurationMinutes = 0 },
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:        "ends before it starts",
+			input:       func(input *models.ScheduleInput) { input.EndDate = input.StartDate.AddDate(0, 0, -1) },
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:        "period longer than a year",
+			input:       func(input *models.ScheduleInput) { input.EndDate = input.StartDate.AddDate(1, 1, 0) },
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:        "invalid flight number",
+			input:       func(input *models.ScheduleInput) { input.Number = "117" },
+			expectError: exceptions.ErrInvalidFlightNumber,
+		},
+		{
+			name:        "destination matches origin",
+			input:       func(input *models.ScheduleInput) { input.Destination = "LHR" },
+			expectError: exceptions.ErrSameOriginAndDestination,
+		},
+		{
+			name: "aircraft not found",
+			setup: func(_ *FakeRepo, a *FakeAircraftClient) {
+				a.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+					return nil, exceptions.ErrAircraftNotFound
+				}
+			},
+			expectError: exceptions.ErrAircraftNotFound,
+		},
+		{
+			name: "repo error",
+			setup: func(r *FakeRepo, _ *FakeAircraftClient) {
+				r.CreateScheduleFn = func(ctx context.Context, s *models.Schedule) error {
+					return repoErr
+				}
+			},
+			expectError: repoErr,
+		},
+		{
+			name: "materialization failure does not fail the create",
+			setup: func(r *FakeRepo, _ *FakeAircraftClient) {
+				r.MaterializeFn = func(ctx context.Context, s *models.Schedule, instances []*models.ScheduledFlight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent, through time.Time) (int, error) {
+					return 0, repoErr
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+			var materialized []*models.ScheduledFlight
+			repo.MaterializeFn = func(ctx context.Context, s *models.Schedule, instances []*models.ScheduledFlight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent, through time.Time) (int, error) {
+				materialized = instances
+				return len(instances), nil
+			}
+			if tt.setup != nil {
+				tt.setup(repo, aircraft)
+			}
+
+			input := validInput()
+			if tt.input != nil {
+				tt.input(&input)
+			}
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: userID, OrgID: testOrgID, OrgName: "British Airways"})
+
+			schedule, err := svc.CreateSchedule(ctx, input)
+
+			if tt.expectError != nil {
+				assert.Nil(t, schedule)
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "BA117", schedule.Number)
+			assert.Equal(t, "LHR", schedule.Origin)
+			assert.Equal(t, "JFK", schedule.Destination)
+			assert.Equal(t, "08:25", schedule.DepartureLocalTime)
+			assert.Equal(t, testOrgID, schedule.OrganizationID)
+			assert.Equal(t, "British Airways", schedule.Airline)
+			assert.Equal(t, userID, schedule.CreatedBy)
+
+			if tt.name == "creates schedule" {
+				require.NotEmpty(t, materialized)
+				for _, instance := range materialized {
+					assert.Equal(t, schedule.ID, instance.ScheduleID)
+					assert.True(t, weekdaysMonToFri.Has(instance.ScheduleDate.Weekday()))
+					assert.Equal(t, "BA117", instance.Flight.Number)
+					assert.Equal(t, userID, instance.Flight.CreatedBy)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateScheduleRequiresOrganization(t *testing.T) {
+	repo, cache, aircraft := defaultTestDeps()
+	svc := NewFlightsService(repo, cache, aircraft)
+
+	schedule, err := svc.CreateSchedule(context.Background(), models.ScheduleInput{})
+
+	assert.Nil(t, schedule)
+	assert.ErrorIs(t, err, exceptions.ErrOrganizationRequired)
+}
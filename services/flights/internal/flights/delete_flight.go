@@ -0,0 +1,61 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// DeleteFlight soft deletes a flight of the caller's organization, records a
+// FlightDeleted event and evicts the flight from the cache. The deleted flight is
+// returned with its DeletedAt set.
+func (service *Service) DeleteFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := service.Repo.GetFlightByID(ctx, id, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%w: flight with id=%s", exceptions.ErrNotFound, id)
+	}
+
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightDeleted, current.ID, current)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationDeleted, current)
+	if err != nil {
+		return nil, err
+	}
+
+	flight := *current
+	deletedBy := middleware.GetRequestUserContext(ctx).UserID
+	if err := service.Repo.DeleteFlight(ctx, &flight, deletedBy, history, event); err != nil {
+		logger.ErrorContext(ctx, "Failed to delete flight in database", "flight_id", id, "err", err)
+		return nil, err
+	}
+
+	// Evicted before returning so a read that follows the delete cannot be served the
+	// live copy from the cache.
+	if service.Cache != nil {
+		if err := service.Cache.DeleteFlights(ctx, flight.OrganizationID, []uuid.UUID{flight.ID}); err != nil {
+			logger.WarnContext(ctx, "Failed to evict deleted flight from cache", "flight_id", id, "err", err)
+		}
+	}
+
+	service.notifyUpdates()
+
+	logger.InfoContext(ctx, "Flight deleted", "flight_id", id, "number", flight.Number, "deleted_by", deletedBy)
+
+	return &flight, nil
+}
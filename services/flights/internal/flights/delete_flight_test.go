@@ -0,0 +1,88 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteFlight(t *testing.T) {
+	flightID := uuid.New()
+	userID := uuid.New()
+	repoErr := errors.New("db failure")
+
+	tests := []struct {
+		name        string
+		missing     bool
+		repoErr     error
+		cacheErr    error
+		expectError error
+	}{
+		{name: "deletes and evicts the flight"},
+		{name: "cache eviction failure is not fatal", cacheErr: errors.New("redis down")},
+		{name: "flight not found", missing: true, expectError: exceptions.ErrNotFound},
+		{name: "repo error", repoErr: repoErr, expectError: repoErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+			repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+				assert.False(t, includeDeleted)
+				if tt.missing {
+					return nil, nil
+				}
+				return &models.Flight{ID: flightID, Number: "AA123", OrganizationID: testOrgID, Version: 2}, nil
+			}
+
+			var written []*models.OutboxEvent
+			repo.DeleteFlightFn = func(ctx context.Context, f *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				if tt.repoErr != nil {
+					return tt.repoErr
+				}
+				assert.Equal(t, userID, deletedBy)
+				now := time.Now()
+				f.DeletedAt = &now
+				f.Version++
+				written = events
+				return nil
+			}
+
+			var evicted []uuid.UUID
+			cache.DeleteFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+				assert.Equal(t, testOrgID, orgID)
+				evicted = ids
+				return tt.cacheErr
+			}
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: userID, OrgID: testOrgID})
+
+			flight, err := svc.DeleteFlight(ctx, flightID)
+
+			if tt.expectError != nil {
+				assert.Nil(t, flight)
+				assert.ErrorIs(t, err, tt.expectError)
+				assert.Empty(t, evicted)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, flight.DeletedAt)
+			assert.Equal(t, int32(3), flight.Version)
+			assert.Equal(t, []uuid.UUID{flightID}, evicted)
+			require.Len(t, written, 1)
+			assert.Equal(t, models.EventTypeFlightDeleted, written[0].EventType)
+			assert.Equal(t, flightID, written[0].AggregateID)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/validation/iata_codes"
+	"github.com/google/uuid"
+)
+
+// FlightUpdates carries the latest state of written flights to subscribers. Each
+// update is numbered, and the most recent are retained so they can be replayed.
+// Changes reach it through the outbox, so every committed change is numbered even if
+// the process that wrote it stops before announcing it.
+type FlightUpdates interface {
+	Publish(ctx context.Context, flights ...*models.Flight) error
+	// Notify signals that changes have been committed to the outbox, so they are
+	// published without waiting for the next poll.
+	Notify()
+	// Subscribe returns the updates to the flights of orgID, or of every organization
+	// when orgID is nil, until ctx is done.
+	Subscribe(ctx context.Context, orgID *uuid.UUID) <-chan *models.FlightChange
+	// Head returns the sequence of the most recently published update.
+	Head(ctx context.Context) (uint64, error)
+	// Replay returns the retained updates to the flights of orgID published after the
+	// given sequence, or exceptions.ErrResumeExpired if some are no longer retained.
+	Replay(ctx context.Context, orgID *uuid.UUID, after uint64) ([]*models.FlightChange, error)
+}
+
+var errUpdatesNotConfigured = errors.New("flight updates are not configured")
+
+// notifyUpdates announces that written flights have committed their outbox events.
+// The changes are published from the outbox, so a missed notification only delays them.
+func (service *Service) notifyUpdates() {
+	if service.Updates != nil {
+		service.Updates.Notify()
+	}
+}
+
+// SubscribeFlight streams every change to a flight of the caller's organization,
+// including its deletion and restoration, until ctx is done.
+func (service *Service) SubscribeFlight(ctx context.Context, id uuid.UUID) (<-chan *models.Flight, error) {
+	if service.Updates == nil {
+		return nil, errUpdatesNotConfigured
+	}
+
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Subscribing before the flight is looked up means no change made in between is
+	// missed.
+	ctx, cancel := context.WithCancel(ctx)
+	updates := service.Updates.Subscribe(ctx, orgID)
+
+	current, err := service.Repo.GetFlightByID(ctx, id, orgID, true)
+	if err != nil && !errors.Is(err, exceptions.ErrNotFound) {
+		cancel()
+		return nil, err
+	}
+	if current == nil {
+		cancel()
+		return nil, fmt.Errorf("%w: flight with id=%s", exceptions.ErrNotFound, id)
+	}
+
+	return forwardUpdates(ctx, cancel, updates, func(f *models.Flight) bool {
+		return f.ID == id
+	}), nil
+}
+
+// SubscribeRoute streams changes to the caller organization's flights between origin
+// and destination until ctx is done. A flight that is moved off the route is sent once
+// more, with its new route, so subscribers can drop it.
+func (service *Service) SubscribeRoute(ctx context.Context, origin, destination string) (<-chan *models.Flight, error) {
+	if service.Updates == nil {
+		return nil, errUpdatesNotConfigured
+	}
+
+	origin, err := iata_codes.ValidateAndNormalizeIATACode(origin)
+	if err != nil {
+		return nil, err
+	}
+	destination, err = iata_codes.ValidateAndNormalizeIATACode(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	updates := service.Updates.Subscribe(ctx, orgID)
+
+	// onRoute holds the flights sent while on the route; it is only touched by the
+	// forwarding goroutine.
+	onRoute := make(map[uuid.UUID]struct{})
+	return forwardUpdates(ctx, cancel, updates, func(f *models.Flight) bool {
+		if f.Origin == origin && f.Destination == destination {
+			onRoute[f.ID] = struct{}{}
+			return true
+		}
+		if _, ok := onRoute[f.ID]; ok {
+			delete(onRoute, f.ID)
+			return true
+		}
+		return false
+	}), nil
+}
+
+// forwardUpdates sends the updates that match to the returned channel, which is closed
+// when updates is closed or ctx is done. cancel releases the underlying subscription.
+func forwardUpdates(ctx context.Context, cancel context.CancelFunc, updates <-chan *models.FlightChange, match func(*models.Flight) bool) <-chan *models.Flight {
+	matched := make(chan *models.Flight)
+
+	go func() {
+		defer close(matched)
+		defer cancel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change, ok := <-updates:
+				if !ok {
+					return
+				}
+				if !match(change.Flight) {
+					continue
+				}
+				select {
+				case matched <- change.Flight:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return matched
+}
@@ -0,0 +1,200 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pubsub"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextUpdate returns the next update on updates, failing the test if none arrives.
+func nextUpdate(t *testing.T, updates <-chan *models.Flight) *models.Flight {
+	t.Helper()
+	select {
+	case flight, ok := <-updates:
+		require.True(t, ok, "subscription closed")
+		return flight
+	case <-time.After(time.Second):
+		t.Fatal("no update received")
+		return nil
+	}
+}
+
+func newUpdatesService(repo *FakeRepo) *Service {
+	service := NewFlightsService(repo, FakeFlightsCache{}, &FakeAircraftClient{})
+	service.Updates = pubsub.NewBroker(nil)
+	return service
+}
+
+func TestSubscribeFlight(t *testing.T) {
+	flight := &models.Flight{ID: uuid.New(), Number: "BA117", OrganizationID: testOrgID, Version: 1}
+	repo := &FakeRepo{
+		GetFlightFn: func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+			assert.True(t, includeDeleted)
+			if id != flight.ID || orgID == nil || *orgID != flight.OrganizationID {
+				return nil, nil
+			}
+			return flight, nil
+		},
+	}
+
+	t.Run("Streams Changes To The Flight", func(t *testing.T) {
+		service := newUpdatesService(repo)
+		ctx, cancel := context.WithCancel(orgContext(testOrgID))
+		defer cancel()
+
+		updates, err := service.SubscribeFlight(ctx, flight.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Updates.Publish(ctx, &models.Flight{ID: uuid.New(), OrganizationID: testOrgID}))
+		require.NoError(t, service.Updates.Publish(ctx, &models.Flight{ID: flight.ID, OrganizationID: testOrgID, Version: 2}))
+
+		assert.Equal(t, int32(2), nextUpdate(t, updates).Version)
+	})
+
+	t.Run("Completes When The Context Is Done", func(t *testing.T) {
+		service := newUpdatesService(repo)
+		ctx, cancel := context.WithCancel(orgContext(testOrgID))
+
+		updates, err := service.SubscribeFlight(ctx, flight.ID)
+		require.NoError(t, err)
+		cancel()
+
+		select {
+		case _, ok := <-updates:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("subscription was not closed")
+		}
+	})
+
+	t.Run("Refuses Another Organization's Flight", func(t *testing.T) {
+		_, err := newUpdatesService(repo).SubscribeFlight(orgContext(uuid.New()), flight.ID)
+
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	})
+
+	t.Run("Requires An Organization", func(t *testing.T) {
+		_, err := newUpdatesService(repo).SubscribeFlight(context.Background(), flight.ID)
+
+		assert.ErrorIs(t, err, exceptions.ErrOrganizationRequired)
+	})
+
+	t.Run("Fails Without Updates Configured", func(t *testing.T) {
+		service := NewFlightsService(repo, FakeFlightsCache{}, &FakeAircraftClient{})
+
+		_, err := service.SubscribeFlight(orgContext(testOrgID), flight.ID)
+
+		assert.ErrorIs(t, err, errUpdatesNotConfigured)
+	})
+}
+
+func TestSubscribeRoute(t *testing.T) {
+	t.Run("Streams Flights On The Route And Those Leaving It", func(t *testing.T) {
+		service := newUpdatesService(&FakeRepo{})
+		ctx, cancel := context.WithCancel(orgContext(testOrgID))
+		defer cancel()
+
+		updates, err := service.SubscribeRoute(ctx, "lhr", "jfk")
+		require.NoError(t, err)
+
+		moved := uuid.New()
+		require.NoError(t, service.Updates.Publish(ctx,
+			&models.Flight{ID: uuid.New(), Origin: "CDG", Destination: "JFK", OrganizationID: testOrgID},
+			&models.Flight{ID: moved, Origin: "LHR", Destination: "JFK", OrganizationID: testOrgID},
+			&models.Flight{ID: uuid.New(), Origin: "LHR", Destination: "JFK", OrganizationID: uuid.New()},
+			&models.Flight{ID: moved, Origin: "LHR", Destination: "BOS", OrganizationID: testOrgID},
+			&models.Flight{ID: moved, Origin: "LHR", Destination: "MIA", OrganizationID: testOrgID},
+			&models.Flight{ID: uuid.New(), Origin: "LHR", Destination: "JFK", OrganizationID: testOrgID, Number: "BA1"},
+		))
+
+		first := nextUpdate(t, updates)
+		assert.Equal(t, moved, first.ID)
+		assert.Equal(t, "JFK", first.Destination)
+
+		left := nextUpdate(t, updates)
+		assert.Equal(t, moved, left.ID)
+		assert.Equal(t, "BOS", left.Destination)
+
+		assert.Equal(t, "BA1", nextUpdate(t, updates).Number)
+	})
+
+	t.Run("Rejects Invalid Airport Codes", func(t *testing.T) {
+		_, err := newUpdatesService(&FakeRepo{}).SubscribeRoute(orgContext(testOrgID), "LHR", "J1")
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidIATACode)
+	})
+}
+
+// notifiedUpdates counts the notifications written flights send.
+type notifiedUpdates struct {
+	FlightUpdates
+	notified int
+}
+
+func (n *notifiedUpdates) Notify() {
+	n.notified++
+}
+
+func TestWritesNotifyUpdates(t *testing.T) {
+	stored := &models.Flight{
+		ID:             uuid.New(),
+		Number:         "BA117",
+		Origin:         "LHR",
+		Destination:    "JFK",
+		DepartureTime:  time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC),
+		ArrivalTime:    time.Date(2025, 6, 1, 17, 0, 0, 0, time.UTC),
+		Status:         models.FlightStatusScheduled,
+		OrganizationID: testOrgID,
+		Version:        1,
+	}
+	writeErr := errors.New("db failure")
+	var failWrite bool
+	// Each write commits an event for the flight, which the change relay publishes.
+	written := func(events []*models.OutboxEvent) error {
+		require.Len(t, events, 1)
+		assert.Equal(t, stored.ID, events[0].AggregateID)
+		if failWrite {
+			return writeErr
+		}
+		return nil
+	}
+	repo := &FakeRepo{
+		GetFlightFn: func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+			current := *stored
+			return &current, nil
+		},
+		UpdateFlightFn: func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			return written(events)
+		},
+		DeleteFlightFn: func(ctx context.Context, f *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			return written(events)
+		},
+	}
+	service := NewFlightsService(repo, FakeFlightsCache{}, &FakeAircraftClient{})
+	updates := &notifiedUpdates{}
+	service.Updates = updates
+	ctx := orgContext(testOrgID)
+
+	number := "BA118"
+	_, err := service.UpdateFlight(ctx, stored.ID, models.FlightUpdate{Number: &number, Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updates.notified)
+
+	_, err = service.DeleteFlight(ctx, stored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updates.notified)
+
+	// Nothing was committed, so there is nothing to relay.
+	failWrite = true
+	_, err = service.DeleteFlight(ctx, stored.ID)
+	assert.ErrorIs(t, err, writeErr)
+	assert.Equal(t, 2, updates.notified)
+}
@@ -8,9 +8,22 @@ import (
 	"github.com/google/uuid"
 )
 
-func (service *Service) GetFlightByID(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
-	if service.Cache != nil {
-		flight, err := service.Cache.GetFlight(ctx, id)
+// GetFlightByID returns the flight with id if it belongs to the caller's organization.
+// Platform admins read across organizations and always go to the database, since the
+// cache is keyed by organization. Deleted flights are only returned to admins that ask
+// for them with includeDeleted; the cache only ever holds live flights.
+func (service *Service) GetFlightByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+	if err := authorizeIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if service.Cache != nil && orgID != nil && !includeDeleted {
+		flight, err := service.Cache.GetFlight(ctx, *orgID, id)
 		if err != nil {
 			logger.WarnContext(ctx, "Cache error during flight retrieval", "flight_id", id, "err", err)
 		} else if flight != nil {
@@ -19,7 +32,7 @@ func (service *Service) GetFlightByID(ctx context.Context, id uuid.UUID) (*model
 		}
 	}
 
-	flight, err := service.Repo.GetFlightByID(ctx, id)
+	flight, err := service.Repo.GetFlightByID(ctx, id, orgID, includeDeleted)
 
 	if err != nil {
 		return nil, err
@@ -28,7 +41,7 @@ func (service *Service) GetFlightByID(ctx context.Context, id uuid.UUID) (*model
 	logger.DebugContext(ctx, "Flight found in db", "flight_id", id)
 
 	if flight != nil {
-		if service.Cache != nil {
+		if service.Cache != nil && flight.DeletedAt == nil {
 			if cacheErr := service.Cache.SetFlight(ctx, flight); cacheErr != nil {
 				logger.WarnContext(ctx, "Failed to cache flight",
 					"flight_id", id,
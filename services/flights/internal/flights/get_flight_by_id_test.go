@@ -35,7 +35,7 @@ func TestService_GetFlightByID_WithFakeRepo(t *testing.T) {
 		{
 			name: "success",
 			fakeRepo: &FakeRepo{
-				GetFlightFn: func(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+				GetFlightFn: func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
 					return expectedFlight, nil
 				},
 			},
@@ -46,7 +46,7 @@ func TestService_GetFlightByID_WithFakeRepo(t *testing.T) {
 		{
 			name: "not found error",
 			fakeRepo: &FakeRepo{
-				GetFlightFn: func(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+				GetFlightFn: func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
 					return nil, errors.New("not found")
 				},
 			},
@@ -57,7 +57,7 @@ func TestService_GetFlightByID_WithFakeRepo(t *testing.T) {
 		{
 			name: "nil flight but no error",
 			fakeRepo: &FakeRepo{
-				GetFlightFn: func(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+				GetFlightFn: func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
 					return nil, nil
 				},
 			},
@@ -71,7 +71,7 @@ func TestService_GetFlightByID_WithFakeRepo(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			service := &Service{Repo: tc.fakeRepo}
 
-			flight, err := service.GetFlightByID(context.Background(), tc.inputID)
+			flight, err := service.GetFlightByID(orgContext(testOrgID), tc.inputID, false)
 
 			if tc.expectErr {
 				assert.Error(t, err)
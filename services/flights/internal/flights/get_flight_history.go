@@ -0,0 +1,68 @@
+package flights
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+)
+
+// GetFlightHistory returns a page of the audit history of a flight of the caller's
+// organization, oldest change first. first and after page through the history the same
+// way they do for ListFlights.
+func (service *Service) GetFlightHistory(
+	ctx context.Context,
+	flightID uuid.UUID,
+	first int32,
+	after string,
+) (*models.FlightHistoryConnection, error) {
+	pageSize, err := pagination.ResolvePageSize(first)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := pagination.DecodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row so we know whether another page exists.
+	entries, err := service.Repo.ListFlightHistory(ctx, flightID, orgID, pageSize+1, cursor)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list flight history", "flight_id", flightID, "err", err)
+		return nil, err
+	}
+
+	hasNextPage := len(entries) > pageSize
+	if hasNextPage {
+		entries = entries[:pageSize]
+	}
+
+	edges := make([]*models.FlightHistoryEdge, 0, len(entries))
+	for _, entry := range entries {
+		edges = append(edges, &models.FlightHistoryEdge{
+			Cursor: pagination.EncodeCursor(pagination.Cursor{Time: entry.CreatedAt, ID: entry.ID}),
+			Node:   entry,
+		})
+	}
+
+	pageInfo := &models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: cursor != nil,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	logger.DebugContext(ctx, "Flight history listed", "flight_id", flightID, "count", len(edges), "has_next_page", hasNextPage)
+
+	return &models.FlightHistoryConnection{Edges: edges, PageInfo: pageInfo}, nil
+}
@@ -0,0 +1,126 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeHistory(flightID uuid.UUID, count int) []*models.FlightHistoryEntry {
+	createdAt := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	entries := make([]*models.FlightHistoryEntry, 0, count)
+	for i := 0; i < count; i++ {
+		entries = append(entries, &models.FlightHistoryEntry{
+			ID:        uuid.New(),
+			FlightID:  flightID,
+			Operation: models.FlightHistoryOperationUpdated,
+			After:     []byte(`{}`),
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	return entries
+}
+
+func TestGetFlightHistory(t *testing.T) {
+	flightID := uuid.New()
+	repoErr := errors.New("db failure")
+
+	tests := []struct {
+		name           string
+		first          int32
+		after          string
+		repoEntries    []*models.FlightHistoryEntry
+		repoErr        error
+		expectError    error
+		expectLimit    int
+		expectEdges    int
+		expectNextPage bool
+		expectPrevPage bool
+	}{
+		{
+			name:        "default page size",
+			repoEntries: makeHistory(flightID, 3),
+			expectLimit: pagination.DefaultPageSize + 1,
+			expectEdges: 3,
+		},
+		{
+			name:           "more rows than requested",
+			first:          2,
+			repoEntries:    makeHistory(flightID, 3),
+			expectLimit:    3,
+			expectEdges:    2,
+			expectNextPage: true,
+		},
+		{
+			name:           "continues from cursor",
+			first:          2,
+			after:          pagination.EncodeCursor(pagination.Cursor{Time: time.Now(), ID: uuid.New()}),
+			repoEntries:    makeHistory(flightID, 1),
+			expectLimit:    3,
+			expectEdges:    1,
+			expectPrevPage: true,
+		},
+		{
+			name:        "page size too large",
+			first:       pagination.MaxPageSize + 1,
+			expectError: exceptions.ErrInvalidPageSize,
+		},
+		{
+			name:        "invalid cursor",
+			after:       "not a cursor",
+			expectError: exceptions.ErrInvalidCursor,
+		},
+		{
+			name:        "repo error",
+			repoErr:     repoErr,
+			expectError: repoErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+
+			var gotLimit int
+			var gotOrg *uuid.UUID
+			repo.ListHistoryFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, limit int, after *pagination.Cursor) ([]*models.FlightHistoryEntry, error) {
+				assert.Equal(t, flightID, id)
+				gotLimit, gotOrg = limit, orgID
+				return tt.repoEntries, tt.repoErr
+			}
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			connection, err := svc.GetFlightHistory(orgContext(testOrgID), flightID, tt.first, tt.after)
+
+			if tt.expectError != nil {
+				assert.Nil(t, connection)
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectLimit, gotLimit)
+			require.NotNil(t, gotOrg)
+			assert.Equal(t, testOrgID, *gotOrg)
+			assert.Len(t, connection.Edges, tt.expectEdges)
+			assert.Equal(t, tt.expectNextPage, connection.PageInfo.HasNextPage)
+			assert.Equal(t, tt.expectPrevPage, connection.PageInfo.HasPreviousPage)
+
+			last := connection.Edges[len(connection.Edges)-1]
+			require.NotNil(t, connection.PageInfo.EndCursor)
+			assert.Equal(t, last.Cursor, *connection.PageInfo.EndCursor)
+
+			cursor, err := pagination.DecodeCursor(last.Cursor)
+			require.NoError(t, err)
+			assert.Equal(t, last.Node.ID, cursor.ID)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package flights
+
+import (
+	"context"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+// GetFlightsByIDs is the batched form of GetFlightByID: the cache is read with one
+// lookup and any misses are loaded from the database with one query. Flights that
+// do not exist are absent from the returned map.
+func (service *Service) GetFlightsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]*models.Flight, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	if service.Cache != nil && orgID != nil {
+		cached, err := service.Cache.GetFlights(ctx, *orgID, ids)
+		if err != nil {
+			logger.WarnContext(ctx, "Cache error during batched flight retrieval", "count", len(ids), "err", err)
+		}
+		for id, flight := range cached {
+			found[id] = flight
+		}
+	}
+
+	missing := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := found[id]; ok {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		missing = append(missing, id)
+	}
+
+	if len(missing) == 0 {
+		logger.DebugContext(ctx, "All flights found in cache", "count", len(ids))
+		return found, nil
+	}
+
+	loaded, err := service.Repo.GetFlightsByIDs(ctx, missing, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, flight := range loaded {
+		found[flight.ID] = flight
+	}
+
+	if service.Cache != nil && len(loaded) > 0 {
+		go func(flights []*models.Flight) {
+			bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			for _, f := range flights {
+				if err := service.Cache.SetFlight(bgCtx, f); err != nil {
+					logger.WarnContext(bgCtx, "Failed to cache flight",
+						"flight_id", f.ID, "err", err)
+				}
+			}
+		}(loaded)
+	}
+
+	logger.DebugContext(ctx, "Flights retrieved", "requested", len(ids), "from_db", len(loaded), "found", len(found))
+
+	return found, nil
+}
@@ -0,0 +1,88 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFlightsByIDs(t *testing.T) {
+	cachedID, storedID, unknownID := uuid.New(), uuid.New(), uuid.New()
+	cached := &models.Flight{ID: cachedID, Number: "AA1"}
+	stored := &models.Flight{ID: storedID, Number: "AA2"}
+
+	t.Run("loads only cache misses from the database", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		cache.GetFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+			return map[uuid.UUID]*models.Flight{cachedID: cached}, nil
+		}
+
+		var queried []uuid.UUID
+		repo.GetFlightsFn = func(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+			queried = ids
+			return []*models.Flight{stored}, nil
+		}
+
+		svc := NewFlightsService(repo, cache, aircraft)
+		flights, err := svc.GetFlightsByIDs(orgContext(testOrgID), []uuid.UUID{cachedID, storedID, unknownID, storedID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{storedID, unknownID}, queried)
+		assert.Len(t, flights, 2)
+		assert.Same(t, cached, flights[cachedID])
+		assert.Same(t, stored, flights[storedID])
+		assert.NotContains(t, flights, unknownID)
+	})
+
+	t.Run("skips the database when every flight is cached", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		cache.GetFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+			return map[uuid.UUID]*models.Flight{cachedID: cached}, nil
+		}
+		repo.GetFlightsFn = func(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+			t.Fatal("database should not be queried")
+			return nil, nil
+		}
+
+		svc := NewFlightsService(repo, cache, aircraft)
+		flights, err := svc.GetFlightsByIDs(orgContext(testOrgID), []uuid.UUID{cachedID})
+
+		require.NoError(t, err)
+		assert.Len(t, flights, 1)
+	})
+
+	t.Run("falls back to the database on cache error", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		cache.GetFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+			return nil, errors.New("redis down")
+		}
+		repo.GetFlightsFn = func(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+			return []*models.Flight{stored}, nil
+		}
+
+		svc := NewFlightsService(repo, cache, aircraft)
+		flights, err := svc.GetFlightsByIDs(orgContext(testOrgID), []uuid.UUID{storedID})
+
+		require.NoError(t, err)
+		assert.Same(t, stored, flights[storedID])
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repoErr := errors.New("db failure")
+		repo, cache, aircraft := defaultTestDeps()
+		repo.GetFlightsFn = func(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+			return nil, repoErr
+		}
+
+		svc := NewFlightsService(repo, cache, aircraft)
+		flights, err := svc.GetFlightsByIDs(orgContext(testOrgID), []uuid.UUID{storedID})
+
+		assert.ErrorIs(t, err, repoErr)
+		assert.Nil(t, flights)
+	})
+}
@@ -2,35 +2,74 @@ package flights
 
 import (
 	"context"
+	"time"
 
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
 
 	"github.com/google/uuid"
 )
 
+var testOrgID = uuid.New()
+
+// orgContext returns a context for a caller belonging to orgID.
+func orgContext(orgID uuid.UUID) context.Context {
+	return middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: uuid.New(), OrgID: orgID})
+}
+
 type FakeRepo struct {
-	CreateFlightFn func(ctx context.Context, f *models.Flight) error
-	GetFlightFn    func(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+	CreateFlightFn      func(ctx context.Context, f *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	CreateFlightsFn     func(ctx context.Context, flights []*models.Flight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent) error
+	FindInstancesFn     func(ctx context.Context, candidates []*models.Flight) ([]*models.Flight, error)
+	GetFlightFn         func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error)
+	GetFlightsFn        func(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error)
+	ListFlightsFn       func(ctx context.Context, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error)
+	ListForAircraftFn   func(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error)
+	UpdateFlightFn      func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	TransitionFn        func(ctx context.Context, f *models.Flight, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	DeleteFlightFn      func(ctx context.Context, f *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	RestoreFlightFn     func(ctx context.Context, f *models.Flight, restoredBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	BackfillAirlineFn   func(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error)
+	ListHistoryFn       func(ctx context.Context, flightID uuid.UUID, orgID *uuid.UUID, limit int, after *pagination.Cursor) ([]*models.FlightHistoryEntry, error)
+	CreateScheduleFn    func(ctx context.Context, s *models.Schedule) error
+	GetScheduleFn       func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID) (*models.Schedule, error)
+	UpdateScheduleFn    func(ctx context.Context, s *models.Schedule, expectedVersion int32) error
+	ListSchedulesFn     func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error)
+	MaterializeFn       func(ctx context.Context, s *models.Schedule, instances []*models.ScheduledFlight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent, through time.Time) (int, error)
+	ListStaleFn         func(ctx context.Context, scheduleID uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error)
+	UpdateScheduledFn   func(ctx context.Context, instance *models.ScheduledFlight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	ListUpcomingFn      func(ctx context.Context, aircraftID uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error)
+	UnassignFn          func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	UnassignSchedulesFn func(ctx context.Context, aircraftID, actorID uuid.UUID, from time.Time) ([]uuid.UUID, error)
+	ReportDelayFn       func(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	RecordMovementFn    func(ctx context.Context, f *models.Flight, expectedVersion int32, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
 }
 
 type FakeFlightsCache struct {
-	SaveFlightFn func(ctx context.Context, f *models.Flight) error
-	GetFlightFn  func(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+	SaveFlightFn    func(ctx context.Context, f *models.Flight) error
+	GetFlightFn     func(ctx context.Context, orgID uuid.UUID, id uuid.UUID) (*models.Flight, error)
+	GetFlightsFn    func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error)
+	DeleteFlightsFn func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error
 }
 
 type FakeAircraftClient struct {
-	ValidateAircraftExistsFn func(ctx context.Context, id uuid.UUID) error
+	GetAircraftFn func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error)
 }
 
-type FakeKafkaPublisher struct {
-	PublishFlightCreatedFn func(ctx context.Context, flight *models.Flight) error
+func (f FakeFlightsCache) GetFlight(ctx context.Context, orgID uuid.UUID, id uuid.UUID) (*models.Flight, error) {
+	if f.GetFlightFn == nil {
+		return nil, nil
+	}
+	return f.GetFlightFn(ctx, orgID, id)
 }
 
-func (f FakeFlightsCache) GetFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
-	if f.GetFlightFn == nil {
+func (f FakeFlightsCache) GetFlights(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+	if f.GetFlightsFn == nil {
 		return nil, nil
 	}
-	return f.GetFlightFn(ctx, id)
+	return f.GetFlightsFn(ctx, orgID, ids)
 }
 
 func (f FakeFlightsCache) SetFlight(ctx context.Context, flight *models.Flight) error {
@@ -40,30 +79,198 @@ func (f FakeFlightsCache) SetFlight(ctx context.Context, flight *models.Flight)
 	return f.SaveFlightFn(ctx, flight)
 }
 
-func (f *FakeRepo) GetFlightByID(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+func (f FakeFlightsCache) DeleteFlights(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+	if f.DeleteFlightsFn == nil {
+		return nil
+	}
+	return f.DeleteFlightsFn(ctx, orgID, ids)
+}
+
+func (f *FakeRepo) GetFlightByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
 	if f.GetFlightFn == nil {
 		return nil, nil
 	}
-	return f.GetFlightFn(ctx, id)
+	return f.GetFlightFn(ctx, id, orgID, includeDeleted)
 }
 
-func (f *FakeRepo) CreateFlight(ctx context.Context, fl *models.Flight) error {
+func (f *FakeRepo) GetFlightsByIDs(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+	if f.GetFlightsFn == nil {
+		return nil, nil
+	}
+	return f.GetFlightsFn(ctx, ids, orgID)
+}
+
+func (f *FakeRepo) CreateFlight(ctx context.Context, fl *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
 	if f.CreateFlightFn == nil {
 		return nil
 	}
-	return f.CreateFlightFn(ctx, fl)
+	return f.CreateFlightFn(ctx, fl, history, events...)
+}
+
+func (f *FakeRepo) CreateFlights(ctx context.Context, flights []*models.Flight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent) error {
+	if f.CreateFlightsFn == nil {
+		return nil
+	}
+	return f.CreateFlightsFn(ctx, flights, history, events)
+}
+
+func (f *FakeRepo) FindFlightInstances(ctx context.Context, candidates []*models.Flight) ([]*models.Flight, error) {
+	if f.FindInstancesFn == nil {
+		return nil, nil
+	}
+	return f.FindInstancesFn(ctx, candidates)
+}
+
+func (f *FakeRepo) ListFlights(ctx context.Context, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+	if f.ListFlightsFn == nil {
+		return nil, nil
+	}
+	return f.ListFlightsFn(ctx, filter, limit, after)
+}
+
+func (f *FakeRepo) ListFlightsForAircraft(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+	if f.ListForAircraftFn == nil {
+		return nil, nil
+	}
+	return f.ListForAircraftFn(ctx, aircraftIDs, filter, limit, after)
+}
+
+func (f *FakeRepo) UpdateFlight(ctx context.Context, fl *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	if f.UpdateFlightFn == nil {
+		return nil
+	}
+	return f.UpdateFlightFn(ctx, fl, expectedVersion, history, events...)
+}
+
+func (f *FakeRepo) TransitionFlightStatus(ctx context.Context, fl *models.Flight, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	if f.TransitionFn == nil {
+		return nil
+	}
+	return f.TransitionFn(ctx, fl, transition, history, events...)
+}
+
+func (f *FakeRepo) DeleteFlight(ctx context.Context, fl *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	if f.DeleteFlightFn == nil {
+		return nil
+	}
+	return f.DeleteFlightFn(ctx, fl, deletedBy, history, events...)
+}
+
+func (f *FakeRepo) RestoreFlight(ctx context.Context, fl *models.Flight, restoredBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	if f.RestoreFlightFn == nil {
+		return nil
+	}
+	return f.RestoreFlightFn(ctx, fl, restoredBy, history, events...)
+}
+
+func (f *FakeRepo) BackfillAirline(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error) {
+	if f.BackfillAirlineFn == nil {
+		return nil, nil
+	}
+	return f.BackfillAirlineFn(ctx, actorID, traceContext)
+}
+
+func (f *FakeRepo) ListFlightHistory(ctx context.Context, flightID uuid.UUID, orgID *uuid.UUID, limit int, after *pagination.Cursor) ([]*models.FlightHistoryEntry, error) {
+	if f.ListHistoryFn == nil {
+		return nil, nil
+	}
+	return f.ListHistoryFn(ctx, flightID, orgID, limit, after)
+}
+
+// testAircraft returns an aircraft that may be assigned to any organization's flights.
+func testAircraft(id uuid.UUID) *models.Aircraft {
+	return &models.Aircraft{ID: id, Registration: "G-TEST", Capacity: 180, Status: models.AircraftStatusAvailable}
+}
+
+func (f *FakeAircraftClient) GetAircraft(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+	if f.GetAircraftFn == nil {
+		return testAircraft(id), nil
+	}
+	return f.GetAircraftFn(ctx, id)
+}
+
+func (f *FakeAircraftClient) Invalidate(id uuid.UUID) {}
+
+func (f *FakeRepo) CreateSchedule(ctx context.Context, s *models.Schedule) error {
+	if f.CreateScheduleFn == nil {
+		return nil
+	}
+	return f.CreateScheduleFn(ctx, s)
+}
+
+func (f *FakeRepo) GetScheduleByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID) (*models.Schedule, error) {
+	if f.GetScheduleFn == nil {
+		return nil, nil
+	}
+	return f.GetScheduleFn(ctx, id, orgID)
+}
+
+func (f *FakeRepo) UpdateSchedule(ctx context.Context, s *models.Schedule, expectedVersion int32) error {
+	if f.UpdateScheduleFn == nil {
+		return nil
+	}
+	return f.UpdateScheduleFn(ctx, s, expectedVersion)
+}
+
+func (f *FakeRepo) ListActiveSchedules(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+	if f.ListSchedulesFn == nil {
+		return nil, nil
+	}
+	return f.ListSchedulesFn(ctx, from, limit, after)
+}
+
+func (f *FakeRepo) MaterializeSchedule(ctx context.Context, s *models.Schedule, instances []*models.ScheduledFlight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent, through time.Time) (int, error) {
+	if f.MaterializeFn == nil {
+		return 0, nil
+	}
+	return f.MaterializeFn(ctx, s, instances, history, events, through)
+}
+
+func (f *FakeRepo) ListStaleScheduledFlights(ctx context.Context, scheduleID uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error) {
+	if f.ListStaleFn == nil {
+		return nil, nil
+	}
+	return f.ListStaleFn(ctx, scheduleID, scheduleVersion, departingAfter)
+}
+
+func (f *FakeRepo) UpdateScheduledFlight(ctx context.Context, instance *models.ScheduledFlight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	if f.UpdateScheduledFn == nil {
+		return nil
+	}
+	return f.UpdateScheduledFn(ctx, instance, expectedVersion, history, events...)
+}
+
+func (f *FakeRepo) ListUpcomingFlightsForAircraft(ctx context.Context, aircraftID uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+	if f.ListUpcomingFn == nil {
+		return nil, nil
+	}
+	return f.ListUpcomingFn(ctx, aircraftID, departingAfter, limit)
+}
+
+func (f *FakeRepo) UnassignAircraft(ctx context.Context, flight *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	if f.UnassignFn == nil {
+		return nil
+	}
+	return f.UnassignFn(ctx, flight, expectedVersion, history, events...)
+}
+
+func (f *FakeRepo) UnassignScheduleAircraft(ctx context.Context, aircraftID, actorID uuid.UUID, from time.Time) ([]uuid.UUID, error) {
+	if f.UnassignSchedulesFn == nil {
+		return nil, nil
+	}
+	return f.UnassignSchedulesFn(ctx, aircraftID, actorID, from)
 }
 
-func (f *FakeAircraftClient) ValidateAircraftExists(ctx context.Context, id uuid.UUID) error {
-	if f.ValidateAircraftExistsFn == nil {
+func (f *FakeRepo) ReportDelay(ctx context.Context, flight *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	if f.ReportDelayFn == nil {
 		return nil
 	}
-	return f.ValidateAircraftExistsFn(ctx, id)
+	return f.ReportDelayFn(ctx, flight, expectedVersion, delay, transition, history, events...)
 }
 
-func (f *FakeKafkaPublisher) PublishFlightCreated(ctx context.Context, flight *models.Flight) error {
-	if f.PublishFlightCreatedFn == nil {
+func (f *FakeRepo) RecordMovement(ctx context.Context, flight *models.Flight, expectedVersion int32, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	if f.RecordMovementFn == nil {
 		return nil
 	}
-	return f.PublishFlightCreatedFn(ctx, flight)
+	return f.RecordMovementFn(ctx, flight, expectedVersion, transition, history, events...)
 }
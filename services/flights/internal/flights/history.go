@@ -0,0 +1,30 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+)
+
+// newHistoryEntry starts the history record of a write made by the caller. before is
+// the flight as it was read ahead of the write and is nil for a new flight; the
+// repository completes the entry with the state it stores.
+func newHistoryEntry(ctx context.Context, operation models.FlightHistoryOperation, before *models.Flight) (*models.FlightHistoryEntry, error) {
+	entry := &models.FlightHistoryEntry{
+		Operation: operation,
+		ActorID:   middleware.GetRequestUserContext(ctx).UserID,
+	}
+
+	if before != nil {
+		body, err := json.Marshal(before)
+		if err != nil {
+			return nil, fmt.Errorf("encode %s history: %w", operation, err)
+		}
+		entry.Before = body
+	}
+
+	return entry, nil
+}
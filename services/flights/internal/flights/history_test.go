@@ -0,0 +1,141 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritesRecordHistory(t *testing.T) {
+	actorID := uuid.New()
+	deletedAt := time.Date(2025, 1, 9, 12, 0, 0, 0, time.UTC)
+	departure := time.Now().Add(24 * time.Hour).UTC()
+
+	stored := func(deleted bool) *models.Flight {
+		flight := &models.Flight{
+			ID:            uuid.New(),
+			Number:        "AA123",
+			Origin:        "JFK",
+			Destination:   "LHR",
+			DepartureTime: departure,
+			ArrivalTime:   departure.Add(7 * time.Hour),
+			Status:        models.FlightStatusScheduled,
+			Version:       4,
+		}
+		if deleted {
+			flight.DeletedAt = &deletedAt
+		}
+		return flight
+	}
+
+	tests := []struct {
+		name      string
+		operation models.FlightHistoryOperation
+		deleted   bool
+		write     func(svc *Service, ctx context.Context, id uuid.UUID) error
+	}{
+		{
+			name:      "create",
+			operation: models.FlightHistoryOperationCreated,
+			write: func(svc *Service, ctx context.Context, _ uuid.UUID) error {
+				_, err := svc.CreateFlight(ctx, "AA123", "JFK", "LHR", departure, departure.Add(7*time.Hour), uuid.New())
+				return err
+			},
+		},
+		{
+			name:      "update",
+			operation: models.FlightHistoryOperationUpdated,
+			write: func(svc *Service, ctx context.Context, id uuid.UUID) error {
+				number := "AA999"
+				_, err := svc.UpdateFlight(ctx, id, models.FlightUpdate{Number: &number, Version: 4})
+				return err
+			},
+		},
+		{
+			name:      "status change",
+			operation: models.FlightHistoryOperationStatusChanged,
+			write: func(svc *Service, ctx context.Context, id uuid.UUID) error {
+				_, err := svc.TransitionFlightStatus(ctx, id, models.FlightStatusDelayed, "weather")
+				return err
+			},
+		},
+		{
+			name:      "delete",
+			operation: models.FlightHistoryOperationDeleted,
+			write: func(svc *Service, ctx context.Context, id uuid.UUID) error {
+				_, err := svc.DeleteFlight(ctx, id)
+				return err
+			},
+		},
+		{
+			name:      "restore",
+			operation: models.FlightHistoryOperationRestored,
+			deleted:   true,
+			write: func(svc *Service, ctx context.Context, id uuid.UUID) error {
+				_, err := svc.RestoreFlight(ctx, id)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := stored(tt.deleted)
+			var recorded *models.FlightHistoryEntry
+
+			repo, cache, aircraft := defaultTestDeps()
+			repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+				return current, nil
+			}
+			repo.CreateFlightFn = func(ctx context.Context, f *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				recorded = history
+				return nil
+			}
+			repo.UpdateFlightFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				recorded = history
+				return nil
+			}
+			repo.TransitionFn = func(ctx context.Context, f *models.Flight, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				recorded = history
+				return nil
+			}
+			repo.DeleteFlightFn = func(ctx context.Context, f *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				recorded = history
+				return nil
+			}
+			repo.RestoreFlightFn = func(ctx context.Context, f *models.Flight, restoredBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				recorded = history
+				return nil
+			}
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: actorID, OrgID: testOrgID})
+
+			require.NoError(t, tt.write(svc, ctx, current.ID))
+
+			require.NotNil(t, recorded)
+			assert.Equal(t, tt.operation, recorded.Operation)
+			assert.Equal(t, actorID, recorded.ActorID)
+
+			if tt.operation == models.FlightHistoryOperationCreated {
+				assert.Nil(t, recorded.Before)
+				return
+			}
+
+			var before models.Flight
+			require.NoError(t, json.Unmarshal(recorded.Before, &before))
+			assert.Equal(t, current.Number, before.Number)
+			assert.Equal(t, current.Status, before.Status)
+			assert.Equal(t, current.Version, before.Version)
+			assert.Equal(t, tt.deleted, before.DeletedAt != nil)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/validation/iata_codes"
+)
+
+// ListFlights returns a page of flights matching filter. first is the requested page
+// size (zero selects the default) and after is the cursor of the last edge already seen.
+func (service *Service) ListFlights(
+	ctx context.Context,
+	filter models.FlightFilter,
+	first int32,
+	after string,
+) (*models.FlightConnection, error) {
+	pageSize, err := pagination.ResolvePageSize(first)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := pagination.DecodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeIncludeDeleted(ctx, filter.IncludeDeleted); err != nil {
+		return nil, err
+	}
+
+	normalizedFilter, err := normalizeFlightFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedFilter.OrganizationID, err = organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row so we know whether another page exists.
+	flights, err := service.Repo.ListFlights(ctx, normalizedFilter, pageSize+1, cursor)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list flights", "err", err)
+		return nil, err
+	}
+
+	hasNextPage := len(flights) > pageSize
+	if hasNextPage {
+		flights = flights[:pageSize]
+	}
+
+	connection := buildFlightConnection(flights, hasNextPage, cursor != nil)
+
+	logger.DebugContext(ctx, "Flights listed", "count", len(flights), "has_next_page", hasNextPage)
+
+	return connection, nil
+}
+
+// normalizeFlightFilter validates the filter and upper-cases airport codes so they
+// match the stored values.
+func normalizeFlightFilter(filter models.FlightFilter) (models.FlightFilter, error) {
+	if filter.Origin != nil {
+		origin, err := iata_codes.ValidateAndNormalizeIATACode(*filter.Origin)
+		if err != nil {
+			return filter, err
+		}
+		filter.Origin = &origin
+	}
+
+	if filter.Destination != nil {
+		destination, err := iata_codes.ValidateAndNormalizeIATACode(*filter.Destination)
+		if err != nil {
+			return filter, err
+		}
+		filter.Destination = &destination
+	}
+
+	if filter.DepartureFrom != nil && filter.DepartureTo != nil && filter.DepartureTo.Before(*filter.DepartureFrom) {
+		return filter, fmt.Errorf("%w: departure window ends before it starts", exceptions.ErrInvalidInput)
+	}
+
+	if filter.Airline != nil {
+		airline := strings.TrimSpace(*filter.Airline)
+		filter.Airline = &airline
+	}
+
+	return filter, nil
+}
+
+func buildFlightConnection(flights []*models.Flight, hasNextPage bool, hasPreviousPage bool) *models.FlightConnection {
+	edges := make([]*models.FlightEdge, 0, len(flights))
+	for _, flight := range flights {
+		edges = append(edges, &models.FlightEdge{
+			Cursor: pagination.EncodeCursor(pagination.Cursor{Time: flight.DepartureTime, ID: flight.ID}),
+			Node:   flight,
+		})
+	}
+
+	pageInfo := &models.PageInfo{
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: hasPreviousPage,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &models.FlightConnection{Edges: edges, PageInfo: pageInfo}
+}
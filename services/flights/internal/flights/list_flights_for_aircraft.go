@@ -0,0 +1,67 @@
+package flights
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+)
+
+// ListFlightsForAircraft returns a page of flights for each aircraft in aircraftIDs,
+// all fetched with one query. The departure window, status, page size and cursor
+// apply to every aircraft; aircraft with no matching flights get an empty page.
+func (service *Service) ListFlightsForAircraft(
+	ctx context.Context,
+	aircraftIDs []uuid.UUID,
+	filter models.FlightFilter,
+	first int32,
+	after string,
+) (map[uuid.UUID]*models.FlightConnection, error) {
+	pageSize, err := pagination.ResolvePageSize(first)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := pagination.DecodeCursor(after)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedFilter, err := normalizeFlightFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedFilter.OrganizationID, err = organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row per aircraft so we know whether another page exists.
+	flights, err := service.Repo.ListFlightsForAircraft(ctx, aircraftIDs, normalizedFilter, pageSize+1, cursor)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list flights for aircraft", "aircraft_count", len(aircraftIDs), "err", err)
+		return nil, err
+	}
+
+	byAircraft := make(map[uuid.UUID][]*models.Flight, len(aircraftIDs))
+	for _, flight := range flights {
+		byAircraft[flight.AircraftID] = append(byAircraft[flight.AircraftID], flight)
+	}
+
+	connections := make(map[uuid.UUID]*models.FlightConnection, len(aircraftIDs))
+	for _, aircraftID := range aircraftIDs {
+		page := byAircraft[aircraftID]
+		hasNextPage := len(page) > pageSize
+		if hasNextPage {
+			page = page[:pageSize]
+		}
+		connections[aircraftID] = buildFlightConnection(page, hasNextPage, cursor != nil)
+	}
+
+	logger.DebugContext(ctx, "Flights listed for aircraft", "aircraft_count", len(aircraftIDs), "count", len(flights))
+
+	return connections, nil
+}
@@ -0,0 +1,84 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFlightsForAircraft(t *testing.T) {
+	busy, quiet, idle := uuid.New(), uuid.New(), uuid.New()
+	dep := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	flight := func(aircraftID uuid.UUID, offset time.Duration) *models.Flight {
+		return &models.Flight{ID: uuid.New(), AircraftID: aircraftID, DepartureTime: dep.Add(offset)}
+	}
+
+	t.Run("groups pages by aircraft", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+
+		var gotLimit int
+		var gotIDs []uuid.UUID
+		repo.ListForAircraftFn = func(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+			gotIDs, gotLimit = aircraftIDs, limit
+			return []*models.Flight{
+				flight(busy, 0), flight(busy, time.Hour), flight(busy, 2*time.Hour),
+				flight(quiet, 0),
+			}, nil
+		}
+
+		svc := NewFlightsService(repo, cache, aircraft)
+		connections, err := svc.ListFlightsForAircraft(orgContext(testOrgID), []uuid.UUID{busy, quiet, idle}, models.FlightFilter{}, 2, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, gotLimit)
+		assert.Equal(t, []uuid.UUID{busy, quiet, idle}, gotIDs)
+
+		assert.Len(t, connections[busy].Edges, 2)
+		assert.True(t, connections[busy].PageInfo.HasNextPage)
+		assert.Len(t, connections[quiet].Edges, 1)
+		assert.False(t, connections[quiet].PageInfo.HasNextPage)
+		require.NotNil(t, connections[idle])
+		assert.Empty(t, connections[idle].Edges)
+	})
+
+	t.Run("invalid departure window", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		from, to := dep, dep.Add(-time.Hour)
+
+		svc := NewFlightsService(repo, cache, aircraft)
+		_, err := svc.ListFlightsForAircraft(orgContext(testOrgID), []uuid.UUID{busy}, models.FlightFilter{DepartureFrom: &from, DepartureTo: &to}, 0, "")
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidInput)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+
+		svc := NewFlightsService(repo, cache, aircraft)
+		_, err := svc.ListFlightsForAircraft(orgContext(testOrgID), []uuid.UUID{busy}, models.FlightFilter{}, 0, "%%%")
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidCursor)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repoErr := errors.New("db failure")
+		repo, cache, aircraft := defaultTestDeps()
+		repo.ListForAircraftFn = func(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+			return nil, repoErr
+		}
+
+		svc := NewFlightsService(repo, cache, aircraft)
+		_, err := svc.ListFlightsForAircraft(orgContext(testOrgID), []uuid.UUID{busy}, models.FlightFilter{}, 0, "")
+
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
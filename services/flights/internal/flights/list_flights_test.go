@@ -0,0 +1,155 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeFlights(count int) []*models.Flight {
+	departure := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	flights := make([]*models.Flight, 0, count)
+	for i := 0; i < count; i++ {
+		flights = append(flights, &models.Flight{
+			ID:            uuid.New(),
+			Number:        "BA117",
+			Origin:        "LHR",
+			Destination:   "JFK",
+			DepartureTime: departure.Add(time.Duration(i) * time.Hour),
+			ArrivalTime:   departure.Add(time.Duration(i+8) * time.Hour),
+			Status:        models.FlightStatusScheduled,
+		})
+	}
+	return flights
+}
+
+func TestListFlights(t *testing.T) {
+	repoErr := errors.New("db failure")
+	lowerOrigin := "lhr"
+	badOrigin := "LONDON"
+	from := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name            string
+		filter          models.FlightFilter
+		first           int32
+		after           string
+		repoFlights     []*models.Flight
+		repoErr         error
+		expectError     error
+		expectLimit     int
+		expectEdges     int
+		expectNextPage  bool
+		expectPrevPage  bool
+		expectOriginArg string
+	}{
+		{
+			name:           "default page size",
+			repoFlights:    makeFlights(3),
+			expectLimit:    pagination.DefaultPageSize + 1,
+			expectEdges:    3,
+			expectNextPage: false,
+		},
+		{
+			name:           "more rows than requested",
+			first:          2,
+			repoFlights:    makeFlights(3),
+			expectLimit:    3,
+			expectEdges:    2,
+			expectNextPage: true,
+		},
+		{
+			name:           "continues from cursor",
+			first:          2,
+			after:          pagination.EncodeCursor(pagination.Cursor{Time: from, ID: uuid.New()}),
+			repoFlights:    makeFlights(1),
+			expectLimit:    3,
+			expectEdges:    1,
+			expectPrevPage: true,
+		},
+		{
+			name:            "normalizes airport codes",
+			filter:          models.FlightFilter{Origin: &lowerOrigin},
+			repoFlights:     makeFlights(1),
+			expectLimit:     pagination.DefaultPageSize + 1,
+			expectEdges:     1,
+			expectOriginArg: "LHR",
+		},
+		{
+			name:        "invalid airport code",
+			filter:      models.FlightFilter{Origin: &badOrigin},
+			expectError: exceptions.ErrInvalidIATACode,
+		},
+		{
+			name:        "inverted departure window",
+			filter:      models.FlightFilter{DepartureFrom: &from, DepartureTo: &to},
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:        "invalid page size",
+			first:       pagination.MaxPageSize + 1,
+			expectError: exceptions.ErrInvalidPageSize,
+		},
+		{
+			name:        "invalid cursor",
+			after:       "not-a-cursor",
+			expectError: exceptions.ErrInvalidCursor,
+		},
+		{
+			name:        "repo error",
+			repoErr:     repoErr,
+			expectError: repoErr,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotLimit int
+			var gotFilter models.FlightFilter
+			repo := &FakeRepo{
+				ListFlightsFn: func(ctx context.Context, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+					gotLimit = limit
+					gotFilter = filter
+					return tc.repoFlights, tc.repoErr
+				},
+			}
+
+			svc := &Service{Repo: repo}
+			connection, err := svc.ListFlights(orgContext(testOrgID), tc.filter, tc.first, tc.after)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+				assert.Nil(t, connection)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectLimit, gotLimit)
+			assert.Len(t, connection.Edges, tc.expectEdges)
+			assert.Equal(t, tc.expectNextPage, connection.PageInfo.HasNextPage)
+			assert.Equal(t, tc.expectPrevPage, connection.PageInfo.HasPreviousPage)
+
+			if tc.expectOriginArg != "" {
+				require.NotNil(t, gotFilter.Origin)
+				assert.Equal(t, tc.expectOriginArg, *gotFilter.Origin)
+			}
+
+			last := connection.Edges[len(connection.Edges)-1]
+			require.NotNil(t, connection.PageInfo.EndCursor)
+			assert.Equal(t, last.Cursor, *connection.PageInfo.EndCursor)
+
+			decoded, err := pagination.DecodeCursor(last.Cursor)
+			require.NoError(t, err)
+			assert.Equal(t, last.Node.ID, decoded.ID)
+		})
+	}
+}
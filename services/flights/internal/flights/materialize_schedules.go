@@ -0,0 +1,249 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// schedulePageSize is the number of schedules MaterializeSchedules reads at a time.
+const schedulePageSize = 100
+
+// MaterializeSchedules brings the flights of every active schedule up to date as of
+// now, as materializeSchedule does for one. A schedule that fails does not stop the
+// others; the failures are returned together.
+func (service *Service) MaterializeSchedules(ctx context.Context, now time.Time) error {
+	// A day early, as the schedule's last day may not yet have ended in its own zone.
+	from := calendarDate(now).AddDate(0, 0, -1)
+
+	var errs []error
+	var after *uuid.UUID
+	for {
+		schedules, err := service.Repo.ListActiveSchedules(ctx, from, schedulePageSize, after)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		for _, schedule := range schedules {
+			if err := service.materializeSchedule(ctx, schedule, now); err != nil {
+				logger.ErrorContext(ctx, "Failed to materialize schedule", "schedule_id", schedule.ID, "err", err)
+				errs = append(errs, err)
+			}
+		}
+
+		if len(schedules) < schedulePageSize {
+			return errors.Join(errs...)
+		}
+		after = &schedules[len(schedules)-1].ID
+	}
+}
+
+// materializeSchedule propagates s to its flights that were generated from an earlier
+// version and have not yet departed, then creates the flights of its operating days
+// from today to the end of the schedule horizon. Days whose flight already exists,
+// or whose flight was deleted, are skipped, so repeated runs create nothing new. The
+// writes are attributed to the user who last changed the schedule.
+func (service *Service) materializeSchedule(ctx context.Context, s *models.Schedule, now time.Time) error {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return fmt.Errorf("materialize schedule %s: %w", s.ID, err)
+	}
+
+	ctx = middleware.SetUserContextInContext(ctx, &userContext.UserContext{
+		UserID:  s.LastUpdatedBy,
+		OrgID:   s.OrganizationID,
+		OrgName: s.Airline,
+	})
+
+	var errs []error
+	if err := service.propagateSchedule(ctx, s, location, now); err != nil {
+		errs = append(errs, err)
+	}
+	if err := service.extendSchedule(ctx, s, location, now); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// propagateSchedule moves each stale, not yet departed flight of s to the schedule's
+// current pattern, or deletes it when s no longer operates on its day. A flight changed
+// by someone else in the meantime, or one its aircraft could no longer operate, is left
+// for the next run.
+func (service *Service) propagateSchedule(ctx context.Context, s *models.Schedule, location *time.Location, now time.Time) error {
+	stale, err := service.Repo.ListStaleScheduledFlights(ctx, s.ID, s.Version, now)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	var touched []*models.Flight
+	for _, instance := range stale {
+		current := instance.Flight
+		flight := *current
+
+		var writeErr error
+		if operatesOn(s, instance.ScheduleDate) {
+			flight.Number, flight.Origin, flight.Destination = s.Number, s.Origin, s.Destination
+			flight.DepartureTime, flight.ArrivalTime = scheduledTimes(s, location, instance.ScheduleDate)
+			flight.AircraftID = s.AircraftID
+			flight.LastUpdatedBy = s.LastUpdatedBy
+			writeErr = service.updateScheduledFlight(ctx, current, &models.ScheduledFlight{
+				Flight:          &flight,
+				ScheduleID:      s.ID,
+				ScheduleDate:    instance.ScheduleDate,
+				ScheduleVersion: s.Version,
+			})
+		} else {
+			writeErr = service.deleteScheduledFlight(ctx, current, &flight, s.LastUpdatedBy)
+		}
+
+		switch {
+		case errors.Is(writeErr, exceptions.ErrVersionConflict), errors.Is(writeErr, exceptions.ErrNotFound):
+			logger.WarnContext(ctx, "Scheduled flight changed during propagation", "schedule_id", s.ID, "flight_id", flight.ID, "err", writeErr)
+		case errors.Is(writeErr, exceptions.ErrAircraftConflict):
+			logger.WarnContext(ctx, "Scheduled flight conflicts with another flight of its aircraft", "schedule_id", s.ID, "flight_id", flight.ID, "err", writeErr)
+		case writeErr != nil:
+			errs = append(errs, fmt.Errorf("propagate schedule %s to flight %s: %w", s.ID, flight.ID, writeErr))
+		default:
+			touched = append(touched, &flight)
+		}
+	}
+
+	if len(touched) > 0 {
+		if service.Cache != nil {
+			ids := make([]uuid.UUID, len(touched))
+			for i, f := range touched {
+				ids[i] = f.ID
+			}
+			if err := service.Cache.DeleteFlights(ctx, s.OrganizationID, ids); err != nil {
+				logger.WarnContext(ctx, "Failed to evict rescheduled flights from cache", "schedule_id", s.ID, "err", err)
+			}
+		}
+		service.notifyUpdates()
+		logger.InfoContext(ctx, "Schedule propagated", "schedule_id", s.ID, "version", s.Version, "flights", len(touched))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (service *Service) updateScheduledFlight(ctx context.Context, current *models.Flight, instance *models.ScheduledFlight) error {
+	flight := instance.Flight
+	if _, _, _, err := validateFlightDetails(flight.Number, flight.Origin, flight.Destination, flight.DepartureTime, flight.ArrivalTime); err != nil {
+		return err
+	}
+
+	updated := *flight
+	updated.Version = current.Version + 1
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightUpdated, flight.ID, updated)
+	if err != nil {
+		return err
+	}
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationUpdated, current)
+	if err != nil {
+		return err
+	}
+
+	return service.Repo.UpdateScheduledFlight(ctx, instance, current.Version, history, event)
+}
+
+func (service *Service) deleteScheduledFlight(ctx context.Context, current *models.Flight, flight *models.Flight, deletedBy uuid.UUID) error {
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightDeleted, current.ID, current)
+	if err != nil {
+		return err
+	}
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationDeleted, current)
+	if err != nil {
+		return err
+	}
+
+	return service.Repo.DeleteFlight(ctx, flight, deletedBy, history, event)
+}
+
+// extendSchedule creates the flights of s's operating days from today, in the
+// schedule's zone, through the end of the schedule horizon, skipping any that would
+// already have departed.
+func (service *Service) extendSchedule(ctx context.Context, s *models.Schedule, location *time.Location, now time.Time) error {
+	first := calendarDate(now.In(location))
+	if s.StartDate.After(first) {
+		first = s.StartDate
+	}
+	last := calendarDate(now.Add(service.scheduleHorizon()).In(location))
+	if s.EndDate.Before(last) {
+		last = s.EndDate
+	}
+	if last.Before(first) {
+		return nil
+	}
+
+	var instances []*models.ScheduledFlight
+	var history []*models.FlightHistoryEntry
+	var events []*models.OutboxEvent
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !s.DaysOfWeek.Has(day.Weekday()) {
+			continue
+		}
+		departure, arrival := scheduledTimes(s, location, day)
+		if !departure.After(now) {
+			continue
+		}
+
+		flight := &models.Flight{
+			ID:             uuid.New(),
+			Number:         s.Number,
+			Origin:         s.Origin,
+			Destination:    s.Destination,
+			DepartureTime:  departure,
+			ArrivalTime:    arrival,
+			Status:         models.FlightStatusScheduled,
+			AircraftID:     s.AircraftID,
+			CreatedBy:      s.LastUpdatedBy,
+			LastUpdatedBy:  s.LastUpdatedBy,
+			OrganizationID: s.OrganizationID,
+			Airline:        s.Airline,
+		}
+
+		event, err := newOutboxEvent(ctx, models.EventTypeFlightCreated, flight.ID, flight)
+		if err != nil {
+			return err
+		}
+		entry, err := newHistoryEntry(ctx, models.FlightHistoryOperationCreated, nil)
+		if err != nil {
+			return err
+		}
+
+		instances = append(instances, &models.ScheduledFlight{
+			Flight:          flight,
+			ScheduleID:      s.ID,
+			ScheduleDate:    day,
+			ScheduleVersion: s.Version,
+		})
+		history = append(history, entry)
+		events = append(events, event)
+	}
+
+	created, err := service.Repo.MaterializeSchedule(ctx, s, instances, history, events, last)
+	if err != nil {
+		return err
+	}
+
+	if created > 0 {
+		service.notifyUpdates()
+		logger.InfoContext(ctx, "Schedule materialized", "schedule_id", s.ID, "created", created, "materialized_through", last.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// operatesOn reports whether s operates on the calendar date day.
+func operatesOn(s *models.Schedule, day time.Time) bool {
+	return !day.Before(s.StartDate) && !day.After(s.EndDate) && s.DaysOfWeek.Has(day.Weekday())
+}
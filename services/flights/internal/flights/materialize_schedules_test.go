@@ -0,0 +1,332 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSchedule is BA117 LHR-JFK at 08:25 London time on weekdays from 28 March 2025,
+// the Friday before British Summer Time starts.
+func testSchedule() *models.Schedule {
+	return &models.Schedule{
+		ID:                 uuid.New(),
+		Number:             "BA117",
+		Origin:             "LHR",
+		Destination:        "JFK",
+		DepartureLocalTime: "08:25",
+		TimeZone:           "Europe/London",
+		DurationMinutes:    480,
+		DaysOfWeek:         weekdaysMonToFri,
+		StartDate:          time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC),
+		EndDate:            time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC),
+		AircraftID:         uuid.New(),
+		OrganizationID:     testOrgID,
+		Airline:            "British Airways",
+		LastUpdatedBy:      uuid.New(),
+		Version:            1,
+	}
+}
+
+func TestMaterializeSchedulesCreatesFlightsWithinHorizon(t *testing.T) {
+	schedule := testSchedule()
+	now := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		assert.Equal(t, time.Date(2025, 3, 27, 0, 0, 0, 0, time.UTC), from)
+		return []*models.Schedule{schedule}, nil
+	}
+
+	var instances []*models.ScheduledFlight
+	var history []*models.FlightHistoryEntry
+	var events []*models.OutboxEvent
+	var through time.Time
+	repo.MaterializeFn = func(ctx context.Context, s *models.Schedule, i []*models.ScheduledFlight, h []*models.FlightHistoryEntry, e []*models.OutboxEvent, th time.Time) (int, error) {
+		instances, history, events, through = i, h, e, th
+		assert.Equal(t, schedule.LastUpdatedBy, middleware.GetRequestUserContext(ctx).UserID)
+		return len(i), nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+	svc.ScheduleHorizon = 4 * 24 * time.Hour
+
+	require.NoError(t, svc.MaterializeSchedules(context.Background(), now))
+
+	// Friday, then Monday and Tuesday after the clocks go forward; the weekend is skipped.
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), through)
+	require.Len(t, instances, 3)
+	assert.Equal(t, time.Date(2025, 3, 28, 8, 25, 0, 0, time.UTC), instances[0].Flight.DepartureTime.UTC())
+	assert.Equal(t, time.Date(2025, 3, 31, 7, 25, 0, 0, time.UTC), instances[1].Flight.DepartureTime.UTC())
+	assert.Equal(t, time.Date(2025, 4, 1, 7, 25, 0, 0, time.UTC), instances[2].Flight.DepartureTime.UTC())
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), instances[1].ScheduleDate)
+
+	for i, instance := range instances {
+		assert.Equal(t, schedule.ID, instance.ScheduleID)
+		assert.Equal(t, int32(1), instance.ScheduleVersion)
+		assert.Equal(t, 8*time.Hour, instance.Flight.ArrivalTime.Sub(instance.Flight.DepartureTime))
+		assert.Equal(t, models.FlightStatusScheduled, instance.Flight.Status)
+		assert.Equal(t, schedule.LastUpdatedBy, instance.Flight.CreatedBy)
+		assert.Equal(t, "British Airways", instance.Flight.Airline)
+		assert.Equal(t, models.FlightHistoryOperationCreated, history[i].Operation)
+		assert.Equal(t, models.EventTypeFlightCreated, events[i].EventType)
+		assert.Equal(t, instance.Flight.ID, events[i].AggregateID)
+	}
+}
+
+func TestMaterializeSchedulesSkipsDepartedFlights(t *testing.T) {
+	schedule := testSchedule()
+	now := time.Date(2025, 3, 28, 9, 0, 0, 0, time.UTC)
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		return []*models.Schedule{schedule}, nil
+	}
+
+	var instances []*models.ScheduledFlight
+	repo.MaterializeFn = func(ctx context.Context, s *models.Schedule, i []*models.ScheduledFlight, h []*models.FlightHistoryEntry, e []*models.OutboxEvent, through time.Time) (int, error) {
+		instances = i
+		return len(i), nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+	svc.ScheduleHorizon = 24 * time.Hour
+
+	require.NoError(t, svc.MaterializeSchedules(context.Background(), now))
+	assert.Empty(t, instances)
+}
+
+func TestMaterializeSchedulesLeavesReleasedSchedulesWithoutAircraft(t *testing.T) {
+	schedule := testSchedule()
+	schedule.AircraftID = uuid.Nil
+	now := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		return []*models.Schedule{schedule}, nil
+	}
+
+	var instances []*models.ScheduledFlight
+	repo.MaterializeFn = func(ctx context.Context, s *models.Schedule, i []*models.ScheduledFlight, h []*models.FlightHistoryEntry, e []*models.OutboxEvent, through time.Time) (int, error) {
+		instances = i
+		return len(i), nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+	svc.ScheduleHorizon = 24 * time.Hour
+
+	require.NoError(t, svc.MaterializeSchedules(context.Background(), now))
+	require.NotEmpty(t, instances)
+	for _, instance := range instances {
+		assert.Equal(t, uuid.Nil, instance.Flight.AircraftID)
+	}
+}
+
+func TestMaterializeSchedulesPropagatesEdits(t *testing.T) {
+	schedule := testSchedule()
+	schedule.DepartureLocalTime = "09:10"
+	schedule.DaysOfWeek = models.WeekdaysOf(time.Monday)
+	schedule.Version = 2
+	now := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+
+	stale := func(day time.Time) *models.ScheduledFlight {
+		departure := day.Add(7*time.Hour + 25*time.Minute)
+		return &models.ScheduledFlight{
+			Flight: &models.Flight{
+				ID:             uuid.New(),
+				Number:         "BA117",
+				Origin:         "LHR",
+				Destination:    "JFK",
+				DepartureTime:  departure,
+				ArrivalTime:    departure.Add(8 * time.Hour),
+				Status:         models.FlightStatusScheduled,
+				AircraftID:     schedule.AircraftID,
+				OrganizationID: testOrgID,
+				Version:        1,
+			},
+			ScheduleID:      schedule.ID,
+			ScheduleDate:    day,
+			ScheduleVersion: 1,
+		}
+	}
+	monday := stale(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC))
+	tuesday := stale(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		return []*models.Schedule{schedule}, nil
+	}
+	repo.ListStaleFn = func(ctx context.Context, scheduleID uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error) {
+		assert.Equal(t, int32(2), scheduleVersion)
+		assert.Equal(t, now, departingAfter)
+		return []*models.ScheduledFlight{monday, tuesday}, nil
+	}
+
+	var updated *models.ScheduledFlight
+	var updateHistory *models.FlightHistoryEntry
+	repo.UpdateScheduledFn = func(ctx context.Context, instance *models.ScheduledFlight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+		updated = instance
+		updateHistory = history
+		assert.Equal(t, int32(1), expectedVersion)
+		require.Len(t, events, 1)
+		assert.Equal(t, models.EventTypeFlightUpdated, events[0].EventType)
+		return nil
+	}
+
+	var deleted *models.Flight
+	repo.DeleteFlightFn = func(ctx context.Context, f *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+		deleted = f
+		assert.Equal(t, schedule.LastUpdatedBy, deletedBy)
+		assert.Equal(t, models.FlightHistoryOperationDeleted, history.Operation)
+		return nil
+	}
+
+	var evicted []uuid.UUID
+	cache.DeleteFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+		evicted = ids
+		return nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+	svc.ScheduleHorizon = 4 * 24 * time.Hour
+
+	require.NoError(t, svc.MaterializeSchedules(context.Background(), now))
+
+	require.NotNil(t, updated)
+	assert.Equal(t, monday.Flight.ID, updated.Flight.ID)
+	assert.Equal(t, int32(2), updated.ScheduleVersion)
+	assert.Equal(t, time.Date(2025, 3, 31, 8, 10, 0, 0, time.UTC), updated.Flight.DepartureTime.UTC())
+	assert.Equal(t, schedule.LastUpdatedBy, updated.Flight.LastUpdatedBy)
+	assert.Equal(t, models.FlightHistoryOperationUpdated, updateHistory.Operation)
+	assert.NotNil(t, updateHistory.Before)
+
+	require.NotNil(t, deleted)
+	assert.Equal(t, tuesday.Flight.ID, deleted.ID)
+	assert.ElementsMatch(t, []uuid.UUID{monday.Flight.ID, tuesday.Flight.ID}, evicted)
+}
+
+func TestMaterializeSchedulesLeavesConcurrentEditsForTheNextRun(t *testing.T) {
+	schedule := testSchedule()
+	schedule.Version = 2
+	now := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		return []*models.Schedule{schedule}, nil
+	}
+	repo.ListStaleFn = func(ctx context.Context, scheduleID uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error) {
+		departure := time.Date(2025, 3, 31, 7, 25, 0, 0, time.UTC)
+		return []*models.ScheduledFlight{{
+			Flight:       &models.Flight{ID: uuid.New(), Number: "BA117", Origin: "LHR", Destination: "JFK", DepartureTime: departure, ArrivalTime: departure.Add(8 * time.Hour), Version: 1},
+			ScheduleID:   schedule.ID,
+			ScheduleDate: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+		}}, nil
+	}
+	repo.UpdateScheduledFn = func(ctx context.Context, instance *models.ScheduledFlight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+		return exceptions.ErrVersionConflict
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+
+	assert.NoError(t, svc.MaterializeSchedules(context.Background(), now))
+}
+
+func TestMaterializeSchedulesLeavesAircraftConflictsForTheNextRun(t *testing.T) {
+	schedule := testSchedule()
+	schedule.Version = 2
+	now := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		return []*models.Schedule{schedule}, nil
+	}
+	repo.ListStaleFn = func(ctx context.Context, scheduleID uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error) {
+		departure := time.Date(2025, 3, 31, 7, 25, 0, 0, time.UTC)
+		return []*models.ScheduledFlight{{
+			Flight:       &models.Flight{ID: uuid.New(), Number: "BA117", Origin: "LHR", Destination: "JFK", DepartureTime: departure, ArrivalTime: departure.Add(8 * time.Hour), Version: 1},
+			ScheduleID:   schedule.ID,
+			ScheduleDate: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+		}}, nil
+	}
+	repo.UpdateScheduledFn = func(ctx context.Context, instance *models.ScheduledFlight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+		return &exceptions.AircraftConflictError{Kind: exceptions.AircraftConflictOverlap, AircraftID: schedule.AircraftID, FlightID: uuid.New(), FlightNumber: "BA175"}
+	}
+
+	var evicted []uuid.UUID
+	cache.DeleteFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+		evicted = append(evicted, ids...)
+		return nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+
+	assert.NoError(t, svc.MaterializeSchedules(context.Background(), now))
+	assert.Empty(t, evicted)
+}
+
+func TestMaterializeSchedulesContinuesPastFailures(t *testing.T) {
+	broken := testSchedule()
+	broken.TimeZone = "Nowhere/Special"
+	healthy := testSchedule()
+	now := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+	repoErr := errors.New("db failure")
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		return []*models.Schedule{broken, healthy}, nil
+	}
+
+	var materialized []uuid.UUID
+	repo.MaterializeFn = func(ctx context.Context, s *models.Schedule, i []*models.ScheduledFlight, h []*models.FlightHistoryEntry, e []*models.OutboxEvent, through time.Time) (int, error) {
+		materialized = append(materialized, s.ID)
+		return 0, repoErr
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+	err := svc.MaterializeSchedules(context.Background(), now)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, repoErr)
+	assert.Contains(t, err.Error(), broken.ID.String())
+	assert.Equal(t, []uuid.UUID{healthy.ID}, materialized)
+}
+
+func TestMaterializeSchedulesReadsEveryPage(t *testing.T) {
+	now := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+	firstPage := make([]*models.Schedule, schedulePageSize)
+	for i := range firstPage {
+		firstPage[i] = testSchedule()
+	}
+	last := testSchedule()
+
+	repo, cache, aircraft := defaultTestDeps()
+	var afters []*uuid.UUID
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		afters = append(afters, after)
+		if after == nil {
+			return firstPage, nil
+		}
+		return []*models.Schedule{last}, nil
+	}
+
+	materialized := 0
+	repo.MaterializeFn = func(ctx context.Context, s *models.Schedule, i []*models.ScheduledFlight, h []*models.FlightHistoryEntry, e []*models.OutboxEvent, through time.Time) (int, error) {
+		materialized++
+		return 0, nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+
+	require.NoError(t, svc.MaterializeSchedules(context.Background(), now))
+	require.Len(t, afters, 2)
+	assert.Equal(t, firstPage[schedulePageSize-1].ID, *afters[1])
+	assert.Equal(t, schedulePageSize+1, materialized)
+}
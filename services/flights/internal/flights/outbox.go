@@ -0,0 +1,35 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// newOutboxEvent builds an outbox row for payload, capturing the current trace
+// context so the relay can continue the trace when the event is published.
+func newOutboxEvent(ctx context.Context, eventType string, aggregateID uuid.UUID, payload any) (*models.OutboxEvent, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+
+	return &models.OutboxEvent{
+		AggregateID:  aggregateID,
+		EventType:    eventType,
+		Payload:      body,
+		TraceContext: traceContext(ctx),
+	}, nil
+}
+
+// traceContext returns the trace context of ctx in the form stored with outbox events.
+func traceContext(ctx context.Context) propagation.MapCarrier {
+	carrier := make(propagation.MapCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
@@ -0,0 +1,162 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// movementStatus is the status a flight reaches once each movement is recorded.
+var movementStatus = map[models.FlightMovement]models.FlightStatus{
+	models.FlightMovementOut: models.FlightStatusDeparted,
+	models.FlightMovementOff: models.FlightStatusInProgress,
+	models.FlightMovementOn:  models.FlightStatusInProgress,
+	models.FlightMovementIn:  models.FlightStatusArrived,
+}
+
+// statusProgress orders the statuses a flight passes through between the gates, so a
+// movement only ever moves a flight forward.
+var statusProgress = map[models.FlightStatus]int{
+	models.FlightStatusScheduled:  0,
+	models.FlightStatusDelayed:    0,
+	models.FlightStatusDeparted:   1,
+	models.FlightStatusInProgress: 2,
+	models.FlightStatusArrived:    3,
+}
+
+// RecordMovement records the time of one of a flight's Out, Off, On and In movements.
+// Movements must be recorded in that order and each must come after the one before it;
+// a recorded movement may be corrected as long as it stays between its neighbours.
+// The flight advances to DEPARTED on OUT, IN_PROGRESS on OFF and ARRIVED on IN, unless
+// it has already reached that status.
+func (service *Service) RecordMovement(
+	ctx context.Context,
+	id uuid.UUID,
+	movement models.FlightMovement,
+	at time.Time,
+) (*models.Flight, error) {
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.Index(models.FlightMovements, movement)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: unknown movement %q", exceptions.ErrInvalidInput, movement)
+	}
+
+	current, err := service.Repo.GetFlightByID(ctx, id, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%w: flight with id=%s", exceptions.ErrNotFound, id)
+	}
+
+	status := movementStatus[movement]
+	if current.Status == models.FlightStatusCancelled {
+		return nil, exceptions.IllegalStatusTransition(current.Status, status)
+	}
+
+	if err := validateMovementOrder(current, index, at); err != nil {
+		logger.WarnContext(ctx, "Rejected flight movement", "flight_id", id, "movement", movement, "err", err)
+		return nil, err
+	}
+
+	userID := middleware.GetRequestUserContext(ctx).UserID
+
+	flight := *current
+	flight.SetMovementTime(movement, at)
+	flight.LastUpdatedBy = userID
+
+	var transition *models.FlightStatusTransition
+	var events []*models.OutboxEvent
+	if statusProgress[status] > statusProgress[current.Status] {
+		if err := validateStatusTransition(current.Status, status); err != nil {
+			logger.WarnContext(ctx, "Rejected flight movement", "flight_id", id, "movement", movement, "status", current.Status)
+			return nil, err
+		}
+		reason := fmt.Sprintf("%s recorded at %s", movement, at.UTC().Format(time.RFC3339))
+		transition = &models.FlightStatusTransition{
+			ID:         uuid.New(),
+			FlightID:   id,
+			FromStatus: current.Status,
+			ToStatus:   status,
+			Reason:     &reason,
+			ChangedBy:  userID,
+		}
+		flight.Status = status
+
+		transitioned, err := transitionEvents(ctx, transition)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, transitioned...)
+	}
+
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightMovementRecorded, id, &models.MovementRecord{
+		FlightID:   id,
+		Movement:   movement,
+		Time:       at,
+		RecordedBy: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, event)
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationMovementRecorded, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.Repo.RecordMovement(ctx, &flight, current.Version, transition, history, events...); err != nil {
+		logger.ErrorContext(ctx, "Failed to record flight movement", "flight_id", id, "movement", movement, "err", err)
+		return nil, err
+	}
+
+	service.notifyUpdates()
+
+	go func(f *models.Flight) {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := service.Cache.SetFlight(bgCtx, f); err != nil {
+			logger.WarnContext(bgCtx, "Failed to cache flight",
+				"flight_id", f.ID, "err", err)
+		}
+	}(&flight)
+
+	logger.InfoContext(ctx, "Flight movement recorded", "flight_id", id, "movement", movement, "status", flight.Status)
+
+	return &flight, nil
+}
+
+// validateMovementOrder returns ErrMovementOutOfOrder unless every movement before the
+// one at index in models.FlightMovements has been recorded before at, and every later
+// movement that has been recorded comes after it.
+func validateMovementOrder(flight *models.Flight, index int, at time.Time) error {
+	movement := models.FlightMovements[index]
+	for _, earlier := range models.FlightMovements[:index] {
+		recorded := flight.MovementTime(earlier)
+		if recorded == nil {
+			return fmt.Errorf("%w: %s has not been recorded before %s", exceptions.ErrMovementOutOfOrder, earlier, movement)
+		}
+		if !at.After(*recorded) {
+			return fmt.Errorf("%w: %s must be after %s at %s", exceptions.ErrMovementOutOfOrder, movement, earlier, recorded.Format(time.RFC3339))
+		}
+	}
+	for _, later := range models.FlightMovements[index+1:] {
+		if recorded := flight.MovementTime(later); recorded != nil && !at.Before(*recorded) {
+			return fmt.Errorf("%w: %s must be before %s at %s", exceptions.ErrMovementOutOfOrder, movement, later, recorded.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
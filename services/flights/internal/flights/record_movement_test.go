@@ -0,0 +1,244 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordMovement(t *testing.T) {
+	departure := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	out := departure.Add(5 * time.Minute)
+	off := out.Add(15 * time.Minute)
+	on := off.Add(7 * time.Hour)
+	in := on.Add(10 * time.Minute)
+	repoErr := errors.New("db failure")
+
+	// movedFlight returns a flight with the movements before the given one recorded and
+	// the status those movements would have left it in.
+	movedFlight := func(upTo models.FlightMovement) *models.Flight {
+		flight := upcomingFlight(uuid.New(), testOrgID, departure)
+		times := map[models.FlightMovement]time.Time{
+			models.FlightMovementOut: out,
+			models.FlightMovementOff: off,
+			models.FlightMovementOn:  on,
+			models.FlightMovementIn:  in,
+		}
+		for _, movement := range models.FlightMovements {
+			if movement == upTo {
+				break
+			}
+			flight.SetMovementTime(movement, times[movement])
+			flight.Status = movementStatus[movement]
+		}
+		return flight
+	}
+
+	tests := []struct {
+		name         string
+		current      *models.Flight
+		missing      bool
+		movement     models.FlightMovement
+		at           time.Time
+		repoErr      error
+		expectError  error
+		expectStatus models.FlightStatus
+		// expectTransition is the status the flight is recorded as moving from, if it moves.
+		expectTransition models.FlightStatus
+	}{
+		{
+			name:             "out departs a scheduled flight",
+			current:          movedFlight(models.FlightMovementOut),
+			movement:         models.FlightMovementOut,
+			at:               out,
+			expectStatus:     models.FlightStatusDeparted,
+			expectTransition: models.FlightStatusScheduled,
+		},
+		{
+			name: "out departs a delayed flight",
+			current: func() *models.Flight {
+				flight := movedFlight(models.FlightMovementOut)
+				flight.Status = models.FlightStatusDelayed
+				return flight
+			}(),
+			movement:         models.FlightMovementOut,
+			at:               out,
+			expectStatus:     models.FlightStatusDeparted,
+			expectTransition: models.FlightStatusDelayed,
+		},
+		{
+			name:             "off puts the flight in progress",
+			current:          movedFlight(models.FlightMovementOff),
+			movement:         models.FlightMovementOff,
+			at:               off,
+			expectStatus:     models.FlightStatusInProgress,
+			expectTransition: models.FlightStatusDeparted,
+		},
+		{
+			name:         "on keeps the flight in progress",
+			current:      movedFlight(models.FlightMovementOn),
+			movement:     models.FlightMovementOn,
+			at:           on,
+			expectStatus: models.FlightStatusInProgress,
+		},
+		{
+			name:             "in arrives the flight",
+			current:          movedFlight(models.FlightMovementIn),
+			movement:         models.FlightMovementIn,
+			at:               in,
+			expectStatus:     models.FlightStatusArrived,
+			expectTransition: models.FlightStatusInProgress,
+		},
+		{
+			name:         "correcting an earlier movement keeps the status",
+			current:      movedFlight(""),
+			movement:     models.FlightMovementOff,
+			at:           off.Add(2 * time.Minute),
+			expectStatus: models.FlightStatusArrived,
+		},
+		{
+			name: "out on a flight already marked departed",
+			current: func() *models.Flight {
+				flight := movedFlight(models.FlightMovementOut)
+				flight.Status = models.FlightStatusDeparted
+				return flight
+			}(),
+			movement:     models.FlightMovementOut,
+			at:           out,
+			expectStatus: models.FlightStatusDeparted,
+		},
+		{
+			name:        "off before out is recorded",
+			current:     movedFlight(models.FlightMovementOut),
+			movement:    models.FlightMovementOff,
+			at:          off,
+			expectError: exceptions.ErrMovementOutOfOrder,
+		},
+		{
+			name:        "on not after off",
+			current:     movedFlight(models.FlightMovementOn),
+			movement:    models.FlightMovementOn,
+			at:          off,
+			expectError: exceptions.ErrMovementOutOfOrder,
+		},
+		{
+			name:        "correction past the next movement",
+			current:     movedFlight(models.FlightMovementIn),
+			movement:    models.FlightMovementOff,
+			at:          on,
+			expectError: exceptions.ErrMovementOutOfOrder,
+		},
+		{
+			name: "cancelled flight",
+			current: func() *models.Flight {
+				flight := movedFlight(models.FlightMovementOut)
+				flight.Status = models.FlightStatusCancelled
+				return flight
+			}(),
+			movement:    models.FlightMovementOut,
+			at:          out,
+			expectError: exceptions.ErrIllegalStatusTransition,
+		},
+		{
+			name:        "unknown movement",
+			current:     movedFlight(models.FlightMovementOut),
+			movement:    "TAXI",
+			at:          out,
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:        "flight not found",
+			missing:     true,
+			movement:    models.FlightMovementOut,
+			at:          out,
+			expectError: exceptions.ErrNotFound,
+		},
+		{
+			name:             "repo error",
+			current:          movedFlight(models.FlightMovementOut),
+			movement:         models.FlightMovementOut,
+			at:               out,
+			repoErr:          repoErr,
+			expectError:      repoErr,
+			expectStatus:     models.FlightStatusDeparted,
+			expectTransition: models.FlightStatusScheduled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+			flightID := uuid.New()
+			if tt.current != nil {
+				flightID = tt.current.ID
+			}
+			repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+				assert.Equal(t, flightID, id)
+				if tt.missing {
+					return nil, nil
+				}
+				return tt.current, nil
+			}
+
+			ctx := orgContext(testOrgID)
+			called := false
+			repo.RecordMovementFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				called = true
+				userID := middleware.GetRequestUserContext(ctx).UserID
+				assert.Equal(t, tt.current.Version, expectedVersion)
+				assert.Equal(t, tt.expectStatus, f.Status)
+				assert.Equal(t, userID, f.LastUpdatedBy)
+				require.NotNil(t, f.MovementTime(tt.movement))
+				assert.Equal(t, tt.at, *f.MovementTime(tt.movement))
+
+				require.NotNil(t, history)
+				assert.Equal(t, models.FlightHistoryOperationMovementRecorded, history.Operation)
+
+				last := events[len(events)-1]
+				assert.Equal(t, models.EventTypeFlightMovementRecorded, last.EventType)
+				var record models.MovementRecord
+				require.NoError(t, json.Unmarshal(last.Payload, &record))
+				assert.Equal(t, tt.movement, record.Movement)
+				assert.Equal(t, userID, record.RecordedBy)
+
+				if tt.expectTransition == "" {
+					assert.Nil(t, transition)
+					assert.Len(t, events, 1)
+				} else {
+					require.NotNil(t, transition)
+					assert.Equal(t, tt.expectTransition, transition.FromStatus)
+					assert.Equal(t, tt.expectStatus, transition.ToStatus)
+					require.NotNil(t, transition.Reason)
+					assert.Contains(t, *transition.Reason, string(tt.movement))
+					require.Len(t, events, 2)
+					assert.Equal(t, models.EventTypeFlightStatusChanged, events[0].EventType)
+				}
+				return tt.repoErr
+			}
+
+			service := NewFlightsService(repo, cache, aircraft)
+			flight, err := service.RecordMovement(ctx, flightID, tt.movement, tt.at)
+
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+				assert.Nil(t, flight)
+				assert.Equal(t, tt.repoErr != nil, called)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, called)
+			assert.Equal(t, tt.expectStatus, flight.Status)
+			assert.NotSame(t, tt.current, flight)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// releasePageSize is the number of flights ReleaseAircraft reads at a time.
+const releasePageSize = 100
+
+// ReleaseAircraft unassigns aircraftID from each of its flights that is scheduled or
+// delayed and departs after now, for when the aircraft has been grounded or retired, so
+// the flights can be given another aircraft. It is first unassigned from the schedules
+// still operating, so the materializer does not go on to give it new flights. Each
+// flight is written with its history and a FlightUpdated event, attributed to actorID
+// within the flight's organization. A flight that fails, for instance because it
+// changed in the meantime, fails the call once the rest of its page is done; released
+// flights are not listed again, so the call can simply be retried. It returns the
+// number of flights released.
+func (service *Service) ReleaseAircraft(ctx context.Context, aircraftID, actorID uuid.UUID, now time.Time) (int, error) {
+	// From a day early, as MaterializeSchedules reads schedules.
+	schedules, err := service.Repo.UnassignScheduleAircraft(ctx, aircraftID, actorID, calendarDate(now).AddDate(0, 0, -1))
+	if err != nil {
+		return 0, err
+	}
+	if len(schedules) > 0 {
+		logger.InfoContext(ctx, "Aircraft released from schedules", "aircraft_id", aircraftID, "schedules", len(schedules))
+	}
+
+	released := 0
+	for {
+		flights, err := service.Repo.ListUpcomingFlightsForAircraft(ctx, aircraftID, now, releasePageSize)
+		if err != nil {
+			return released, err
+		}
+
+		var errs []error
+		touched := make(map[uuid.UUID][]uuid.UUID)
+		for _, current := range flights {
+			if err := service.releaseFlight(ctx, current, actorID); err != nil {
+				logger.ErrorContext(ctx, "Failed to unassign aircraft from flight", "aircraft_id", aircraftID, "flight_id", current.ID, "err", err)
+				errs = append(errs, fmt.Errorf("unassign aircraft %s from flight %s: %w", aircraftID, current.ID, err))
+				continue
+			}
+			touched[current.OrganizationID] = append(touched[current.OrganizationID], current.ID)
+			released++
+		}
+
+		if service.Cache != nil {
+			for orgID, ids := range touched {
+				if err := service.Cache.DeleteFlights(ctx, orgID, ids); err != nil {
+					logger.WarnContext(ctx, "Failed to evict released flights from cache", "aircraft_id", aircraftID, "err", err)
+				}
+			}
+		}
+		if len(touched) > 0 {
+			service.notifyUpdates()
+		}
+
+		if len(errs) > 0 || len(flights) < releasePageSize {
+			if released > 0 {
+				logger.InfoContext(ctx, "Aircraft released from flights", "aircraft_id", aircraftID, "flights", released)
+			}
+			return released, errors.Join(errs...)
+		}
+	}
+}
+
+func (service *Service) releaseFlight(ctx context.Context, current *models.Flight, actorID uuid.UUID) error {
+	ctx = middleware.SetUserContextInContext(ctx, &userContext.UserContext{
+		UserID:  actorID,
+		OrgID:   current.OrganizationID,
+		OrgName: current.Airline,
+	})
+
+	flight := *current
+	flight.LastUpdatedBy = actorID
+
+	updated := flight
+	updated.AircraftID = uuid.Nil
+	updated.Version = current.Version + 1
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightUpdated, flight.ID, updated)
+	if err != nil {
+		return err
+	}
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationUpdated, current)
+	if err != nil {
+		return err
+	}
+
+	if err := service.Repo.UnassignAircraft(ctx, &flight, current.Version, history, event); err != nil {
+		return err
+	}
+	return nil
+}
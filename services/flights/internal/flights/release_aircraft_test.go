@@ -0,0 +1,187 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func upcomingFlight(aircraftID, orgID uuid.UUID, departure time.Time) *models.Flight {
+	return &models.Flight{
+		ID:             uuid.New(),
+		Number:         "BA117",
+		Origin:         "LHR",
+		Destination:    "JFK",
+		DepartureTime:  departure,
+		ArrivalTime:    departure.Add(8 * time.Hour),
+		Status:         models.FlightStatusScheduled,
+		AircraftID:     aircraftID,
+		OrganizationID: orgID,
+		Airline:        "British Airways",
+		Version:        2,
+	}
+}
+
+func TestReleaseAircraft(t *testing.T) {
+	now := time.Date(2025, 4, 1, 6, 0, 0, 0, time.UTC)
+	aircraftID := uuid.New()
+	actorID := uuid.New()
+	otherOrgID := uuid.New()
+
+	t.Run("Unassigns Upcoming Flights", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		flights := []*models.Flight{
+			upcomingFlight(aircraftID, testOrgID, now.Add(time.Hour)),
+			upcomingFlight(aircraftID, otherOrgID, now.Add(24*time.Hour)),
+		}
+		repo.ListUpcomingFn = func(ctx context.Context, id uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+			assert.Equal(t, aircraftID, id)
+			assert.Equal(t, now, departingAfter)
+			return flights, nil
+		}
+
+		var unassigned []*models.Flight
+		repo.UnassignFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			assert.Equal(t, int32(2), expectedVersion)
+			assert.Equal(t, aircraftID, f.AircraftID)
+			assert.Equal(t, actorID, f.LastUpdatedBy)
+			assert.Equal(t, f.OrganizationID, middleware.GetRequestUserContext(ctx).OrgID)
+
+			require.NotNil(t, history)
+			assert.Equal(t, models.FlightHistoryOperationUpdated, history.Operation)
+			assert.Equal(t, actorID, history.ActorID)
+
+			require.Len(t, events, 1)
+			assert.Equal(t, models.EventTypeFlightUpdated, events[0].EventType)
+			var payload models.Flight
+			require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+			assert.Equal(t, uuid.Nil, payload.AircraftID)
+			assert.Equal(t, int32(3), payload.Version)
+
+			unassigned = append(unassigned, f)
+			return nil
+		}
+
+		evicted := make(map[uuid.UUID][]uuid.UUID)
+		cache.DeleteFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+			evicted[orgID] = append(evicted[orgID], ids...)
+			return nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		released, err := service.ReleaseAircraft(context.Background(), aircraftID, actorID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, released)
+		assert.Len(t, unassigned, 2)
+		assert.Equal(t, []uuid.UUID{flights[0].ID}, evicted[testOrgID])
+		assert.Equal(t, []uuid.UUID{flights[1].ID}, evicted[otherOrgID])
+	})
+
+	t.Run("Unassigns Active Schedules First", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		var steps []string
+		repo.UnassignSchedulesFn = func(ctx context.Context, id, actor uuid.UUID, from time.Time) ([]uuid.UUID, error) {
+			steps = append(steps, "schedules")
+			assert.Equal(t, aircraftID, id)
+			assert.Equal(t, actorID, actor)
+			assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), from)
+			return []uuid.UUID{uuid.New()}, nil
+		}
+		repo.ListUpcomingFn = func(ctx context.Context, id uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+			steps = append(steps, "flights")
+			return nil, nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		released, err := service.ReleaseAircraft(context.Background(), aircraftID, actorID, now)
+
+		require.NoError(t, err)
+		assert.Zero(t, released)
+		assert.Equal(t, []string{"schedules", "flights"}, steps)
+	})
+
+	t.Run("Schedule Error Leaves Flights", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		repo.UnassignSchedulesFn = func(ctx context.Context, id, actor uuid.UUID, from time.Time) ([]uuid.UUID, error) {
+			return nil, errors.New("connection reset")
+		}
+		repo.ListUpcomingFn = func(ctx context.Context, id uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+			t.Fatal("flights listed after the schedules failed")
+			return nil, nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		released, err := service.ReleaseAircraft(context.Background(), aircraftID, actorID, now)
+
+		assert.ErrorContains(t, err, "connection reset")
+		assert.Zero(t, released)
+	})
+
+	t.Run("Continues Past A Changed Flight And Reports It", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		changed := upcomingFlight(aircraftID, testOrgID, now.Add(time.Hour))
+		repo.ListUpcomingFn = func(ctx context.Context, id uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+			return []*models.Flight{changed, upcomingFlight(aircraftID, testOrgID, now.Add(2*time.Hour))}, nil
+		}
+		repo.UnassignFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			if f.ID == changed.ID {
+				return exceptions.ErrVersionConflict
+			}
+			return nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		released, err := service.ReleaseAircraft(context.Background(), aircraftID, actorID, now)
+
+		assert.ErrorIs(t, err, exceptions.ErrVersionConflict)
+		assert.Contains(t, err.Error(), changed.ID.String())
+		assert.Equal(t, 1, released)
+	})
+
+	t.Run("Reads Pages Until None Are Left", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		calls := 0
+		repo.ListUpcomingFn = func(ctx context.Context, id uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+			calls++
+			count := limit
+			if calls > 1 {
+				count = 1
+			}
+			page := make([]*models.Flight, count)
+			for i := range page {
+				page[i] = upcomingFlight(aircraftID, testOrgID, now.Add(time.Duration(i+1)*time.Hour))
+			}
+			return page, nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		released, err := service.ReleaseAircraft(context.Background(), aircraftID, actorID, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, releasePageSize+1, released)
+	})
+
+	t.Run("List Error", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		repo.ListUpcomingFn = func(ctx context.Context, id uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+			return nil, errors.New("connection reset")
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		released, err := service.ReleaseAircraft(context.Background(), aircraftID, actorID, now)
+
+		assert.ErrorContains(t, err, "connection reset")
+		assert.Zero(t, released)
+	})
+}
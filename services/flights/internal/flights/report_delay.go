@@ -0,0 +1,249 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/validation/delay_codes"
+	"github.com/google/uuid"
+)
+
+// knockOnPageSize is the number of the aircraft's later flights read at a time while a
+// delay is carried forward.
+const knockOnPageSize = 50
+
+// ReportDelay records that a flight will leave later than scheduled. The flight's
+// estimates and delay code are updated and, if it was scheduled, it moves to DELAYED.
+// The delay is then carried forward to the later flights of the same aircraft: each
+// one the aircraft can no longer reach with MinTurnaround to spare is delayed in turn
+// with code 93, until a flight has enough slack to absorb it. A knock-on flight that
+// fails to update is logged and ends the propagation without failing the report.
+func (service *Service) ReportDelay(ctx context.Context, report models.DelayReport) (*models.DelayResult, error) {
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := delay_codes.ValidateAndNormalizeDelayCode(report.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := service.Repo.GetFlightByID(ctx, report.FlightID, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%w: flight with id=%s", exceptions.ErrNotFound, report.FlightID)
+	}
+
+	if current.Status != models.FlightStatusDelayed {
+		if err := validateStatusTransition(current.Status, models.FlightStatusDelayed); err != nil {
+			logger.WarnContext(ctx, "Rejected delay report", "flight_id", current.ID, "status", current.Status)
+			return nil, err
+		}
+	}
+
+	departure := report.EstimatedDepartureTime
+	if !departure.After(current.DepartureTime) {
+		return nil, fmt.Errorf("%w: estimated departure must be after the scheduled departure %s", exceptions.ErrInvalidTimes, current.DepartureTime.Format(time.RFC3339))
+	}
+	arrival := departure.Add(current.ArrivalTime.Sub(current.DepartureTime))
+	if report.EstimatedArrivalTime != nil {
+		arrival = *report.EstimatedArrivalTime
+		if !arrival.After(departure) {
+			return nil, fmt.Errorf("%w: estimated arrival must be after the estimated departure", exceptions.ErrInvalidTimes)
+		}
+	}
+
+	var remarks *string
+	if report.Remarks != nil {
+		if trimmed := strings.TrimSpace(*report.Remarks); trimmed != "" {
+			remarks = &trimmed
+		}
+	}
+
+	flight, err := service.delayFlight(ctx, current, code, departure, arrival, remarks, nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to report flight delay", "flight_id", current.ID, "err", err)
+		return nil, err
+	}
+
+	knockOn := service.propagateDelay(ctx, flight)
+	service.evictFlights(ctx, knockOn)
+
+	service.notifyUpdates()
+
+	go func(f *models.Flight) {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := service.Cache.SetFlight(bgCtx, f); err != nil {
+			logger.WarnContext(bgCtx, "Failed to cache flight",
+				"flight_id", f.ID, "err", err)
+		}
+	}(flight)
+
+	logger.InfoContext(ctx, "Flight delay reported", "flight_id", flight.ID, "code", code, "minutes", flight.DelayMinutes(), "knock_on_flights", len(knockOn))
+
+	result := &models.DelayResult{Flight: flight, KnockOnFlights: []*models.Flight{}}
+	for _, f := range knockOn {
+		if orgID == nil || f.OrganizationID == *orgID {
+			result.KnockOnFlights = append(result.KnockOnFlights, f)
+		}
+	}
+	return result, nil
+}
+
+// propagateDelay delays the later flights of delayed's aircraft that it can no longer
+// reach in time, in departure order, and returns them. Each is attributed to the
+// caller within the flight's own organization.
+func (service *Service) propagateDelay(ctx context.Context, delayed *models.Flight) []*models.Flight {
+	if delayed.AircraftID == uuid.Nil {
+		return nil
+	}
+
+	user := middleware.GetRequestUserContext(ctx)
+	previous := delayed
+	after := delayed.DepartureTime
+
+	var knockOn []*models.Flight
+	for {
+		flights, err := service.Repo.ListUpcomingFlightsForAircraft(ctx, delayed.AircraftID, after, knockOnPageSize)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to list flights to carry a delay forward to", "aircraft_id", delayed.AircraftID, "err", err)
+			return knockOn
+		}
+
+		for _, next := range flights {
+			ready := previous.ExpectedArrivalTime().Add(service.MinTurnaround)
+			if !ready.After(next.ExpectedDepartureTime()) {
+				return knockOn
+			}
+
+			flightCtx := middleware.SetUserContextInContext(ctx, &userContext.UserContext{
+				UserID:  user.UserID,
+				OrgID:   next.OrganizationID,
+				OrgName: next.Airline,
+			})
+			arrival := ready.Add(next.ArrivalTime.Sub(next.DepartureTime))
+			flight, err := service.delayFlight(flightCtx, next, delay_codes.AircraftRotation, ready, arrival, nil, &previous.ID)
+			if err != nil {
+				logger.ErrorContext(ctx, "Failed to carry delay forward", "flight_id", next.ID, "caused_by", previous.ID, "err", err)
+				return knockOn
+			}
+			knockOn = append(knockOn, flight)
+			previous = flight
+		}
+
+		if len(flights) < knockOnPageSize {
+			return knockOn
+		}
+		after = flights[len(flights)-1].DepartureTime
+	}
+}
+
+// delayFlight writes a delay with the given code and estimates to current, moving it to
+// DELAYED if it is not already. causedBy names the flight whose late arrival the delay
+// was carried forward from.
+func (service *Service) delayFlight(
+	ctx context.Context,
+	current *models.Flight,
+	code string,
+	departure, arrival time.Time,
+	remarks *string,
+	causedBy *uuid.UUID,
+) (*models.Flight, error) {
+	userID := middleware.GetRequestUserContext(ctx).UserID
+
+	flight := *current
+	flight.Status = models.FlightStatusDelayed
+	flight.EstimatedDepartureTime = &departure
+	flight.EstimatedArrivalTime = &arrival
+	flight.DelayCode = &code
+	flight.LastUpdatedBy = userID
+
+	delay := &models.FlightDelay{
+		ID:                     uuid.New(),
+		FlightID:               current.ID,
+		Code:                   code,
+		Minutes:                flight.DelayMinutes(),
+		EstimatedDepartureTime: departure,
+		EstimatedArrivalTime:   arrival,
+		Remarks:                remarks,
+		CausedByFlightID:       causedBy,
+		ReportedBy:             userID,
+	}
+
+	var transition *models.FlightStatusTransition
+	var events []*models.OutboxEvent
+	if current.Status != models.FlightStatusDelayed {
+		transition = &models.FlightStatusTransition{
+			ID:         uuid.New(),
+			FlightID:   current.ID,
+			FromStatus: current.Status,
+			ToStatus:   models.FlightStatusDelayed,
+			Reason:     delayReason(code, remarks),
+			ChangedBy:  userID,
+		}
+		transitioned, err := transitionEvents(ctx, transition)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, transitioned...)
+	}
+
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightDelayed, current.ID, delay)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, event)
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationDelayed, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.Repo.ReportDelay(ctx, &flight, current.Version, delay, transition, history, events...); err != nil {
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// delayReason is the reason recorded when a delay moves a flight to DELAYED: the remarks
+// when given, otherwise the delay code and its description.
+func delayReason(code string, remarks *string) *string {
+	if remarks != nil {
+		return remarks
+	}
+	reason := code
+	if delayCode, ok := delay_codes.Lookup(code); ok {
+		reason = fmt.Sprintf("%s: %s", code, delayCode.Description)
+	}
+	return &reason
+}
+
+// evictFlights removes flights from the cache, grouped by organization, so they are
+// read again from the database.
+func (service *Service) evictFlights(ctx context.Context, flights []*models.Flight) {
+	if service.Cache == nil || len(flights) == 0 {
+		return
+	}
+
+	touched := make(map[uuid.UUID][]uuid.UUID)
+	for _, flight := range flights {
+		touched[flight.OrganizationID] = append(touched[flight.OrganizationID], flight.ID)
+	}
+	for orgID, ids := range touched {
+		if err := service.Cache.DeleteFlights(ctx, orgID, ids); err != nil {
+			logger.WarnContext(ctx, "Failed to evict delayed flights from cache", "organization_id", orgID, "err", err)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/validation/delay_codes"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportDelay(t *testing.T) {
+	departure := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	aircraftID := uuid.New()
+
+	t.Run("Delays A Scheduled Flight", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		current := upcomingFlight(aircraftID, testOrgID, departure)
+		repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+			assert.Equal(t, current.ID, id)
+			require.NotNil(t, orgID)
+			assert.Equal(t, testOrgID, *orgID)
+			return current, nil
+		}
+
+		estimated := departure.Add(40 * time.Minute)
+		ctx := orgContext(testOrgID)
+		repo.ReportDelayFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			userID := middleware.GetRequestUserContext(ctx).UserID
+			assert.Equal(t, int32(2), expectedVersion)
+			assert.Equal(t, models.FlightStatusDelayed, f.Status)
+			assert.Equal(t, estimated, *f.EstimatedDepartureTime)
+			assert.Equal(t, estimated.Add(8*time.Hour), *f.EstimatedArrivalTime)
+			assert.Equal(t, "41", *f.DelayCode)
+			assert.Equal(t, userID, f.LastUpdatedBy)
+
+			assert.Equal(t, "41", delay.Code)
+			assert.Equal(t, int32(40), delay.Minutes)
+			require.NotNil(t, delay.Remarks)
+			assert.Equal(t, "hydraulic leak", *delay.Remarks)
+			assert.Nil(t, delay.CausedByFlightID)
+
+			require.NotNil(t, transition)
+			assert.Equal(t, models.FlightStatusScheduled, transition.FromStatus)
+			assert.Equal(t, models.FlightStatusDelayed, transition.ToStatus)
+			assert.Equal(t, "hydraulic leak", *transition.Reason)
+
+			require.NotNil(t, history)
+			assert.Equal(t, models.FlightHistoryOperationDelayed, history.Operation)
+
+			require.Len(t, events, 2)
+			assert.Equal(t, models.EventTypeFlightStatusChanged, events[0].EventType)
+			assert.Equal(t, models.EventTypeFlightDelayed, events[1].EventType)
+			var payload models.FlightDelay
+			require.NoError(t, json.Unmarshal(events[1].Payload, &payload))
+			assert.Equal(t, delay.ID, payload.ID)
+			return nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		remarks := "  hydraulic leak "
+		result, err := service.ReportDelay(ctx, models.DelayReport{
+			FlightID:               current.ID,
+			Code:                   "td",
+			EstimatedDepartureTime: estimated,
+			Remarks:                &remarks,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.FlightStatusDelayed, result.Flight.Status)
+		assert.Equal(t, int32(40), result.Flight.DelayMinutes())
+		assert.NotNil(t, result.KnockOnFlights)
+		assert.Empty(t, result.KnockOnFlights)
+		assert.Equal(t, models.FlightStatusScheduled, current.Status)
+	})
+
+	t.Run("Updating A Delayed Flight Records No Transition", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		current := upcomingFlight(aircraftID, testOrgID, departure)
+		current.Status = models.FlightStatusDelayed
+		repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+			return current, nil
+		}
+
+		estimated := departure.Add(2 * time.Hour)
+		arrival := estimated.Add(7 * time.Hour)
+		repo.ReportDelayFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			assert.Nil(t, transition)
+			assert.Equal(t, arrival, *f.EstimatedArrivalTime)
+			require.Len(t, events, 1)
+			assert.Equal(t, models.EventTypeFlightDelayed, events[0].EventType)
+			return nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		result, err := service.ReportDelay(orgContext(testOrgID), models.DelayReport{
+			FlightID:               current.ID,
+			Code:                   "71",
+			EstimatedDepartureTime: estimated,
+			EstimatedArrivalTime:   &arrival,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "71", *result.Flight.DelayCode)
+	})
+
+	t.Run("Carries The Delay Forward Until It Is Absorbed", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		otherOrgID := uuid.New()
+		current := upcomingFlight(aircraftID, testOrgID, departure)
+		second := upcomingFlight(aircraftID, otherOrgID, departure.Add(9*time.Hour))
+		third := upcomingFlight(aircraftID, testOrgID, departure.Add(18*time.Hour))
+		fourth := upcomingFlight(aircraftID, testOrgID, departure.Add(30*time.Hour))
+		repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+			return current, nil
+		}
+		repo.ListUpcomingFn = func(ctx context.Context, id uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+			assert.Equal(t, aircraftID, id)
+			assert.Equal(t, departure, departingAfter)
+			return []*models.Flight{second, third, fourth}, nil
+		}
+
+		delays := make(map[uuid.UUID]*models.FlightDelay)
+		repo.ReportDelayFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			assert.Equal(t, f.OrganizationID, middleware.GetRequestUserContext(ctx).OrgID)
+			delays[f.ID] = delay
+			return nil
+		}
+
+		evicted := make(map[uuid.UUID][]uuid.UUID)
+		cache.DeleteFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) error {
+			evicted[orgID] = append(evicted[orgID], ids...)
+			return nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		service.MinTurnaround = 45 * time.Minute
+		result, err := service.ReportDelay(orgContext(testOrgID), models.DelayReport{
+			FlightID:               current.ID,
+			Code:                   "41",
+			EstimatedDepartureTime: departure.Add(2 * time.Hour),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, delays, 3)
+		assert.NotContains(t, delays, fourth.ID)
+
+		secondDelay := delays[second.ID]
+		assert.Equal(t, delay_codes.AircraftRotation, secondDelay.Code)
+		assert.Equal(t, current.ID, *secondDelay.CausedByFlightID)
+		assert.Equal(t, departure.Add(10*time.Hour+45*time.Minute), secondDelay.EstimatedDepartureTime)
+		assert.Equal(t, int32(105), secondDelay.Minutes)
+
+		thirdDelay := delays[third.ID]
+		assert.Equal(t, second.ID, *thirdDelay.CausedByFlightID)
+		assert.Equal(t, departure.Add(19*time.Hour+30*time.Minute), thirdDelay.EstimatedDepartureTime)
+
+		require.Len(t, result.KnockOnFlights, 1)
+		assert.Equal(t, third.ID, result.KnockOnFlights[0].ID)
+		assert.Equal(t, []uuid.UUID{third.ID}, evicted[testOrgID])
+		assert.Equal(t, []uuid.UUID{second.ID}, evicted[otherOrgID])
+	})
+
+	t.Run("Knock-On Failure Does Not Fail The Report", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		current := upcomingFlight(aircraftID, testOrgID, departure)
+		next := upcomingFlight(aircraftID, testOrgID, departure.Add(9*time.Hour))
+		repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+			return current, nil
+		}
+		repo.ListUpcomingFn = func(ctx context.Context, id uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+			return []*models.Flight{next}, nil
+		}
+		repo.ReportDelayFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			if f.ID == next.ID {
+				return exceptions.ErrVersionConflict
+			}
+			return nil
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		result, err := service.ReportDelay(orgContext(testOrgID), models.DelayReport{
+			FlightID:               current.ID,
+			Code:                   "41",
+			EstimatedDepartureTime: departure.Add(2 * time.Hour),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, current.ID, result.Flight.ID)
+		assert.Empty(t, result.KnockOnFlights)
+	})
+
+	rejections := []struct {
+		name        string
+		status      models.FlightStatus
+		missing     bool
+		code        string
+		departure   time.Time
+		arrival     *time.Time
+		expectError error
+	}{
+		{
+			name:        "unknown delay code",
+			code:        "07",
+			departure:   departure.Add(time.Hour),
+			expectError: exceptions.ErrInvalidDelayCode,
+		},
+		{
+			name:        "estimate not after the schedule",
+			code:        "41",
+			departure:   departure,
+			expectError: exceptions.ErrInvalidTimes,
+		},
+		{
+			name:        "arrival not after departure",
+			code:        "41",
+			departure:   departure.Add(time.Hour),
+			arrival:     &departure,
+			expectError: exceptions.ErrInvalidTimes,
+		},
+		{
+			name:        "departed flight",
+			status:      models.FlightStatusDeparted,
+			code:        "41",
+			departure:   departure.Add(time.Hour),
+			expectError: exceptions.ErrIllegalStatusTransition,
+		},
+		{
+			name:        "flight not found",
+			missing:     true,
+			code:        "41",
+			departure:   departure.Add(time.Hour),
+			expectError: exceptions.ErrNotFound,
+		},
+	}
+
+	for _, tt := range rejections {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+			current := upcomingFlight(aircraftID, testOrgID, departure)
+			if tt.status != "" {
+				current.Status = tt.status
+			}
+			repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+				if tt.missing {
+					return nil, nil
+				}
+				return current, nil
+			}
+			repo.ReportDelayFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				t.Fatal("ReportDelay should not be called")
+				return nil
+			}
+
+			service := NewFlightsService(repo, cache, aircraft)
+			result, err := service.ReportDelay(orgContext(testOrgID), models.DelayReport{
+				FlightID:               current.ID,
+				Code:                   tt.code,
+				EstimatedDepartureTime: tt.departure,
+				EstimatedArrivalTime:   tt.arrival,
+			})
+
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.expectError)
+		})
+	}
+
+	t.Run("Repo Error", func(t *testing.T) {
+		repo, cache, aircraft := defaultTestDeps()
+		current := upcomingFlight(aircraftID, testOrgID, departure)
+		repoErr := errors.New("db failure")
+		repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+			return current, nil
+		}
+		repo.ReportDelayFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+			return repoErr
+		}
+
+		service := NewFlightsService(repo, cache, aircraft)
+		result, err := service.ReportDelay(orgContext(testOrgID), models.DelayReport{
+			FlightID:               current.ID,
+			Code:                   "41",
+			EstimatedDepartureTime: departure.Add(time.Hour),
+		})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
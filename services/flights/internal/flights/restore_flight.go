@@ -0,0 +1,69 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// RestoreFlight undoes the soft delete of a flight of the caller's organization and
+// records a FlightUpdated event describing the restored flight.
+func (service *Service) RestoreFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := service.Repo.GetFlightByID(ctx, id, orgID, true)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || current.DeletedAt == nil {
+		return nil, fmt.Errorf("%w: deleted flight with id=%s", exceptions.ErrNotFound, id)
+	}
+
+	flight := *current
+	restoredBy := middleware.GetRequestUserContext(ctx).UserID
+
+	// A deleted flight cannot be updated, so the restore moves it to the next version.
+	restored := flight
+	restored.DeletedAt = nil
+	restored.LastUpdatedBy = restoredBy
+	restored.Version = current.Version + 1
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightUpdated, flight.ID, restored)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationRestored, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.Repo.RestoreFlight(ctx, &flight, restoredBy, history, event); err != nil {
+		logger.ErrorContext(ctx, "Failed to restore flight in database", "flight_id", id, "err", err)
+		return nil, err
+	}
+
+	service.notifyUpdates()
+
+	go func(f *models.Flight) {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := service.Cache.SetFlight(bgCtx, f); err != nil {
+			logger.WarnContext(bgCtx, "Failed to cache flight",
+				"flight_id", f.ID, "err", err)
+		}
+	}(&flight)
+
+	logger.InfoContext(ctx, "Flight restored", "flight_id", id, "number", flight.Number, "restored_by", restoredBy)
+
+	return &flight, nil
+}
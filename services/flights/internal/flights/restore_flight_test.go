@@ -0,0 +1,113 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreFlight(t *testing.T) {
+	flightID := uuid.New()
+	userID := uuid.New()
+	deletedAt := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		stored      *models.Flight
+		repoErr     error
+		expectError error
+	}{
+		{
+			name:   "restores a deleted flight",
+			stored: &models.Flight{ID: flightID, Number: "AA123", Version: 3, DeletedAt: &deletedAt},
+		},
+		{
+			name:        "live flight cannot be restored",
+			stored:      &models.Flight{ID: flightID, Number: "AA123", Version: 3},
+			expectError: exceptions.ErrNotFound,
+		},
+		{
+			name:        "flight not found",
+			expectError: exceptions.ErrNotFound,
+		},
+		{
+			name:        "number taken by a live flight",
+			stored:      &models.Flight{ID: flightID, Number: "AA123", Version: 3, DeletedAt: &deletedAt},
+			repoErr:     exceptions.ErrDuplicateFlight,
+			expectError: exceptions.ErrDuplicateFlight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+			repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+				assert.True(t, includeDeleted)
+				return tt.stored, nil
+			}
+
+			var written []*models.OutboxEvent
+			repo.RestoreFlightFn = func(ctx context.Context, f *models.Flight, restoredBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				if tt.repoErr != nil {
+					return tt.repoErr
+				}
+				assert.Equal(t, userID, restoredBy)
+				f.DeletedAt = nil
+				f.Version++
+				written = events
+				return nil
+			}
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: userID, OrgID: testOrgID})
+
+			flight, err := svc.RestoreFlight(ctx, flightID)
+
+			if tt.expectError != nil {
+				assert.Nil(t, flight)
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Nil(t, flight.DeletedAt)
+			assert.Equal(t, int32(4), flight.Version)
+
+			require.Len(t, written, 1)
+			assert.Equal(t, models.EventTypeFlightUpdated, written[0].EventType)
+
+			var payload models.Flight
+			require.NoError(t, json.Unmarshal(written[0].Payload, &payload))
+			assert.Nil(t, payload.DeletedAt)
+			assert.Equal(t, int32(4), payload.Version)
+			assert.Equal(t, userID, payload.LastUpdatedBy)
+		})
+	}
+}
+
+func TestIncludeDeletedRequiresAdmin(t *testing.T) {
+	repo, cache, aircraft := defaultTestDeps()
+	repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+		return &models.Flight{ID: id}, nil
+	}
+	svc := NewFlightsService(repo, cache, aircraft)
+
+	_, err := svc.GetFlightByID(orgContext(testOrgID), uuid.New(), true)
+	assert.ErrorIs(t, err, exceptions.ErrForbidden)
+
+	_, err = svc.ListFlights(orgContext(testOrgID), models.FlightFilter{IncludeDeleted: true}, 0, "")
+	assert.ErrorIs(t, err, exceptions.ErrForbidden)
+
+	admin := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: uuid.New(), OrgID: testOrgID, Roles: "ADMIN"})
+	_, err = svc.GetFlightByID(admin, uuid.New(), true)
+	assert.NoError(t, err)
+}
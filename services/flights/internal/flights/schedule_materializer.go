@@ -0,0 +1,48 @@
+package flights
+
+import (
+	"context"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+)
+
+// ScheduleMaterializer periodically runs MaterializeSchedules, so each schedule's
+// flights stay materialized a full horizon ahead and edits that could not be
+// propagated when they were made are retried. Every write it makes is idempotent or
+// version checked, so it is safe to run in each replica of the service.
+type ScheduleMaterializer struct {
+	service  *Service
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewScheduleMaterializer returns a ScheduleMaterializer that materializes the schedules of service every interval.
+func NewScheduleMaterializer(service *Service, interval time.Duration) *ScheduleMaterializer {
+	return &ScheduleMaterializer{
+		service:  service,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run materializes schedules until ctx is cancelled.
+func (m *ScheduleMaterializer) Run(ctx context.Context) {
+	logger.InfoContext(ctx, "Starting schedule materializer", "interval", m.interval, "horizon", m.service.scheduleHorizon())
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.service.MaterializeSchedules(ctx, m.now()); err != nil && ctx.Err() == nil {
+			logger.ErrorContext(ctx, "Schedule materialization incomplete", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Schedule materializer stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
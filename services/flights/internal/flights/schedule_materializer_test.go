@@ -0,0 +1,49 @@
+package flights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleMaterializerRunsUntilCancelled(t *testing.T) {
+	now := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+	runs := make(chan time.Time, 1)
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.ListSchedulesFn = func(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+		select {
+		case runs <- from:
+		default:
+		}
+		return nil, nil
+	}
+
+	materializer := NewScheduleMaterializer(NewFlightsService(repo, cache, aircraft), time.Hour)
+	materializer.now = func() time.Time { return now }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		materializer.Run(ctx)
+	}()
+
+	select {
+	case from := <-runs:
+		assert.Equal(t, time.Date(2025, 3, 27, 0, 0, 0, 0, time.UTC), from)
+	case <-time.After(time.Second):
+		t.Fatal("materializer did not run on start")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("materializer did not stop")
+	}
+}
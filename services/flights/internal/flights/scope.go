@@ -0,0 +1,39 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// organizationScope returns the organization the caller's reads are restricted to.
+// Platform admins get a nil scope and may read across organizations; any other caller
+// without an organization is refused rather than allowed to read unscoped.
+func organizationScope(ctx context.Context) (*uuid.UUID, error) {
+	user := middleware.GetRequestUserContext(ctx)
+	if user.HasRole(userContext.RolePlatformAdmin) {
+		return nil, nil
+	}
+	if user.OrgID == uuid.Nil {
+		return nil, exceptions.ErrOrganizationRequired
+	}
+
+	orgID := user.OrgID
+	return &orgID, nil
+}
+
+// authorizeIncludeDeleted refuses requests for deleted flights from callers who are not
+// admins.
+func authorizeIncludeDeleted(ctx context.Context, includeDeleted bool) error {
+	if !includeDeleted {
+		return nil
+	}
+	if !middleware.GetRequestUserContext(ctx).HasAnyRole(userContext.RoleAdmin, userContext.RolePlatformAdmin) {
+		return fmt.Errorf("%w: reading deleted flights requires the %s role", exceptions.ErrForbidden, userContext.RoleAdmin)
+	}
+	return nil
+}
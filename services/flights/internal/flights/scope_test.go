@@ -0,0 +1,135 @@
+package flights
+
+import (
+	"context"
+	"testing"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func adminContext() context.Context {
+	return middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{
+		UserID: uuid.New(),
+		OrgID:  uuid.New(),
+		Roles:  "DISPATCHER,platform_admin",
+	})
+}
+
+func TestOrganizationScope(t *testing.T) {
+	t.Run("member is scoped to their organization", func(t *testing.T) {
+		orgID, err := organizationScope(orgContext(testOrgID))
+
+		require.NoError(t, err)
+		require.NotNil(t, orgID)
+		assert.Equal(t, testOrgID, *orgID)
+	})
+
+	t.Run("platform admin is unscoped", func(t *testing.T) {
+		orgID, err := organizationScope(adminContext())
+
+		assert.NoError(t, err)
+		assert.Nil(t, orgID)
+	})
+
+	t.Run("missing organization is refused", func(t *testing.T) {
+		orgID, err := organizationScope(context.Background())
+
+		assert.ErrorIs(t, err, exceptions.ErrOrganizationRequired)
+		assert.Nil(t, orgID)
+	})
+}
+
+func TestGetFlightByIDIsolatesOrganizations(t *testing.T) {
+	ownerOrg, otherOrg := uuid.New(), uuid.New()
+	flight := &models.Flight{ID: uuid.New(), Number: "AA123", OrganizationID: ownerOrg}
+
+	// The fakes only hold the flight for its owning organization, as the scoped
+	// query and the per-organization cache key do.
+	repo := &FakeRepo{
+		GetFlightFn: func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+			if orgID != nil && *orgID != flight.OrganizationID {
+				return nil, exceptions.ErrNotFound
+			}
+			return flight, nil
+		},
+	}
+	var cacheReads []uuid.UUID
+	cache := &FakeFlightsCache{
+		GetFlightFn: func(ctx context.Context, orgID uuid.UUID, id uuid.UUID) (*models.Flight, error) {
+			cacheReads = append(cacheReads, orgID)
+			if orgID == flight.OrganizationID {
+				return flight, nil
+			}
+			return nil, nil
+		},
+	}
+	svc := NewFlightsService(repo, cache, &FakeAircraftClient{})
+
+	got, err := svc.GetFlightByID(orgContext(ownerOrg), flight.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, flight, got)
+
+	got, err = svc.GetFlightByID(orgContext(otherOrg), flight.ID, false)
+	assert.ErrorIs(t, err, exceptions.ErrNotFound)
+	assert.Nil(t, got)
+
+	got, err = svc.GetFlightByID(adminContext(), flight.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, flight, got)
+
+	assert.Equal(t, []uuid.UUID{ownerOrg, otherOrg}, cacheReads, "platform admin reads bypass the cache")
+}
+
+func TestGetFlightsByIDsScopesRepositoryAndCache(t *testing.T) {
+	repo, cache, aircraft := defaultTestDeps()
+	var repoOrg *uuid.UUID
+	var cacheOrg uuid.UUID
+	repo.GetFlightsFn = func(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+		repoOrg = orgID
+		return nil, nil
+	}
+	cache.GetFlightsFn = func(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+		cacheOrg = orgID
+		return nil, nil
+	}
+	svc := NewFlightsService(repo, cache, aircraft)
+
+	_, err := svc.GetFlightsByIDs(orgContext(testOrgID), []uuid.UUID{uuid.New()})
+
+	require.NoError(t, err)
+	require.NotNil(t, repoOrg)
+	assert.Equal(t, testOrgID, *repoOrg)
+	assert.Equal(t, testOrgID, cacheOrg)
+
+	_, err = svc.GetFlightsByIDs(context.Background(), []uuid.UUID{uuid.New()})
+	assert.ErrorIs(t, err, exceptions.ErrOrganizationRequired)
+}
+
+func TestListFlightsScopesFilterToOrganization(t *testing.T) {
+	repo, cache, aircraft := defaultTestDeps()
+	var listed models.FlightFilter
+	repo.ListFlightsFn = func(ctx context.Context, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+		listed = filter
+		return nil, nil
+	}
+	svc := NewFlightsService(repo, cache, aircraft)
+
+	_, err := svc.ListFlights(orgContext(testOrgID), models.FlightFilter{}, 0, "")
+	require.NoError(t, err)
+	require.NotNil(t, listed.OrganizationID)
+	assert.Equal(t, testOrgID, *listed.OrganizationID)
+
+	_, err = svc.ListFlights(adminContext(), models.FlightFilter{}, 0, "")
+	require.NoError(t, err)
+	assert.Nil(t, listed.OrganizationID)
+
+	_, err = svc.ListFlights(context.Background(), models.FlightFilter{}, 0, "")
+	assert.ErrorIs(t, err, exceptions.ErrOrganizationRequired)
+}
@@ -2,31 +2,70 @@ package flights
 
 import (
 	"context"
+	"time"
 
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/cache/repositories/flights"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/clients/aircraft_client"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
 	"github.com/google/uuid"
 )
 
 type repository interface {
-	CreateFlight(ctx context.Context, f *models.Flight) error
-	GetFlightByID(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+	CreateFlight(ctx context.Context, f *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	CreateFlights(ctx context.Context, flights []*models.Flight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent) error
+	FindFlightInstances(ctx context.Context, candidates []*models.Flight) ([]*models.Flight, error)
+	GetFlightByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error)
+	GetFlightsByIDs(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error)
+	ListFlights(ctx context.Context, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error)
+	ListFlightsForAircraft(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error)
+	UpdateFlight(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	TransitionFlightStatus(ctx context.Context, f *models.Flight, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	DeleteFlight(ctx context.Context, f *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	RestoreFlight(ctx context.Context, f *models.Flight, restoredBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	BackfillAirline(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error)
+	ListFlightHistory(ctx context.Context, flightID uuid.UUID, orgID *uuid.UUID, limit int, after *pagination.Cursor) ([]*models.FlightHistoryEntry, error)
+	CreateSchedule(ctx context.Context, s *models.Schedule) error
+	GetScheduleByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID) (*models.Schedule, error)
+	UpdateSchedule(ctx context.Context, s *models.Schedule, expectedVersion int32) error
+	ListActiveSchedules(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error)
+	MaterializeSchedule(ctx context.Context, s *models.Schedule, instances []*models.ScheduledFlight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent, through time.Time) (int, error)
+	ListStaleScheduledFlights(ctx context.Context, scheduleID uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error)
+	UpdateScheduledFlight(ctx context.Context, instance *models.ScheduledFlight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	ListUpcomingFlightsForAircraft(ctx context.Context, aircraftID uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error)
+	UnassignAircraft(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	UnassignScheduleAircraft(ctx context.Context, aircraftID, actorID uuid.UUID, from time.Time) ([]uuid.UUID, error)
+	ReportDelay(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
+	RecordMovement(ctx context.Context, f *models.Flight, expectedVersion int32, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error
 }
 
-type kafkaPublisher interface {
-	PublishFlightCreated(ctx context.Context, flight *models.Flight) error
-}
+// DefaultScheduleHorizon is the schedule horizon of a Service that does not set one.
+const DefaultScheduleHorizon = 90 * 24 * time.Hour
 
 type Service struct {
 	Repo           repository
 	Cache          flights.FlightCacheRepository
-	AircraftClient aircraft_client.AircraftValidator
-	KafkaPublisher kafkaPublisher
+	AircraftClient aircraft_client.AircraftLookup
+	// ScheduleHorizon is how far ahead flights are materialized from schedules;
+	// DefaultScheduleHorizon is used when it is zero.
+	ScheduleHorizon time.Duration
+	// MinTurnaround is the least time an aircraft spends on the ground between flights,
+	// used to carry a delay forward to the aircraft's later flights.
+	MinTurnaround time.Duration
+	// Updates, when set, receives every flight the service writes and backs flight
+	// subscriptions.
+	Updates FlightUpdates
 }
 
 // NewFlightsService returns a new *Service that uses the provided repository for flight persistence.
 func NewFlightsService(repo repository, cache flights.FlightCacheRepository,
-	aircraftClient aircraft_client.AircraftValidator, kafkaPublisher kafkaPublisher) *Service {
-	return &Service{Repo: repo, Cache: cache, AircraftClient: aircraftClient, KafkaPublisher: kafkaPublisher}
+	aircraftClient aircraft_client.AircraftLookup) *Service {
+	return &Service{Repo: repo, Cache: cache, AircraftClient: aircraftClient}
+}
+
+func (service *Service) scheduleHorizon() time.Duration {
+	if service.ScheduleHorizon <= 0 {
+		return DefaultScheduleHorizon
+	}
+	return service.ScheduleHorizon
 }
@@ -0,0 +1,41 @@
+package flights
+
+import (
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+// statusTransitions lists, for each status, the statuses a flight may move to next.
+// ARRIVED and CANCELLED are terminal.
+var statusTransitions = map[models.FlightStatus][]models.FlightStatus{
+	models.FlightStatusScheduled: {
+		models.FlightStatusDelayed,
+		models.FlightStatusDeparted,
+		models.FlightStatusCancelled,
+	},
+	models.FlightStatusDelayed: {
+		models.FlightStatusScheduled,
+		models.FlightStatusDeparted,
+		models.FlightStatusCancelled,
+	},
+	models.FlightStatusDeparted: {
+		models.FlightStatusInProgress,
+		models.FlightStatusArrived,
+	},
+	models.FlightStatusInProgress: {
+		models.FlightStatusArrived,
+	},
+	models.FlightStatusArrived:   {},
+	models.FlightStatusCancelled: {},
+}
+
+// validateStatusTransition returns ErrIllegalStatusTransition unless the state machine
+// allows a flight in status from to move to status to.
+func validateStatusTransition(from, to models.FlightStatus) error {
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return exceptions.IllegalStatusTransition(from, to)
+}
@@ -0,0 +1,48 @@
+package flights
+
+import (
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStatusTransition(t *testing.T) {
+	tests := []struct {
+		from    models.FlightStatus
+		to      models.FlightStatus
+		allowed bool
+	}{
+		{models.FlightStatusScheduled, models.FlightStatusDelayed, true},
+		{models.FlightStatusScheduled, models.FlightStatusDeparted, true},
+		{models.FlightStatusScheduled, models.FlightStatusCancelled, true},
+		{models.FlightStatusScheduled, models.FlightStatusArrived, false},
+		{models.FlightStatusScheduled, models.FlightStatusScheduled, false},
+		{models.FlightStatusDelayed, models.FlightStatusScheduled, true},
+		{models.FlightStatusDelayed, models.FlightStatusDeparted, true},
+		{models.FlightStatusDelayed, models.FlightStatusCancelled, true},
+		{models.FlightStatusDeparted, models.FlightStatusInProgress, true},
+		{models.FlightStatusDeparted, models.FlightStatusArrived, true},
+		{models.FlightStatusDeparted, models.FlightStatusCancelled, false},
+		{models.FlightStatusInProgress, models.FlightStatusArrived, true},
+		{models.FlightStatusInProgress, models.FlightStatusDelayed, false},
+		{models.FlightStatusArrived, models.FlightStatusScheduled, false},
+		{models.FlightStatusArrived, models.FlightStatusDeparted, false},
+		{models.FlightStatusCancelled, models.FlightStatusScheduled, false},
+		{models.FlightStatusCancelled, models.FlightStatusDelayed, false},
+		{models.FlightStatusScheduled, models.FlightStatusUnspecified, false},
+		{models.FlightStatusUnspecified, models.FlightStatusScheduled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := validateStatusTransition(tt.from, tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, exceptions.ErrIllegalStatusTransition)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// TransitionFlightStatus moves a flight to a new status if the status state machine
+// allows it, recording the reason and the calling user alongside the change.
+func (service *Service) TransitionFlightStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	status models.FlightStatus,
+	reason string,
+) (*models.Flight, error) {
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := service.Repo.GetFlightByID(ctx, id, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%w: flight with id=%s", exceptions.ErrNotFound, id)
+	}
+
+	if err := validateStatusTransition(current.Status, status); err != nil {
+		logger.WarnContext(ctx, "Rejected flight status transition", "flight_id", id, "from", current.Status, "to", status)
+		return nil, err
+	}
+
+	transition := &models.FlightStatusTransition{
+		ID:         uuid.New(),
+		FlightID:   id,
+		FromStatus: current.Status,
+		ToStatus:   status,
+		ChangedBy:  middleware.GetRequestUserContext(ctx).UserID,
+	}
+	if trimmed := strings.TrimSpace(reason); trimmed != "" {
+		transition.Reason = &trimmed
+	}
+
+	events, err := transitionEvents(ctx, transition)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationStatusChanged, current)
+	if err != nil {
+		return nil, err
+	}
+
+	flight := *current
+	if err := service.Repo.TransitionFlightStatus(ctx, &flight, transition, history, events...); err != nil {
+		logger.ErrorContext(ctx, "Failed to transition flight status", "flight_id", id, "err", err)
+		return nil, err
+	}
+
+	service.notifyUpdates()
+
+	go func(f *models.Flight) {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := service.Cache.SetFlight(bgCtx, f); err != nil {
+			logger.WarnContext(bgCtx, "Failed to cache flight",
+				"flight_id", f.ID, "err", err)
+		}
+	}(&flight)
+
+	logger.InfoContext(ctx, "Flight status changed", "flight_id", id, "from", transition.FromStatus, "to", transition.ToStatus, "changed_by", transition.ChangedBy)
+
+	return &flight, nil
+}
+
+// transitionEvents returns the events recorded for a status change: FlightStatusChanged
+// for every transition, followed by FlightCancelled when the flight is being cancelled.
+func transitionEvents(ctx context.Context, transition *models.FlightStatusTransition) ([]*models.OutboxEvent, error) {
+	eventTypes := []string{models.EventTypeFlightStatusChanged}
+	if transition.ToStatus == models.FlightStatusCancelled {
+		eventTypes = append(eventTypes, models.EventTypeFlightCancelled)
+	}
+
+	events := make([]*models.OutboxEvent, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		event, err := newOutboxEvent(ctx, eventType, transition.FlightID, transition)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,173 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitionFlightStatus(t *testing.T) {
+	flightID := uuid.New()
+	userID := uuid.New()
+	repoErr := errors.New("db failure")
+
+	tests := []struct {
+		name          string
+		currentStatus models.FlightStatus
+		missing       bool
+		status        models.FlightStatus
+		reason        string
+		repoErr       error
+		expectError   error
+		checkRecorded func(t *testing.T, transition *models.FlightStatusTransition)
+	}{
+		{
+			name:          "scheduled to delayed records reason and actor",
+			currentStatus: models.FlightStatusScheduled,
+			status:        models.FlightStatusDelayed,
+			reason:        "  late inbound aircraft ",
+			checkRecorded: func(t *testing.T, transition *models.FlightStatusTransition) {
+				require.NotNil(t, transition.Reason)
+				assert.Equal(t, "late inbound aircraft", *transition.Reason)
+				assert.Equal(t, userID, transition.ChangedBy)
+				assert.Equal(t, models.FlightStatusScheduled, transition.FromStatus)
+				assert.Equal(t, models.FlightStatusDelayed, transition.ToStatus)
+				assert.Equal(t, flightID, transition.FlightID)
+			},
+		},
+		{
+			name:          "blank reason is stored as null",
+			currentStatus: models.FlightStatusDeparted,
+			status:        models.FlightStatusArrived,
+			reason:        "   ",
+			checkRecorded: func(t *testing.T, transition *models.FlightStatusTransition) {
+				assert.Nil(t, transition.Reason)
+			},
+		},
+		{
+			name:          "arrived back to scheduled is rejected",
+			currentStatus: models.FlightStatusArrived,
+			status:        models.FlightStatusScheduled,
+			expectError:   exceptions.ErrIllegalStatusTransition,
+		},
+		{
+			name:          "cancelled is terminal",
+			currentStatus: models.FlightStatusCancelled,
+			status:        models.FlightStatusDelayed,
+			expectError:   exceptions.ErrIllegalStatusTransition,
+		},
+		{
+			name:        "flight not found",
+			missing:     true,
+			status:      models.FlightStatusDelayed,
+			expectError: exceptions.ErrNotFound,
+		},
+		{
+			name:          "repo error",
+			currentStatus: models.FlightStatusScheduled,
+			status:        models.FlightStatusCancelled,
+			repoErr:       repoErr,
+			expectError:   repoErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+			repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+				if tt.missing {
+					return nil, nil
+				}
+				return &models.Flight{ID: flightID, Number: "AA123", Status: tt.currentStatus, Version: 2}, nil
+			}
+
+			var recorded *models.FlightStatusTransition
+			repo.TransitionFn = func(ctx context.Context, f *models.Flight, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				if tt.repoErr != nil {
+					return tt.repoErr
+				}
+				recorded = transition
+				f.Status = transition.ToStatus
+				f.Version++
+				return nil
+			}
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: userID, OrgID: testOrgID})
+
+			flight, err := svc.TransitionFlightStatus(ctx, flightID, tt.status, tt.reason)
+
+			if tt.expectError != nil {
+				assert.Nil(t, flight)
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, flight.Status)
+			assert.Equal(t, int32(3), flight.Version)
+			require.NotNil(t, recorded)
+			tt.checkRecorded(t, recorded)
+		})
+	}
+}
+
+func TestTransitionFlightStatusWritesOutboxEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   models.FlightStatus
+		expected []string
+	}{
+		{
+			name:     "delay records a status change",
+			status:   models.FlightStatusDelayed,
+			expected: []string{models.EventTypeFlightStatusChanged},
+		},
+		{
+			name:     "cancellation also records a cancellation",
+			status:   models.FlightStatusCancelled,
+			expected: []string{models.EventTypeFlightStatusChanged, models.EventTypeFlightCancelled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flightID := uuid.New()
+			repo, cache, aircraft := defaultTestDeps()
+			repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+				return &models.Flight{ID: flightID, Status: models.FlightStatusScheduled}, nil
+			}
+
+			var written []*models.OutboxEvent
+			repo.TransitionFn = func(ctx context.Context, f *models.Flight, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+				written = events
+				return nil
+			}
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			_, err := svc.TransitionFlightStatus(orgContext(testOrgID), flightID, tt.status, "weather")
+			require.NoError(t, err)
+
+			require.Len(t, written, len(tt.expected))
+			for i, eventType := range tt.expected {
+				assert.Equal(t, eventType, written[i].EventType)
+				assert.Equal(t, flightID, written[i].AggregateID)
+
+				var payload models.FlightStatusTransition
+				require.NoError(t, json.Unmarshal(written[i].Payload, &payload))
+				assert.Equal(t, tt.status, payload.ToStatus)
+				require.NotNil(t, payload.Reason)
+				assert.Equal(t, "weather", *payload.Reason)
+			}
+		})
+	}
+}
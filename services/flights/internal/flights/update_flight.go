@@ -0,0 +1,105 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// UpdateFlight applies a partial update to a flight. The stored flight is read from the
+// database rather than the cache so the version check is made against the latest row.
+func (service *Service) UpdateFlight(ctx context.Context, id uuid.UUID, update models.FlightUpdate) (*models.Flight, error) {
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := service.Repo.GetFlightByID(ctx, id, orgID, false)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%w: flight with id=%s", exceptions.ErrNotFound, id)
+	}
+
+	if current.Version != update.Version {
+		return nil, fmt.Errorf("%w: id=%s expected version=%d, current version=%d",
+			exceptions.ErrVersionConflict, id, update.Version, current.Version)
+	}
+
+	flight := *current
+	if update.Number != nil {
+		flight.Number = *update.Number
+	}
+	if update.Origin != nil {
+		flight.Origin = *update.Origin
+	}
+	if update.Destination != nil {
+		flight.Destination = *update.Destination
+	}
+	if update.DepartureTime != nil {
+		flight.DepartureTime = *update.DepartureTime
+	}
+	if update.ArrivalTime != nil {
+		flight.ArrivalTime = *update.ArrivalTime
+	}
+	if update.AircraftID != nil {
+		flight.AircraftID = *update.AircraftID
+	}
+
+	flight.Number, flight.Origin, flight.Destination, err = validateFlightDetails(
+		flight.Number, flight.Origin, flight.Destination, flight.DepartureTime, flight.ArrivalTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// A flight whose aircraft was released keeps none until one is assigned.
+	if flight.AircraftID != uuid.Nil {
+		if err := service.validateAircraft(ctx, flight.AircraftID); err != nil {
+			return nil, err
+		}
+	}
+
+	flight.LastUpdatedBy = middleware.GetRequestUserContext(ctx).UserID
+
+	// The repository only applies the update at update.Version, so a committed event
+	// always describes the row at the next version.
+	updated := flight
+	updated.Version = update.Version + 1
+	event, err := newOutboxEvent(ctx, models.EventTypeFlightUpdated, flight.ID, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := newHistoryEntry(ctx, models.FlightHistoryOperationUpdated, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.Repo.UpdateFlight(ctx, &flight, update.Version, history, event); err != nil {
+		logger.ErrorContext(ctx, "Failed to update flight in database", "flight_id", flight.ID, "err", err)
+		return nil, err
+	}
+
+	service.notifyUpdates()
+
+	go func(f *models.Flight) {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := service.Cache.SetFlight(bgCtx, f); err != nil {
+			logger.WarnContext(bgCtx, "Failed to cache flight",
+				"flight_id", f.ID, "err", err)
+		}
+	}(&flight)
+
+	logger.InfoContext(ctx, "Flight updated", "flight_id", flight.ID, "number", flight.Number, "origin", flight.Origin, "destination", flight.Destination, "departure_time", flight.DepartureTime, "arrival_time", flight.ArrivalTime, "aircraft_id", flight.AircraftID, "version", flight.Version)
+
+	return &flight, nil
+}
@@ -0,0 +1,239 @@
+package flights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateFlight(t *testing.T) {
+	dep := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	arr := dep.Add(2 * time.Hour)
+	flightID := uuid.New()
+	newAircraftID := uuid.New()
+	userID := uuid.New()
+
+	storedFlight := func() *models.Flight {
+		return &models.Flight{
+			ID:            flightID,
+			Number:        "AA123",
+			Origin:        "JFK",
+			Destination:   "LHR",
+			DepartureTime: dep,
+			ArrivalTime:   arr,
+			Status:        models.FlightStatusScheduled,
+			AircraftID:    uuid.New(),
+			Version:       4,
+		}
+	}
+
+	strPtr := func(s string) *string { return &s }
+	timePtr := func(t time.Time) *time.Time { return &t }
+
+	repoErr := errors.New("db failure")
+	aircraftErr := errors.New("aircraft not found")
+
+	tests := []struct {
+		name        string
+		update      models.FlightUpdate
+		setup       func(r *FakeRepo, a *FakeAircraftClient)
+		expectError error
+		check       func(t *testing.T, flight *models.Flight)
+	}{
+		{
+			name: "updates route, times and aircraft",
+			update: models.FlightUpdate{
+				Origin:        strPtr("cdg"),
+				DepartureTime: timePtr(dep.Add(time.Hour)),
+				ArrivalTime:   timePtr(arr.Add(time.Hour)),
+				AircraftID:    &newAircraftID,
+				Version:       4,
+			},
+			setup: func(r *FakeRepo, _ *FakeAircraftClient) {
+				r.UpdateFlightFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+					assert.Equal(t, int32(4), expectedVersion)
+					f.Version = expectedVersion + 1
+					return nil
+				}
+			},
+			check: func(t *testing.T, flight *models.Flight) {
+				assert.Equal(t, "CDG", flight.Origin)
+				assert.Equal(t, "LHR", flight.Destination)
+				assert.Equal(t, "AA123", flight.Number)
+				assert.Equal(t, dep.Add(time.Hour), flight.DepartureTime)
+				assert.Equal(t, newAircraftID, flight.AircraftID)
+				assert.Equal(t, userID, flight.LastUpdatedBy)
+				assert.Equal(t, int32(5), flight.Version)
+			},
+		},
+		{
+			name:        "flight not found",
+			update:      models.FlightUpdate{Version: 4},
+			setup:       func(r *FakeRepo, _ *FakeAircraftClient) { r.GetFlightFn = nil },
+			expectError: exceptions.ErrNotFound,
+		},
+		{
+			name:        "stale version",
+			update:      models.FlightUpdate{Number: strPtr("AA124"), Version: 3},
+			setup:       func(_ *FakeRepo, _ *FakeAircraftClient) {},
+			expectError: exceptions.ErrVersionConflict,
+		},
+		{
+			name:        "arrival moved before departure",
+			update:      models.FlightUpdate{ArrivalTime: timePtr(dep.Add(-time.Hour)), Version: 4},
+			setup:       func(_ *FakeRepo, _ *FakeAircraftClient) {},
+			expectError: exceptions.ErrInvalidTimes,
+		},
+		{
+			name:        "invalid flight number",
+			update:      models.FlightUpdate{Number: strPtr("123"), Version: 4},
+			setup:       func(_ *FakeRepo, _ *FakeAircraftClient) {},
+			expectError: exceptions.ErrInvalidFlightNumber,
+		},
+		{
+			name:        "destination matches origin",
+			update:      models.FlightUpdate{Destination: strPtr("JFK"), Version: 4},
+			setup:       func(_ *FakeRepo, _ *FakeAircraftClient) {},
+			expectError: exceptions.ErrSameOriginAndDestination,
+		},
+		{
+			name:   "aircraft validation error",
+			update: models.FlightUpdate{AircraftID: &newAircraftID, Version: 4},
+			setup: func(_ *FakeRepo, a *FakeAircraftClient) {
+				a.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+					return nil, aircraftErr
+				}
+			},
+			expectError: aircraftErr,
+		},
+		{
+			name:   "concurrent write detected by repository",
+			update: models.FlightUpdate{Version: 4},
+			setup: func(r *FakeRepo, _ *FakeAircraftClient) {
+				r.UpdateFlightFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+					return exceptions.ErrVersionConflict
+				}
+			},
+			expectError: exceptions.ErrVersionConflict,
+		},
+		{
+			name:   "repo error",
+			update: models.FlightUpdate{Version: 4},
+			setup: func(r *FakeRepo, _ *FakeAircraftClient) {
+				r.UpdateFlightFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+					return repoErr
+				}
+			},
+			expectError: repoErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+			repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+				return storedFlight(), nil
+			}
+			tt.setup(repo, aircraft)
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: userID, OrgID: testOrgID})
+
+			flight, err := svc.UpdateFlight(ctx, flightID, tt.update)
+
+			if tt.expectError != nil {
+				assert.Nil(t, flight)
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, flight)
+		})
+	}
+}
+
+func TestUpdateFlightWritesOutboxEvent(t *testing.T) {
+	flightID := uuid.New()
+	dep := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	repo, cache, aircraft := defaultTestDeps()
+	repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+		return &models.Flight{
+			ID:            flightID,
+			Number:        "AA123",
+			Origin:        "JFK",
+			Destination:   "LHR",
+			DepartureTime: dep,
+			ArrivalTime:   dep.Add(7 * time.Hour),
+			Version:       4,
+		}, nil
+	}
+
+	var written []*models.OutboxEvent
+	repo.UpdateFlightFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+		written = events
+		return nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+	number := "AA999"
+	_, err := svc.UpdateFlight(orgContext(testOrgID), flightID, models.FlightUpdate{Number: &number, Version: 4})
+	require.NoError(t, err)
+
+	require.Len(t, written, 1)
+	assert.Equal(t, models.EventTypeFlightUpdated, written[0].EventType)
+	assert.Equal(t, flightID, written[0].AggregateID)
+
+	var payload models.Flight
+	require.NoError(t, json.Unmarshal(written[0].Payload, &payload))
+	assert.Equal(t, "AA999", payload.Number)
+	assert.Equal(t, int32(5), payload.Version)
+}
+
+func TestUpdateFlightWithoutAircraft(t *testing.T) {
+	flightID := uuid.New()
+	dep := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	// A flight left without an aircraft when its aircraft was released.
+	repo, cache, aircraft := defaultTestDeps()
+	repo.GetFlightFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+		return &models.Flight{
+			ID:            flightID,
+			Number:        "AA123",
+			Origin:        "JFK",
+			Destination:   "LHR",
+			DepartureTime: dep,
+			ArrivalTime:   dep.Add(7 * time.Hour),
+			Version:       4,
+		}, nil
+	}
+	aircraft.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+		t.Errorf("aircraft %s should not be looked up", id)
+		return nil, exceptions.AircraftNotFound(id)
+	}
+	repo.UpdateFlightFn = func(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+		assert.Equal(t, uuid.Nil, f.AircraftID)
+		f.Version = expectedVersion + 1
+		return nil
+	}
+
+	svc := NewFlightsService(repo, cache, aircraft)
+	number := "AA124"
+	flight, err := svc.UpdateFlight(orgContext(testOrgID), flightID, models.FlightUpdate{Number: &number, Version: 4})
+
+	require.NoError(t, err)
+	assert.Equal(t, "AA124", flight.Number)
+	assert.Equal(t, uuid.Nil, flight.AircraftID)
+	assert.Equal(t, int32(5), flight.Version)
+}
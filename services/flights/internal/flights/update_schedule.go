@@ -0,0 +1,96 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// UpdateSchedule applies a partial update to a schedule and propagates it to the
+// schedule's flights that have not yet departed: each is moved to the new pattern, or
+// deleted if the schedule no longer operates on its day, and new days are
+// materialized. As with CreateSchedule, a failure to propagate is only logged and is
+// retried by the schedule materializer.
+func (service *Service) UpdateSchedule(ctx context.Context, id uuid.UUID, update models.ScheduleUpdate) (*models.Schedule, error) {
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := service.Repo.GetScheduleByID(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("%w: schedule with id=%s", exceptions.ErrNotFound, id)
+	}
+
+	if current.Version != update.Version {
+		return nil, fmt.Errorf("%w: schedule id=%s expected version=%d, current version=%d",
+			exceptions.ErrVersionConflict, id, update.Version, current.Version)
+	}
+
+	schedule := *current
+	if update.Number != nil {
+		schedule.Number = *update.Number
+	}
+	if update.Origin != nil {
+		schedule.Origin = *update.Origin
+	}
+	if update.Destination != nil {
+		schedule.Destination = *update.Destination
+	}
+	if update.DepartureLocalTime != nil {
+		schedule.DepartureLocalTime = *update.DepartureLocalTime
+	}
+	if update.TimeZone != nil {
+		schedule.TimeZone = *update.TimeZone
+	}
+	if update.DurationMinutes != nil {
+		schedule.DurationMinutes = *update.DurationMinutes
+	}
+	if update.DaysOfWeek != nil {
+		schedule.DaysOfWeek = *update.DaysOfWeek
+	}
+	if update.StartDate != nil {
+		schedule.StartDate = *update.StartDate
+	}
+	if update.EndDate != nil {
+		schedule.EndDate = *update.EndDate
+	}
+	if update.AircraftID != nil {
+		schedule.AircraftID = *update.AircraftID
+	}
+
+	if _, err := validateSchedule(&schedule); err != nil {
+		return nil, err
+	}
+
+	// A schedule whose aircraft was released has none until it is given another.
+	if schedule.AircraftID != uuid.Nil {
+		if err := service.validateAircraft(ctx, schedule.AircraftID); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule.LastUpdatedBy = middleware.GetRequestUserContext(ctx).UserID
+
+	if err := service.Repo.UpdateSchedule(ctx, &schedule, update.Version); err != nil {
+		logger.ErrorContext(ctx, "Failed to update schedule in database", "schedule_id", id, "err", err)
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Schedule updated", "schedule_id", schedule.ID, "number", schedule.Number, "departure_local_time", schedule.DepartureLocalTime, "time_zone", schedule.TimeZone, "start_date", schedule.StartDate, "end_date", schedule.EndDate, "version", schedule.Version)
+
+	if err := service.materializeSchedule(ctx, &schedule, time.Now()); err != nil {
+		logger.WarnContext(ctx, "Failed to propagate schedule update", "schedule_id", schedule.ID, "err", err)
+	}
+
+	return &schedule, nil
+}
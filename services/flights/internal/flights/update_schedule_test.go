@@ -0,0 +1,131 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSchedule(t *testing.T) {
+	scheduleID := uuid.New()
+	userID := uuid.New()
+	today := calendarDate(time.Now())
+	repoErr := errors.New("db failure")
+
+	storedSchedule := func() *models.Schedule {
+		return &models.Schedule{
+			ID:                 scheduleID,
+			Number:             "BA117",
+			Origin:             "LHR",
+			Destination:        "JFK",
+			DepartureLocalTime: "08:25",
+			TimeZone:           "Europe/London",
+			DurationMinutes:    480,
+			DaysOfWeek:         weekdaysMonToFri,
+			StartDate:          today,
+			EndDate:            today.AddDate(0, 1, 0),
+			AircraftID:         uuid.New(),
+			OrganizationID:     testOrgID,
+			Version:            2,
+		}
+	}
+
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		update      models.ScheduleUpdate
+		setup       func(r *FakeRepo)
+		expectError error
+		check       func(t *testing.T, schedule *models.Schedule)
+	}{
+		{
+			name:   "updates departure time and propagates",
+			update: models.ScheduleUpdate{DepartureLocalTime: strPtr("09:10"), Version: 2},
+			setup: func(r *FakeRepo) {
+				r.UpdateScheduleFn = func(ctx context.Context, s *models.Schedule, expectedVersion int32) error {
+					assert.Equal(t, int32(2), expectedVersion)
+					s.Version = expectedVersion + 1
+					return nil
+				}
+				r.ListStaleFn = func(ctx context.Context, id uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error) {
+					assert.Equal(t, scheduleID, id)
+					assert.Equal(t, int32(3), scheduleVersion)
+					return nil, nil
+				}
+			},
+			check: func(t *testing.T, schedule *models.Schedule) {
+				assert.Equal(t, "09:10", schedule.DepartureLocalTime)
+				assert.Equal(t, userID, schedule.LastUpdatedBy)
+				assert.Equal(t, int32(3), schedule.Version)
+			},
+		},
+		{
+			name: "schedule not found",
+			setup: func(r *FakeRepo) {
+				r.GetScheduleFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID) (*models.Schedule, error) {
+					return nil, exceptions.ErrNotFound
+				}
+			},
+			update:      models.ScheduleUpdate{Version: 2},
+			expectError: exceptions.ErrNotFound,
+		},
+		{
+			name:        "stale version",
+			update:      models.ScheduleUpdate{Version: 1},
+			expectError: exceptions.ErrVersionConflict,
+		},
+		{
+			name:        "invalid change",
+			update:      models.ScheduleUpdate{TimeZone: strPtr("Nowhere/Special"), Version: 2},
+			expectError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:   "repo error",
+			update: models.ScheduleUpdate{Version: 2},
+			setup: func(r *FakeRepo) {
+				r.UpdateScheduleFn = func(ctx context.Context, s *models.Schedule, expectedVersion int32) error {
+					return repoErr
+				}
+			},
+			expectError: repoErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cache, aircraft := defaultTestDeps()
+			repo.GetScheduleFn = func(ctx context.Context, id uuid.UUID, orgID *uuid.UUID) (*models.Schedule, error) {
+				require.NotNil(t, orgID)
+				assert.Equal(t, testOrgID, *orgID)
+				return storedSchedule(), nil
+			}
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			svc := NewFlightsService(repo, cache, aircraft)
+			ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{UserID: userID, OrgID: testOrgID})
+
+			schedule, err := svc.UpdateSchedule(ctx, scheduleID, tt.update)
+
+			if tt.expectError != nil {
+				assert.Nil(t, schedule)
+				assert.ErrorIs(t, err, tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, schedule)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package flights
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// validateAircraft looks up aircraftID and checks that the caller may assign it to
+// flights, as checkAircraftAssignable does.
+func (service *Service) validateAircraft(ctx context.Context, aircraftID uuid.UUID) error {
+	aircraft, err := service.AircraftClient.GetAircraft(ctx, aircraftID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to look up aircraft", "aircraft_id", aircraftID, "err", err)
+		return err
+	}
+
+	if err := checkAircraftAssignable(ctx, aircraft); err != nil {
+		logger.InfoContext(ctx, "Aircraft cannot be assigned", "aircraft_id", aircraftID, "status", aircraft.Status, "err", err)
+		return err
+	}
+	return nil
+}
+
+// checkAircraftAssignable rejects an aircraft that is in maintenance or grounded, that
+// has no seats, or that is registered to an airline other than the caller's
+// organization. Aircraft with no airline recorded, or callers without an organization
+// name, skip the airline check.
+func checkAircraftAssignable(ctx context.Context, aircraft *models.Aircraft) error {
+	switch aircraft.Status {
+	case models.AircraftStatusMaintenance:
+		return exceptions.AircraftInMaintenance(aircraft.ID)
+	case models.AircraftStatusGrounded:
+		return exceptions.AircraftGrounded(aircraft.ID)
+	}
+
+	if aircraft.Capacity <= 0 {
+		return exceptions.AircraftNoCapacity(aircraft.ID)
+	}
+
+	airline := middleware.GetRequestUserContext(ctx).OrgName
+	if aircraft.Airline != "" && airline != "" && aircraft.Airline != airline {
+		return exceptions.AircraftWrongAirline(aircraft.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAircraftAssignable(t *testing.T) {
+	ctx := middleware.SetUserContextInContext(context.Background(), &userContext.UserContext{
+		UserID:  uuid.New(),
+		OrgID:   testOrgID,
+		OrgName: "British Airways",
+	})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		modify      func(a *models.Aircraft)
+		expectError error
+	}{
+		{
+			name:   "available",
+			ctx:    ctx,
+			modify: func(a *models.Aircraft) { a.Airline = "British Airways" },
+		},
+		{
+			name:   "in service",
+			ctx:    ctx,
+			modify: func(a *models.Aircraft) { a.Status = models.AircraftStatusInService },
+		},
+		{
+			name:        "in maintenance",
+			ctx:         ctx,
+			modify:      func(a *models.Aircraft) { a.Status = models.AircraftStatusMaintenance },
+			expectError: exceptions.ErrAircraftInMaintenance,
+		},
+		{
+			name:        "grounded",
+			ctx:         ctx,
+			modify:      func(a *models.Aircraft) { a.Status = models.AircraftStatusGrounded },
+			expectError: exceptions.ErrAircraftGrounded,
+		},
+		{
+			name:        "no seats",
+			ctx:         ctx,
+			modify:      func(a *models.Aircraft) { a.Capacity = 0 },
+			expectError: exceptions.ErrAircraftNoCapacity,
+		},
+		{
+			name:        "other airline",
+			ctx:         ctx,
+			modify:      func(a *models.Aircraft) { a.Airline = "Virgin Atlantic" },
+			expectError: exceptions.ErrAircraftWrongAirline,
+		},
+		{
+			name:   "caller without an organization name",
+			ctx:    context.Background(),
+			modify: func(a *models.Aircraft) { a.Airline = "Virgin Atlantic" },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			aircraft := testAircraft(uuid.New())
+			tc.modify(aircraft)
+
+			err := checkAircraftAssignable(tc.ctx, aircraft)
+
+			if tc.expectError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectError)
+			assert.Contains(t, err.Error(), aircraft.ID.String())
+		})
+	}
+}
+
+func TestCreateFlightRejectsUnassignableAircraft(t *testing.T) {
+	repo, cache, aircraft := defaultTestDeps()
+	aircraft.GetAircraftFn = func(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+		a := testAircraft(id)
+		a.Status = models.AircraftStatusMaintenance
+		return a, nil
+	}
+	repo.CreateFlightFn = func(ctx context.Context, f *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+		return errors.New("should not be stored")
+	}
+
+	departure := time.Now().Add(time.Hour)
+	service := NewFlightsService(repo, cache, aircraft)
+	flight, err := service.CreateFlight(orgContext(testOrgID), "BA117", "LHR", "JFK", departure, departure.Add(8*time.Hour), uuid.New())
+
+	assert.ErrorIs(t, err, exceptions.ErrAircraftInMaintenance)
+	assert.Nil(t, flight)
+}
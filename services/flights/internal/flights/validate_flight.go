@@ -0,0 +1,40 @@
+package flights
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/validation/flight_number"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/validation/iata_codes"
+)
+
+// validateFlightDetails applies the schedule and identifier rules shared by every
+// write path and returns the normalized flight number, origin and destination.
+func validateFlightDetails(number, origin, destination string, departure, arrival time.Time) (string, string, string, error) {
+	if !arrival.After(departure) {
+		return "", "", "", fmt.Errorf("%w: departure=%v, arrival=%v",
+			exceptions.ErrInvalidTimes, departure, arrival)
+	}
+
+	normalizedNumber, err := flight_number.ValidateAndNormalizeFlightNumber(number)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	normalizedOrigin, err := iata_codes.ValidateAndNormalizeIATACode(origin)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	normalizedDestination, err := iata_codes.ValidateAndNormalizeIATACode(destination)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if normalizedOrigin == normalizedDestination {
+		return "", "", "", exceptions.ErrSameOriginAndDestination
+	}
+
+	return normalizedNumber, normalizedOrigin, normalizedDestination, nil
+}
@@ -0,0 +1,74 @@
+package flights
+
+import (
+	"fmt"
+	"time"
+	// Embedded so schedule time zones resolve in images without a zoneinfo database.
+	_ "time/tzdata"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+const (
+	// maxScheduleDuration is the longest block time a schedule may have.
+	maxScheduleDuration = 24 * time.Hour
+	// maxSchedulePeriod is the longest period a single schedule may cover.
+	maxSchedulePeriod = 366 * 24 * time.Hour
+)
+
+// validateSchedule applies the rules of validateFlightDetails to s along with the
+// schedule's own, normalizing s in place: identifiers as for a flight, the departure
+// time to HH:MM and the dates to midnight UTC. It returns the schedule's time zone.
+func validateSchedule(s *models.Schedule) (*time.Location, error) {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil || s.TimeZone == "" || s.TimeZone == "Local" {
+		return nil, fmt.Errorf("%w: unknown time zone %q", exceptions.ErrInvalidInput, s.TimeZone)
+	}
+
+	clock, err := time.Parse("15:04", s.DepartureLocalTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: departure time %q must be HH:MM", exceptions.ErrInvalidInput, s.DepartureLocalTime)
+	}
+	s.DepartureLocalTime = clock.Format("15:04")
+
+	duration := time.Duration(s.DurationMinutes) * time.Minute
+	if duration <= 0 || duration > maxScheduleDuration {
+		return nil, fmt.Errorf("%w: duration must be between 1 and %d minutes", exceptions.ErrInvalidInput, int(maxScheduleDuration.Minutes()))
+	}
+
+	if s.DaysOfWeek <= 0 || s.DaysOfWeek > models.AllWeekdays {
+		return nil, fmt.Errorf("%w: at least one day of the week is required", exceptions.ErrInvalidInput)
+	}
+
+	s.StartDate = calendarDate(s.StartDate)
+	s.EndDate = calendarDate(s.EndDate)
+	if s.EndDate.Before(s.StartDate) {
+		return nil, fmt.Errorf("%w: schedule ends before it starts", exceptions.ErrInvalidInput)
+	}
+	if s.EndDate.Sub(s.StartDate) >= maxSchedulePeriod {
+		return nil, fmt.Errorf("%w: a schedule may cover at most %d days", exceptions.ErrInvalidInput, int(maxSchedulePeriod.Hours()/24))
+	}
+
+	departure, arrival := scheduledTimes(s, location, s.StartDate)
+	s.Number, s.Origin, s.Destination, err = validateFlightDetails(s.Number, s.Origin, s.Destination, departure, arrival)
+	if err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// scheduledTimes returns the departure and arrival of s on the operating day, whose
+// date is read as a calendar date. A departure on a wall clock time that a daylight
+// saving change skips or repeats is resolved as time.Date resolves it.
+func scheduledTimes(s *models.Schedule, location *time.Location, day time.Time) (time.Time, time.Time) {
+	clock, _ := time.Parse("15:04", s.DepartureLocalTime)
+	departure := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, location)
+	return departure, departure.Add(time.Duration(s.DurationMinutes) * time.Minute)
+}
+
+// calendarDate returns the date of t as midnight UTC.
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,275 @@
+package flights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/google/uuid"
+)
+
+// watchSnapshotLookback is how long before the watch starts the snapshot of a route
+// or airline begins, so that flights still in the air are included.
+const watchSnapshotLookback = 24 * time.Hour
+
+// WatchFlights sends the caller organization's flights selected by watch, and then
+// every change to them, until ctx is done or send fails. A new watch starts with a
+// snapshot of the matching flights, ended by a SNAPSHOT_COMPLETE event; a resumed
+// watch replays the changes it missed instead. A flight that stops matching is sent
+// once more, with its new state, if it was sent earlier on the same watch. A resumed
+// watch cannot tell which flights were sent before it, so the first change it sees
+// to a flight that no longer matches is sent as well, unless the flight has never
+// had another state.
+func (service *Service) WatchFlights(ctx context.Context, watch models.FlightWatch, send func(*models.FlightEvent) error) error {
+	if service.Updates == nil {
+		return errUpdatesNotConfigured
+	}
+
+	watch, err := normalizeFlightWatch(watch)
+	if err != nil {
+		return err
+	}
+
+	orgID, err := organizationScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Subscribing before the snapshot or replay is read means no change made in
+	// between is missed; changes that were already sent are skipped by sequence.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	updates := service.Updates.Subscribe(ctx, orgID)
+
+	watcher := newFlightWatcher(watch, send)
+	if watch.AfterSequence != nil {
+		err = service.replayWatch(ctx, watcher, orgID, *watch.AfterSequence)
+	} else {
+		err = service.snapshotWatch(ctx, watcher, orgID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case change, ok := <-updates:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return fmt.Errorf("%w: resume after sequence %d", exceptions.ErrUpdatesInterrupted, watcher.last)
+			}
+			if err := watcher.forward(change); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// normalizeFlightWatch validates the watch the same way a listing filter is validated.
+func normalizeFlightWatch(watch models.FlightWatch) (models.FlightWatch, error) {
+	if len(watch.FlightIDs) > pagination.MaxPageSize {
+		return watch, fmt.Errorf("%w: at most %d flight IDs can be watched", exceptions.ErrInvalidInput, pagination.MaxPageSize)
+	}
+
+	filter, err := normalizeFlightFilter(models.FlightFilter{
+		Origin:      watch.Origin,
+		Destination: watch.Destination,
+		Airline:     watch.Airline,
+	})
+	if err != nil {
+		return watch, err
+	}
+
+	watch.Origin = filter.Origin
+	watch.Destination = filter.Destination
+	watch.Airline = filter.Airline
+	return watch, nil
+}
+
+// snapshotWatch sends the flights that currently match, then a SNAPSHOT_COMPLETE
+// event carrying the sequence the watch continues from. A change published while
+// the snapshot is read may be sent again as an update.
+func (service *Service) snapshotWatch(ctx context.Context, watcher *flightWatcher, orgID *uuid.UUID) error {
+	head, err := service.Updates.Head(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(watcher.watch.FlightIDs) > 0 {
+		flights, err := service.Repo.GetFlightsByIDs(ctx, watcher.watch.FlightIDs, orgID)
+		if err != nil {
+			return err
+		}
+		byID := make(map[uuid.UUID]*models.Flight, len(flights))
+		for _, flight := range flights {
+			byID[flight.ID] = flight
+		}
+		for _, id := range watcher.watch.FlightIDs {
+			if flight, ok := byID[id]; ok {
+				if err := watcher.snapshot(flight); err != nil {
+					return err
+				}
+				// A repeated ID is only sent once.
+				delete(byID, id)
+			}
+		}
+	} else {
+		departureFrom := time.Now().Add(-watchSnapshotLookback)
+		filter := models.FlightFilter{
+			Origin:         watcher.watch.Origin,
+			Destination:    watcher.watch.Destination,
+			Airline:        watcher.watch.Airline,
+			DepartureFrom:  &departureFrom,
+			OrganizationID: orgID,
+		}
+
+		var cursor *pagination.Cursor
+		for {
+			flights, err := service.Repo.ListFlights(ctx, filter, pagination.MaxPageSize, cursor)
+			if err != nil {
+				return err
+			}
+			for _, flight := range flights {
+				if err := watcher.snapshot(flight); err != nil {
+					return err
+				}
+			}
+			if len(flights) < pagination.MaxPageSize {
+				break
+			}
+			last := flights[len(flights)-1]
+			cursor = &pagination.Cursor{Time: last.DepartureTime, ID: last.ID}
+		}
+	}
+
+	watcher.last = head
+	return watcher.send(&models.FlightEvent{Type: models.FlightEventSnapshotComplete, Sequence: head})
+}
+
+// replayWatch sends the changes published after the sequence a watch resumes from.
+func (service *Service) replayWatch(ctx context.Context, watcher *flightWatcher, orgID *uuid.UUID, after uint64) error {
+	changes, err := service.Updates.Replay(ctx, orgID, after)
+	if err != nil {
+		return err
+	}
+
+	watcher.last = after
+	watcher.seen = make(map[uuid.UUID]struct{})
+	for _, change := range changes {
+		if err := watcher.forward(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flightWatcher decides which changes a watch sends. It is only used by the goroutine
+// serving the watch.
+type flightWatcher struct {
+	watch models.FlightWatch
+	ids   map[uuid.UUID]struct{}
+	send  func(*models.FlightEvent) error
+	// last is the sequence of the latest change the watch has seen.
+	last uint64
+	// sent holds the flights sent while they matched.
+	sent map[uuid.UUID]struct{}
+	// seen holds the flights a resumed watch has had a change to; it is nil for a
+	// watch that started with a snapshot, which knows every flight it sent.
+	seen map[uuid.UUID]struct{}
+}
+
+func newFlightWatcher(watch models.FlightWatch, send func(*models.FlightEvent) error) *flightWatcher {
+	watcher := &flightWatcher{watch: watch, send: send, sent: make(map[uuid.UUID]struct{})}
+	if len(watch.FlightIDs) > 0 {
+		watcher.ids = make(map[uuid.UUID]struct{}, len(watch.FlightIDs))
+		for _, id := range watch.FlightIDs {
+			watcher.ids[id] = struct{}{}
+		}
+	}
+	return watcher
+}
+
+func (w *flightWatcher) matches(flight *models.Flight) bool {
+	if w.ids != nil {
+		if _, ok := w.ids[flight.ID]; !ok {
+			return false
+		}
+	}
+	if w.watch.Origin != nil && flight.Origin != *w.watch.Origin {
+		return false
+	}
+	if w.watch.Destination != nil && flight.Destination != *w.watch.Destination {
+		return false
+	}
+	if w.watch.Airline != nil && flight.Airline != *w.watch.Airline {
+		return false
+	}
+	return true
+}
+
+// mayHaveSent reports whether flight could have been sent by the watch that was
+// resumed, which is the case until a change to it is seen unless it is at its first
+// version, the only state it has had.
+func (w *flightWatcher) mayHaveSent(flight *models.Flight) bool {
+	if w.seen == nil || flight.Version <= 1 {
+		return false
+	}
+	if _, ok := w.seen[flight.ID]; ok {
+		return false
+	}
+	if w.ids != nil {
+		if _, ok := w.ids[flight.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *flightWatcher) snapshot(flight *models.Flight) error {
+	if !w.matches(flight) {
+		return nil
+	}
+	w.sent[flight.ID] = struct{}{}
+	return w.send(&models.FlightEvent{Type: models.FlightEventSnapshot, Flight: flight})
+}
+
+// forward sends change if it is new to the watch and concerns a flight the watch
+// follows or has sent.
+func (w *flightWatcher) forward(change *models.FlightChange) error {
+	if change.Sequence <= w.last {
+		return nil
+	}
+	w.last = change.Sequence
+
+	flight := change.Flight
+	matches := w.matches(flight)
+	_, wasSent := w.sent[flight.ID]
+	if !wasSent {
+		wasSent = w.mayHaveSent(flight)
+	}
+	if w.seen != nil {
+		w.seen[flight.ID] = struct{}{}
+	}
+
+	eventType := models.FlightEventUpdated
+	switch {
+	case flight.DeletedAt != nil && (matches || wasSent):
+		eventType = models.FlightEventDeleted
+		delete(w.sent, flight.ID)
+	case matches:
+		w.sent[flight.ID] = struct{}{}
+	case wasSent:
+		delete(w.sent, flight.ID)
+	default:
+		return nil
+	}
+
+	return w.send(&models.FlightEvent{Type: eventType, Sequence: change.Sequence, Flight: flight})
+}
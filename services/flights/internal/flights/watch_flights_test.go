@@ -0,0 +1,264 @@
+package flights
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pubsub"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startWatch runs WatchFlights in the background, returning the events it sends and
+// the error it ends with.
+func startWatch(ctx context.Context, service *Service, watch models.FlightWatch) (<-chan *models.FlightEvent, <-chan error) {
+	events := make(chan *models.FlightEvent, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- service.WatchFlights(ctx, watch, func(event *models.FlightEvent) error {
+			events <- event
+			return nil
+		})
+	}()
+	return events, done
+}
+
+// nextEvent returns the next event of a watch, failing the test if none arrives.
+func nextEvent(t *testing.T, events <-chan *models.FlightEvent) *models.FlightEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return nil
+	}
+}
+
+func watchResult(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("watch did not end")
+		return nil
+	}
+}
+
+func TestWatchFlights(t *testing.T) {
+	onRoute := func(origin string) *models.Flight {
+		return &models.Flight{ID: uuid.New(), Origin: origin, Destination: "JFK", OrganizationID: testOrgID}
+	}
+
+	t.Run("Sends A Snapshot And Then Changes To Matching Flights", func(t *testing.T) {
+		snapshot := onRoute("LHR")
+		repo := &FakeRepo{
+			ListFlightsFn: func(ctx context.Context, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+				assert.Equal(t, "LHR", *filter.Origin)
+				assert.Equal(t, testOrgID, *filter.OrganizationID)
+				require.NotNil(t, filter.DepartureFrom)
+				assert.WithinDuration(t, time.Now().Add(-watchSnapshotLookback), *filter.DepartureFrom, time.Minute)
+				return []*models.Flight{snapshot}, nil
+			},
+		}
+		service := newUpdatesService(repo)
+		ctx, cancel := context.WithCancel(orgContext(testOrgID))
+		defer cancel()
+
+		origin := "lhr"
+		events, _ := startWatch(ctx, service, models.FlightWatch{Origin: &origin})
+
+		first := nextEvent(t, events)
+		assert.Equal(t, models.FlightEventSnapshot, first.Type)
+		assert.Equal(t, snapshot.ID, first.Flight.ID)
+		assert.Zero(t, first.Sequence)
+		complete := nextEvent(t, events)
+		assert.Equal(t, models.FlightEventSnapshotComplete, complete.Type)
+		assert.Zero(t, complete.Sequence)
+
+		moved := *snapshot
+		moved.Origin = "CDG"
+		added := onRoute("LHR")
+		deleted := *added
+		deletedAt := time.Now()
+		deleted.DeletedAt = &deletedAt
+		require.NoError(t, service.Updates.Publish(ctx, onRoute("CDG"), &moved, &moved, added, &deleted))
+
+		left := nextEvent(t, events)
+		assert.Equal(t, models.FlightEventUpdated, left.Type)
+		assert.Equal(t, "CDG", left.Flight.Origin)
+		assert.Equal(t, uint64(2), left.Sequence)
+
+		joined := nextEvent(t, events)
+		assert.Equal(t, models.FlightEventUpdated, joined.Type)
+		assert.Equal(t, added.ID, joined.Flight.ID)
+		assert.Equal(t, uint64(4), joined.Sequence)
+
+		removed := nextEvent(t, events)
+		assert.Equal(t, models.FlightEventDeleted, removed.Type)
+		assert.Equal(t, uint64(5), removed.Sequence)
+	})
+
+	t.Run("Snapshots The Named Flights In Order", func(t *testing.T) {
+		first, second := onRoute("LHR"), onRoute("CDG")
+		repo := &FakeRepo{
+			GetFlightsFn: func(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+				assert.Equal(t, testOrgID, *orgID)
+				return []*models.Flight{second, first}, nil
+			},
+		}
+		service := newUpdatesService(repo)
+		ctx, cancel := context.WithCancel(orgContext(testOrgID))
+		defer cancel()
+
+		events, _ := startWatch(ctx, service, models.FlightWatch{FlightIDs: []uuid.UUID{first.ID, second.ID, first.ID, uuid.New()}})
+
+		assert.Equal(t, first.ID, nextEvent(t, events).Flight.ID)
+		assert.Equal(t, second.ID, nextEvent(t, events).Flight.ID)
+		assert.Equal(t, models.FlightEventSnapshotComplete, nextEvent(t, events).Type)
+
+		require.NoError(t, service.Updates.Publish(ctx, onRoute("LHR"), second))
+
+		update := nextEvent(t, events)
+		assert.Equal(t, second.ID, update.Flight.ID)
+		assert.Equal(t, uint64(2), update.Sequence)
+	})
+
+	t.Run("Resumes After A Sequence Without A Snapshot", func(t *testing.T) {
+		watched := onRoute("LHR")
+		service := newUpdatesService(&FakeRepo{})
+		ctx, cancel := context.WithCancel(orgContext(testOrgID))
+		defer cancel()
+		require.NoError(t, service.Updates.Publish(ctx, watched, onRoute("CDG"), watched, watched))
+
+		after := uint64(1)
+		events, _ := startWatch(ctx, service, models.FlightWatch{FlightIDs: []uuid.UUID{watched.ID}, AfterSequence: &after})
+
+		assert.Equal(t, uint64(3), nextEvent(t, events).Sequence)
+		assert.Equal(t, uint64(4), nextEvent(t, events).Sequence)
+
+		require.NoError(t, service.Updates.Publish(ctx, watched))
+
+		live := nextEvent(t, events)
+		assert.Equal(t, models.FlightEventUpdated, live.Type)
+		assert.Equal(t, uint64(5), live.Sequence)
+	})
+
+	t.Run("Resumed Watch Sends Flights That Stopped Matching", func(t *testing.T) {
+		service := newUpdatesService(&FakeRepo{})
+		ctx, cancel := context.WithCancel(orgContext(testOrgID))
+		defer cancel()
+
+		// Sent before the watch disconnected.
+		left := onRoute("LHR")
+		left.Version = 2
+		require.NoError(t, service.Updates.Publish(ctx, left))
+
+		moved := *left
+		moved.Origin = "CDG"
+		moved.Version = 3
+		movedAgain := moved
+		movedAgain.Version = 4
+		created := onRoute("CDG")
+		created.Version = 1
+		require.NoError(t, service.Updates.Publish(ctx, &moved, created, &movedAgain))
+
+		origin := "LHR"
+		after := uint64(1)
+		events, _ := startWatch(ctx, service, models.FlightWatch{Origin: &origin, AfterSequence: &after})
+
+		gone := nextEvent(t, events)
+		assert.Equal(t, models.FlightEventUpdated, gone.Type)
+		assert.Equal(t, left.ID, gone.Flight.ID)
+		assert.Equal(t, "CDG", gone.Flight.Origin)
+		assert.Equal(t, uint64(2), gone.Sequence)
+
+		// Neither the new flight nor the second change to the one that left is sent.
+		joined := onRoute("LHR")
+		require.NoError(t, service.Updates.Publish(ctx, joined))
+
+		live := nextEvent(t, events)
+		assert.Equal(t, joined.ID, live.Flight.ID)
+		assert.Equal(t, uint64(5), live.Sequence)
+	})
+
+	t.Run("Refuses A Resume Point That Is Not Retained", func(t *testing.T) {
+		after := uint64(5)
+		err := newUpdatesService(&FakeRepo{}).WatchFlights(orgContext(testOrgID), models.FlightWatch{AfterSequence: &after}, func(*models.FlightEvent) error {
+			return nil
+		})
+
+		assert.ErrorIs(t, err, exceptions.ErrResumeExpired)
+	})
+
+	t.Run("Rejects Invalid Airport Codes", func(t *testing.T) {
+		destination := "J1"
+		err := newUpdatesService(&FakeRepo{}).WatchFlights(orgContext(testOrgID), models.FlightWatch{Destination: &destination}, nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidIATACode)
+	})
+
+	t.Run("Rejects Too Many Flight IDs", func(t *testing.T) {
+		ids := make([]uuid.UUID, pagination.MaxPageSize+1)
+
+		err := newUpdatesService(&FakeRepo{}).WatchFlights(orgContext(testOrgID), models.FlightWatch{FlightIDs: ids}, nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidInput)
+	})
+
+	t.Run("Requires An Organization", func(t *testing.T) {
+		err := newUpdatesService(&FakeRepo{}).WatchFlights(context.Background(), models.FlightWatch{}, nil)
+
+		assert.ErrorIs(t, err, exceptions.ErrOrganizationRequired)
+	})
+
+	t.Run("Fails Without Updates Configured", func(t *testing.T) {
+		service := NewFlightsService(&FakeRepo{}, FakeFlightsCache{}, &FakeAircraftClient{})
+
+		err := service.WatchFlights(orgContext(testOrgID), models.FlightWatch{}, nil)
+
+		assert.ErrorIs(t, err, errUpdatesNotConfigured)
+	})
+
+	t.Run("Ends When Send Fails", func(t *testing.T) {
+		sendErr := errors.New("stream closed")
+
+		err := newUpdatesService(&FakeRepo{}).WatchFlights(orgContext(testOrgID), models.FlightWatch{}, func(*models.FlightEvent) error {
+			return sendErr
+		})
+
+		assert.ErrorIs(t, err, sendErr)
+	})
+
+	t.Run("Ends When The Context Is Done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(orgContext(testOrgID))
+		events, done := startWatch(ctx, newUpdatesService(&FakeRepo{}), models.FlightWatch{})
+		require.Equal(t, models.FlightEventSnapshotComplete, nextEvent(t, events).Type)
+
+		cancel()
+
+		assert.ErrorIs(t, watchResult(t, done), context.Canceled)
+	})
+
+	t.Run("Ends As Interrupted When Updates Stop", func(t *testing.T) {
+		broker := pubsub.NewBroker(nil)
+		service := NewFlightsService(&FakeRepo{}, FakeFlightsCache{}, &FakeAircraftClient{})
+		service.Updates = broker
+		runCtx, stop := context.WithCancel(context.Background())
+		go broker.Run(runCtx)
+
+		events, done := startWatch(orgContext(testOrgID), service, models.FlightWatch{})
+		require.Equal(t, models.FlightEventSnapshotComplete, nextEvent(t, events).Type)
+
+		stop()
+
+		assert.ErrorIs(t, watchResult(t, done), exceptions.ErrUpdatesInterrupted)
+	})
+}
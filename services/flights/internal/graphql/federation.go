@@ -153,6 +153,25 @@ func (ec *executionContext) resolveEntity(
 	}()
 
 	switch typeName {
+	case "Aircraft":
+		resolverName, err := entityResolverNameForAircraft(ctx, rep)
+		if err != nil {
+			return nil, fmt.Errorf(`finding resolver for Entity "Aircraft": %w`, err)
+		}
+		switch resolverName {
+
+		case "findAircraftByID":
+			id0, err := ec.unmarshalNID2string(ctx, rep["id"])
+			if err != nil {
+				return nil, fmt.Errorf(`unmarshalling param 0 for findAircraftByID(): %w`, err)
+			}
+			entity, err := ec.resolvers.Entity().FindAircraftByID(ctx, id0)
+			if err != nil {
+				return nil, fmt.Errorf(`resolving Entity "Aircraft": %w`, err)
+			}
+
+			return entity, nil
+		}
 	case "Flight":
 		resolverName, err := entityResolverNameForFlight(ctx, rep)
 		if err != nil {
@@ -198,6 +217,41 @@ func (ec *executionContext) resolveManyEntities(
 	}
 }
 
+func entityResolverNameForAircraft(ctx context.Context, rep EntityRepresentation) (string, error) {
+	// we collect errors because a later entity resolver may work fine
+	// when an entity has multiple keys
+	entityResolverErrs := []error{}
+	for {
+		var (
+			m   EntityRepresentation
+			val any
+			ok  bool
+		)
+		_ = val
+		// if all of the KeyFields values for this resolver are null,
+		// we shouldn't use use it
+		allNull := true
+		m = rep
+		val, ok = m["id"]
+		if !ok {
+			entityResolverErrs = append(entityResolverErrs,
+				fmt.Errorf("%w due to missing Key Field \"id\" for Aircraft", ErrTypeNotFound))
+			break
+		}
+		if allNull {
+			allNull = val == nil
+		}
+		if allNull {
+			entityResolverErrs = append(entityResolverErrs,
+				fmt.Errorf("%w due to all null value KeyFields for Aircraft", ErrTypeNotFound))
+			break
+		}
+		return "findAircraftByID", nil
+	}
+	return "", fmt.Errorf("%w for Aircraft due to %v", ErrTypeNotFound,
+		errors.Join(entityResolverErrs...).Error())
+}
+
 func entityResolverNameForFlight(ctx context.Context, rep EntityRepresentation) (string, error) {
 	// we collect errors because a later entity resolver may work fine
 	// when an entity has multiple keys
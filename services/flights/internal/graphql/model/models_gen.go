@@ -2,14 +2,141 @@
 
 package model
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
 type Aircraft struct {
-	ID string `json:"id"`
+	ID      string                   `json:"id"`
+	Flights *models.FlightConnection `json:"flights"`
 }
 
 func (Aircraft) IsEntity() {}
 
+type CreateScheduleInput struct {
+	Number             string      `json:"number"`
+	Origin             string      `json:"origin"`
+	Destination        string      `json:"destination"`
+	DepartureLocalTime string      `json:"departureLocalTime"`
+	TimeZone           string      `json:"timeZone"`
+	DurationMinutes    int32       `json:"durationMinutes"`
+	DaysOfWeek         []DayOfWeek `json:"daysOfWeek"`
+	StartDate          string      `json:"startDate"`
+	EndDate            string      `json:"endDate"`
+	AircraftID         string      `json:"aircraftId"`
+}
+
+type FlightFilterInput struct {
+	Origin         *string              `json:"origin,omitempty"`
+	Destination    *string              `json:"destination,omitempty"`
+	DepartureFrom  *time.Time           `json:"departureFrom,omitempty"`
+	DepartureTo    *time.Time           `json:"departureTo,omitempty"`
+	Status         *models.FlightStatus `json:"status,omitempty"`
+	Airline        *string              `json:"airline,omitempty"`
+	AircraftID     *string              `json:"aircraftId,omitempty"`
+	IncludeDeleted *bool                `json:"includeDeleted,omitempty"`
+}
+
 type Mutation struct {
 }
 
 type Query struct {
 }
+
+type Subscription struct {
+}
+
+type UpdateFlightInput struct {
+	Number        *string    `json:"number,omitempty"`
+	Origin        *string    `json:"origin,omitempty"`
+	Destination   *string    `json:"destination,omitempty"`
+	DepartureTime *time.Time `json:"departureTime,omitempty"`
+	ArrivalTime   *time.Time `json:"arrivalTime,omitempty"`
+	AircraftID    *string    `json:"aircraftId,omitempty"`
+	Version       int32      `json:"version"`
+}
+
+type UpdateScheduleInput struct {
+	Number             *string     `json:"number,omitempty"`
+	Origin             *string     `json:"origin,omitempty"`
+	Destination        *string     `json:"destination,omitempty"`
+	DepartureLocalTime *string     `json:"departureLocalTime,omitempty"`
+	TimeZone           *string     `json:"timeZone,omitempty"`
+	DurationMinutes    *int32      `json:"durationMinutes,omitempty"`
+	DaysOfWeek         []DayOfWeek `json:"daysOfWeek,omitempty"`
+	StartDate          *string     `json:"startDate,omitempty"`
+	EndDate            *string     `json:"endDate,omitempty"`
+	AircraftID         *string     `json:"aircraftId,omitempty"`
+	Version            int32       `json:"version"`
+}
+
+type DayOfWeek string
+
+const (
+	DayOfWeekMonday    DayOfWeek = "MONDAY"
+	DayOfWeekTuesday   DayOfWeek = "TUESDAY"
+	DayOfWeekWednesday DayOfWeek = "WEDNESDAY"
+	DayOfWeekThursday  DayOfWeek = "THURSDAY"
+	DayOfWeekFriday    DayOfWeek = "FRIDAY"
+	DayOfWeekSaturday  DayOfWeek = "SATURDAY"
+	DayOfWeekSunday    DayOfWeek = "SUNDAY"
+)
+
+var AllDayOfWeek = []DayOfWeek{
+	DayOfWeekMonday,
+	DayOfWeekTuesday,
+	DayOfWeekWednesday,
+	DayOfWeekThursday,
+	DayOfWeekFriday,
+	DayOfWeekSaturday,
+	DayOfWeekSunday,
+}
+
+func (e DayOfWeek) IsValid() bool {
+	switch e {
+	case DayOfWeekMonday, DayOfWeekTuesday, DayOfWeekWednesday, DayOfWeekThursday, DayOfWeekFriday, DayOfWeekSaturday, DayOfWeekSunday:
+		return true
+	}
+	return false
+}
+
+func (e DayOfWeek) String() string {
+	return string(e)
+}
+
+func (e *DayOfWeek) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DayOfWeek(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DayOfWeek", str)
+	}
+	return nil
+}
+
+func (e DayOfWeek) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DayOfWeek) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DayOfWeek) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
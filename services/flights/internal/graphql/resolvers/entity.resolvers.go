@@ -6,15 +6,20 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
 	graphql1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
 )
 
+// FindAircraftByID is the resolver for the findAircraftByID field.
+func (r *entityResolver) FindAircraftByID(ctx context.Context, id string) (*model.Aircraft, error) {
+	return r.Resolver.AircraftFlightsResolver.FindAircraftByID(ctx, id)
+}
+
 // FindFlightByID is the resolver for the findFlightByID field.
 func (r *entityResolver) FindFlightByID(ctx context.Context, id string) (*models.Flight, error) {
-	panic(fmt.Errorf("not implemented: FindFlightByID - findFlightByID"))
+	return r.Resolver.GetFlightResolver.FindFlightByID(ctx, id)
 }
 
 // Entity returns graphql1.EntityResolver implementation.
@@ -1,8 +1,19 @@
 package resolvers
 
 import (
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/aircraft"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/bulk"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/create"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/delay"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/deletion"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/get"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/history"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/list"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/movement"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/schedule"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/subscription"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/transition"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/update"
 )
 
 // This file will not be regenerated automatically.
@@ -10,6 +21,17 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	CreateFlightResolver *create.FlightResolver
-	GetFlightResolver    *get.FlightResolver
+	AircraftFlightsResolver   *aircraft.FlightResolver
+	CreateFlightResolver      *create.FlightResolver
+	GetFlightResolver         *get.FlightResolver
+	ListFlightsResolver       *list.FlightResolver
+	UpdateFlightResolver      *update.FlightResolver
+	TransitionResolver        *transition.FlightResolver
+	DeleteFlightResolver      *deletion.FlightResolver
+	FlightHistoryResolver     *history.FlightResolver
+	BulkCreateFlightsResolver *bulk.FlightResolver
+	ScheduleResolver          *schedule.FlightResolver
+	SubscriptionResolver      *subscription.FlightResolver
+	ReportDelayResolver       *delay.FlightResolver
+	RecordMovementResolver    *movement.FlightResolver
 }
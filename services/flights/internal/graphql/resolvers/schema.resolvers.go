@@ -8,13 +8,30 @@ import (
 	"context"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/airports"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
 	graphql1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/schedule"
 	"github.com/google/uuid"
 )
 
+// Flights is the resolver for the flights field.
+func (r *aircraftResolver) Flights(ctx context.Context, obj *model.Aircraft, from *time.Time, to *time.Time, status *models.FlightStatus, first *int32, after *string) (*models.FlightConnection, error) {
+	return r.Resolver.AircraftFlightsResolver.Flights(ctx, obj, from, to, status, first, after)
+}
+
+// FlightID is the resolver for the flightId field.
+func (r *bulkCreateFlightResultResolver) FlightID(ctx context.Context, obj *models.BulkCreateResult) (*string, error) {
+	if obj.FlightID == nil {
+		return nil, nil
+	}
+	id := obj.FlightID.String()
+	return &id, nil
+}
+
 // ID is the resolver for the id field.
 func (r *flightResolver) ID(ctx context.Context, obj *models.Flight) (string, error) {
 	return obj.ID.String(), nil
@@ -33,6 +50,85 @@ func (r *flightResolver) Aircraft(ctx context.Context, obj *models.Flight) (*mod
 	}, nil
 }
 
+// History is the resolver for the history field.
+func (r *flightResolver) History(ctx context.Context, obj *models.Flight, first *int32, after *string) (*models.FlightHistoryConnection, error) {
+	return r.Resolver.FlightHistoryResolver.History(ctx, obj, first, after)
+}
+
+// DepartureLocalTime is the resolver for the departureLocalTime field.
+func (r *flightResolver) DepartureLocalTime(ctx context.Context, obj *models.Flight) (*time.Time, error) {
+	local, ok := airports.LocalTime(obj.Origin, obj.DepartureTime)
+	if !ok {
+		return nil, nil
+	}
+	return &local, nil
+}
+
+// ArrivalLocalTime is the resolver for the arrivalLocalTime field.
+func (r *flightResolver) ArrivalLocalTime(ctx context.Context, obj *models.Flight) (*time.Time, error) {
+	local, ok := airports.LocalTime(obj.Destination, obj.ArrivalTime)
+	if !ok {
+		return nil, nil
+	}
+	return &local, nil
+}
+
+// OriginAirport is the resolver for the originAirport field.
+func (r *flightResolver) OriginAirport(ctx context.Context, obj *models.Flight) (*airports.Airport, error) {
+	airport, _ := airports.Lookup(obj.Origin)
+	return airport, nil
+}
+
+// DestinationAirport is the resolver for the destinationAirport field.
+func (r *flightResolver) DestinationAirport(ctx context.Context, obj *models.Flight) (*airports.Airport, error) {
+	airport, _ := airports.Lookup(obj.Destination)
+	return airport, nil
+}
+
+// BlockMinutes is the resolver for the blockMinutes field.
+func (r *flightResolver) BlockMinutes(ctx context.Context, obj *models.Flight) (*int32, error) {
+	blockTime := obj.BlockTime()
+	if blockTime == nil {
+		return nil, nil
+	}
+	minutes := int32(blockTime.Minutes())
+	return &minutes, nil
+}
+
+// AirborneMinutes is the resolver for the airborneMinutes field.
+func (r *flightResolver) AirborneMinutes(ctx context.Context, obj *models.Flight) (*int32, error) {
+	airborneTime := obj.AirborneTime()
+	if airborneTime == nil {
+		return nil, nil
+	}
+	minutes := int32(airborneTime.Minutes())
+	return &minutes, nil
+}
+
+// ID is the resolver for the id field.
+func (r *flightHistoryEntryResolver) ID(ctx context.Context, obj *models.FlightHistoryEntry) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ActorID is the resolver for the actorId field.
+func (r *flightHistoryEntryResolver) ActorID(ctx context.Context, obj *models.FlightHistoryEntry) (string, error) {
+	return obj.ActorID.String(), nil
+}
+
+// Before is the resolver for the before field.
+func (r *flightHistoryEntryResolver) Before(ctx context.Context, obj *models.FlightHistoryEntry) (*string, error) {
+	if len(obj.Before) == 0 {
+		return nil, nil
+	}
+	before := string(obj.Before)
+	return &before, nil
+}
+
+// After is the resolver for the after field.
+func (r *flightHistoryEntryResolver) After(ctx context.Context, obj *models.FlightHistoryEntry) (string, error) {
+	return string(obj.After), nil
+}
+
 // CreateFlight is the resolver for the createFlight field.
 func (r *mutationResolver) CreateFlight(ctx context.Context, number string, origin string, destination string, departureTime time.Time, arrivalTime time.Time, aircraftID string) (*models.Flight, error) {
 	parsedAircraftId, err := uuid.Parse(aircraftID)
@@ -51,20 +147,144 @@ func (r *mutationResolver) CreateFlight(ctx context.Context, number string, orig
 	)
 }
 
+// UpdateFlight is the resolver for the updateFlight field.
+func (r *mutationResolver) UpdateFlight(ctx context.Context, id string, input model.UpdateFlightInput) (*models.Flight, error) {
+	return r.Resolver.UpdateFlightResolver.UpdateFlight(ctx, id, input)
+}
+
+// TransitionFlightStatus is the resolver for the transitionFlightStatus field.
+func (r *mutationResolver) TransitionFlightStatus(ctx context.Context, id string, status models.FlightStatus, reason *string) (*models.Flight, error) {
+	return r.Resolver.TransitionResolver.TransitionFlightStatus(ctx, id, status, reason)
+}
+
+// DeleteFlight is the resolver for the deleteFlight field.
+func (r *mutationResolver) DeleteFlight(ctx context.Context, id string) (*models.Flight, error) {
+	return r.Resolver.DeleteFlightResolver.DeleteFlight(ctx, id)
+}
+
+// RestoreFlight is the resolver for the restoreFlight field.
+func (r *mutationResolver) RestoreFlight(ctx context.Context, id string) (*models.Flight, error) {
+	return r.Resolver.DeleteFlightResolver.RestoreFlight(ctx, id)
+}
+
+// BulkCreateFlights is the resolver for the bulkCreateFlights field.
+func (r *mutationResolver) BulkCreateFlights(ctx context.Context, file graphql.Upload, format models.ScheduleFormat, aircraftID *string, dryRun *bool) (*models.BulkCreateReport, error) {
+	return r.Resolver.BulkCreateFlightsResolver.BulkCreateFlights(ctx, file, format, aircraftID, dryRun)
+}
+
+// CreateSchedule is the resolver for the createSchedule field.
+func (r *mutationResolver) CreateSchedule(ctx context.Context, input model.CreateScheduleInput) (*models.Schedule, error) {
+	return r.Resolver.ScheduleResolver.CreateSchedule(ctx, input)
+}
+
+// UpdateSchedule is the resolver for the updateSchedule field.
+func (r *mutationResolver) UpdateSchedule(ctx context.Context, id string, input model.UpdateScheduleInput) (*models.Schedule, error) {
+	return r.Resolver.ScheduleResolver.UpdateSchedule(ctx, id, input)
+}
+
+// ReportDelay is the resolver for the reportDelay field.
+func (r *mutationResolver) ReportDelay(ctx context.Context, id string, code string, estimatedDepartureTime time.Time, estimatedArrivalTime *time.Time, remarks *string) (*models.DelayResult, error) {
+	return r.Resolver.ReportDelayResolver.ReportDelay(ctx, id, code, estimatedDepartureTime, estimatedArrivalTime, remarks)
+}
+
+// RecordMovement is the resolver for the recordMovement field.
+func (r *mutationResolver) RecordMovement(ctx context.Context, id string, movement models.FlightMovement, time time.Time) (*models.Flight, error) {
+	return r.Resolver.RecordMovementResolver.RecordMovement(ctx, id, movement, time)
+}
+
 // GetFlightByID is the resolver for the getFlightById field.
-func (r *queryResolver) GetFlightByID(ctx context.Context, id string) (*models.Flight, error) {
-	return r.Resolver.GetFlightResolver.GetFlightById(ctx, id)
+func (r *queryResolver) GetFlightByID(ctx context.Context, id string, includeDeleted *bool) (*models.Flight, error) {
+	return r.Resolver.GetFlightResolver.GetFlightById(ctx, id, includeDeleted)
+}
+
+// Flights is the resolver for the flights field.
+func (r *queryResolver) Flights(ctx context.Context, filter *model.FlightFilterInput, first *int32, after *string) (*models.FlightConnection, error) {
+	return r.Resolver.ListFlightsResolver.ListFlights(ctx, filter, first, after)
+}
+
+// ID is the resolver for the id field.
+func (r *scheduleResolver) ID(ctx context.Context, obj *models.Schedule) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// DaysOfWeek is the resolver for the daysOfWeek field.
+func (r *scheduleResolver) DaysOfWeek(ctx context.Context, obj *models.Schedule) ([]model.DayOfWeek, error) {
+	return schedule.FromWeekdays(obj.DaysOfWeek), nil
+}
+
+// StartDate is the resolver for the startDate field.
+func (r *scheduleResolver) StartDate(ctx context.Context, obj *models.Schedule) (string, error) {
+	return schedule.FormatDate(obj.StartDate), nil
+}
+
+// EndDate is the resolver for the endDate field.
+func (r *scheduleResolver) EndDate(ctx context.Context, obj *models.Schedule) (string, error) {
+	return schedule.FormatDate(obj.EndDate), nil
+}
+
+// Aircraft is the resolver for the aircraft field.
+func (r *scheduleResolver) Aircraft(ctx context.Context, obj *models.Schedule) (*model.Aircraft, error) {
+	if obj.AircraftID == uuid.Nil {
+		return nil, nil
+	}
+
+	return &model.Aircraft{
+		ID: obj.AircraftID.String(),
+	}, nil
+}
+
+// MaterializedThrough is the resolver for the materializedThrough field.
+func (r *scheduleResolver) MaterializedThrough(ctx context.Context, obj *models.Schedule) (*string, error) {
+	if obj.MaterializedThrough == nil {
+		return nil, nil
+	}
+	date := schedule.FormatDate(*obj.MaterializedThrough)
+	return &date, nil
+}
+
+// FlightUpdated is the resolver for the flightUpdated field.
+func (r *subscriptionResolver) FlightUpdated(ctx context.Context, id string) (<-chan *models.Flight, error) {
+	return r.Resolver.SubscriptionResolver.FlightUpdated(ctx, id)
+}
+
+// FlightsOnRoute is the resolver for the flightsOnRoute field.
+func (r *subscriptionResolver) FlightsOnRoute(ctx context.Context, origin string, destination string) (<-chan *models.Flight, error) {
+	return r.Resolver.SubscriptionResolver.FlightsOnRoute(ctx, origin, destination)
+}
+
+// Aircraft returns graphql1.AircraftResolver implementation.
+func (r *Resolver) Aircraft() graphql1.AircraftResolver { return &aircraftResolver{r} }
+
+// BulkCreateFlightResult returns graphql1.BulkCreateFlightResultResolver implementation.
+func (r *Resolver) BulkCreateFlightResult() graphql1.BulkCreateFlightResultResolver {
+	return &bulkCreateFlightResultResolver{r}
 }
 
 // Flight returns graphql1.FlightResolver implementation.
 func (r *Resolver) Flight() graphql1.FlightResolver { return &flightResolver{r} }
 
+// FlightHistoryEntry returns graphql1.FlightHistoryEntryResolver implementation.
+func (r *Resolver) FlightHistoryEntry() graphql1.FlightHistoryEntryResolver {
+	return &flightHistoryEntryResolver{r}
+}
+
 // Mutation returns graphql1.MutationResolver implementation.
 func (r *Resolver) Mutation() graphql1.MutationResolver { return &mutationResolver{r} }
 
 // Query returns graphql1.QueryResolver implementation.
 func (r *Resolver) Query() graphql1.QueryResolver { return &queryResolver{r} }
 
+// Schedule returns graphql1.ScheduleResolver implementation.
+func (r *Resolver) Schedule() graphql1.ScheduleResolver { return &scheduleResolver{r} }
+
+// Subscription returns graphql1.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() graphql1.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type aircraftResolver struct{ *Resolver }
+type bulkCreateFlightResultResolver struct{ *Resolver }
 type flightResolver struct{ *Resolver }
+type flightHistoryEntryResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type scheduleResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,14 @@
+package identity
+
+// Claims is the caller identity asserted by the gateway. Field names follow the
+// claims the gateway reads from the user's token.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	OrgID     string   `json:"org_id"`
+	OrgName   string   `json:"org_name"`
+	Roles     []string `json:"roles"`
+	Issuer    string   `json:"iss,omitempty"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+}
@@ -0,0 +1,79 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// loadTestKey reads one of the throwaway RSA keys in testdata.
+func loadTestKey(t *testing.T, name string) *rsa.PrivateKey {
+	t.Helper()
+
+	raw, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	return key.(*rsa.PrivateKey)
+}
+
+func testClaims() Claims {
+	return Claims{
+		Subject:   "123e4567-e89b-12d3-a456-426614174000",
+		OrgID:     "987fcdeb-51a2-43d1-9f87-123456789abc",
+		OrgName:   "Test Airline",
+		Roles:     []string{"DISPATCHER"},
+		Issuer:    "gateway",
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func encodeSegment(t *testing.T, value any) string {
+	t.Helper()
+	raw, err := json.Marshal(value)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims Claims) string {
+	t.Helper()
+	signingInput := encodeSegment(t, header{Alg: "RS256", Kid: kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signHS256(t *testing.T, secret []byte, kid string, claims Claims) string {
+	t.Helper()
+	signingInput := encodeSegment(t, header{Alg: "HS256", Kid: kid}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// jwkFor returns the JWKS entry publishing key's public half under kid.
+func jwkFor(key *rsa.PrivateKey, kid string) jwk {
+	return jwk{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
@@ -0,0 +1,56 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// HMACKeySource holds the shared keys accepted for HS256 assertions, by key ID.
+// Rotating a key means adding the new one, moving the gateway over, then removing
+// the old one; both verify while they are configured together.
+type HMACKeySource struct {
+	keys map[string][]byte
+}
+
+// NewHMACKeySource returns an HMACKeySource accepting keys.
+func NewHMACKeySource(keys map[string][]byte) *HMACKeySource {
+	return &HMACKeySource{keys: keys}
+}
+
+// ParseHMACKeys parses IDENTITY_HMAC_KEYS, a comma-separated list of kid=secret pairs.
+func ParseHMACKeys(spec string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, secret, ok := strings.Cut(entry, "=")
+		kid, secret = strings.TrimSpace(kid), strings.TrimSpace(secret)
+		if !ok || kid == "" || secret == "" {
+			return nil, fmt.Errorf("invalid IDENTITY_HMAC_KEYS entry %q, expected kid=secret", entry)
+		}
+		keys[kid] = []byte(secret)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("identity mode %q requires IDENTITY_HMAC_KEYS", ModeHMAC)
+	}
+	return keys, nil
+}
+
+func (s *HMACKeySource) key(_ context.Context, alg, kid string) (any, error) {
+	if alg != "HS256" {
+		return nil, invalid("unsupported algorithm")
+	}
+	if kid == "" && len(s.keys) == 1 {
+		for _, secret := range s.keys {
+			return secret, nil
+		}
+	}
+	secret, ok := s.keys[kid]
+	if !ok {
+		return nil, invalid("unknown key id")
+	}
+	return secret, nil
+}
@@ -0,0 +1,169 @@
+package identity
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"golang.org/x/sync/singleflight"
+)
+
+// minRefreshInterval bounds how often the JWKS endpoint is fetched after the last
+// attempt, so neither a stream of tokens with made-up key IDs nor an endpoint that
+// keeps failing once the cache has expired turns every request into a fetch.
+const minRefreshInterval = 30 * time.Second
+
+// fetchTimeout bounds a JWKS fetch, which is shared by every request waiting on it
+// and so does not follow any one request's cancellation.
+const fetchTimeout = 10 * time.Second
+
+// JWKSKeySource resolves RS256 keys from a JWKS endpoint. Keys are cached for ttl;
+// a token signed with a key ID not in the cache triggers an early refresh, which is
+// how newly rotated keys are picked up. If a refresh fails the cached keys are kept.
+// Concurrent requests share a single fetch, made without holding the cache lock.
+type JWKSKeySource struct {
+	url    string
+	client *http.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	refreshes singleflight.Group
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewJWKSKeySource returns a JWKSKeySource for the JWKS document at url.
+func NewJWKSKeySource(url string, client *http.Client, ttl time.Duration) *JWKSKeySource {
+	return &JWKSKeySource{url: url, client: client, ttl: ttl, now: time.Now}
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+func (s *JWKSKeySource) key(ctx context.Context, alg, kid string) (any, error) {
+	if alg != "RS256" {
+		return nil, invalid("unsupported algorithm")
+	}
+
+	key, due := s.cached(kid)
+	if due {
+		// The result is read back from the cache; a failed fetch leaves it unchanged.
+		_, _, _ = s.refreshes.Do("jwks", func() (any, error) {
+			s.refresh(ctx)
+			return nil, nil
+		})
+		key, _ = s.cached(kid)
+	}
+
+	if key == nil {
+		return nil, invalid("unknown key id")
+	}
+	return key, nil
+}
+
+// cached returns the cached key for kid, if any, and whether the cache should be
+// refreshed: it has expired or does not know kid, and the last attempt was at least
+// minRefreshInterval ago.
+func (s *JWKSKeySource) cached(kid string) (*rsa.PublicKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	key, known := s.keys[kid]
+	stale := !known || now.Sub(s.fetchedAt) >= s.ttl
+	return key, stale && now.Sub(s.attemptedAt) >= minRefreshInterval
+}
+
+func (s *JWKSKeySource) refresh(ctx context.Context) {
+	fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+	defer cancel()
+
+	keys, err := s.fetch(fetchCtx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.attemptedAt = now
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to refresh JWKS, using cached keys", "url", s.url, "err", err)
+		return
+	}
+	s.keys = keys
+	s.fetchedAt = now
+}
+
+func (s *JWKSKeySource) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var document jwks
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(document.Keys))
+	for _, k := range document.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		publicKey, err := rsaPublicKey(k)
+		if err != nil {
+			logger.WarnContext(ctx, "Skipping unreadable JWKS key", "kid", k.Kid, "err", err)
+			continue
+		}
+		keys[k.Kid] = publicKey
+	}
+
+	logger.DebugContext(ctx, "Fetched JWKS", "url", s.url, "keys", len(keys))
+	return keys, nil
+}
+
+func rsaPublicKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 || exponent.Int64() < 3 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
@@ -0,0 +1,208 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jwksServer serves a mutable key set and counts how often it is fetched.
+type jwksServer struct {
+	mu      sync.Mutex
+	keys    []jwk
+	fail    bool
+	fetches int
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fetches++
+	if s.fail {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(jwks{Keys: s.keys})
+}
+
+func (s *jwksServer) set(fail bool, keys ...jwk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = fail
+	s.keys = keys
+}
+
+func (s *jwksServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func newJWKSVerifier(t *testing.T, ttl time.Duration) (*JWTVerifier, *jwksServer, *time.Time) {
+	t.Helper()
+
+	keys := &jwksServer{}
+	server := httptest.NewServer(keys)
+	t.Cleanup(server.Close)
+
+	now := time.Now()
+	source := NewJWKSKeySource(server.URL, server.Client(), ttl)
+	source.now = func() time.Time { return now }
+
+	return &JWTVerifier{keys: source, now: time.Now}, keys, &now
+}
+
+func TestJWKSVerifierCachesKeys(t *testing.T) {
+	key := loadTestKey(t, "gateway_test_key.pem")
+	verifier, server, now := newJWKSVerifier(t, 10*time.Minute)
+	server.set(false, jwkFor(key, "gateway-1"))
+
+	token := signRS256(t, key, "gateway-1", testClaims())
+	for range 3 {
+		claims, err := verifier.Verify(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, "Test Airline", claims.OrgName)
+	}
+	assert.Equal(t, 1, server.fetchCount())
+
+	*now = now.Add(11 * time.Minute)
+	_, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.fetchCount(), "expired cache is refreshed")
+}
+
+func TestJWKSVerifierPicksUpRotatedKeys(t *testing.T) {
+	current := loadTestKey(t, "gateway_test_key.pem")
+	rotated := loadTestKey(t, "gateway_test_rotated_key.pem")
+	verifier, server, now := newJWKSVerifier(t, 10*time.Minute)
+	server.set(false, jwkFor(current, "gateway-1"))
+
+	_, err := verifier.Verify(context.Background(), signRS256(t, current, "gateway-1", testClaims()))
+	require.NoError(t, err)
+
+	server.set(false, jwkFor(current, "gateway-1"), jwkFor(rotated, "gateway-2"))
+	*now = now.Add(time.Minute)
+
+	_, err = verifier.Verify(context.Background(), signRS256(t, rotated, "gateway-2", testClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.fetchCount())
+
+	_, err = verifier.Verify(context.Background(), signRS256(t, rotated, "made-up", testClaims()))
+	assert.ErrorIs(t, err, exceptions.ErrInvalidIdentityAssertion)
+	assert.Equal(t, 2, server.fetchCount(), "unknown key ids do not refetch within the refresh interval")
+}
+
+func TestJWKSVerifierKeepsKeysWhenRefreshFails(t *testing.T) {
+	key := loadTestKey(t, "gateway_test_key.pem")
+	verifier, server, now := newJWKSVerifier(t, 10*time.Minute)
+	server.set(false, jwkFor(key, "gateway-1"))
+	token := signRS256(t, key, "gateway-1", testClaims())
+
+	_, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+
+	server.set(true)
+	*now = now.Add(11 * time.Minute)
+
+	_, err = verifier.Verify(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.fetchCount())
+}
+
+func TestJWKSVerifierRateLimitsRefreshWhileEndpointIsDown(t *testing.T) {
+	key := loadTestKey(t, "gateway_test_key.pem")
+	verifier, server, now := newJWKSVerifier(t, 10*time.Minute)
+	server.set(false, jwkFor(key, "gateway-1"))
+	token := signRS256(t, key, "gateway-1", testClaims())
+
+	_, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+
+	server.set(true)
+	*now = now.Add(11 * time.Minute)
+
+	for range 5 {
+		_, err = verifier.Verify(context.Background(), token)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, server.fetchCount(), "an expired cache is not refetched on every request while refreshes fail")
+
+	*now = now.Add(minRefreshInterval)
+	server.set(false, jwkFor(key, "gateway-1"))
+
+	_, err = verifier.Verify(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, server.fetchCount(), "the refresh is retried once the interval has passed")
+
+	_, err = verifier.Verify(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, server.fetchCount())
+}
+
+func TestJWKSVerifierSharesConcurrentFetches(t *testing.T) {
+	key := loadTestKey(t, "gateway_test_key.pem")
+	keys := &jwksServer{}
+	keys.set(false, jwkFor(key, "gateway-1"))
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		keys.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	verifier := &JWTVerifier{keys: NewJWKSKeySource(server.URL, server.Client(), 10*time.Minute), now: time.Now}
+	token := signRS256(t, key, "gateway-1", testClaims())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := verifier.Verify(context.Background(), token)
+			errs <- err
+		}()
+	}
+
+	// Let every request reach the key source before the fetch completes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, keys.fetchCount())
+}
+
+func TestJWKSVerifierRejectsForgedTokens(t *testing.T) {
+	key := loadTestKey(t, "gateway_test_key.pem")
+	other := loadTestKey(t, "gateway_test_rotated_key.pem")
+	verifier, server, _ := newJWKSVerifier(t, 10*time.Minute)
+	server.set(false, jwkFor(key, "gateway-1"))
+
+	tests := map[string]string{
+		"signed with another key": signRS256(t, other, "gateway-1", testClaims()),
+		"hs256 with public key":   signHS256(t, key.N.Bytes(), "gateway-1", testClaims()),
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), token)
+
+			assert.ErrorIs(t, err, exceptions.ErrInvalidIdentityAssertion)
+			assert.Nil(t, claims)
+		})
+	}
+}
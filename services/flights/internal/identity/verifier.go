@@ -0,0 +1,176 @@
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/config"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+const (
+	// ModeHeaders trusts the x-user-* headers as sent. It is the default.
+	ModeHeaders = "headers"
+	// ModeJWKS verifies RS256 assertions against the keys published at a JWKS URL.
+	ModeJWKS = "jwks"
+	// ModeHMAC verifies HS256 assertions against shared keys.
+	ModeHMAC = "hmac"
+
+	// AssertionHeader carries the gateway's signed assertion. When it is absent the
+	// bearer token in the Authorization header is used instead.
+	AssertionHeader = "x-identity-assertion"
+
+	// clockSkew is how far exp and nbf may be off before a token is rejected.
+	clockSkew = 30 * time.Second
+)
+
+// Verifier checks a signed identity assertion and returns its claims.
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+// keySource resolves the key a token was signed with. Each source only returns keys
+// for its own algorithm, so a token cannot pick a weaker one by changing its header.
+type keySource interface {
+	key(ctx context.Context, alg, kid string) (any, error)
+}
+
+// JWTVerifier verifies compact JWS assertions signed with RS256 or HS256.
+type JWTVerifier struct {
+	keys   keySource
+	issuer string
+	now    func() time.Time
+}
+
+// NewVerifier returns the Verifier selected by cfg.IdentityMode, or nil in headers
+// mode where the x-user-* headers are trusted as sent.
+func NewVerifier(cfg config.Config) (Verifier, error) {
+	switch strings.ToLower(cfg.IdentityMode) {
+	case "", ModeHeaders:
+		return nil, nil
+	case ModeJWKS:
+		if cfg.IdentityJWKSURL == "" {
+			return nil, fmt.Errorf("identity mode %q requires IDENTITY_JWKS_URL", ModeJWKS)
+		}
+		source := NewJWKSKeySource(cfg.IdentityJWKSURL, &http.Client{Timeout: 5 * time.Second}, cfg.IdentityKeyCacheTTL)
+		return &JWTVerifier{keys: source, issuer: cfg.IdentityIssuer, now: time.Now}, nil
+	case ModeHMAC:
+		keys, err := ParseHMACKeys(cfg.IdentityHMACKeys)
+		if err != nil {
+			return nil, err
+		}
+		return &JWTVerifier{keys: NewHMACKeySource(keys), issuer: cfg.IdentityIssuer, now: time.Now}, nil
+	default:
+		return nil, fmt.Errorf("unknown identity mode %q", cfg.IdentityMode)
+	}
+}
+
+// TokenFromHeaders returns the assertion carried by headers, or "" when there is none.
+func TokenFromHeaders(headers http.Header) string {
+	if token := strings.TrimSpace(headers.Get(AssertionHeader)); token != "" {
+		return token
+	}
+	authorization := headers.Get("Authorization")
+	if len(authorization) > len("Bearer ") && strings.EqualFold(authorization[:len("Bearer ")], "Bearer ") {
+		return strings.TrimSpace(authorization[len("Bearer "):])
+	}
+	return ""
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the token's signature, expiry and issuer.
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token")
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, invalid("malformed header")
+	}
+
+	key, err := v.keys.key(ctx, h.Alg, h.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature")
+	}
+	if err := verifySignature(h.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalid("malformed claims")
+	}
+
+	now := v.now()
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, invalid("token expired")
+	}
+	if claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, invalid("token not yet valid")
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, invalid("unexpected issuer")
+	}
+
+	return &claims, nil
+}
+
+func verifySignature(alg string, key any, signingInput string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case "RS256":
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return invalid("key does not match algorithm")
+		}
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return invalid("bad signature")
+		}
+	case "HS256":
+		secret, ok := key.([]byte)
+		if !ok {
+			return invalid("key does not match algorithm")
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return invalid("bad signature")
+		}
+	default:
+		return invalid("unsupported algorithm")
+	}
+	return nil
+}
+
+func decodeSegment(segment string, into any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, into)
+}
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", exceptions.ErrInvalidIdentityAssertion, reason)
+}
@@ -0,0 +1,123 @@
+package identity
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/config"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTVerifierHS256(t *testing.T) {
+	secret := []byte("current-secret")
+	verifier := &JWTVerifier{
+		keys:   NewHMACKeySource(map[string][]byte{"current": secret}),
+		issuer: "gateway",
+		now:    time.Now,
+	}
+
+	expired := testClaims()
+	expired.ExpiresAt = time.Now().Add(-time.Hour).Unix()
+	notYetValid := testClaims()
+	notYetValid.NotBefore = time.Now().Add(time.Hour).Unix()
+	noExpiry := testClaims()
+	noExpiry.ExpiresAt = 0
+	otherIssuer := testClaims()
+	otherIssuer.Issuer = "someone-else"
+
+	valid := signHS256(t, secret, "current", testClaims())
+	parts := strings.Split(valid, ".")
+	tampered := parts[0] + "." + encodeSegment(t, Claims{Subject: "attacker", ExpiresAt: time.Now().Add(time.Hour).Unix()}) + "." + parts[2]
+	unsigned := encodeSegment(t, header{Alg: "none"}) + "." + parts[1] + "."
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{name: "valid", token: valid, valid: true},
+		{name: "wrong secret", token: signHS256(t, []byte("guess"), "current", testClaims())},
+		{name: "unknown key id", token: signHS256(t, secret, "retired", testClaims())},
+		{name: "tampered claims", token: tampered},
+		{name: "alg none", token: unsigned},
+		{name: "expired", token: signHS256(t, secret, "current", expired)},
+		{name: "not yet valid", token: signHS256(t, secret, "current", notYetValid)},
+		{name: "missing expiry", token: signHS256(t, secret, "current", noExpiry)},
+		{name: "wrong issuer", token: signHS256(t, secret, "current", otherIssuer)},
+		{name: "malformed", token: "not-a-token"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tc.token)
+
+			if tc.valid {
+				require.NoError(t, err)
+				assert.Equal(t, testClaims().Subject, claims.Subject)
+				assert.Equal(t, []string{"DISPATCHER"}, claims.Roles)
+				return
+			}
+			assert.ErrorIs(t, err, exceptions.ErrInvalidIdentityAssertion)
+			assert.Nil(t, claims)
+		})
+	}
+}
+
+func TestHMACKeyRotation(t *testing.T) {
+	keys, err := ParseHMACKeys("previous=old-secret, current=new-secret")
+	require.NoError(t, err)
+	verifier := &JWTVerifier{keys: NewHMACKeySource(keys), now: time.Now}
+
+	for kid, secret := range map[string]string{"previous": "old-secret", "current": "new-secret"} {
+		_, err := verifier.Verify(context.Background(), signHS256(t, []byte(secret), kid, testClaims()))
+		assert.NoError(t, err, kid)
+	}
+
+	_, err = verifier.Verify(context.Background(), signHS256(t, []byte("new-secret"), "", testClaims()))
+	assert.ErrorIs(t, err, exceptions.ErrInvalidIdentityAssertion, "kid is required once several keys are configured")
+}
+
+func TestParseHMACKeys(t *testing.T) {
+	keys, err := ParseHMACKeys("a=one,b=two")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"a": []byte("one"), "b": []byte("two")}, keys)
+
+	for _, spec := range []string{"", "no-separator", "=secret", "kid="} {
+		_, err := ParseHMACKeys(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	verifier, err := NewVerifier(config.Config{IdentityMode: "headers"})
+	assert.NoError(t, err)
+	assert.Nil(t, verifier)
+
+	verifier, err = NewVerifier(config.Config{IdentityMode: "hmac", IdentityHMACKeys: "k=secret"})
+	assert.NoError(t, err)
+	assert.NotNil(t, verifier)
+
+	_, err = NewVerifier(config.Config{IdentityMode: "hmac"})
+	assert.Error(t, err)
+
+	_, err = NewVerifier(config.Config{IdentityMode: "jwks"})
+	assert.Error(t, err)
+
+	_, err = NewVerifier(config.Config{IdentityMode: "magic"})
+	assert.Error(t, err)
+}
+
+func TestTokenFromHeaders(t *testing.T) {
+	headers := map[string][]string{}
+	assert.Equal(t, "", TokenFromHeaders(headers))
+
+	headers["Authorization"] = []string{"Bearer user-token"}
+	assert.Equal(t, "user-token", TokenFromHeaders(headers))
+
+	headers["X-Identity-Assertion"] = []string{"gateway-token"}
+	assert.Equal(t, "gateway-token", TokenFromHeaders(headers))
+}
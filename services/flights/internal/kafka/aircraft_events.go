@@ -0,0 +1,33 @@
+package kafka
+
+// Event types of the aircraft topic, carried in the eventType header as on the flights
+// topic. Each is its own Avro record, registered under "<topic>-aircraft.<EventType>".
+const (
+	EventTypeAircraftStatusChanged = "AircraftStatusChanged"
+	EventTypeAircraftRetired       = "AircraftRetired"
+)
+
+// aircraftStatusGrounded is the status of an aircraft that can no longer fly.
+const aircraftStatusGrounded = "GROUNDED"
+
+// AircraftStatusChanged represents the Avro structure for an aircraft status change
+type AircraftStatusChanged struct {
+	AircraftId   string  `avro:"aircraftId"`
+	Registration string  `avro:"registration"`
+	Airline      string  `avro:"airline"`
+	FromStatus   string  `avro:"fromStatus"`
+	ToStatus     string  `avro:"toStatus"`
+	Reason       *string `avro:"reason"`
+	ChangedBy    string  `avro:"changedBy"`
+	ChangedAt    string  `avro:"changedAt"`
+}
+
+// AircraftRetired represents the Avro structure for an aircraft leaving the fleet
+type AircraftRetired struct {
+	AircraftId   string  `avro:"aircraftId"`
+	Registration string  `avro:"registration"`
+	Airline      string  `avro:"airline"`
+	Reason       *string `avro:"reason"`
+	RetiredBy    string  `avro:"retiredBy"`
+	RetiredAt    string  `avro:"retiredAt"`
+}
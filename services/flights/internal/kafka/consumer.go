@@ -0,0 +1,396 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/confluentinc/confluent-kafka-go/v2/schemaregistry"
+	"github.com/confluentinc/confluent-kafka-go/v2/schemaregistry/serde"
+	"github.com/confluentinc/confluent-kafka-go/v2/schemaregistry/serde/avro"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AircraftReleaser unassigns an aircraft from the upcoming flights it can no longer operate.
+type AircraftReleaser interface {
+	ReleaseAircraft(ctx context.Context, aircraftID, actorID uuid.UUID, now time.Time) (int, error)
+}
+
+// AircraftInvalidator forgets a cached aircraft, so writes stop trusting its old status.
+type AircraftInvalidator interface {
+	Invalidate(aircraftID uuid.UUID)
+}
+
+type messageReader interface {
+	ReadMessage(timeout time.Duration) (*kafka.Message, error)
+	CommitMessage(m *kafka.Message) ([]kafka.TopicPartition, error)
+	Seek(partition kafka.TopicPartition, ignoredTimeoutMs int) error
+}
+
+type messageDeserializer interface {
+	DeserializeInto(topic string, payload []byte, msg interface{}) error
+}
+
+type messageProducer interface {
+	Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error
+}
+
+// pollTimeout bounds how long Run waits for a message before checking for shutdown.
+const pollTimeout = time.Second
+
+// AircraftEventsConsumer consumes the aircraft service's lifecycle events and releases
+// grounded and retired aircraft from their upcoming flights, which emits a
+// FlightUpdated event for each. Offsets are committed only once a message has been
+// handled, so a crash redelivers it; releasing is idempotent. A message that cannot be
+// decoded, or whose release still fails after maxAttempts, is copied to the dead-letter
+// topic and skipped, so one bad message cannot stall its partition.
+type AircraftEventsConsumer struct {
+	consumer     *kafka.Consumer
+	producer     *kafka.Producer
+	reader       messageReader
+	deserializer messageDeserializer
+	dlq          messageProducer
+	releaser     AircraftReleaser
+	aircraft     AircraftInvalidator
+	topic        string
+	dlqTopic     string
+	tracer       trace.Tracer
+	maxAttempts  int
+	retryBackoff time.Duration
+	now          func() time.Time
+}
+
+// NewAircraftEventsConsumer joins groupID on topic, decoding messages with the Avro
+// schemas in the registry at schemaRegistryURL and parking failed ones on dlqTopic.
+func NewAircraftEventsConsumer(brokerURL, schemaRegistryURL, groupID, topic, dlqTopic string, releaser AircraftReleaser, aircraft AircraftInvalidator) (*AircraftEventsConsumer, error) {
+	logger.InfoContext(context.Background(), "Initializing Kafka consumer",
+		"broker_url", brokerURL,
+		"schema_registry_url", schemaRegistryURL,
+		"group_id", groupID,
+		"topic", topic,
+		"dlq_topic", dlqTopic)
+
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":       brokerURL,
+		"client.id":               "flights-service",
+		"group.id":                groupID,
+		"auto.offset.reset":       "earliest",
+		"enable.auto.commit":      false,
+		"socket.keepalive.enable": true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create consumer: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = consumer.Close()
+		}
+	}()
+
+	if err = consumer.SubscribeTopics([]string{topic}, nil); err != nil {
+		return nil, fmt.Errorf("subscribe to %s: %w", topic, err)
+	}
+
+	srClient, err := schemaregistry.NewClient(schemaregistry.NewConfig(schemaRegistryURL))
+	if err != nil {
+		return nil, fmt.Errorf("create schema registry client: %w", err)
+	}
+
+	deserializer, err := avro.NewGenericDeserializer(srClient, serde.ValueSerde, avro.NewDeserializerConfig())
+	if err != nil {
+		return nil, fmt.Errorf("create Avro deserializer: %w", err)
+	}
+
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers":   brokerURL,
+		"client.id":           "flights-service-dlq",
+		"acks":                "all",
+		"enable.idempotence":  true,
+		"go.delivery.reports": true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create dead-letter producer: %w", err)
+	}
+
+	// Delivery reports go to each Produce call's channel; only client errors arrive here.
+	go func() {
+		for e := range producer.Events() {
+			if kafkaErr, ok := e.(kafka.Error); ok {
+				recordKafkaError(context.Background(), kafkaErr, "dlq_producer_error", "Kafka dead-letter producer error", dlqTopic, "")
+			}
+		}
+	}()
+
+	logger.InfoContext(context.Background(), "Kafka consumer initialized successfully")
+
+	return &AircraftEventsConsumer{
+		consumer:     consumer,
+		producer:     producer,
+		reader:       consumer,
+		deserializer: deserializer,
+		dlq:          producer,
+		releaser:     releaser,
+		aircraft:     aircraft,
+		topic:        topic,
+		dlqTopic:     dlqTopic,
+		tracer:       otel.Tracer("kafka-consumer"),
+		maxAttempts:  3,
+		retryBackoff: time.Second,
+		now:          time.Now,
+	}, nil
+}
+
+// Close leaves the consumer group and flushes any pending dead-letter messages. Run
+// must have returned first.
+func (c *AircraftEventsConsumer) Close() {
+	if err := c.consumer.Close(); err != nil {
+		logger.Warn("Error closing Kafka consumer", "err", err)
+	}
+
+	if remaining := c.producer.Flush(5000); remaining > 0 {
+		logger.Error("Failed to flush all dead-letter messages", "remaining", remaining)
+	}
+	c.producer.Close()
+}
+
+// Run consumes messages until ctx is cancelled.
+func (c *AircraftEventsConsumer) Run(ctx context.Context) {
+	logger.InfoContext(ctx, "Starting aircraft events consumer", "topic", c.topic, "dlq_topic", c.dlqTopic)
+
+	for ctx.Err() == nil {
+		msg, err := c.reader.ReadMessage(pollTimeout)
+		if err != nil {
+			var kafkaErr kafka.Error
+			if errors.As(err, &kafkaErr) && kafkaErr.Code() == kafka.ErrTimedOut {
+				continue
+			}
+			recordKafkaError(ctx, err, "consume_error", "Kafka consume failed", c.topic, "")
+			continue
+		}
+		c.handle(ctx, msg)
+	}
+
+	logger.Info("Aircraft events consumer stopped")
+}
+
+// aircraftRelease is a lifecycle event that takes an aircraft off its flights.
+type aircraftRelease struct {
+	aircraftID uuid.UUID
+	actorID    uuid.UUID
+	reason     string
+}
+
+// handle processes msg within the trace its producer started, then commits its offset,
+// dead-lettering it first if it failed. If it can be neither processed nor
+// dead-lettered, the partition is rewound so it is read again.
+func (c *AircraftEventsConsumer) handle(ctx context.Context, msg *kafka.Message) {
+	eventType := headerValue(msg.Headers, "eventType")
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Headers))
+	ctx, span := c.tracer.Start(ctx, "kafka.consume",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", c.topic),
+			attribute.String("messaging.operation", "process"),
+			attribute.Int("messaging.kafka.partition", int(msg.TopicPartition.Partition)),
+			attribute.Int64("messaging.kafka.offset", int64(msg.TopicPartition.Offset)),
+			attribute.String("event.type", eventType),
+		))
+	defer span.End()
+
+	result, err := c.process(ctx, msg, eventType)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down: leave the offset uncommitted so the message is redelivered.
+			return
+		}
+
+		span.RecordError(err)
+		if dlqErr := c.deadLetter(ctx, msg, err); dlqErr != nil {
+			span.SetStatus(codes.Error, "Dead-lettering failed")
+			recordKafkaError(ctx, dlqErr, "dlq_error", "Failed to dead-letter Kafka message", c.dlqTopic, eventType)
+			c.rewind(ctx, msg)
+			return
+		}
+		span.SetStatus(codes.Error, "Message dead-lettered")
+		result = "dead_lettered"
+	}
+
+	if _, err := c.reader.CommitMessage(msg); err != nil {
+		recordKafkaError(ctx, err, "commit_error", "Failed to commit Kafka offset", c.topic, eventType)
+	}
+
+	span.SetAttributes(attribute.String("messaging.result", result))
+	metrics.KafkaMessagesConsumed.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("topic", c.topic),
+			attribute.String("event_type", eventType),
+			attribute.String("result", result),
+		))
+}
+
+// process decodes msg and releases the aircraft it names, retrying transient failures.
+// It returns "processed", or "skipped" for events that do not affect flights.
+func (c *AircraftEventsConsumer) process(ctx context.Context, msg *kafka.Message, eventType string) (string, error) {
+	release, err := c.decode(msg, eventType)
+	if err != nil {
+		return "", err
+	}
+	if release == nil {
+		return "skipped", nil
+	}
+
+	// Drop the cached aircraft first, so new flights cannot be given it while its
+	// current ones are released.
+	c.aircraft.Invalidate(release.aircraftID)
+
+	for attempt := 1; ; attempt++ {
+		released, err := c.releaser.ReleaseAircraft(ctx, release.aircraftID, release.actorID, c.now())
+		if err == nil {
+			logger.InfoContext(ctx, "Released aircraft from upcoming flights",
+				"aircraft_id", release.aircraftID, "reason", release.reason, "flights", released)
+			return "processed", nil
+		}
+		if attempt >= c.maxAttempts {
+			return "", fmt.Errorf("release aircraft %s after %d attempts: %w", release.aircraftID, attempt, err)
+		}
+
+		logger.WarnContext(ctx, "Failed to release aircraft, retrying",
+			"aircraft_id", release.aircraftID, "attempt", attempt, "err", err)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(c.retryBackoff * time.Duration(attempt)):
+		}
+	}
+}
+
+// decode returns the release msg calls for, or nil if it does not call for one.
+func (c *AircraftEventsConsumer) decode(msg *kafka.Message, eventType string) (*aircraftRelease, error) {
+	switch eventType {
+	case EventTypeAircraftStatusChanged:
+		var event AircraftStatusChanged
+		if err := c.deserializer.DeserializeInto(c.topic, msg.Value, &event); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", eventType, err)
+		}
+		if event.ToStatus != aircraftStatusGrounded {
+			return nil, nil
+		}
+		return newAircraftRelease(event.AircraftId, event.ChangedBy, "grounded")
+
+	case EventTypeAircraftRetired:
+		var event AircraftRetired
+		if err := c.deserializer.DeserializeInto(c.topic, msg.Value, &event); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", eventType, err)
+		}
+		return newAircraftRelease(event.AircraftId, event.RetiredBy, "retired")
+
+	default:
+		return nil, nil
+	}
+}
+
+// newAircraftRelease parses the ids of an event. The actor is only recorded against
+// the flights, so one that is missing or malformed is recorded as the nil user.
+func newAircraftRelease(aircraftID, actorID, reason string) (*aircraftRelease, error) {
+	id, err := uuid.Parse(aircraftID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aircraft id %q: %w", aircraftID, err)
+	}
+	actor, err := uuid.Parse(actorID)
+	if err != nil {
+		actor = uuid.Nil
+	}
+	return &aircraftRelease{aircraftID: id, actorID: actor, reason: reason}, nil
+}
+
+// deadLetter copies msg to the dead-letter topic with headers recording where it came
+// from and why it failed, and waits for the broker to acknowledge it.
+func (c *AircraftEventsConsumer) deadLetter(ctx context.Context, msg *kafka.Message, cause error) error {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	headers := append(slices.Clone(msg.Headers),
+		kafka.Header{Key: "dlq.error", Value: []byte(cause.Error())},
+		kafka.Header{Key: "dlq.source.topic", Value: []byte(c.topic)},
+		kafka.Header{Key: "dlq.source.partition", Value: []byte(strconv.Itoa(int(msg.TopicPartition.Partition)))},
+		kafka.Header{Key: "dlq.source.offset", Value: []byte(strconv.FormatInt(int64(msg.TopicPartition.Offset), 10))},
+	)
+
+	deliveryChan := make(chan kafka.Event, 1)
+	err := c.dlq.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &c.dlqTopic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        headers,
+		Timestamp:      time.Now(),
+	}, deliveryChan)
+	if err != nil {
+		return fmt.Errorf("produce failed: %w", err)
+	}
+
+	select {
+	case e := <-deliveryChan:
+		m, ok := e.(*kafka.Message)
+		if !ok {
+			return fmt.Errorf("unexpected delivery event %T", e)
+		}
+		if m.TopicPartition.Error != nil {
+			return fmt.Errorf("delivery failed: %w", m.TopicPartition.Error)
+		}
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for delivery: %w", ctx.Err())
+	}
+
+	logger.WarnContext(ctx, "Kafka message sent to dead-letter topic",
+		"topic", c.topic,
+		"dlq_topic", c.dlqTopic,
+		"partition", msg.TopicPartition.Partition,
+		"offset", msg.TopicPartition.Offset,
+		"err", cause)
+	return nil
+}
+
+// rewind seeks msg's partition back to msg so it is read again, after a pause so a
+// message that keeps failing does not spin.
+func (c *AircraftEventsConsumer) rewind(ctx context.Context, msg *kafka.Message) {
+	if err := c.reader.Seek(msg.TopicPartition, 0); err != nil {
+		recordKafkaError(ctx, err, "seek_error", "Failed to rewind Kafka partition", c.topic, "")
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(c.retryBackoff):
+	}
+}
+
+func headerValue(headers []kafka.Header, key string) string {
+	for _, h := range headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// headerCarrier exposes message headers to the trace propagator, mirroring eventHeaders.
+func headerCarrier(headers []kafka.Header) propagation.MapCarrier {
+	carrier := make(propagation.MapCarrier, len(headers))
+	for _, h := range headers {
+		carrier[h.Key] = string(h.Value)
+	}
+	return carrier
+}
@@ -0,0 +1,283 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type fakeMessageReader struct {
+	committed []*kafka.Message
+	seeked    []kafka.TopicPartition
+}
+
+func (f *fakeMessageReader) ReadMessage(timeout time.Duration) (*kafka.Message, error) {
+	return nil, kafka.NewError(kafka.ErrTimedOut, "timed out", false)
+}
+
+func (f *fakeMessageReader) CommitMessage(m *kafka.Message) ([]kafka.TopicPartition, error) {
+	f.committed = append(f.committed, m)
+	return nil, nil
+}
+
+func (f *fakeMessageReader) Seek(partition kafka.TopicPartition, ignoredTimeoutMs int) error {
+	f.seeked = append(f.seeked, partition)
+	return nil
+}
+
+// fakeDeserializer decodes the value as the event registered for it, failing for
+// any other value.
+type fakeDeserializer struct {
+	events map[string]any
+}
+
+func (f *fakeDeserializer) DeserializeInto(topic string, payload []byte, msg interface{}) error {
+	event, ok := f.events[string(payload)]
+	if !ok {
+		return errors.New("unknown magic byte")
+	}
+	switch target := msg.(type) {
+	case *AircraftStatusChanged:
+		*target = event.(AircraftStatusChanged)
+	case *AircraftRetired:
+		*target = event.(AircraftRetired)
+	default:
+		return errors.New("unexpected target")
+	}
+	return nil
+}
+
+type fakeMessageProducer struct {
+	produced []*kafka.Message
+	err      error
+}
+
+func (f *fakeMessageProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.produced = append(f.produced, msg)
+	deliveryChan <- msg
+	return nil
+}
+
+type fakeAircraftReleaser struct {
+	calls       []uuid.UUID
+	actors      []uuid.UUID
+	contexts    []context.Context
+	errs        []error
+	invalidated []uuid.UUID
+	// invalidatedFirst records, for each release, whether the aircraft had already
+	// been invalidated.
+	invalidatedFirst []bool
+}
+
+func (f *fakeAircraftReleaser) Invalidate(aircraftID uuid.UUID) {
+	f.invalidated = append(f.invalidated, aircraftID)
+}
+
+func (f *fakeAircraftReleaser) ReleaseAircraft(ctx context.Context, aircraftID, actorID uuid.UUID, now time.Time) (int, error) {
+	f.calls = append(f.calls, aircraftID)
+	f.invalidatedFirst = append(f.invalidatedFirst, slices.Contains(f.invalidated, aircraftID))
+	f.actors = append(f.actors, actorID)
+	f.contexts = append(f.contexts, ctx)
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return 0, err
+	}
+	return 2, nil
+}
+
+func newTestConsumer(events map[string]any) (*AircraftEventsConsumer, *fakeMessageReader, *fakeMessageProducer, *fakeAircraftReleaser) {
+	reader := &fakeMessageReader{}
+	producer := &fakeMessageProducer{}
+	releaser := &fakeAircraftReleaser{}
+	return &AircraftEventsConsumer{
+		reader:       reader,
+		deserializer: &fakeDeserializer{events: events},
+		dlq:          producer,
+		releaser:     releaser,
+		aircraft:     releaser,
+		topic:        "aircraft",
+		dlqTopic:     "aircraft-dlq",
+		tracer:       otel.Tracer("kafka-consumer"),
+		maxAttempts:  3,
+		retryBackoff: time.Millisecond,
+		now:          time.Now,
+	}, reader, producer, releaser
+}
+
+func aircraftMessage(eventType, value string, offset kafka.Offset, extra ...kafka.Header) *kafka.Message {
+	topic := "aircraft"
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 2, Offset: offset},
+		Key:            []byte("key"),
+		Value:          []byte(value),
+		Headers:        append([]kafka.Header{{Key: "eventType", Value: []byte(eventType)}}, extra...),
+	}
+}
+
+func TestAircraftEventsConsumer(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+
+	aircraftID := uuid.New()
+	actorID := uuid.New()
+	events := map[string]any{
+		"grounded":    AircraftStatusChanged{AircraftId: aircraftID.String(), FromStatus: "AVAILABLE", ToStatus: "GROUNDED", ChangedBy: actorID.String()},
+		"maintenance": AircraftStatusChanged{AircraftId: aircraftID.String(), FromStatus: "AVAILABLE", ToStatus: "MAINTENANCE"},
+		"retired":     AircraftRetired{AircraftId: aircraftID.String(), RetiredBy: "system"},
+		"bad-id":      AircraftRetired{AircraftId: "not-a-uuid"},
+	}
+
+	t.Run("Releases A Grounded Aircraft", func(t *testing.T) {
+		consumer, reader, producer, releaser := newTestConsumer(events)
+		msg := aircraftMessage(EventTypeAircraftStatusChanged, "grounded", 10)
+
+		consumer.handle(context.Background(), msg)
+
+		assert.Equal(t, []uuid.UUID{aircraftID}, releaser.calls)
+		assert.Equal(t, []uuid.UUID{actorID}, releaser.actors)
+		assert.Equal(t, []uuid.UUID{aircraftID}, releaser.invalidated)
+		assert.Equal(t, []bool{true}, releaser.invalidatedFirst)
+		assert.Equal(t, []*kafka.Message{msg}, reader.committed)
+		assert.Empty(t, producer.produced)
+	})
+
+	t.Run("Releases A Retired Aircraft With An Unknown Actor", func(t *testing.T) {
+		consumer, reader, _, releaser := newTestConsumer(events)
+
+		consumer.handle(context.Background(), aircraftMessage(EventTypeAircraftRetired, "retired", 11))
+
+		assert.Equal(t, []uuid.UUID{aircraftID}, releaser.calls)
+		assert.Equal(t, []uuid.UUID{uuid.Nil}, releaser.actors)
+		assert.Len(t, reader.committed, 1)
+	})
+
+	t.Run("Skips Other Status Changes And Event Types", func(t *testing.T) {
+		consumer, reader, producer, releaser := newTestConsumer(events)
+
+		consumer.handle(context.Background(), aircraftMessage(EventTypeAircraftStatusChanged, "maintenance", 12))
+		consumer.handle(context.Background(), aircraftMessage("AircraftCreated", "anything", 13))
+
+		assert.Empty(t, releaser.calls)
+		assert.Empty(t, releaser.invalidated)
+		assert.Len(t, reader.committed, 2)
+		assert.Empty(t, producer.produced)
+	})
+
+	t.Run("Continues The Producer's Trace", func(t *testing.T) {
+		previous := otel.GetTextMapPropagator()
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		defer otel.SetTextMapPropagator(previous)
+
+		consumer, _, _, releaser := newTestConsumer(events)
+		traceparent := kafka.Header{Key: "traceparent", Value: []byte("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")}
+
+		consumer.handle(context.Background(), aircraftMessage(EventTypeAircraftStatusChanged, "grounded", 14, traceparent))
+
+		require.Len(t, releaser.contexts, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(releaser.contexts[0]).TraceID().String())
+	})
+
+	t.Run("Dead-Letters Undecodable Messages", func(t *testing.T) {
+		consumer, reader, producer, releaser := newTestConsumer(events)
+		garbled := aircraftMessage(EventTypeAircraftStatusChanged, "garbled", 15)
+		badID := aircraftMessage(EventTypeAircraftRetired, "bad-id", 16)
+
+		consumer.handle(context.Background(), garbled)
+		consumer.handle(context.Background(), badID)
+
+		assert.Empty(t, releaser.calls)
+		assert.Equal(t, []*kafka.Message{garbled, badID}, reader.committed)
+		require.Len(t, producer.produced, 2)
+
+		dead := producer.produced[0]
+		assert.Equal(t, "aircraft-dlq", *dead.TopicPartition.Topic)
+		assert.Equal(t, garbled.Key, dead.Key)
+		assert.Equal(t, garbled.Value, dead.Value)
+		assert.Equal(t, EventTypeAircraftStatusChanged, headerValue(dead.Headers, "eventType"))
+		assert.Contains(t, headerValue(dead.Headers, "dlq.error"), "unknown magic byte")
+		assert.Equal(t, "aircraft", headerValue(dead.Headers, "dlq.source.topic"))
+		assert.Equal(t, "2", headerValue(dead.Headers, "dlq.source.partition"))
+		assert.Equal(t, "15", headerValue(dead.Headers, "dlq.source.offset"))
+
+		assert.Contains(t, headerValue(producer.produced[1].Headers, "dlq.error"), "invalid aircraft id")
+	})
+
+	t.Run("Retries A Failed Release", func(t *testing.T) {
+		consumer, reader, producer, releaser := newTestConsumer(events)
+		releaser.errs = []error{errors.New("connection reset")}
+
+		consumer.handle(context.Background(), aircraftMessage(EventTypeAircraftStatusChanged, "grounded", 17))
+
+		assert.Len(t, releaser.calls, 2)
+		assert.Len(t, reader.committed, 1)
+		assert.Empty(t, producer.produced)
+	})
+
+	t.Run("Dead-Letters A Release That Keeps Failing", func(t *testing.T) {
+		consumer, reader, producer, releaser := newTestConsumer(events)
+		failure := errors.New("connection reset")
+		releaser.errs = []error{failure, failure, failure}
+
+		consumer.handle(context.Background(), aircraftMessage(EventTypeAircraftStatusChanged, "grounded", 18))
+
+		assert.Len(t, releaser.calls, 3)
+		assert.Len(t, reader.committed, 1)
+		require.Len(t, producer.produced, 1)
+		assert.Contains(t, headerValue(producer.produced[0].Headers, "dlq.error"), "after 3 attempts: connection reset")
+	})
+
+	t.Run("Rewinds When The Dead-Letter Topic Is Unavailable", func(t *testing.T) {
+		consumer, reader, producer, _ := newTestConsumer(events)
+		producer.err = errors.New("queue full")
+		msg := aircraftMessage(EventTypeAircraftStatusChanged, "garbled", 19)
+
+		consumer.handle(context.Background(), msg)
+
+		assert.Empty(t, reader.committed)
+		assert.Equal(t, []kafka.TopicPartition{msg.TopicPartition}, reader.seeked)
+	})
+
+	t.Run("Leaves The Offset Uncommitted On Shutdown", func(t *testing.T) {
+		consumer, reader, producer, releaser := newTestConsumer(events)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		releaser.errs = []error{context.Canceled}
+
+		consumer.handle(ctx, aircraftMessage(EventTypeAircraftStatusChanged, "grounded", 20))
+
+		assert.Empty(t, reader.committed)
+		assert.Empty(t, producer.produced)
+	})
+}
+
+func TestAircraftEventsConsumerRunStopsWithContext(t *testing.T) {
+	consumer, _, _, _ := newTestConsumer(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		consumer.Run(ctx)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after its context was cancelled")
+	}
+}
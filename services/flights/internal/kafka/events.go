@@ -0,0 +1,247 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+// Event is a message published to the flights topic. Each event type is its own
+// Avro record, registered under the subject "<topic>-flights.<EventType>".
+type Event interface {
+	// EventType is the record name and the value of the eventType header.
+	EventType() string
+	// Key is the message key; all events for a flight share it so they stay ordered.
+	Key() string
+}
+
+// FlightCreated represents the Avro structure for a created flight
+type FlightCreated struct {
+	FlightId      string `avro:"flightId"`
+	Number        string `avro:"number"`
+	Origin        string `avro:"origin"`
+	Destination   string `avro:"destination"`
+	DepartureTime string `avro:"departureTime"`
+	ArrivalTime   string `avro:"arrivalTime"`
+	Airline       string `avro:"airline"`
+	Status        string `avro:"status"`
+}
+
+// FlightUpdated represents the Avro structure for a flight whose details were edited
+type FlightUpdated struct {
+	FlightId      string `avro:"flightId"`
+	Number        string `avro:"number"`
+	Origin        string `avro:"origin"`
+	Destination   string `avro:"destination"`
+	DepartureTime string `avro:"departureTime"`
+	ArrivalTime   string `avro:"arrivalTime"`
+	Airline       string `avro:"airline"`
+	Status        string `avro:"status"`
+	AircraftId    string `avro:"aircraftId"`
+	Version       int32  `avro:"version"`
+	UpdatedAt     string `avro:"updatedAt"`
+}
+
+// FlightStatusChanged represents the Avro structure for a flight status transition
+type FlightStatusChanged struct {
+	FlightId   string  `avro:"flightId"`
+	FromStatus string  `avro:"fromStatus"`
+	ToStatus   string  `avro:"toStatus"`
+	Reason     *string `avro:"reason"`
+	ChangedBy  string  `avro:"changedBy"`
+	ChangedAt  string  `avro:"changedAt"`
+}
+
+// FlightCancelled represents the Avro structure for a cancelled flight
+type FlightCancelled struct {
+	FlightId       string  `avro:"flightId"`
+	PreviousStatus string  `avro:"previousStatus"`
+	Reason         *string `avro:"reason"`
+	CancelledBy    string  `avro:"cancelledBy"`
+	CancelledAt    string  `avro:"cancelledAt"`
+}
+
+// FlightDelayed represents the Avro structure for a delay reported against a flight, or
+// carried forward to it from the late arrival of its aircraft's previous flight
+type FlightDelayed struct {
+	FlightId               string  `avro:"flightId"`
+	Code                   string  `avro:"code"`
+	Minutes                int32   `avro:"minutes"`
+	EstimatedDepartureTime string  `avro:"estimatedDepartureTime"`
+	EstimatedArrivalTime   string  `avro:"estimatedArrivalTime"`
+	Remarks                *string `avro:"remarks"`
+	CausedByFlightId       *string `avro:"causedByFlightId"`
+	ReportedBy             string  `avro:"reportedBy"`
+	ReportedAt             string  `avro:"reportedAt"`
+}
+
+// FlightMovementRecorded represents the Avro structure for an Out, Off, On or In time
+// recorded against a flight
+type FlightMovementRecorded struct {
+	FlightId   string `avro:"flightId"`
+	Movement   string `avro:"movement"`
+	Time       string `avro:"time"`
+	RecordedBy string `avro:"recordedBy"`
+	RecordedAt string `avro:"recordedAt"`
+}
+
+// FlightDeleted represents the Avro structure for a deleted flight
+type FlightDeleted struct {
+	FlightId  string `avro:"flightId"`
+	Number    string `avro:"number"`
+	DeletedAt string `avro:"deletedAt"`
+}
+
+func (e *FlightCreated) EventType() string          { return models.EventTypeFlightCreated }
+func (e *FlightCreated) Key() string                { return e.FlightId }
+func (e *FlightUpdated) EventType() string          { return models.EventTypeFlightUpdated }
+func (e *FlightUpdated) Key() string                { return e.FlightId }
+func (e *FlightStatusChanged) EventType() string    { return models.EventTypeFlightStatusChanged }
+func (e *FlightStatusChanged) Key() string          { return e.FlightId }
+func (e *FlightCancelled) EventType() string        { return models.EventTypeFlightCancelled }
+func (e *FlightCancelled) Key() string              { return e.FlightId }
+func (e *FlightDelayed) EventType() string          { return models.EventTypeFlightDelayed }
+func (e *FlightDelayed) Key() string                { return e.FlightId }
+func (e *FlightMovementRecorded) EventType() string { return models.EventTypeFlightMovementRecorded }
+func (e *FlightMovementRecorded) Key() string       { return e.FlightId }
+func (e *FlightDeleted) EventType() string          { return models.EventTypeFlightDeleted }
+func (e *FlightDeleted) Key() string                { return e.FlightId }
+
+// eventFromOutbox decodes an outbox row into the Avro event it describes. Flight
+// events carry a models.Flight payload, status events a models.FlightStatusTransition,
+// delay events a models.FlightDelay and movement events a models.MovementRecord;
+// the outbox row's created_at, which shares the writing transaction's timestamp, is
+// used as the time the change happened.
+func eventFromOutbox(ev *models.OutboxEvent) (Event, error) {
+	occurredAt := ev.CreatedAt.UTC().Format(time.RFC3339)
+
+	switch ev.EventType {
+	case models.EventTypeFlightCreated:
+		var f models.Flight
+		if err := decodePayload(ev, &f); err != nil {
+			return nil, err
+		}
+		return &FlightCreated{
+			FlightId:      f.ID.String(),
+			Number:        f.Number,
+			Origin:        f.Origin,
+			Destination:   f.Destination,
+			DepartureTime: f.DepartureTime.Format(time.RFC3339),
+			ArrivalTime:   f.ArrivalTime.Format(time.RFC3339),
+			Airline:       f.Airline,
+			Status:        string(f.Status),
+		}, nil
+
+	case models.EventTypeFlightUpdated:
+		var f models.Flight
+		if err := decodePayload(ev, &f); err != nil {
+			return nil, err
+		}
+		return &FlightUpdated{
+			FlightId:      f.ID.String(),
+			Number:        f.Number,
+			Origin:        f.Origin,
+			Destination:   f.Destination,
+			DepartureTime: f.DepartureTime.Format(time.RFC3339),
+			ArrivalTime:   f.ArrivalTime.Format(time.RFC3339),
+			Airline:       f.Airline,
+			Status:        string(f.Status),
+			AircraftId:    uuidString(f.AircraftID),
+			Version:       f.Version,
+			UpdatedAt:     occurredAt,
+		}, nil
+
+	case models.EventTypeFlightStatusChanged:
+		var t models.FlightStatusTransition
+		if err := decodePayload(ev, &t); err != nil {
+			return nil, err
+		}
+		return &FlightStatusChanged{
+			FlightId:   t.FlightID.String(),
+			FromStatus: string(t.FromStatus),
+			ToStatus:   string(t.ToStatus),
+			Reason:     t.Reason,
+			ChangedBy:  uuidString(t.ChangedBy),
+			ChangedAt:  occurredAt,
+		}, nil
+
+	case models.EventTypeFlightCancelled:
+		var t models.FlightStatusTransition
+		if err := decodePayload(ev, &t); err != nil {
+			return nil, err
+		}
+		return &FlightCancelled{
+			FlightId:       t.FlightID.String(),
+			PreviousStatus: string(t.FromStatus),
+			Reason:         t.Reason,
+			CancelledBy:    uuidString(t.ChangedBy),
+			CancelledAt:    occurredAt,
+		}, nil
+
+	case models.EventTypeFlightDelayed:
+		var d models.FlightDelay
+		if err := decodePayload(ev, &d); err != nil {
+			return nil, err
+		}
+		event := &FlightDelayed{
+			FlightId:               d.FlightID.String(),
+			Code:                   d.Code,
+			Minutes:                d.Minutes,
+			EstimatedDepartureTime: d.EstimatedDepartureTime.Format(time.RFC3339),
+			EstimatedArrivalTime:   d.EstimatedArrivalTime.Format(time.RFC3339),
+			Remarks:                d.Remarks,
+			ReportedBy:             uuidString(d.ReportedBy),
+			ReportedAt:             occurredAt,
+		}
+		if d.CausedByFlightID != nil {
+			causedBy := d.CausedByFlightID.String()
+			event.CausedByFlightId = &causedBy
+		}
+		return event, nil
+
+	case models.EventTypeFlightMovementRecorded:
+		var m models.MovementRecord
+		if err := decodePayload(ev, &m); err != nil {
+			return nil, err
+		}
+		return &FlightMovementRecorded{
+			FlightId:   m.FlightID.String(),
+			Movement:   string(m.Movement),
+			Time:       m.Time.UTC().Format(time.RFC3339),
+			RecordedBy: uuidString(m.RecordedBy),
+			RecordedAt: occurredAt,
+		}, nil
+
+	case models.EventTypeFlightDeleted:
+		var f models.Flight
+		if err := decodePayload(ev, &f); err != nil {
+			return nil, err
+		}
+		return &FlightDeleted{
+			FlightId:  f.ID.String(),
+			Number:    f.Number,
+			DeletedAt: occurredAt,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown outbox event type %q", ev.EventType)
+	}
+}
+
+func decodePayload(ev *models.OutboxEvent, into any) error {
+	if err := json.Unmarshal(ev.Payload, into); err != nil {
+		return fmt.Errorf("decode %s payload: %w", ev.EventType, err)
+	}
+	return nil
+}
+
+// uuidString renders id, leaving unset ids empty rather than the nil UUID.
+func uuidString(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,224 @@
+package kafka
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/schemaregistry"
+	"github.com/confluentinc/confluent-kafka-go/v2/schemaregistry/serde"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func outboxEvent(t *testing.T, eventType string, payload any) *models.OutboxEvent {
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return &models.OutboxEvent{
+		ID:        1,
+		EventType: eventType,
+		Payload:   body,
+		CreatedAt: time.Date(2024, 12, 15, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestEventFromOutbox(t *testing.T) {
+	dep := time.Date(2024, 12, 20, 10, 0, 0, 0, time.UTC)
+	flight := models.Flight{
+		ID:            uuid.New(),
+		Number:        "AA123",
+		Origin:        "JFK",
+		Destination:   "LHR",
+		DepartureTime: dep,
+		ArrivalTime:   dep.Add(7 * time.Hour),
+		Status:        models.FlightStatusScheduled,
+		AircraftID:    uuid.New(),
+		Airline:       "Test Airline",
+		Version:       3,
+	}
+	reason := "crew shortage"
+	transition := models.FlightStatusTransition{
+		ID:         uuid.New(),
+		FlightID:   flight.ID,
+		FromStatus: models.FlightStatusScheduled,
+		ToStatus:   models.FlightStatusCancelled,
+		Reason:     &reason,
+		ChangedBy:  uuid.New(),
+	}
+	causedBy := uuid.New()
+	delay := models.FlightDelay{
+		ID:                     uuid.New(),
+		FlightID:               flight.ID,
+		Code:                   "93",
+		Minutes:                50,
+		EstimatedDepartureTime: dep.Add(50 * time.Minute),
+		EstimatedArrivalTime:   dep.Add(7*time.Hour + 50*time.Minute),
+		CausedByFlightID:       &causedBy,
+		ReportedBy:             uuid.New(),
+	}
+	causedByID := causedBy.String()
+	movement := models.MovementRecord{
+		FlightID:   flight.ID,
+		Movement:   models.FlightMovementOff,
+		Time:       dep.Add(12 * time.Minute),
+		RecordedBy: uuid.New(),
+	}
+
+	tests := []struct {
+		name     string
+		event    *models.OutboxEvent
+		expected Event
+	}{
+		{
+			name:  "FlightCreated",
+			event: outboxEvent(t, models.EventTypeFlightCreated, flight),
+			expected: &FlightCreated{
+				FlightId:      flight.ID.String(),
+				Number:        "AA123",
+				Origin:        "JFK",
+				Destination:   "LHR",
+				DepartureTime: "2024-12-20T10:00:00Z",
+				ArrivalTime:   "2024-12-20T17:00:00Z",
+				Airline:       "Test Airline",
+				Status:        "SCHEDULED",
+			},
+		},
+		{
+			name:  "FlightUpdated",
+			event: outboxEvent(t, models.EventTypeFlightUpdated, flight),
+			expected: &FlightUpdated{
+				FlightId:      flight.ID.String(),
+				Number:        "AA123",
+				Origin:        "JFK",
+				Destination:   "LHR",
+				DepartureTime: "2024-12-20T10:00:00Z",
+				ArrivalTime:   "2024-12-20T17:00:00Z",
+				Airline:       "Test Airline",
+				Status:        "SCHEDULED",
+				AircraftId:    flight.AircraftID.String(),
+				Version:       3,
+				UpdatedAt:     "2024-12-15T09:30:00Z",
+			},
+		},
+		{
+			name:  "FlightStatusChanged",
+			event: outboxEvent(t, models.EventTypeFlightStatusChanged, transition),
+			expected: &FlightStatusChanged{
+				FlightId:   flight.ID.String(),
+				FromStatus: "SCHEDULED",
+				ToStatus:   "CANCELLED",
+				Reason:     &reason,
+				ChangedBy:  transition.ChangedBy.String(),
+				ChangedAt:  "2024-12-15T09:30:00Z",
+			},
+		},
+		{
+			name:  "FlightCancelled",
+			event: outboxEvent(t, models.EventTypeFlightCancelled, transition),
+			expected: &FlightCancelled{
+				FlightId:       flight.ID.String(),
+				PreviousStatus: "SCHEDULED",
+				Reason:         &reason,
+				CancelledBy:    transition.ChangedBy.String(),
+				CancelledAt:    "2024-12-15T09:30:00Z",
+			},
+		},
+		{
+			name:  "FlightDelayed",
+			event: outboxEvent(t, models.EventTypeFlightDelayed, delay),
+			expected: &FlightDelayed{
+				FlightId:               flight.ID.String(),
+				Code:                   "93",
+				Minutes:                50,
+				EstimatedDepartureTime: "2024-12-20T10:50:00Z",
+				EstimatedArrivalTime:   "2024-12-20T17:50:00Z",
+				CausedByFlightId:       &causedByID,
+				ReportedBy:             delay.ReportedBy.String(),
+				ReportedAt:             "2024-12-15T09:30:00Z",
+			},
+		},
+		{
+			name:  "FlightMovementRecorded",
+			event: outboxEvent(t, models.EventTypeFlightMovementRecorded, movement),
+			expected: &FlightMovementRecorded{
+				FlightId:   flight.ID.String(),
+				Movement:   "OFF",
+				Time:       "2024-12-20T10:12:00Z",
+				RecordedBy: movement.RecordedBy.String(),
+				RecordedAt: "2024-12-15T09:30:00Z",
+			},
+		},
+		{
+			name:  "FlightDeleted",
+			event: outboxEvent(t, models.EventTypeFlightDeleted, flight),
+			expected: &FlightDeleted{
+				FlightId:  flight.ID.String(),
+				Number:    "AA123",
+				DeletedAt: "2024-12-15T09:30:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := eventFromOutbox(tt.event)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, event)
+			assert.Equal(t, tt.name, event.EventType())
+			assert.Equal(t, flight.ID.String(), event.Key())
+		})
+	}
+}
+
+func TestEventFromOutboxErrors(t *testing.T) {
+	_, err := eventFromOutbox(&models.OutboxEvent{EventType: "FlightTeleported", Payload: []byte(`{}`)})
+	assert.ErrorContains(t, err, `unknown outbox event type "FlightTeleported"`)
+
+	_, err = eventFromOutbox(&models.OutboxEvent{EventType: models.EventTypeFlightUpdated, Payload: []byte(`not json`)})
+	assert.ErrorContains(t, err, "decode FlightUpdated payload")
+}
+
+func TestRecordSubjectName(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "derived schema without namespace",
+			schema:   `{"type":"record","name":"FlightUpdated","fields":[]}`,
+			expected: "flights-flights.FlightUpdated",
+		},
+		{
+			name:     "explicit namespace",
+			schema:   `{"type":"record","namespace":"ops","name":"FlightCancelled","fields":[]}`,
+			expected: "flights-ops.FlightCancelled",
+		},
+		{
+			name:    "missing name",
+			schema:  `{"type":"record","fields":[]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid schema",
+			schema:  `not json`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, err := recordSubjectName("flights", serde.ValueSerde, schemaregistry.SchemaInfo{Schema: tt.schema})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, subject)
+		})
+	}
+}
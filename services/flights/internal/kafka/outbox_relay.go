@@ -0,0 +1,156 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type outboxStore interface {
+	ProcessPending(ctx context.Context, limit int, handle func(ctx context.Context, events []*models.OutboxEvent) []models.OutboxResult) (int, error)
+	OldestPendingAge(ctx context.Context) (float64, error)
+	DeadCount(ctx context.Context) (int64, error)
+}
+
+type eventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// OutboxRelay polls the outbox and publishes pending events through the Publisher.
+// Failed events are retried with exponential backoff; because the store only hands
+// out the oldest pending event per flight, a failing event holds back later events
+// for the same flight rather than letting them overtake it. An event that fails
+// maxAttempts times, or cannot be decoded at all, is parked as dead so it stops
+// blocking its flight; dead events are reported through the flights.outbox.dead gauge.
+type OutboxRelay struct {
+	store        outboxStore
+	publisher    eventPublisher
+	interval     time.Duration
+	batchSize    int
+	maxAttempts  int32
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+	now          func() time.Time
+}
+
+// NewOutboxRelay returns an OutboxRelay that polls store every interval, relays up to batchSize events per claim
+// and gives up on an event after maxAttempts failed deliveries.
+func NewOutboxRelay(store outboxStore, publisher eventPublisher, interval time.Duration, batchSize int, maxAttempts int32) *OutboxRelay {
+	return &OutboxRelay{
+		store:        store,
+		publisher:    publisher,
+		interval:     interval,
+		batchSize:    batchSize,
+		maxAttempts:  maxAttempts,
+		retryBackoff: time.Second,
+		maxBackoff:   5 * time.Minute,
+		now:          time.Now,
+	}
+}
+
+// Run relays events until ctx is cancelled.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	logger.InfoContext(ctx, "Starting outbox relay", "interval", r.interval, "batch_size", r.batchSize)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+		r.recordBacklog(ctx)
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain claims batches until the outbox has no more due events.
+func (r *OutboxRelay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := r.store.ProcessPending(ctx, r.batchSize, r.publishBatch)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to process outbox batch", "err", err)
+			return
+		}
+		if processed < r.batchSize {
+			return
+		}
+	}
+}
+
+func (r *OutboxRelay) publishBatch(ctx context.Context, events []*models.OutboxEvent) []models.OutboxResult {
+	results := make([]models.OutboxResult, 0, len(events))
+
+	for _, event := range events {
+		eventCtx := otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(event.TraceContext))
+
+		result := models.OutboxResult{ID: event.ID}
+		outcome := "published"
+		decoded, err := eventFromOutbox(event)
+		if err == nil {
+			err = r.publisher.Publish(eventCtx, decoded)
+		} else {
+			// Retrying cannot fix a payload that does not decode.
+			result.Dead = true
+		}
+		if err != nil {
+			result.Err = err
+			result.Dead = result.Dead || event.Attempts+1 >= r.maxAttempts
+			result.NextAttemptAt = r.now().Add(r.backoff(event.Attempts))
+			outcome = "failed"
+		}
+		if result.Dead {
+			logger.ErrorContext(ctx, "Giving up on outbox event", "outbox_id", event.ID, "event_type", event.EventType, "aggregate_id", event.AggregateID, "attempts", event.Attempts+1, "err", err)
+			outcome = "dead"
+		}
+
+		metrics.OutboxEventsRelayed.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("event_type", event.EventType),
+				attribute.String("result", outcome),
+			))
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// backoff returns the delay before the next attempt of an event that has already
+// failed attempts times, doubling from retryBackoff up to maxBackoff.
+func (r *OutboxRelay) backoff(attempts int32) time.Duration {
+	delay := r.retryBackoff
+	for i := int32(0); i < attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.maxBackoff)
+}
+
+// recordBacklog reports the age of the oldest pending event and the number of dead
+// events, which should stay at zero.
+func (r *OutboxRelay) recordBacklog(ctx context.Context) {
+	lag, err := r.store.OldestPendingAge(ctx)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to read outbox lag", "err", err)
+		return
+	}
+	metrics.OutboxLag.Record(ctx, lag)
+
+	dead, err := r.store.DeadCount(ctx)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to count dead outbox events", "err", err)
+		return
+	}
+	metrics.OutboxDead.Record(ctx, dead)
+}
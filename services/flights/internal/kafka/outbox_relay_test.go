@@ -0,0 +1,187 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOutboxStore struct {
+	batches [][]*models.OutboxEvent
+	results [][]models.OutboxResult
+	err     error
+	lag     float64
+	dead    int64
+}
+
+func (f *fakeOutboxStore) ProcessPending(ctx context.Context, limit int, handle func(ctx context.Context, events []*models.OutboxEvent) []models.OutboxResult) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	if len(f.batches) == 0 {
+		return 0, nil
+	}
+	batch := f.batches[0]
+	f.batches = f.batches[1:]
+	f.results = append(f.results, handle(ctx, batch))
+	return len(batch), nil
+}
+
+func (f *fakeOutboxStore) OldestPendingAge(ctx context.Context) (float64, error) {
+	return f.lag, nil
+}
+
+func (f *fakeOutboxStore) DeadCount(ctx context.Context) (int64, error) {
+	return f.dead, nil
+}
+
+type fakeEventPublisher struct {
+	published []Event
+	failFor   map[string]error
+}
+
+func (f *fakeEventPublisher) Publish(ctx context.Context, event Event) error {
+	if err := f.failFor[event.Key()]; err != nil {
+		return err
+	}
+	f.published = append(f.published, event)
+	return nil
+}
+
+func flightCreatedEvent(t *testing.T, id int64, flight *models.Flight, attempts int32) *models.OutboxEvent {
+	payload, err := json.Marshal(flight)
+	require.NoError(t, err)
+	return &models.OutboxEvent{
+		ID:          id,
+		AggregateID: flight.ID,
+		EventType:   models.EventTypeFlightCreated,
+		Payload:     payload,
+		Attempts:    attempts,
+	}
+}
+
+func TestOutboxRelayPublishBatch(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+
+	now := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
+	good := &models.Flight{ID: uuid.New(), Number: "AA123", Origin: "JFK", Destination: "LHR"}
+	bad := &models.Flight{ID: uuid.New(), Number: "AA124", Origin: "JFK", Destination: "CDG"}
+
+	publisher := &fakeEventPublisher{failFor: map[string]error{bad.ID.String(): errors.New("broker down")}}
+	relay := NewOutboxRelay(&fakeOutboxStore{}, publisher, time.Second, 10, 5)
+	relay.now = func() time.Time { return now }
+
+	results := relay.publishBatch(context.Background(), []*models.OutboxEvent{
+		flightCreatedEvent(t, 1, good, 0),
+		flightCreatedEvent(t, 2, bad, 2),
+		{ID: 3, AggregateID: uuid.New(), EventType: "Unknown", Payload: []byte(`{}`)},
+	})
+
+	require.Len(t, results, 3)
+
+	assert.Equal(t, int64(1), results[0].ID)
+	assert.NoError(t, results[0].Err)
+	require.Len(t, publisher.published, 1)
+	if assert.IsType(t, &FlightCreated{}, publisher.published[0]) {
+		created := publisher.published[0].(*FlightCreated)
+		assert.Equal(t, good.ID.String(), created.FlightId)
+		assert.Equal(t, "AA123", created.Number)
+	}
+
+	assert.Equal(t, int64(2), results[1].ID)
+	assert.EqualError(t, results[1].Err, "broker down")
+	assert.Equal(t, now.Add(4*time.Second), results[1].NextAttemptAt)
+	assert.False(t, results[1].Dead)
+
+	assert.Equal(t, int64(3), results[2].ID)
+	assert.ErrorContains(t, results[2].Err, "unknown outbox event type")
+	assert.True(t, results[2].Dead, "events that cannot be decoded are never retried")
+}
+
+func TestOutboxRelayParksEventsAfterMaxAttempts(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+
+	flight := &models.Flight{ID: uuid.New(), Number: "AA124"}
+	publisher := &fakeEventPublisher{failFor: map[string]error{flight.ID.String(): errors.New("broker down")}}
+	relay := NewOutboxRelay(&fakeOutboxStore{}, publisher, time.Second, 10, 5)
+
+	results := relay.publishBatch(context.Background(), []*models.OutboxEvent{
+		flightCreatedEvent(t, 1, flight, 3),
+		flightCreatedEvent(t, 2, flight, 4),
+	})
+
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Dead, "the fourth failure is retried")
+	assert.True(t, results[1].Dead, "the fifth failure parks the event")
+	assert.EqualError(t, results[1].Err, "broker down")
+}
+
+func TestOutboxRelayBackoff(t *testing.T) {
+	relay := NewOutboxRelay(&fakeOutboxStore{}, &fakeEventPublisher{}, time.Second, 10, 5)
+
+	assert.Equal(t, time.Second, relay.backoff(0))
+	assert.Equal(t, 2*time.Second, relay.backoff(1))
+	assert.Equal(t, 8*time.Second, relay.backoff(3))
+	assert.Equal(t, 5*time.Minute, relay.backoff(20))
+	assert.Equal(t, 5*time.Minute, relay.backoff(1000))
+}
+
+func TestOutboxRelayDrain(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+
+	first := &models.Flight{ID: uuid.New(), Number: "AA1"}
+	second := &models.Flight{ID: uuid.New(), Number: "AA2"}
+	third := &models.Flight{ID: uuid.New(), Number: "AA3"}
+
+	t.Run("keeps claiming while batches are full", func(t *testing.T) {
+		store := &fakeOutboxStore{batches: [][]*models.OutboxEvent{
+			{flightCreatedEvent(t, 1, first, 0), flightCreatedEvent(t, 2, second, 0)},
+			{flightCreatedEvent(t, 3, third, 0)},
+		}}
+		publisher := &fakeEventPublisher{}
+		relay := NewOutboxRelay(store, publisher, time.Second, 2, 5)
+
+		relay.drain(context.Background())
+
+		assert.Len(t, store.results, 2)
+		assert.Len(t, publisher.published, 3)
+	})
+
+	t.Run("stops on store error", func(t *testing.T) {
+		store := &fakeOutboxStore{err: errors.New("db down")}
+		relay := NewOutboxRelay(store, &fakeEventPublisher{}, time.Second, 2, 5)
+
+		relay.drain(context.Background())
+
+		assert.Empty(t, store.results)
+	})
+}
+
+func TestOutboxRelayRunStopsOnCancel(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	relay := NewOutboxRelay(&fakeOutboxStore{}, &fakeEventPublisher{}, time.Hour, 10, 5)
+
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop after context cancellation")
+	}
+}
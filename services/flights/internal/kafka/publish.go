@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Publish serializes event as Avro and sends it to Kafka, keyed by event.Key() and
+// with the eventType header set so consumers can route without decoding the value.
+func (p *Publisher) Publish(ctx context.Context, event Event) error {
+	eventType := event.EventType()
+
+	ctx, span := p.tracer.Start(ctx, "kafka.publish",
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", p.topic),
+			attribute.String("messaging.destination_kind", "topic"),
+			attribute.String("messaging.operation", "publish"),
+			attribute.String("event.type", eventType),
+			attribute.String("flight.id", event.Key()),
+		))
+	defer span.End()
+
+	logFields := []any{
+		"topic", p.topic,
+		"event_type", eventType,
+		"flight_id", event.Key(),
+	}
+
+	logger.InfoContext(ctx, "Publishing flight event", logFields...)
+
+	metrics.KafkaMessagesProduced.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("topic", p.topic),
+			attribute.String("event_type", eventType),
+		))
+
+	start := time.Now()
+	valueBytes, err := p.serializer.Serialize(p.topic, event)
+	serializationDuration := time.Since(start)
+
+	logger.DebugContext(ctx, "Avro serialization result",
+		"bytes_length", len(valueBytes),
+		"serialization_duration_ms", serializationDuration.Milliseconds())
+
+	metrics.KafkaSerializationTime.Record(
+		ctx,
+		float64(serializationDuration.Microseconds())/1000.0,
+		metric.WithAttributes(
+			attribute.String("topic", p.topic),
+			attribute.String("event_type", eventType),
+			attribute.String("unit", "ms"),
+		),
+	)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Avro serialization failed")
+		recordKafkaError(ctx, err, "serialization_error", "Avro serialization failed", p.topic, eventType)
+		return fmt.Errorf("avro serialization failed: %w", err)
+	}
+
+	msg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &p.topic,
+			Partition: kafka.PartitionAny,
+		},
+		Key:       []byte(event.Key()),
+		Value:     valueBytes,
+		Headers:   eventHeaders(ctx, eventType),
+		Timestamp: time.Now(),
+	}
+
+	if err := p.produce(ctx, msg, eventType); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Produce failed")
+		return err
+	}
+
+	logger.DebugContext(ctx, "Message delivered to Kafka", logFields...)
+	return nil
+}
+
+// eventHeaders returns the eventType header followed by the trace context of ctx.
+func eventHeaders(ctx context.Context, eventType string) []kafka.Header {
+	headers := []kafka.Header{{Key: "eventType", Value: []byte(eventType)}}
+	carrier := make(propagation.MapCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for k, v := range carrier {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return headers
+}
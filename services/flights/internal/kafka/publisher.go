@@ -2,7 +2,9 @@ package kafka
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/confluentinc/confluent-kafka-go/v2/schemaregistry"
@@ -37,6 +39,7 @@ func NewPublisher(brokerURL, schemaRegistryURL, topic string) (*Publisher, error
 		"bootstrap.servers":       brokerURL,
 		"client.id":               "flights-service",
 		"acks":                    "all",
+		"enable.idempotence":      true,
 		"go.delivery.reports":     true,
 		"retries":                 5,
 		"retry.backoff.ms":        100,
@@ -76,6 +79,7 @@ func NewPublisher(brokerURL, schemaRegistryURL, topic string) (*Publisher, error
 		logger.ErrorContext(context.Background(), "Failed to create Avro serializer", "err", err)
 		return nil, fmt.Errorf("create Avro serializer: %w", err)
 	}
+	serializer.SubjectNameStrategy = recordSubjectName
 
 	logger.InfoContext(context.Background(), "Kafka publisher initialized successfully")
 
@@ -111,6 +115,82 @@ func (p *Publisher) Close() {
 	<-p.done
 }
 
+// avroNamespace is the namespace of the flight event records in kafka/schemas.
+const avroNamespace = "flights"
+
+// recordSubjectName resolves the Schema Registry subject for a record using the
+// topic-record naming strategy, "<topic>-<namespace>.<name>", so every event type
+// can share the flights topic while evolving its own schema.
+func recordSubjectName(topic string, _ serde.Type, info schemaregistry.SchemaInfo) (string, error) {
+	var record struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}
+	if err := json.Unmarshal([]byte(info.Schema), &record); err != nil {
+		return "", fmt.Errorf("parse record schema: %w", err)
+	}
+	if record.Name == "" {
+		return "", fmt.Errorf("record schema has no name")
+	}
+	if record.Namespace == "" {
+		record.Namespace = avroNamespace
+	}
+	return fmt.Sprintf("%s-%s.%s", topic, record.Namespace, record.Name), nil
+}
+
+// deliveryTimeout bounds how long produce waits for a delivery report when the
+// caller's context has no earlier deadline.
+const deliveryTimeout = 10 * time.Second
+
+// produce enqueues msg and waits for the broker to acknowledge it, so callers
+// only treat a message as published once it has been delivered.
+func (p *Publisher) produce(ctx context.Context, msg *kafka.Message, eventType string) error {
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	produceStart := time.Now()
+	deliveryChan := make(chan kafka.Event, 1)
+
+	if err := p.producer.Produce(msg, deliveryChan); err != nil {
+		recordKafkaError(ctx, err, "produce_error", "Kafka produce failed", p.topic, eventType)
+		return fmt.Errorf("produce failed: %w", err)
+	}
+
+	select {
+	case e := <-deliveryChan:
+		m, ok := e.(*kafka.Message)
+		if !ok {
+			err := fmt.Errorf("unexpected delivery event %T", e)
+			recordKafkaError(ctx, err, "delivery_error", "Kafka message delivery failed", p.topic, eventType)
+			return err
+		}
+		if m.TopicPartition.Error != nil {
+			recordKafkaError(ctx, m.TopicPartition.Error, "delivery_error", "Kafka message delivery failed", p.topic, eventType)
+			return fmt.Errorf("delivery failed: %w", m.TopicPartition.Error)
+		}
+
+		metrics.KafkaMessagesSent.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("topic", p.topic),
+				attribute.Int("partition", int(m.TopicPartition.Partition)),
+			))
+	case <-ctx.Done():
+		recordKafkaError(ctx, ctx.Err(), "delivery_timeout", "Timed out waiting for Kafka delivery report", p.topic, eventType)
+		return fmt.Errorf("waiting for delivery: %w", ctx.Err())
+	}
+
+	metrics.KafkaProducerLatency.Record(
+		ctx,
+		time.Since(produceStart).Seconds(),
+		metric.WithAttributes(
+			attribute.String("topic", p.topic),
+			attribute.String("event_type", eventType),
+		),
+	)
+
+	return nil
+}
+
 // recordKafkaError tracks Kafka-related errors with metrics and logs
 func recordKafkaError(
 	ctx context.Context,
@@ -0,0 +1,95 @@
+package loaders
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/dataloader"
+	"github.com/google/uuid"
+)
+
+type flightsGetter interface {
+	GetFlightsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error)
+	ListFlightsForAircraft(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, first int32, after string) (map[uuid.UUID]*models.FlightConnection, error)
+}
+
+// Loaders holds the request-scoped DataLoaders used by the GraphQL resolvers.
+type Loaders struct {
+	FlightByID *dataloader.Loader[uuid.UUID, *models.Flight]
+
+	service         flightsGetter
+	mu              sync.Mutex
+	aircraftFlights map[aircraftFlightsArgs]*dataloader.Loader[uuid.UUID, *models.FlightConnection]
+}
+
+// aircraftFlightsArgs is the comparable form of the Aircraft.flights arguments.
+type aircraftFlightsArgs struct {
+	from, to time.Time
+	status   models.FlightStatus
+	first    int32
+	after    string
+}
+
+type contextKey string
+
+const loadersCtxKey contextKey = "loaders"
+
+// NewLoaders returns a fresh set of loaders backed by service.
+func NewLoaders(service flightsGetter) *Loaders {
+	return &Loaders{
+		FlightByID:      dataloader.New(service.GetFlightsByIDs),
+		service:         service,
+		aircraftFlights: make(map[aircraftFlightsArgs]*dataloader.Loader[uuid.UUID, *models.FlightConnection]),
+	}
+}
+
+// AircraftFlights returns the loader for one set of Aircraft.flights arguments.
+// Aircraft entities resolved with the same arguments share a loader, so their
+// pages are fetched together.
+func (l *Loaders) AircraftFlights(filter models.FlightFilter, first int32, after string) *dataloader.Loader[uuid.UUID, *models.FlightConnection] {
+	args := aircraftFlightsArgs{first: first, after: after}
+	if filter.DepartureFrom != nil {
+		args.from = filter.DepartureFrom.UTC()
+	}
+	if filter.DepartureTo != nil {
+		args.to = filter.DepartureTo.UTC()
+	}
+	if filter.Status != nil {
+		args.status = *filter.Status
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	loader, ok := l.aircraftFlights[args]
+	if !ok {
+		loader = dataloader.New(func(ctx context.Context, aircraftIDs []uuid.UUID) (map[uuid.UUID]*models.FlightConnection, error) {
+			return l.service.ListFlightsForAircraft(ctx, aircraftIDs, filter, first, after)
+		})
+		l.aircraftFlights[args] = loader
+	}
+	return loader
+}
+
+// Middleware attaches a new set of loaders to every request, so batching and
+// memoisation never cross request boundaries.
+func Middleware(service flightsGetter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := WithLoaders(r.Context(), NewLoaders(service))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithLoaders returns a copy of ctx carrying loaders.
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersCtxKey, loaders)
+}
+
+// FromContext returns the loaders attached to ctx, or nil outside a GraphQL request.
+func FromContext(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersCtxKey).(*Loaders)
+	return loaders
+}
@@ -0,0 +1,78 @@
+package loaders
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFlightsGetter struct {
+	calls int
+}
+
+func (f *fakeFlightsGetter) GetFlightsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+	f.calls++
+	flights := make(map[uuid.UUID]*models.Flight, len(ids))
+	for _, id := range ids {
+		flights[id] = &models.Flight{ID: id}
+	}
+	return flights, nil
+}
+
+func (f *fakeFlightsGetter) ListFlightsForAircraft(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, first int32, after string) (map[uuid.UUID]*models.FlightConnection, error) {
+	f.calls++
+	connections := make(map[uuid.UUID]*models.FlightConnection, len(aircraftIDs))
+	for _, id := range aircraftIDs {
+		connections[id] = &models.FlightConnection{}
+	}
+	return connections, nil
+}
+
+func TestMiddlewareAttachesLoadersPerRequest(t *testing.T) {
+	service := &fakeFlightsGetter{}
+	var seen []*Loaders
+
+	handler := Middleware(service, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loaders := FromContext(r.Context())
+		require.NotNil(t, loaders)
+		seen = append(seen, loaders)
+
+		id := uuid.New()
+		flight, err := loaders.FlightByID.Load(r.Context(), id)
+		require.NoError(t, err)
+		assert.Equal(t, id, flight.ID)
+	}))
+
+	for range 2 {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/graphql", nil))
+	}
+
+	require.Len(t, seen, 2)
+	assert.NotSame(t, seen[0], seen[1])
+	assert.Equal(t, 2, service.calls)
+}
+
+func TestFromContextWithoutLoaders(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+}
+
+func TestAircraftFlightsSharesLoaderPerArguments(t *testing.T) {
+	loaders := NewLoaders(&fakeFlightsGetter{})
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	sameFrom := from.In(time.FixedZone("CET", 3600))
+	status := models.FlightStatusScheduled
+
+	first := loaders.AircraftFlights(models.FlightFilter{DepartureFrom: &from, Status: &status}, 10, "")
+	second := loaders.AircraftFlights(models.FlightFilter{DepartureFrom: &sameFrom, Status: &status}, 10, "")
+	other := loaders.AircraftFlights(models.FlightFilter{DepartureFrom: &from}, 10, "")
+
+	assert.Same(t, first, second)
+	assert.NotSame(t, first, other)
+}
@@ -2,7 +2,6 @@ package metrics
 
 import (
 	"context"
-	"errors"
 	"strings"
 	"time"
 
@@ -21,57 +20,117 @@ func (GrpcMetricsInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFun
 	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
 		start := time.Now()
 		resp, err := next(ctx, req)
-		duration := time.Since(start).Seconds()
-
-		statusCode := "OK"
-		var connectErr *connect.Error
-		if errors.As(err, &connectErr) {
-			statusCode = connectErr.Code().String()
-		}
+		recordInbound(ctx, req.Spec().Procedure, start, err)
+		return resp, err
+	}
+}
 
-		procedure, service := parseProcedureAndService(req.Spec().Procedure)
-		if procedure == "" {
-			procedure = "unknown"
-		}
-		if service == "" {
-			service = "unknown"
-		}
+// WrapStreamingClient leaves outbound streams unmeasured: the service makes no
+// outbound Connect calls, and its gRPC clients use OutboundGrpcUnaryClientInterceptor.
+func (GrpcMetricsInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
 
-		GrpcRequests.Add(ctx, 1,
-			metric.WithAttributes(
-				attribute.String("direction", "inbound"),
-				attribute.String("procedure", procedure),
-				attribute.String("service", service),
-				attribute.String("status", statusCode),
-			),
-		)
+// WrapStreamingHandler records a streaming call when it ends, so its duration is the
+// lifetime of the stream, and counts each message as it is sent or received.
+func (GrpcMetricsInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		procedure, service := procedureAttributes(conn.Spec().Procedure)
+		err := next(ctx, &countingHandlerConn{
+			StreamingHandlerConn: conn,
+			ctx:                  ctx,
+			procedure:            procedure,
+			service:              service,
+		})
+		recordInbound(ctx, conn.Spec().Procedure, start, err)
+		return err
+	}
+}
 
-		GrpcDuration.Record(ctx, duration,
-			metric.WithAttributes(
-				attribute.String("direction", "inbound"),
-				attribute.String("procedure", procedure),
-				attribute.String("service", service),
-			),
-		)
+// countingHandlerConn counts the messages of a streaming call as they pass.
+type countingHandlerConn struct {
+	connect.StreamingHandlerConn
+	ctx       context.Context
+	procedure string
+	service   string
+}
 
-		logger.DebugContext(ctx, "gRPC request handled",
-			"direction", "inbound",
-			"procedure", procedure,
-			"service", service,
-			"status", statusCode,
-			"duration_s", duration,
-		)
+func (c *countingHandlerConn) Receive(msg any) error {
+	err := c.StreamingHandlerConn.Receive(msg)
+	if err == nil {
+		c.count("received")
+	}
+	return err
+}
 
-		return resp, err
+func (c *countingHandlerConn) Send(msg any) error {
+	err := c.StreamingHandlerConn.Send(msg)
+	if err == nil {
+		c.count("sent")
 	}
+	return err
 }
 
-func (GrpcMetricsInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
-	return next
+func (c *countingHandlerConn) count(direction string) {
+	GrpcStreamMessages.Add(c.ctx, 1,
+		metric.WithAttributes(
+			attribute.String("direction", direction),
+			attribute.String("procedure", c.procedure),
+			attribute.String("service", c.service),
+		),
+	)
 }
 
-func (GrpcMetricsInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
-	return next
+// recordInbound records a handled call to procedure that started at start and ended
+// with err.
+func recordInbound(ctx context.Context, rawProcedure string, start time.Time, err error) {
+	duration := time.Since(start).Seconds()
+
+	statusCode := "OK"
+	if err != nil {
+		statusCode = connect.CodeOf(err).String()
+	}
+
+	procedure, service := procedureAttributes(rawProcedure)
+
+	GrpcRequests.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("direction", "inbound"),
+			attribute.String("procedure", procedure),
+			attribute.String("service", service),
+			attribute.String("status", statusCode),
+		),
+	)
+
+	GrpcDuration.Record(ctx, duration,
+		metric.WithAttributes(
+			attribute.String("direction", "inbound"),
+			attribute.String("procedure", procedure),
+			attribute.String("service", service),
+		),
+	)
+
+	logger.DebugContext(ctx, "gRPC request handled",
+		"direction", "inbound",
+		"procedure", procedure,
+		"service", service,
+		"status", statusCode,
+		"duration_s", duration,
+	)
+}
+
+// procedureAttributes is parseProcedureAndService with "unknown" in place of empty
+// values.
+func procedureAttributes(raw string) (procedure string, service string) {
+	procedure, service = parseProcedureAndService(raw)
+	if procedure == "" {
+		procedure = "unknown"
+	}
+	if service == "" {
+		service = "unknown"
+	}
+	return
 }
 
 func OutboundGrpcUnaryClientInterceptor() grpc.UnaryClientInterceptor {
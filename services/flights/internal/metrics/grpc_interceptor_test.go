@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// collect returns the int64 sums of the named counter, keyed by the given attribute.
+func collect(t *testing.T, reader sdkmetric.Reader, name string, key attribute.Key) map[string]int64 {
+	t.Helper()
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+
+	sums := make(map[string]int64)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				value, _ := point.Attributes.Value(key)
+				sums[value.AsString()] += point.Value
+			}
+		}
+	}
+	return sums
+}
+
+func TestGrpcMetricsInterceptorStreamingHandler(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("flights-service")
+	require.NoError(t, InitInstruments())
+
+	watchErr := errors.New("updates interrupted")
+	handler := func(ctx context.Context, req *connect.Request[v1.WatchFlightsRequest], stream *connect.ServerStream[v1.FlightEvent]) error {
+		for range 3 {
+			if err := stream.Send(&v1.FlightEvent{}); err != nil {
+				return err
+			}
+		}
+		if req.Msg.AfterSequence != nil {
+			return connect.NewError(connect.CodeUnavailable, watchErr)
+		}
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(flightsv1connect.FlightsServiceWatchFlightsProcedure,
+		connect.NewServerStreamHandler(flightsv1connect.FlightsServiceWatchFlightsProcedure, handler,
+			connect.WithInterceptors(GrpcMetricsInterceptor{})))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := connect.NewClient[v1.WatchFlightsRequest, v1.FlightEvent](
+		server.Client(), server.URL+flightsv1connect.FlightsServiceWatchFlightsProcedure)
+
+	watch := func(msg *v1.WatchFlightsRequest) {
+		stream, err := client.CallServerStream(context.Background(), connect.NewRequest(msg))
+		require.NoError(t, err)
+		for stream.Receive() {
+		}
+		_ = stream.Close()
+	}
+
+	watch(&v1.WatchFlightsRequest{})
+	after := uint64(1)
+	watch(&v1.WatchFlightsRequest{AfterSequence: &after})
+
+	assert.Equal(t, map[string]int64{"OK": 1, "unavailable": 1},
+		collect(t, reader, "flights.grpc.requests", "status"))
+	assert.Equal(t, map[string]int64{"received": 2, "sent": 6},
+		collect(t, reader, "flights.grpc.stream.messages", "direction"))
+}
@@ -8,16 +8,27 @@ import (
 var (
 	meter = otel.GetMeterProvider().Meter("flights-service")
 
-	GrpcRequests    metric.Int64Counter
-	GrpcDuration    metric.Float64Histogram
-	GraphQLRequests metric.Int64Counter
-	GraphQLDuration metric.Float64Histogram
+	GrpcRequests       metric.Int64Counter
+	GrpcDuration       metric.Float64Histogram
+	GrpcStreamMessages metric.Int64Counter
+	GraphQLRequests    metric.Int64Counter
+	GraphQLDuration    metric.Float64Histogram
 
 	KafkaMessagesProduced  metric.Int64Counter
 	KafkaMessagesSent      metric.Int64Counter
 	KafkaMessagesErrors    metric.Int64Counter
 	KafkaSerializationTime metric.Float64Histogram
 	KafkaProducerLatency   metric.Float64Histogram
+	KafkaMessagesConsumed  metric.Int64Counter
+
+	OutboxLag           metric.Float64Gauge
+	OutboxDead          metric.Int64Gauge
+	OutboxEventsRelayed metric.Int64Counter
+
+	GrpcClientRetries        metric.Int64Counter
+	CircuitBreakerState      metric.Int64Gauge
+	CircuitBreakerRejections metric.Int64Counter
+	AircraftCacheLookups     metric.Int64Counter
 )
 
 func InitInstruments() error {
@@ -39,6 +50,14 @@ func InitInstruments() error {
 		return err
 	}
 
+	GrpcStreamMessages, err = meter.Int64Counter(
+		"flights.grpc.stream.messages",
+		metric.WithDescription("Messages sent and received on streaming gRPC calls"),
+	)
+	if err != nil {
+		return err
+	}
+
 	GraphQLRequests, err = meter.Int64Counter(
 		"flights.graphql.requests",
 		metric.WithDescription("Total GraphQL requests for the flights service"),
@@ -95,5 +114,69 @@ func InitInstruments() error {
 		return err
 	}
 
+	KafkaMessagesConsumed, err = meter.Int64Counter(
+		"flights.kafka.messages.consumed",
+		metric.WithDescription("Total messages consumed from Kafka, by result"),
+	)
+	if err != nil {
+		return err
+	}
+
+	OutboxLag, err = meter.Float64Gauge(
+		"flights.outbox.lag.seconds",
+		metric.WithDescription("Age of the oldest outbox event that has not yet been published"),
+	)
+	if err != nil {
+		return err
+	}
+
+	OutboxDead, err = meter.Int64Gauge(
+		"flights.outbox.dead",
+		metric.WithDescription("Outbox events parked after exhausting their delivery attempts"),
+	)
+	if err != nil {
+		return err
+	}
+
+	OutboxEventsRelayed, err = meter.Int64Counter(
+		"flights.outbox.events.relayed",
+		metric.WithDescription("Total outbox events the relay attempted to publish, by result"),
+	)
+	if err != nil {
+		return err
+	}
+
+	GrpcClientRetries, err = meter.Int64Counter(
+		"flights.grpc.client.retries",
+		metric.WithDescription("Outbound gRPC calls retried after a transient failure"),
+	)
+	if err != nil {
+		return err
+	}
+
+	CircuitBreakerState, err = meter.Int64Gauge(
+		"flights.grpc.client.circuit_breaker.state",
+		metric.WithDescription("State of an outbound circuit breaker: 0 closed, 1 half-open, 2 open"),
+	)
+	if err != nil {
+		return err
+	}
+
+	CircuitBreakerRejections, err = meter.Int64Counter(
+		"flights.grpc.client.circuit_breaker.rejections",
+		metric.WithDescription("Outbound gRPC calls failed fast by an open circuit breaker"),
+	)
+	if err != nil {
+		return err
+	}
+
+	AircraftCacheLookups, err = meter.Int64Counter(
+		"flights.aircraft.cache.lookups",
+		metric.WithDescription("Aircraft lookups served by the local aircraft cache, by result"),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+)
+
+// ProcedurePolicies maps a fully qualified Connect procedure to the roles allowed to
+// call it. Procedures without an entry are open to every caller.
+type ProcedurePolicies map[string][]string
+
+// AuthorizationInterceptor rejects calls to procedures in its policy table unless the
+// caller's x-user-roles include one of the permitted roles.
+type AuthorizationInterceptor struct {
+	policies ProcedurePolicies
+}
+
+// NewAuthorizationInterceptor returns an AuthorizationInterceptor enforcing policies.
+func NewAuthorizationInterceptor(policies ProcedurePolicies) *AuthorizationInterceptor {
+	return &AuthorizationInterceptor{policies: policies}
+}
+
+func (i *AuthorizationInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		if err := i.authorize(ctx, req.Spec().Procedure, req.Header()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *AuthorizationInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *AuthorizationInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.authorize(ctx, conn.Spec().Procedure, conn.RequestHeader()); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+func (i *AuthorizationInterceptor) authorize(ctx context.Context, procedure string, headers http.Header) error {
+	roles, ok := i.policies[procedure]
+	if !ok {
+		return nil
+	}
+
+	ctx, err := UserContextFromHeaders(ctx, headers)
+	if err != nil {
+		logger.Warn("Rejected unauthenticated call", "procedure", procedure, "err", err)
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if !GetRequestUserContext(ctx).HasAnyRole(roles...) {
+		logger.Warn("Rejected call without a permitted role", "procedure", procedure, "required", roles)
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%w: requires one of %v", exceptions.ErrForbidden, roles))
+	}
+
+	return nil
+}
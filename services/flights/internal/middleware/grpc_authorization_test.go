@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	restrictedProcedure = "/test.v1.TestService/Restricted"
+	openProcedure       = "/test.v1.TestService/Open"
+)
+
+func newAuthorizationTestClients(t *testing.T) (restricted, open *connect.Client[emptypb.Empty, emptypb.Empty]) {
+	t.Helper()
+
+	interceptor := NewAuthorizationInterceptor(ProcedurePolicies{
+		restrictedProcedure: {"DISPATCHER", "ADMIN"},
+	})
+	handle := func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(restrictedProcedure, connect.NewUnaryHandler(restrictedProcedure, handle, connect.WithInterceptors(interceptor)))
+	mux.Handle(openProcedure, connect.NewUnaryHandler(openProcedure, handle, connect.WithInterceptors(interceptor)))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	restricted = connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+restrictedProcedure)
+	open = connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+openProcedure)
+	return restricted, open
+}
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	restricted, open := newAuthorizationTestClients(t)
+
+	tests := []struct {
+		name         string
+		client       *connect.Client[emptypb.Empty, emptypb.Empty]
+		roles        string
+		anonymous    bool
+		expectedCode connect.Code
+	}{
+		{name: "permitted role", client: restricted, roles: "dispatcher"},
+		{name: "one of several roles", client: restricted, roles: "VIEWER,ADMIN"},
+		{name: "missing role", client: restricted, roles: "VIEWER", expectedCode: connect.CodePermissionDenied},
+		{name: "no roles", client: restricted, expectedCode: connect.CodePermissionDenied},
+		{name: "unauthenticated", client: restricted, anonymous: true, expectedCode: connect.CodeUnauthenticated},
+		{name: "procedure without policy", client: open, anonymous: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := connect.NewRequest(&emptypb.Empty{})
+			if !tc.anonymous {
+				req.Header().Set("x-user-sub", uuid.NewString())
+				req.Header().Set("x-org-id", uuid.NewString())
+				req.Header().Set("x-org-name", "Test Airline")
+				req.Header().Set("x-user-roles", tc.roles)
+			}
+
+			_, err := tc.client.CallUnary(context.Background(), req)
+
+			if tc.expectedCode != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+// UserContextFromHeaders builds the caller's UserContext from gRPC metadata and stores it
+// in the returned context. Unlike UserContextMiddleware it rejects requests that do not
+// carry a valid user sub, organization ID and organization name. When an identity
+// verifier is set the values come from the verified gateway assertion instead.
+func UserContextFromHeaders(ctx context.Context, headers http.Header) (context.Context, error) {
+	headers, err := identityHeaders(ctx, headers)
+	if errors.Is(err, errMissingAssertion) {
+		logger.Warn("Missing identity assertion in metadata")
+		return ctx, errors.New("missing required user authentication")
+	}
+	if err != nil {
+		logger.Warn("Invalid identity assertion in metadata", "err", err)
+		return ctx, err
+	}
+
+	userSub := headers.Get("x-user-sub")
+	orgID := headers.Get("x-org-id")
+	orgName := headers.Get("x-org-name")
+	roles := headers.Get("x-user-roles")
+
+	logger.Debug("Extracting user context from gRPC metadata", "userSub", userSub, "orgID", orgID, "orgName", orgName, "roles", roles)
+
+	if userSub == "" {
+		logger.Warn("Missing required user sub in metadata")
+		return ctx, errors.New("missing required user authentication")
+	}
+
+	if orgID == "" {
+		logger.Warn("Missing required organization ID in metadata")
+		return ctx, errors.New("missing required organization context")
+	}
+
+	if orgName == "" {
+		logger.Warn("Missing required organization name in metadata")
+		return ctx, errors.New("missing required organization context")
+	}
+
+	parsedUserID, err := uuid.Parse(userSub)
+	if err != nil {
+		logger.Warn("Invalid user ID in metadata", "userSub", userSub, "err", err)
+		return ctx, errors.New("invalid user ID format")
+	}
+
+	parsedOrgID, err := uuid.Parse(orgID)
+	if err != nil {
+		logger.Warn("Invalid organization ID in metadata", "orgID", orgID, "err", err)
+		return ctx, errors.New("invalid organization ID format")
+	}
+
+	userCtx := &userContext.UserContext{
+		UserID:  parsedUserID,
+		OrgID:   parsedOrgID,
+		OrgName: orgName,
+		Roles:   roles,
+	}
+
+	logger.Debug("Created user context", "userID", parsedUserID, "orgID", parsedOrgID, "roles", roles)
+
+	return SetUserContextInContext(ctx, userCtx), nil
+}
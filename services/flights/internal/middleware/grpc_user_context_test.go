@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserContextFromHeaders(t *testing.T) {
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	validHeaders := func() http.Header {
+		headers := http.Header{}
+		headers.Set("x-user-sub", userID.String())
+		headers.Set("x-org-id", orgID.String())
+		headers.Set("x-org-name", "Test Airline")
+		headers.Set("x-user-roles", "dispatcher")
+		return headers
+	}
+
+	tests := []struct {
+		name          string
+		mutate        func(http.Header)
+		expectedError string
+	}{
+		{name: "valid headers", mutate: func(http.Header) {}},
+		{name: "missing user sub", mutate: func(h http.Header) { h.Del("x-user-sub") }, expectedError: "missing required user authentication"},
+		{name: "missing org id", mutate: func(h http.Header) { h.Del("x-org-id") }, expectedError: "missing required organization context"},
+		{name: "missing org name", mutate: func(h http.Header) { h.Del("x-org-name") }, expectedError: "missing required organization context"},
+		{name: "invalid user sub", mutate: func(h http.Header) { h.Set("x-user-sub", "not-a-uuid") }, expectedError: "invalid user ID format"},
+		{name: "invalid org id", mutate: func(h http.Header) { h.Set("x-org-id", "not-a-uuid") }, expectedError: "invalid organization ID format"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			headers := validHeaders()
+			tc.mutate(headers)
+
+			ctx, err := UserContextFromHeaders(context.Background(), headers)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedError, err.Error())
+				assert.Equal(t, uuid.Nil, GetRequestUserContext(ctx).UserID)
+				return
+			}
+
+			require.NoError(t, err)
+			userCtx := GetRequestUserContext(ctx)
+			assert.Equal(t, userID, userCtx.UserID)
+			assert.Equal(t, orgID, userCtx.OrgID)
+			assert.Equal(t, "Test Airline", userCtx.OrgName)
+			assert.Equal(t, "dispatcher", userCtx.Roles)
+		})
+	}
+}
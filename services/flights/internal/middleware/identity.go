@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/identity"
+)
+
+var errMissingAssertion = errors.New("missing identity assertion")
+
+var identityVerifier atomic.Pointer[identity.Verifier]
+
+// SetIdentityVerifier makes UserContextMiddleware and UserContextFromHeaders take the
+// caller's identity from a verified gateway assertion rather than the raw x-user-*
+// headers. A nil verifier restores trusting the headers.
+func SetIdentityVerifier(verifier identity.Verifier) {
+	if verifier == nil {
+		identityVerifier.Store(nil)
+		return
+	}
+	identityVerifier.Store(&verifier)
+}
+
+// identityHeaders returns the identity headers to build a UserContext from. Without a
+// verifier they are the request headers; with one they are rebuilt from the verified
+// claims, so x-user-* headers sent by the caller are ignored.
+func identityHeaders(ctx context.Context, headers http.Header) (http.Header, error) {
+	verifier := identityVerifier.Load()
+	if verifier == nil {
+		return headers, nil
+	}
+
+	token := identity.TokenFromHeaders(headers)
+	if token == "" {
+		return nil, errMissingAssertion
+	}
+
+	claims, err := (*verifier).Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	verified := http.Header{}
+	verified.Set("x-user-sub", claims.Subject)
+	verified.Set("x-org-id", claims.OrgID)
+	verified.Set("x-org-name", claims.OrgName)
+	verified.Set("x-user-roles", strings.Join(claims.Roles, ","))
+	return verified, nil
+}
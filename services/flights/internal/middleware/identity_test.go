@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/identity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubVerifier accepts a single token and returns fixed claims for it.
+type stubVerifier struct {
+	token  string
+	claims *identity.Claims
+}
+
+func (v stubVerifier) Verify(ctx context.Context, token string) (*identity.Claims, error) {
+	if token != v.token {
+		return nil, fmt.Errorf("%w: bad signature", exceptions.ErrInvalidIdentityAssertion)
+	}
+	return v.claims, nil
+}
+
+func useStubVerifier(t *testing.T) *identity.Claims {
+	t.Helper()
+
+	claims := &identity.Claims{
+		Subject: uuid.NewString(),
+		OrgID:   uuid.NewString(),
+		OrgName: "Verified Airline",
+		Roles:   []string{"DISPATCHER", "ADMIN"},
+	}
+	SetIdentityVerifier(stubVerifier{token: "signed", claims: claims})
+	t.Cleanup(func() { SetIdentityVerifier(nil) })
+	return claims
+}
+
+func spoofedHeaders() http.Header {
+	headers := http.Header{}
+	headers.Set("x-user-sub", uuid.NewString())
+	headers.Set("x-org-id", uuid.NewString())
+	headers.Set("x-org-name", "Spoofed Airline")
+	headers.Set("x-user-roles", "PLATFORM_ADMIN")
+	return headers
+}
+
+func TestUserContextFromHeadersUsesVerifiedClaims(t *testing.T) {
+	claims := useStubVerifier(t)
+
+	t.Run("claims replace spoofed headers", func(t *testing.T) {
+		headers := spoofedHeaders()
+		headers.Set(identity.AssertionHeader, "signed")
+
+		ctx, err := UserContextFromHeaders(context.Background(), headers)
+		require.NoError(t, err)
+
+		userCtx := GetRequestUserContext(ctx)
+		assert.Equal(t, claims.Subject, userCtx.UserID.String())
+		assert.Equal(t, claims.OrgID, userCtx.OrgID.String())
+		assert.Equal(t, "Verified Airline", userCtx.OrgName)
+		assert.Equal(t, "DISPATCHER,ADMIN", userCtx.Roles)
+	})
+
+	t.Run("bearer token is accepted", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("Authorization", "Bearer signed")
+
+		_, err := UserContextFromHeaders(context.Background(), headers)
+		assert.NoError(t, err)
+	})
+
+	t.Run("headers without an assertion are rejected", func(t *testing.T) {
+		_, err := UserContextFromHeaders(context.Background(), spoofedHeaders())
+		assert.EqualError(t, err, "missing required user authentication")
+	})
+
+	t.Run("invalid assertion is rejected", func(t *testing.T) {
+		headers := spoofedHeaders()
+		headers.Set(identity.AssertionHeader, "forged")
+
+		_, err := UserContextFromHeaders(context.Background(), headers)
+		assert.ErrorIs(t, err, exceptions.ErrInvalidIdentityAssertion)
+	})
+}
+
+func TestUserContextMiddlewareUsesVerifiedClaims(t *testing.T) {
+	claims := useStubVerifier(t)
+
+	serve := func(headers http.Header) (*httptest.ResponseRecorder, string) {
+		var seenOrg string
+		handler := UserContextMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seenOrg = GetRequestUserContext(r.Context()).OrgName
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		req.Header = headers
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec, seenOrg
+	}
+
+	t.Run("valid assertion", func(t *testing.T) {
+		headers := spoofedHeaders()
+		headers.Set(identity.AssertionHeader, "signed")
+
+		rec, orgName := serve(headers)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, claims.OrgName, orgName)
+	})
+
+	t.Run("missing assertion is anonymous", func(t *testing.T) {
+		rec, orgName := serve(spoofedHeaders())
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, orgName)
+	})
+
+	t.Run("invalid assertion is unauthorized", func(t *testing.T) {
+		headers := spoofedHeaders()
+		headers.Set(identity.AssertionHeader, "forged")
+
+		rec, _ := serve(headers)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
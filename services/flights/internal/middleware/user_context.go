@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
 	"github.com/google/uuid"
 )
 
@@ -14,7 +16,14 @@ const userCtxKey = contextKey("userContext")
 
 func UserContextMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		headers := r.Header
+		headers, err := identityHeaders(r.Context(), r.Header)
+		if errors.Is(err, errMissingAssertion) {
+			headers = http.Header{}
+		} else if err != nil {
+			logger.Warn("Rejected request with invalid identity assertion", "err", err)
+			http.Error(w, "invalid identity assertion", http.StatusUnauthorized)
+			return
+		}
 
 		userSub := headers.Get("x-user-sub")
 		orgID := headers.Get("x-org-id")
@@ -22,7 +31,6 @@ func UserContextMiddleware(next http.Handler) http.Handler {
 		roles := headers.Get("x-user-roles")
 
 		var parsedUserID, parsedOrgID uuid.UUID
-		var err error
 
 		if userSub != "" {
 			parsedUserID, err = uuid.Parse(userSub)
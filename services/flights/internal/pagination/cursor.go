@@ -0,0 +1,70 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Cursor identifies a position in a result set ordered by (Time, ID).
+type Cursor struct {
+	Time time.Time
+	ID   uuid.UUID
+}
+
+// EncodeCursor returns the opaque string form of the cursor.
+func EncodeCursor(cursor Cursor) string {
+	raw := cursor.Time.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor. An empty string
+// yields a nil cursor, meaning "start from the beginning".
+func DecodeCursor(encoded string) (*Cursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", exceptions.ErrInvalidCursor, err)
+	}
+
+	timePart, idPart, found := strings.Cut(string(raw), "|")
+	if !found {
+		return nil, exceptions.ErrInvalidCursor
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339Nano, timePart)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", exceptions.ErrInvalidCursor, err)
+	}
+
+	parsedID, err := uuid.Parse(idPart)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", exceptions.ErrInvalidCursor, err)
+	}
+
+	return &Cursor{Time: parsedTime, ID: parsedID}, nil
+}
+
+// ResolvePageSize applies the default page size when first is zero and
+// rejects sizes outside 1..MaxPageSize.
+func ResolvePageSize(first int32) (int, error) {
+	if first == 0 {
+		return DefaultPageSize, nil
+	}
+	if first < 0 || first > MaxPageSize {
+		return 0, fmt.Errorf("%w: must be between 1 and %d, got %d", exceptions.ErrInvalidPageSize, MaxPageSize, first)
+	}
+	return int(first), nil
+}
@@ -0,0 +1,87 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{
+		Time: time.Date(2025, 3, 1, 8, 25, 0, 123456000, time.UTC),
+		ID:   uuid.New(),
+	}
+
+	decoded, err := DecodeCursor(EncodeCursor(cursor))
+
+	require.NoError(t, err)
+	require.NotNil(t, decoded)
+	assert.True(t, cursor.Time.Equal(decoded.Time))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expectNil bool
+		expectErr bool
+	}{
+		{name: "empty", input: "", expectNil: true},
+		{name: "not base64", input: "%%%", expectErr: true},
+		{name: "missing separator", input: base64.RawURLEncoding.EncodeToString([]byte("abc")), expectErr: true},
+		{name: "bad time", input: base64.RawURLEncoding.EncodeToString([]byte("yesterday|" + uuid.NewString())), expectErr: true},
+		{name: "bad id", input: base64.RawURLEncoding.EncodeToString([]byte("2025-01-01T00:00:00Z|nope")), expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cursor, err := DecodeCursor(tc.input)
+
+			if tc.expectErr {
+				assert.ErrorIs(t, err, exceptions.ErrInvalidCursor)
+				assert.Nil(t, cursor)
+				return
+			}
+
+			assert.NoError(t, err)
+			if tc.expectNil {
+				assert.Nil(t, cursor)
+			}
+		})
+	}
+}
+
+func TestResolvePageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     int32
+		expected  int
+		expectErr bool
+	}{
+		{name: "default", first: 0, expected: DefaultPageSize},
+		{name: "within range", first: 5, expected: 5},
+		{name: "max", first: MaxPageSize, expected: MaxPageSize},
+		{name: "negative", first: -1, expectErr: true},
+		{name: "too large", first: MaxPageSize + 1, expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			size, err := ResolvePageSize(tc.first)
+
+			if tc.expectErr {
+				assert.ErrorIs(t, err, exceptions.ErrInvalidPageSize)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, size)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+// Package pubsub delivers flight updates to subscribers. Committed changes are read
+// from the transactional outbox by a ChangeRelay, so none is lost when a publish
+// fails. With Redis configured, updates are published to a channel per organization
+// so that a write handled by one replica reaches the subscribers connected to every
+// other replica. Every update is numbered and the most recent ones are retained, so a
+// subscriber that reconnects can replay what it missed.
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	channelPrefix = "flight-updates:"
+	// The keys share a hash tag so the publish script can use both on a Redis cluster.
+	sequenceKey = "{flight-changes}:sequence"
+	logKey      = "{flight-changes}:log"
+
+	// retainedChanges is roughly how many of the most recent changes are kept for
+	// replay. Redis trims the log lazily, so it may briefly hold a few more.
+	retainedChanges = 10000
+	// replayPage is how many retained changes are read from Redis at a time.
+	replayPage = 500
+)
+
+// publishScript numbers each update, appends it to the change log and publishes it
+// in one step, so the order of the sequence numbers is the order subscribers receive
+// the updates in. Log entries are given the ID 0-<sequence> to be read back by range.
+var publishScript = redis.NewScript(`
+local sequence = 0
+for i = 2, #ARGV, 2 do
+	sequence = redis.call('INCR', KEYS[1])
+	local change = '{"sequence":' .. sequence .. ',"flight":' .. ARGV[i + 1] .. '}'
+	redis.call('XADD', KEYS[2], 'MAXLEN', '~', ARGV[1], '0-' .. sequence, 'change', change)
+	redis.call('PUBLISH', ARGV[i], change)
+end
+return sequence
+`)
+
+type redisClient interface {
+	redis.Scripter
+	Get(ctx context.Context, key string) *redis.StringCmd
+	XRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd
+	PSubscribe(ctx context.Context, channels ...string) *redis.PubSub
+}
+
+// channel is the Redis channel the updates of orgID's flights are published on.
+func channel(orgID uuid.UUID) string {
+	return channelPrefix + orgID.String()
+}
+
+// Broker publishes flight updates and fans them out to the subscribers of this
+// process. Without a Redis client, updates only reach subscribers of the process that
+// published them.
+type Broker struct {
+	client    redisClient
+	hub       *hub
+	retention int
+	// wake carries Notify calls to the ChangeRelay of this process.
+	wake chan struct{}
+
+	// mu guards sequence and log, which hold the numbering and the retained changes
+	// when there is no Redis client.
+	mu       sync.Mutex
+	sequence uint64
+	log      []*models.FlightChange
+}
+
+// NewBroker returns a Broker backed by Redis, or an in-process Broker when client is
+// nil.
+func NewBroker(client *redis.Client) *Broker {
+	broker := &Broker{hub: newHub(), retention: retainedChanges, wake: make(chan struct{}, 1)}
+	if client == nil {
+		logger.Info("Redis client is nil, flight updates are only delivered within this process")
+		return broker
+	}
+	broker.client = client
+	return broker
+}
+
+// Publish announces the latest state of flights to their organizations' subscribers.
+func (b *Broker) Publish(ctx context.Context, flights ...*models.Flight) error {
+	if len(flights) == 0 {
+		return nil
+	}
+
+	if b.client == nil {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		for _, flight := range flights {
+			update := *flight
+			b.sequence++
+			change := &models.FlightChange{Sequence: b.sequence, Flight: &update}
+			b.log = append(b.log, change)
+			if len(b.log) > b.retention {
+				b.log = b.log[len(b.log)-b.retention:]
+			}
+			b.hub.deliver(change)
+		}
+		return nil
+	}
+
+	tracer := otel.Tracer("flights-service")
+	ctx, span := tracer.Start(ctx, "pubsub.publish")
+	defer span.End()
+	span.SetAttributes(attribute.Int("pubsub.messages", len(flights)))
+
+	args := make([]any, 0, 1+2*len(flights))
+	args = append(args, b.retention)
+	for _, flight := range flights {
+		payload, err := json.Marshal(flight)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("encode flight update: %w", err)
+		}
+		args = append(args, channel(flight.OrganizationID), payload)
+	}
+
+	if err := publishScript.Run(ctx, b.client, []string{sequenceKey, logKey}, args...).Err(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("publish flight updates: %w", err)
+	}
+	return nil
+}
+
+// Notify tells the ChangeRelay of this process that changes have been committed to
+// the outbox, so it relays them without waiting for its next poll. It never blocks.
+func (b *Broker) Notify() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe returns the updates to the flights of orgID, or of every organization when
+// orgID is nil. The channel is closed when ctx is done, so a subscription is released
+// as soon as its socket closes.
+func (b *Broker) Subscribe(ctx context.Context, orgID *uuid.UUID) <-chan *models.FlightChange {
+	return b.hub.subscribe(ctx, orgID)
+}
+
+// Head returns the sequence of the most recently published update, or zero when none
+// has been published.
+func (b *Broker) Head(ctx context.Context) (uint64, error) {
+	if b.client == nil {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.sequence, nil
+	}
+
+	value, err := b.client.Get(ctx, sequenceKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read flight update sequence: %w", err)
+	}
+	sequence, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("read flight update sequence: %w", err)
+	}
+	return sequence, nil
+}
+
+// Replay returns the retained updates to the flights of orgID, or of every
+// organization when orgID is nil, published after the given sequence. It fails with
+// exceptions.ErrResumeExpired when some of those updates are no longer retained, or
+// when after is ahead of every published update.
+func (b *Broker) Replay(ctx context.Context, orgID *uuid.UUID, after uint64) ([]*models.FlightChange, error) {
+	if b.client == nil {
+		return b.replayInProcess(orgID, after)
+	}
+
+	// The head is read first so an update published during the replay cannot be
+	// mistaken for a gap; it is delivered to live subscribers instead.
+	head, err := b.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if after > head {
+		return nil, resumeExpired(after)
+	}
+
+	var changes []*models.FlightChange
+	next := after + 1
+	for next <= head {
+		messages, err := b.client.XRangeN(ctx, logKey, "0-"+strconv.FormatUint(next, 10), "+", replayPage).Result()
+		if err != nil {
+			return nil, fmt.Errorf("replay flight updates: %w", err)
+		}
+		for _, msg := range messages {
+			// Sequences have no gaps, so a missing one has been trimmed from the log.
+			if msg.ID != "0-"+strconv.FormatUint(next, 10) {
+				return nil, resumeExpired(after)
+			}
+			next++
+
+			change, err := decodeChange(msg.Values["change"])
+			if err != nil {
+				logger.WarnContext(ctx, "Skipping undecodable retained flight update", "id", msg.ID, "err", err)
+				continue
+			}
+			if inScope(orgID, change) {
+				changes = append(changes, change)
+			}
+		}
+		if len(messages) < replayPage {
+			break
+		}
+	}
+	if next <= head {
+		return nil, resumeExpired(after)
+	}
+	return changes, nil
+}
+
+func (b *Broker) replayInProcess(orgID *uuid.UUID, after uint64) ([]*models.FlightChange, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	oldest := b.sequence - uint64(len(b.log)) + 1
+	if after > b.sequence || after+1 < oldest {
+		return nil, resumeExpired(after)
+	}
+
+	var changes []*models.FlightChange
+	for _, change := range b.log[after+1-oldest:] {
+		if inScope(orgID, change) {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func resumeExpired(after uint64) error {
+	return fmt.Errorf("%w: sequence=%d", exceptions.ErrResumeExpired, after)
+}
+
+func inScope(orgID *uuid.UUID, change *models.FlightChange) bool {
+	return orgID == nil || *orgID == change.Flight.OrganizationID
+}
+
+func decodeChange(value any) (*models.FlightChange, error) {
+	encoded, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected change of type %T", value)
+	}
+	var change models.FlightChange
+	if err := json.Unmarshal([]byte(encoded), &change); err != nil {
+		return nil, err
+	}
+	if change.Flight == nil {
+		return nil, errors.New("change has no flight")
+	}
+	return &change, nil
+}
+
+// Run relays updates published by every replica to this process's subscribers until
+// ctx is cancelled, then completes the remaining subscriptions. A single pattern
+// subscription is shared by all subscribers of the process.
+func (b *Broker) Run(ctx context.Context) {
+	defer b.hub.close()
+
+	if b.client == nil {
+		<-ctx.Done()
+		return
+	}
+
+	logger.InfoContext(ctx, "Starting flight updates subscriber", "pattern", channelPrefix+"*")
+
+	subscription := b.client.PSubscribe(ctx, channelPrefix+"*")
+	defer func() {
+		if err := subscription.Close(); err != nil {
+			logger.Warn("Failed to close flight updates subscription", "err", err)
+		}
+	}()
+
+	b.receive(ctx, subscription.Channel())
+	logger.Info("Flight updates subscriber stopped")
+}
+
+func (b *Broker) receive(ctx context.Context, messages <-chan *redis.Message) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			b.handle(ctx, msg)
+		}
+	}
+}
+
+func (b *Broker) handle(ctx context.Context, msg *redis.Message) {
+	change, err := decodeChange(msg.Payload)
+	if err != nil {
+		logger.WarnContext(ctx, "Discarding undecodable flight update", "channel", msg.Channel, "err", err)
+		return
+	}
+
+	// An update is only delivered when its channel and payload agree on the
+	// organization that owns the flight.
+	orgID, err := uuid.Parse(strings.TrimPrefix(msg.Channel, channelPrefix))
+	if err != nil || orgID != change.Flight.OrganizationID {
+		logger.WarnContext(ctx, "Discarding flight update published on an unexpected channel", "channel", msg.Channel, "flight_id", change.Flight.ID)
+		return
+	}
+
+	b.hub.deliver(change)
+}
@@ -0,0 +1,280 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedisClient records the publish scripts it is asked to run and serves the
+// sequence and change log from memory instead of Redis.
+type fakeRedisClient struct {
+	redis.Scripter
+	keys   []string
+	args   []any
+	head   string
+	log    []redis.XMessage
+	ranges int
+	err    error
+}
+
+func (f *fakeRedisClient) EvalSha(ctx context.Context, sha1 string, keys []string, args ...any) *redis.Cmd {
+	f.keys = keys
+	f.args = args
+	return redis.NewCmdResult(int64(len(args)/2), f.err)
+}
+
+func (f *fakeRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	if f.head == "" {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(f.head, f.err)
+}
+
+func (f *fakeRedisClient) XRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd {
+	f.ranges++
+	from, _ := strconv.ParseUint(strings.TrimPrefix(start, "0-"), 10, 64)
+	var messages []redis.XMessage
+	for _, msg := range f.log {
+		sequence, _ := strconv.ParseUint(strings.TrimPrefix(msg.ID, "0-"), 10, 64)
+		if sequence >= from && int64(len(messages)) < count {
+			messages = append(messages, msg)
+		}
+	}
+	return redis.NewXMessageSliceCmdResult(messages, f.err)
+}
+
+func (f *fakeRedisClient) PSubscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	panic("not used")
+}
+
+// logEntry encodes flight as the change log entry with the given sequence.
+func logEntry(t *testing.T, sequence uint64, flight *models.Flight) redis.XMessage {
+	t.Helper()
+	change, err := json.Marshal(changeOf(sequence, flight))
+	require.NoError(t, err)
+	return redis.XMessage{ID: "0-" + strconv.FormatUint(sequence, 10), Values: map[string]any{"change": string(change)}}
+}
+
+func sequences(changes []*models.FlightChange) []uint64 {
+	result := make([]uint64, len(changes))
+	for i, change := range changes {
+		result[i] = change.Sequence
+	}
+	return result
+}
+
+func TestBrokerPublish(t *testing.T) {
+	alpha, bravo := uuid.New(), uuid.New()
+
+	t.Run("Delivers In Process Without Redis", func(t *testing.T) {
+		broker := NewBroker(nil)
+		updates := broker.Subscribe(t.Context(), &alpha)
+		flight := &models.Flight{ID: uuid.New(), Number: "BA117", OrganizationID: alpha}
+
+		require.NoError(t, broker.Publish(t.Context(), flight))
+
+		received := receive(t, updates)
+		assert.Equal(t, uint64(1), received.Sequence)
+		assert.Equal(t, flight, received.Flight)
+		assert.NotSame(t, flight, received.Flight)
+	})
+
+	t.Run("Numbers And Publishes To Each Organization's Channel", func(t *testing.T) {
+		client := &fakeRedisClient{}
+		broker := &Broker{client: client, hub: newHub(), retention: retainedChanges}
+		updates := broker.Subscribe(t.Context(), nil)
+		first := &models.Flight{ID: uuid.New(), OrganizationID: alpha}
+		second := &models.Flight{ID: uuid.New(), OrganizationID: bravo}
+
+		require.NoError(t, broker.Publish(t.Context(), first, second))
+
+		assert.Equal(t, []string{sequenceKey, logKey}, client.keys)
+		require.Len(t, client.args, 5)
+		assert.Equal(t, retainedChanges, client.args[0])
+		assert.Equal(t, "flight-updates:"+alpha.String(), client.args[1])
+		assert.Equal(t, "flight-updates:"+bravo.String(), client.args[3])
+
+		var decoded models.Flight
+		require.NoError(t, json.Unmarshal(client.args[4].([]byte), &decoded))
+		assert.Equal(t, second.ID, decoded.ID)
+
+		// Updates only reach this process's subscribers through Redis.
+		assertNothingReceived(t, updates)
+	})
+
+	t.Run("Returns Redis Errors", func(t *testing.T) {
+		broker := &Broker{client: &fakeRedisClient{err: errors.New("connection refused")}, hub: newHub()}
+
+		err := broker.Publish(t.Context(), &models.Flight{ID: uuid.New(), OrganizationID: alpha})
+
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
+
+func TestBrokerReplay(t *testing.T) {
+	alpha, bravo := uuid.New(), uuid.New()
+	orgs := []uuid.UUID{alpha, bravo, alpha, bravo, alpha}
+
+	t.Run("Replays Retained Changes In Process", func(t *testing.T) {
+		broker := NewBroker(nil)
+		broker.retention = 3
+		for _, org := range orgs {
+			require.NoError(t, broker.Publish(t.Context(), &models.Flight{ID: uuid.New(), OrganizationID: org}))
+		}
+
+		head, err := broker.Head(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), head)
+
+		changes, err := broker.Replay(t.Context(), &alpha, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{3, 5}, sequences(changes))
+
+		changes, err = broker.Replay(t.Context(), nil, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{3, 4, 5}, sequences(changes))
+
+		changes, err = broker.Replay(t.Context(), nil, 5)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("Refuses Changes No Longer Retained In Process", func(t *testing.T) {
+		broker := NewBroker(nil)
+		broker.retention = 3
+		for _, org := range orgs {
+			require.NoError(t, broker.Publish(t.Context(), &models.Flight{ID: uuid.New(), OrganizationID: org}))
+		}
+
+		_, err := broker.Replay(t.Context(), nil, 1)
+		assert.ErrorIs(t, err, exceptions.ErrResumeExpired)
+
+		_, err = broker.Replay(t.Context(), nil, 6)
+		assert.ErrorIs(t, err, exceptions.ErrResumeExpired)
+	})
+
+	t.Run("Replays Retained Changes From Redis", func(t *testing.T) {
+		client := &fakeRedisClient{head: "7"}
+		for sequence := uint64(3); sequence <= 7; sequence++ {
+			client.log = append(client.log, logEntry(t, sequence, &models.Flight{ID: uuid.New(), OrganizationID: orgs[sequence%5]}))
+		}
+		broker := &Broker{client: client, hub: newHub(), retention: retainedChanges}
+
+		changes, err := broker.Replay(t.Context(), &alpha, 4)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{5, 7}, sequences(changes))
+		assert.Equal(t, alpha, changes[0].Flight.OrganizationID)
+
+		client.ranges = 0
+		changes, err = broker.Replay(t.Context(), nil, 7)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Zero(t, client.ranges)
+	})
+
+	t.Run("Refuses Changes Trimmed From Redis", func(t *testing.T) {
+		client := &fakeRedisClient{head: "7"}
+		for sequence := uint64(3); sequence <= 7; sequence++ {
+			client.log = append(client.log, logEntry(t, sequence, &models.Flight{ID: uuid.New(), OrganizationID: alpha}))
+		}
+		broker := &Broker{client: client, hub: newHub(), retention: retainedChanges}
+
+		_, err := broker.Replay(t.Context(), nil, 1)
+		assert.ErrorIs(t, err, exceptions.ErrResumeExpired)
+
+		_, err = broker.Replay(t.Context(), nil, 8)
+		assert.ErrorIs(t, err, exceptions.ErrResumeExpired)
+	})
+
+	t.Run("Reads Every Page From Redis", func(t *testing.T) {
+		client := &fakeRedisClient{head: strconv.Itoa(replayPage + 10)}
+		for sequence := uint64(1); sequence <= replayPage+10; sequence++ {
+			client.log = append(client.log, logEntry(t, sequence, &models.Flight{ID: uuid.New(), OrganizationID: alpha}))
+		}
+		broker := &Broker{client: client, hub: newHub(), retention: retainedChanges}
+
+		changes, err := broker.Replay(t.Context(), &alpha, 0)
+
+		require.NoError(t, err)
+		assert.Len(t, changes, replayPage+10)
+		assert.Equal(t, 2, client.ranges)
+	})
+
+	t.Run("Head Is Zero Before Anything Is Published", func(t *testing.T) {
+		broker := &Broker{client: &fakeRedisClient{}, hub: newHub()}
+
+		head, err := broker.Head(t.Context())
+
+		require.NoError(t, err)
+		assert.Zero(t, head)
+	})
+}
+
+func TestBrokerReceive(t *testing.T) {
+	alpha, bravo := uuid.New(), uuid.New()
+	flight := &models.Flight{ID: uuid.New(), Number: "BA117", Origin: "LHR", Destination: "JFK", OrganizationID: alpha, Version: 4}
+	payload, err := json.Marshal(changeOf(12, flight))
+	require.NoError(t, err)
+
+	broker := &Broker{client: &fakeRedisClient{}, hub: newHub()}
+	alphaUpdates := broker.Subscribe(t.Context(), &alpha)
+	bravoUpdates := broker.Subscribe(t.Context(), &bravo)
+
+	messages := make(chan *redis.Message, 4)
+	messages <- &redis.Message{Channel: "flight-updates:" + bravo.String(), Payload: string(payload)}
+	messages <- &redis.Message{Channel: "flight-updates:" + alpha.String(), Payload: "not json"}
+	messages <- &redis.Message{Channel: "flight-updates:" + alpha.String(), Payload: `{"sequence":11}`}
+	messages <- &redis.Message{Channel: "flight-updates:" + alpha.String(), Payload: string(payload)}
+	close(messages)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		broker.receive(t.Context(), messages)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("receive did not stop when the subscription closed")
+	}
+
+	received := receive(t, alphaUpdates)
+	assert.Equal(t, uint64(12), received.Sequence)
+	assert.Equal(t, flight.ID, received.Flight.ID)
+	assert.Equal(t, int32(4), received.Flight.Version)
+	assertNothingReceived(t, alphaUpdates)
+	assertNothingReceived(t, bravoUpdates)
+}
+
+func TestBrokerRunWithoutRedis(t *testing.T) {
+	broker := NewBroker(nil)
+	updates := broker.Subscribe(t.Context(), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		broker.Run(ctx)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after its context was cancelled")
+	}
+	assertClosed(t, updates)
+}
@@ -0,0 +1,107 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+type changeStore interface {
+	ProcessUnbroadcast(ctx context.Context, limit int, handle func(ctx context.Context, aggregateIDs []uuid.UUID) error) (int, error)
+}
+
+type flightStates interface {
+	GetFlightStates(ctx context.Context, ids []uuid.UUID) ([]*models.Flight, error)
+}
+
+// ChangeRelay publishes every change committed to the outbox through a Broker, so that
+// each is numbered and delivered even if the process that wrote it fails to publish.
+// For each claimed event it publishes the flight's state as it is when relayed, which
+// is never older than the change itself; changes a flight went through in between may
+// be delivered once. Events stay claimable until their flights have been published.
+type ChangeRelay struct {
+	store     changeStore
+	flights   flightStates
+	broker    *Broker
+	interval  time.Duration
+	batchSize int
+}
+
+// NewChangeRelay returns a ChangeRelay that polls store every interval, and whenever
+// broker is notified, relaying up to batchSize events per claim.
+func NewChangeRelay(store changeStore, flights flightStates, broker *Broker, interval time.Duration, batchSize int) *ChangeRelay {
+	return &ChangeRelay{
+		store:     store,
+		flights:   flights,
+		broker:    broker,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run relays changes until ctx is cancelled.
+func (r *ChangeRelay) Run(ctx context.Context) {
+	logger.InfoContext(ctx, "Starting flight change relay", "interval", r.interval, "batch_size", r.batchSize)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Flight change relay stopped")
+			return
+		case <-ticker.C:
+		case <-r.broker.wake:
+		}
+	}
+}
+
+// drain claims batches until the outbox has no more changes to relay.
+func (r *ChangeRelay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := r.store.ProcessUnbroadcast(ctx, r.batchSize, r.publish)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to relay flight changes", "err", err)
+			return
+		}
+		if processed < r.batchSize {
+			return
+		}
+	}
+}
+
+// publish publishes the current state of the flights in ids. A flight that no longer
+// exists has nothing left to announce and is skipped.
+func (r *ChangeRelay) publish(ctx context.Context, ids []uuid.UUID) error {
+	flights, err := r.flights.GetFlightStates(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(flights) < len(ids) {
+		logger.WarnContext(ctx, "Skipping changes to flights that no longer exist", "changed", len(ids), "found", len(flights))
+	}
+
+	// Published in the order the changes were made.
+	byID := make(map[uuid.UUID]*models.Flight, len(flights))
+	for _, flight := range flights {
+		byID[flight.ID] = flight
+	}
+	ordered := make([]*models.Flight, 0, len(flights))
+	for _, id := range ids {
+		if flight, ok := byID[id]; ok {
+			ordered = append(ordered, flight)
+		}
+	}
+
+	if err := r.broker.Publish(ctx, ordered...); err != nil {
+		return fmt.Errorf("relay %d flight changes: %w", len(ordered), err)
+	}
+	return nil
+}
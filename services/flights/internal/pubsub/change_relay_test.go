@@ -0,0 +1,140 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChangeStore hands out its batches of changed flights in order, keeping a batch
+// whose handler fails to be claimed again, as the outbox does.
+type fakeChangeStore struct {
+	batches [][]uuid.UUID
+	claims  chan struct{}
+	err     error
+}
+
+func (f *fakeChangeStore) ProcessUnbroadcast(ctx context.Context, limit int, handle func(ctx context.Context, aggregateIDs []uuid.UUID) error) (int, error) {
+	if f.claims != nil {
+		f.claims <- struct{}{}
+	}
+	if f.err != nil {
+		return 0, f.err
+	}
+	if len(f.batches) == 0 {
+		return 0, nil
+	}
+	if err := handle(ctx, f.batches[0]); err != nil {
+		return 0, err
+	}
+	processed := len(f.batches[0])
+	f.batches = f.batches[1:]
+	return processed, nil
+}
+
+type fakeFlightStates struct {
+	flights map[uuid.UUID]*models.Flight
+	err     error
+}
+
+func (f *fakeFlightStates) GetFlightStates(ctx context.Context, ids []uuid.UUID) ([]*models.Flight, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	// Returned in reverse, as the database gives no order.
+	var found []*models.Flight
+	for i := len(ids) - 1; i >= 0; i-- {
+		if flight, ok := f.flights[ids[i]]; ok {
+			found = append(found, flight)
+		}
+	}
+	return found, nil
+}
+
+func TestChangeRelayDrain(t *testing.T) {
+	orgID := uuid.New()
+	first := &models.Flight{ID: uuid.New(), OrganizationID: orgID}
+	second := &models.Flight{ID: uuid.New(), OrganizationID: orgID}
+	third := &models.Flight{ID: uuid.New(), OrganizationID: orgID}
+	states := &fakeFlightStates{flights: map[uuid.UUID]*models.Flight{first.ID: first, second.ID: second, third.ID: third}}
+
+	t.Run("Publishes Every Batch In The Order Of The Changes", func(t *testing.T) {
+		broker := NewBroker(nil)
+		updates := broker.Subscribe(t.Context(), &orgID)
+		store := &fakeChangeStore{batches: [][]uuid.UUID{{first.ID, second.ID}, {third.ID, uuid.New()}}}
+		relay := NewChangeRelay(store, states, broker, time.Hour, 2)
+
+		relay.drain(t.Context())
+
+		for i, flight := range []*models.Flight{first, second, third} {
+			received := receive(t, updates)
+			assert.Equal(t, uint64(i+1), received.Sequence)
+			assert.Equal(t, flight.ID, received.Flight.ID)
+		}
+		assertNothingReceived(t, updates)
+		assert.Empty(t, store.batches)
+	})
+
+	t.Run("Keeps A Batch That Could Not Be Read", func(t *testing.T) {
+		broker := NewBroker(nil)
+		store := &fakeChangeStore{batches: [][]uuid.UUID{{first.ID}}}
+		relay := NewChangeRelay(store, &fakeFlightStates{err: errors.New("connection reset")}, broker, time.Hour, 2)
+
+		relay.drain(t.Context())
+
+		head, err := broker.Head(t.Context())
+		require.NoError(t, err)
+		assert.Zero(t, head)
+		assert.Len(t, store.batches, 1)
+	})
+
+	t.Run("Keeps A Batch That Could Not Be Published", func(t *testing.T) {
+		broker := &Broker{client: &fakeRedisClient{err: errors.New("connection refused")}, hub: newHub()}
+		store := &fakeChangeStore{batches: [][]uuid.UUID{{first.ID}}}
+		relay := NewChangeRelay(store, states, broker, time.Hour, 2)
+
+		relay.drain(t.Context())
+
+		assert.Len(t, store.batches, 1)
+	})
+}
+
+func TestChangeRelayRun(t *testing.T) {
+	broker := NewBroker(nil)
+	store := &fakeChangeStore{claims: make(chan struct{})}
+	relay := NewChangeRelay(store, &fakeFlightStates{}, broker, time.Hour, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+
+	claimed := func() {
+		t.Helper()
+		select {
+		case <-store.claims:
+		case <-time.After(time.Second):
+			t.Fatal("the outbox was not read")
+		}
+	}
+
+	// Read on start, and again when notified rather than after the interval.
+	claimed()
+	broker.Notify()
+	claimed()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after its context was cancelled")
+	}
+}
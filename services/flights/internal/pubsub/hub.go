@@ -0,0 +1,105 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+// subscriberBuffer is how many updates a subscriber may fall behind before it is
+// dropped.
+const subscriberBuffer = 32
+
+type subscriber struct {
+	// orgID is the organization whose flights the subscriber receives, or nil for
+	// every organization.
+	orgID   *uuid.UUID
+	updates chan *models.FlightChange
+}
+
+// hub fans flight updates out to the subscribers of this process.
+type hub struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+func newHub() *hub {
+	return &hub{subscribers: make(map[*subscriber]struct{})}
+}
+
+// subscribe registers a subscriber for the flights of orgID, or of every organization
+// when orgID is nil. The returned channel is closed once ctx is done, the hub is
+// closed or the subscriber falls too far behind.
+func (h *hub) subscribe(ctx context.Context, orgID *uuid.UUID) <-chan *models.FlightChange {
+	sub := &subscriber{orgID: orgID, updates: make(chan *models.FlightChange, subscriberBuffer)}
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(sub.updates)
+		return sub.updates
+	}
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.unsubscribe(sub)
+	}()
+
+	return sub.updates
+}
+
+func (h *hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub)
+}
+
+// remove must be called with mu held; removing a subscriber twice is a no-op.
+func (h *hub) remove(sub *subscriber) {
+	if _, ok := h.subscribers[sub]; !ok {
+		return
+	}
+	delete(h.subscribers, sub)
+	close(sub.updates)
+}
+
+// deliver sends change to every subscriber of its flight's organization. A subscriber
+// whose buffer is full is dropped rather than waited for, so one stalled socket cannot
+// hold back the others; its subscription completes and the client can resubscribe.
+func (h *hub) deliver(change *models.FlightChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if sub.orgID != nil && *sub.orgID != change.Flight.OrganizationID {
+			continue
+		}
+		select {
+		case sub.updates <- change:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// close completes every subscription and refuses new ones.
+func (h *hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		h.remove(sub)
+	}
+}
+
+func (h *hub) len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
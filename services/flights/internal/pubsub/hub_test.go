@@ -0,0 +1,114 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receive returns the next update on updates, failing the test if none arrives.
+func receive(t *testing.T, updates <-chan *models.FlightChange) *models.FlightChange {
+	t.Helper()
+	select {
+	case change, ok := <-updates:
+		require.True(t, ok, "subscription closed")
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("no update received")
+		return nil
+	}
+}
+
+// changeOf wraps flight in a change with the given sequence.
+func changeOf(sequence uint64, flight *models.Flight) *models.FlightChange {
+	return &models.FlightChange{Sequence: sequence, Flight: flight}
+}
+
+// assertClosed fails the test unless updates is closed once any pending updates are read.
+func assertClosed(t *testing.T, updates <-chan *models.FlightChange) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("subscription was not closed")
+		}
+	}
+}
+
+func assertNothingReceived(t *testing.T, updates <-chan *models.FlightChange) {
+	t.Helper()
+	select {
+	case change := <-updates:
+		t.Fatalf("unexpected update for flight %s", change.Flight.ID)
+	default:
+	}
+}
+
+func TestHub(t *testing.T) {
+	alpha, bravo := uuid.New(), uuid.New()
+
+	t.Run("Delivers Only The Subscriber's Organization", func(t *testing.T) {
+		h := newHub()
+		alphaUpdates := h.subscribe(t.Context(), &alpha)
+		allUpdates := h.subscribe(t.Context(), nil)
+
+		h.deliver(changeOf(1, &models.Flight{ID: uuid.New(), OrganizationID: bravo}))
+		alphaChange := changeOf(2, &models.Flight{ID: uuid.New(), OrganizationID: alpha})
+		h.deliver(alphaChange)
+
+		assert.Equal(t, alphaChange, receive(t, alphaUpdates))
+		assertNothingReceived(t, alphaUpdates)
+
+		assert.Equal(t, bravo, receive(t, allUpdates).Flight.OrganizationID)
+		assert.Equal(t, alpha, receive(t, allUpdates).Flight.OrganizationID)
+	})
+
+	t.Run("Releases A Subscription When Its Context Is Done", func(t *testing.T) {
+		h := newHub()
+		ctx, cancel := context.WithCancel(context.Background())
+		updates := h.subscribe(ctx, &alpha)
+		require.Equal(t, 1, h.len())
+
+		cancel()
+
+		assertClosed(t, updates)
+		assert.Equal(t, 0, h.len())
+	})
+
+	t.Run("Drops A Subscriber That Falls Behind", func(t *testing.T) {
+		h := newHub()
+		slow := h.subscribe(t.Context(), &alpha)
+		fast := h.subscribe(t.Context(), &alpha)
+
+		for i := range subscriberBuffer {
+			h.deliver(changeOf(uint64(i+1), &models.Flight{ID: uuid.New(), OrganizationID: alpha}))
+			receive(t, fast)
+		}
+		h.deliver(changeOf(subscriberBuffer+1, &models.Flight{ID: uuid.New(), OrganizationID: alpha}))
+
+		assert.Equal(t, 1, h.len())
+		receive(t, fast)
+		assertClosed(t, slow)
+	})
+
+	t.Run("Close Completes Subscriptions And Refuses New Ones", func(t *testing.T) {
+		h := newHub()
+		updates := h.subscribe(t.Context(), nil)
+
+		h.close()
+
+		assertClosed(t, updates)
+		assertClosed(t, h.subscribe(t.Context(), nil))
+		assert.Equal(t, 0, h.len())
+	})
+}
@@ -0,0 +1,75 @@
+package aircraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/loaders"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+// FindAircraftByID resolves an Aircraft entity reference. The aircraft subgraph owns
+// the aircraft itself, so only the key is needed to hang Aircraft.flights off it.
+func (r *FlightResolver) FindAircraftByID(ctx context.Context, id string) (*model.Aircraft, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+	}
+	return &model.Aircraft{ID: id}, nil
+}
+
+// Flights lists the flights scheduled on an aircraft. Within a GraphQL request the
+// aircraft sharing the same arguments are batched through the request's loaders.
+func (r *FlightResolver) Flights(
+	ctx context.Context,
+	obj *model.Aircraft,
+	from *time.Time,
+	to *time.Time,
+	status *models.FlightStatus,
+	first *int32,
+	after *string,
+) (*models.FlightConnection, error) {
+	logger.Debug("Aircraft.flights GraphQL request", "aircraft_id", obj.ID)
+
+	if r.service == nil {
+		logger.Error("Aircraft.flights service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	aircraftID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+	}
+
+	filter := models.FlightFilter{
+		DepartureFrom: from,
+		DepartureTo:   to,
+		Status:        status,
+	}
+
+	var pageSize int32
+	if first != nil {
+		pageSize = *first
+	}
+
+	var cursor string
+	if after != nil {
+		cursor = *after
+	}
+
+	if l := loaders.FromContext(ctx); l != nil {
+		return l.AircraftFlights(filter, pageSize, cursor).Load(ctx, aircraftID)
+	}
+
+	connections, err := r.service.ListFlightsForAircraft(ctx, []uuid.UUID{aircraftID}, filter, pageSize, cursor)
+	if err != nil {
+		logger.Error("Failed to list flights for aircraft", "aircraft_id", aircraftID, "err", err)
+		return nil, err
+	}
+	return connections[aircraftID], nil
+}
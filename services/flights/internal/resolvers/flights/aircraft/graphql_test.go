@@ -0,0 +1,189 @@
+package aircraft
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/loaders"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) ListFlightsForAircraft(
+	ctx context.Context,
+	aircraftIDs []uuid.UUID,
+	filter models.FlightFilter,
+	first int32,
+	after string,
+) (map[uuid.UUID]*models.FlightConnection, error) {
+	args := m.Called(ctx, aircraftIDs, filter, first, after)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*models.FlightConnection), args.Error(1)
+}
+
+type batchFlightService struct {
+	mu      sync.Mutex
+	batches [][]uuid.UUID
+}
+
+func (s *batchFlightService) GetFlightsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+	return map[uuid.UUID]*models.Flight{}, nil
+}
+
+func (s *batchFlightService) ListFlightsForAircraft(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, first int32, after string) (map[uuid.UUID]*models.FlightConnection, error) {
+	s.mu.Lock()
+	s.batches = append(s.batches, aircraftIDs)
+	s.mu.Unlock()
+
+	connections := make(map[uuid.UUID]*models.FlightConnection, len(aircraftIDs))
+	for _, id := range aircraftIDs {
+		connections[id] = &models.FlightConnection{
+			Edges:    []*models.FlightEdge{{Cursor: "c", Node: &models.Flight{ID: uuid.New(), AircraftID: id}}},
+			PageInfo: &models.PageInfo{},
+		}
+	}
+	return connections, nil
+}
+
+func TestFlightResolverFlights(t *testing.T) {
+	aircraftID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	status := models.FlightStatusScheduled
+	first := int32(5)
+	after := "cursor-1"
+
+	connection := &models.FlightConnection{
+		Edges:    []*models.FlightEdge{{Cursor: "cursor-2", Node: &models.Flight{ID: uuid.New(), AircraftID: aircraftID}}},
+		PageInfo: &models.PageInfo{},
+	}
+	serviceErr := errors.New("database error")
+
+	tests := []struct {
+		name          string
+		aircraftID    string
+		first         *int32
+		after         *string
+		serviceSetup  func(*MockFlightService)
+		expectedError error
+	}{
+		{
+			name:       "success with filters and pagination",
+			aircraftID: aircraftID.String(),
+			first:      &first,
+			after:      &after,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlightsForAircraft", mock.Anything, []uuid.UUID{aircraftID},
+					models.FlightFilter{DepartureFrom: &from, DepartureTo: &to, Status: &status},
+					first, after).
+					Return(map[uuid.UUID]*models.FlightConnection{aircraftID: connection}, nil)
+			},
+		},
+		{
+			name:       "defaults pagination when omitted",
+			aircraftID: aircraftID.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlightsForAircraft", mock.Anything, []uuid.UUID{aircraftID}, mock.Anything, int32(0), "").
+					Return(map[uuid.UUID]*models.FlightConnection{aircraftID: connection}, nil)
+			},
+		},
+		{
+			name:          "invalid aircraft id",
+			aircraftID:    "not-a-uuid",
+			serviceSetup:  func(m *MockFlightService) {},
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:       "service error",
+			aircraftID: aircraftID.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlightsForAircraft", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, serviceErr)
+			},
+			expectedError: serviceErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFlightService)
+			tt.serviceSetup(mockService)
+			resolver := NewAircraftFlightsResolver(mockService)
+
+			result, err := resolver.Flights(context.Background(), &model.Aircraft{ID: tt.aircraftID}, &from, &to, &status, tt.first, tt.after)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, connection, result)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverFlightsServiceNotConfigured(t *testing.T) {
+	resolver := NewAircraftFlightsResolver(nil)
+
+	result, err := resolver.Flights(context.Background(), &model.Aircraft{ID: uuid.NewString()}, nil, nil, nil, nil, nil)
+
+	assert.EqualError(t, err, "service not configured")
+	assert.Nil(t, result)
+}
+
+func TestFlightResolverFlightsBatchesThroughLoaders(t *testing.T) {
+	resolver := NewAircraftFlightsResolver(new(MockFlightService))
+	batched := &batchFlightService{}
+	ctx := loaders.WithLoaders(context.Background(), loaders.NewLoaders(batched))
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	results := make([]*models.FlightConnection, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			connection, err := resolver.Flights(ctx, &model.Aircraft{ID: id.String()}, nil, nil, nil, nil, nil)
+			assert.NoError(t, err)
+			results[i] = connection
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, batched.batches, 1)
+	assert.ElementsMatch(t, ids, batched.batches[0])
+	for i, id := range ids {
+		if assert.NotNil(t, results[i]) {
+			assert.Equal(t, id, results[i].Edges[0].Node.AircraftID)
+		}
+	}
+}
+
+func TestFlightResolverFindAircraftByID(t *testing.T) {
+	resolver := NewAircraftFlightsResolver(nil)
+	id := uuid.NewString()
+
+	aircraft, err := resolver.FindAircraftByID(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Aircraft{ID: id}, aircraft)
+
+	aircraft, err = resolver.FindAircraftByID(context.Background(), "bad")
+	assert.ErrorIs(t, err, exceptions.ErrInvalidInput)
+	assert.Nil(t, aircraft)
+}
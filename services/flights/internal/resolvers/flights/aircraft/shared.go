@@ -0,0 +1,21 @@
+package aircraft
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+type AircraftFlightsLister interface {
+	ListFlightsForAircraft(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, first int32, after string) (map[uuid.UUID]*models.FlightConnection, error)
+}
+
+type FlightResolver struct {
+	service AircraftFlightsLister
+}
+
+// NewAircraftFlightsResolver returns a FlightResolver that lists an aircraft's flights through the provided AircraftFlightsLister.
+func NewAircraftFlightsResolver(service AircraftFlightsLister) *FlightResolver {
+	return &FlightResolver{service: service}
+}
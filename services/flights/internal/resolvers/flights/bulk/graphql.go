@@ -0,0 +1,55 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/schedule_import"
+	"github.com/google/uuid"
+)
+
+// BulkCreateFlights imports a schedule file sent as a multipart upload.
+func (r *FlightResolver) BulkCreateFlights(
+	ctx context.Context,
+	file graphql.Upload,
+	format models.ScheduleFormat,
+	aircraftID *string,
+	dryRun *bool,
+) (*models.BulkCreateReport, error) {
+	logger.Debug("BulkCreateFlights GraphQL request", "filename", file.Filename, "size", file.Size, "format", format)
+
+	if r.service == nil {
+		logger.Error("BulkCreateFlights service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	if file.Size > schedule_import.MaxFileSize {
+		return nil, fmt.Errorf("%w: schedule file is larger than %d bytes", exceptions.ErrInvalidInput, schedule_import.MaxFileSize)
+	}
+
+	options := models.BulkCreateOptions{Format: format}
+	if dryRun != nil {
+		options.DryRun = *dryRun
+	}
+	if aircraftID != nil {
+		id, err := uuid.Parse(*aircraftID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+		}
+		options.AircraftID = &id
+	}
+
+	report, err := r.service.BulkCreateFlights(ctx, file.File, options)
+	if err != nil {
+		logger.Error("Failed to import flight schedule", "err", err)
+		return nil, err
+	}
+
+	logger.Debug("BulkCreateFlights GraphQL response", "created", report.CreatedCount, "failed", report.FailedCount)
+	return report, nil
+}
@@ -0,0 +1,151 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/schedule_import"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) BulkCreateFlights(
+	ctx context.Context,
+	file io.Reader,
+	options models.BulkCreateOptions,
+) (*models.BulkCreateReport, error) {
+	body, _ := io.ReadAll(file)
+	args := m.Called(ctx, string(body), options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BulkCreateReport), args.Error(1)
+}
+
+func testReport() *models.BulkCreateReport {
+	flightID := uuid.New()
+	message := "duplicate flight"
+	return &models.BulkCreateReport{
+		CreatedCount: 1,
+		FailedCount:  1,
+		Results: []*models.BulkCreateResult{
+			{Line: 2, Number: "BA123", FlightID: &flightID},
+			{Line: 3, Number: "BA124", Error: &message},
+		},
+	}
+}
+
+func upload(content string) graphql.Upload {
+	return graphql.Upload{
+		File:     strings.NewReader(content),
+		Filename: "schedule.csv",
+		Size:     int64(len(content)),
+	}
+}
+
+func TestFlightResolverBulkCreateFlights(t *testing.T) {
+	aircraftID := uuid.New()
+	aircraftIDString := aircraftID.String()
+	invalidAircraftID := "fake uuid"
+	dryRun := true
+	report := testReport()
+
+	tests := []struct {
+		name          string
+		file          graphql.Upload
+		aircraftID    *string
+		dryRun        *bool
+		serviceSetup  func(*MockFlightService)
+		expectErr     bool
+		expectedError error
+	}{
+		{
+			name: "success with defaults",
+			file: upload("csv body"),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("BulkCreateFlights", mock.Anything, "csv body", models.BulkCreateOptions{Format: models.ScheduleFormatCSV}).
+					Return(report, nil)
+			},
+		},
+		{
+			name:       "success with aircraft and dry run",
+			file:       upload("csv body"),
+			aircraftID: &aircraftIDString,
+			dryRun:     &dryRun,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("BulkCreateFlights", mock.Anything, "csv body", models.BulkCreateOptions{
+					Format:     models.ScheduleFormatCSV,
+					AircraftID: &aircraftID,
+					DryRun:     true,
+				}).Return(report, nil)
+			},
+		},
+		{
+			name:          "invalid aircraft id",
+			file:          upload("csv body"),
+			aircraftID:    &invalidAircraftID,
+			serviceSetup:  func(_ *MockFlightService) {},
+			expectErr:     true,
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:          "file too large",
+			file:          graphql.Upload{File: strings.NewReader(""), Size: schedule_import.MaxFileSize + 1},
+			serviceSetup:  func(_ *MockFlightService) {},
+			expectErr:     true,
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name: "service returns error",
+			file: upload("csv body"),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("BulkCreateFlights", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewBulkCreateFlightsResolver(mockService)
+
+			result, err := resolver.BulkCreateFlights(context.Background(), tc.file, models.ScheduleFormatCSV, tc.aircraftID, tc.dryRun)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, report, result)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverBulkCreateFlightsServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	result, err := resolver.BulkCreateFlights(context.Background(), upload(""), models.ScheduleFormatCSV, nil, nil)
+
+	assert.EqualError(t, err, "service not configured")
+	assert.Nil(t, result)
+}
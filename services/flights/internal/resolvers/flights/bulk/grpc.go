@@ -0,0 +1,100 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/schedule_import"
+	"github.com/google/uuid"
+)
+
+// BulkCreateFlightsGRPC collects a schedule file streamed in chunks and imports it once
+// the client closes the stream.
+func (r *FlightResolver) BulkCreateFlightsGRPC(
+	ctx context.Context,
+	stream *connect.ClientStream[v1.BulkCreateFlightsRequest],
+) (*connect.Response[v1.BulkCreateFlightsResponse], error) {
+	ctx, err := middleware.UserContextFromHeaders(ctx, stream.RequestHeader())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("BulkCreateFlights service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	var file bytes.Buffer
+	var options *v1.BulkCreateFlightsOptions
+	for stream.Receive() {
+		msg := stream.Msg()
+		if options == nil {
+			options = msg.GetOptions()
+			if options == nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("the first message must carry the import options"))
+			}
+		}
+
+		if file.Len()+len(msg.GetChunk()) > schedule_import.MaxFileSize {
+			return nil, connect.NewError(connect.CodeResourceExhausted,
+				fmt.Errorf("schedule file is larger than %d bytes", schedule_import.MaxFileSize))
+		}
+		file.Write(msg.GetChunk())
+	}
+	if err := stream.Err(); err != nil {
+		logger.Error("Failed to receive schedule file", "err", err)
+		return nil, err
+	}
+	if options == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("no import options were sent"))
+	}
+
+	logger.Debug("BulkCreateFlights GRPC request", "size", file.Len(), "format", options.GetFormat())
+
+	importOptions, err := fromProtoOptions(options)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	report, err := r.service.BulkCreateFlights(ctx, &file, importOptions)
+	if err != nil {
+		logger.Error("Failed to import flight schedule", "err", err)
+		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+	}
+
+	logger.Debug("BulkCreateFlights GRPC response", "created", report.CreatedCount, "failed", report.FailedCount)
+	return connect.NewResponse(converters.ToProtoBulkCreateReport(report)), nil
+}
+
+func fromProtoOptions(options *v1.BulkCreateFlightsOptions) (models.BulkCreateOptions, error) {
+	result := models.BulkCreateOptions{DryRun: options.GetDryRun()}
+
+	format, ok := converters.FromProtoScheduleFormat(options.GetFormat())
+	if !ok {
+		return result, fmt.Errorf("%w: a schedule format is required", exceptions.ErrInvalidInput)
+	}
+	result.Format = format
+
+	if options.AircraftId != nil {
+		aircraftID, err := uuid.Parse(options.GetAircraftId())
+		if err != nil {
+			return result, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+		}
+		result.AircraftID = &aircraftID
+	}
+
+	return result, nil
+}
@@ -0,0 +1,182 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/schedule_import"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// sendStream streams messages to the resolver through a real connect handler, since a
+// ClientStream can only be created by connect itself.
+func sendStream(t *testing.T, resolver *FlightResolver, withUser bool, messages ...*v1.BulkCreateFlightsRequest) (*connect.Response[v1.BulkCreateFlightsResponse], error) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(flightsv1connect.FlightsServiceBulkCreateFlightsProcedure,
+		connect.NewClientStreamHandler(flightsv1connect.FlightsServiceBulkCreateFlightsProcedure, resolver.BulkCreateFlightsGRPC))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := connect.NewClient[v1.BulkCreateFlightsRequest, v1.BulkCreateFlightsResponse](
+		server.Client(), server.URL+flightsv1connect.FlightsServiceBulkCreateFlightsProcedure)
+
+	stream := client.CallClientStream(context.Background())
+	if withUser {
+		stream.RequestHeader().Set("x-user-sub", "123e4567-e89b-12d3-a456-426614174000")
+		stream.RequestHeader().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+		stream.RequestHeader().Set("x-org-name", "Test Airline")
+	}
+	for _, msg := range messages {
+		if err := stream.Send(msg); err != nil {
+			break
+		}
+	}
+	return stream.CloseAndReceive()
+}
+
+func TestFlightGrpcResolverBulkCreateFlights(t *testing.T) {
+	aircraftID := uuid.New()
+	aircraftIDString := aircraftID.String()
+	invalidAircraftID := "fake uuid"
+	report := testReport()
+
+	tests := []struct {
+		name         string
+		messages     []*v1.BulkCreateFlightsRequest
+		serviceSetup func(*MockFlightService)
+		expectErr    bool
+		expectedCode connect.Code
+	}{
+		{
+			name: "joins chunks",
+			messages: []*v1.BulkCreateFlightsRequest{
+				{
+					Options: &v1.BulkCreateFlightsOptions{
+						Format:     v1.ScheduleFormat_SCHEDULE_FORMAT_SSIM,
+						AircraftId: &aircraftIDString,
+						DryRun:     true,
+					},
+					Chunk: []byte("first "),
+				},
+				{Chunk: []byte("second")},
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("BulkCreateFlights", mock.Anything, "first second", models.BulkCreateOptions{
+					Format:     models.ScheduleFormatSSIM,
+					AircraftID: &aircraftID,
+					DryRun:     true,
+				}).Return(report, nil)
+			},
+		},
+		{
+			name:         "first message without options",
+			messages:     []*v1.BulkCreateFlightsRequest{{Chunk: []byte("data")}},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "empty stream",
+			serviceSetup: func(_ *MockFlightService) {},
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "missing format",
+			messages: []*v1.BulkCreateFlightsRequest{
+				{Options: &v1.BulkCreateFlightsOptions{}, Chunk: []byte("data")},
+			},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid aircraft id",
+			messages: []*v1.BulkCreateFlightsRequest{
+				{Options: &v1.BulkCreateFlightsOptions{Format: v1.ScheduleFormat_SCHEDULE_FORMAT_CSV, AircraftId: &invalidAircraftID}},
+			},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "file too large",
+			messages: []*v1.BulkCreateFlightsRequest{
+				{Options: &v1.BulkCreateFlightsOptions{Format: v1.ScheduleFormat_SCHEDULE_FORMAT_CSV}, Chunk: make([]byte, schedule_import.MaxFileSize/2)},
+				{Chunk: make([]byte, schedule_import.MaxFileSize/2)},
+				{Chunk: []byte("x")},
+			},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectErr:    true,
+			expectedCode: connect.CodeResourceExhausted,
+		},
+		{
+			name: "service returns error",
+			messages: []*v1.BulkCreateFlightsRequest{
+				{Options: &v1.BulkCreateFlightsOptions{Format: v1.ScheduleFormat_SCHEDULE_FORMAT_CSV}, Chunk: []byte("data")},
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("BulkCreateFlights", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			expectErr:    true,
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewBulkCreateFlightsResolver(mockService)
+
+			resp, err := sendStream(t, resolver, true, tc.messages...)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int32(1), resp.Msg.CreatedCount)
+			assert.Equal(t, int32(1), resp.Msg.FailedCount)
+			require.Len(t, resp.Msg.Results, 2)
+			assert.Equal(t, report.Results[0].FlightID.String(), resp.Msg.Results[0].FlightId)
+			assert.Equal(t, "duplicate flight", resp.Msg.Results[1].Error)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightGrpcResolverBulkCreateFlightsServiceNotConfigured(t *testing.T) {
+	resp, err := sendStream(t, &FlightResolver{}, true)
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
+
+func TestFlightGrpcResolverBulkCreateFlightsMissingUserContext(t *testing.T) {
+	mockService := &MockFlightService{}
+
+	resp, err := sendStream(t, NewBulkCreateFlightsResolver(mockService), false)
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+	mockService.AssertNotCalled(t, "BulkCreateFlights")
+}
@@ -0,0 +1,21 @@
+package bulk
+
+import (
+	"context"
+	"io"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+type FlightBulkCreator interface {
+	BulkCreateFlights(ctx context.Context, file io.Reader, options models.BulkCreateOptions) (*models.BulkCreateReport, error)
+}
+
+type FlightResolver struct {
+	service FlightBulkCreator
+}
+
+// NewBulkCreateFlightsResolver returns a FlightResolver that delegates schedule imports to the provided FlightBulkCreator.
+func NewBulkCreateFlightsResolver(service FlightBulkCreator) *FlightResolver {
+	return &FlightResolver{service: service}
+}
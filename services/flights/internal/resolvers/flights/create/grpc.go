@@ -6,69 +6,12 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
-	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
 	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
 	"github.com/google/uuid"
-	"google.golang.org/protobuf/types/known/timestamppb"
-
-	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
 )
 
-func extractUserContextFromMetadata(ctx context.Context, req *connect.Request[v1.CreateFlightRequest]) (context.Context, error) {
-	logger.Debug("All gRPC headers", "headers", req.Header())
-
-	userSub := req.Header().Get("x-user-sub")
-	orgID := req.Header().Get("x-org-id")
-	orgName := req.Header().Get("x-org-name")
-	roles := req.Header().Get("x-user-roles")
-
-	logger.Debug("Extracting user context from gRPC metadata", "userSub", userSub, "orgID", orgID, "orgName", orgName, "roles", roles)
-
-	// Require both user sub and org ID
-	if userSub == "" {
-		logger.Warn("Missing required user sub in metadata")
-		return ctx, errors.New("missing required user authentication")
-	}
-
-	if orgID == "" {
-		logger.Warn("Missing required organization ID in metadata")
-		return ctx, errors.New("missing required organization context")
-	}
-
-	if orgName == "" {
-		logger.Warn("Missing required organization name in metadata")
-		return ctx, errors.New("missing required organization context")
-	}
-
-	var parsedUserID, parsedOrgID uuid.UUID
-	var err error
-
-	parsedUserID, err = uuid.Parse(userSub)
-	if err != nil {
-		logger.Warn("Invalid user ID in metadata", "userSub", userSub, "err", err)
-		return ctx, errors.New("invalid user ID format")
-	}
-
-	parsedOrgID, err = uuid.Parse(orgID)
-	if err != nil {
-		logger.Warn("Invalid organization ID in metadata", "orgID", orgID, "err", err)
-		return ctx, errors.New("invalid organization ID format")
-	}
-
-	userCtx := &userContext.UserContext{
-		UserID:  parsedUserID,
-		OrgID:   parsedOrgID,
-		OrgName: orgName,
-		Roles:   roles,
-	}
-
-	logger.Debug("Created user context", "userID", parsedUserID, "orgID", parsedOrgID, "roles", roles)
-
-	return middleware.SetUserContextInContext(ctx, userCtx), nil
-}
-
 func (r *FlightResolver) CreateFlightGRPC(
 	ctx context.Context,
 	req *connect.Request[v1.CreateFlightRequest],
@@ -76,7 +19,7 @@ func (r *FlightResolver) CreateFlightGRPC(
 	logger.Debug("CreateFlight request", "number", req.Msg.GetNumber())
 
 	// Extract user context from gRPC metadata
-	ctx, err := extractUserContextFromMetadata(ctx, req)
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
 	if err != nil {
 		logger.Error("Failed to extract user context", "err", err)
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
@@ -126,20 +69,11 @@ func (r *FlightResolver) CreateFlightGRPC(
 
 	if err != nil {
 		logger.Error("Failed to create flight", "err", err)
-		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+		return nil, converters.ToConnectError(err)
 	}
 
 	resp := &v1.CreateFlightResponse{
-		Flight: &v1.Flight{
-			Id:            flight.ID.String(),
-			Number:        flight.Number,
-			Origin:        flight.Origin,
-			Destination:   flight.Destination,
-			DepartureTime: timestamppb.New(flight.DepartureTime),
-			ArrivalTime:   timestamppb.New(flight.ArrivalTime),
-			Status:        converters.ToProtoStatus(flight.Status),
-			AircraftId:    flight.AircraftID.String(),
-		},
+		Flight: converters.ToProtoFlight(flight),
 	}
 
 	logger.Debug("CreateFlight response created", "number", flight.Number, "id", flight.ID)
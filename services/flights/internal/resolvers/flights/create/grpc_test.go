@@ -19,6 +19,7 @@ func newRequestWithUserContext(req *v1.CreateFlightRequest) *connect.Request[v1.
 	connectReq := connect.NewRequest(req)
 	connectReq.Header().Set("x-user-sub", "123e4567-e89b-12d3-a456-426614174000")
 	connectReq.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	connectReq.Header().Set("x-org-name", "Test Airline")
 	connectReq.Header().Set("x-user-roles", "user")
 	return connectReq
 }
@@ -282,3 +283,54 @@ func TestCreateFlightGRPCServiceError(testingHelper *testing.T) {
 		testingHelper.Errorf("expected CodeInternal, got %v", connectionError.Code())
 	}
 }
+
+func TestCreateFlightGRPCAircraftConflict(testingHelper *testing.T) {
+	dep := time.Now()
+	arr := dep.Add(1 * time.Hour)
+	clashID := uuid.New()
+
+	f := &fakeService{
+		createFn: func(ctx context.Context, number, origin, dest string, depTime, arrTime time.Time, aircraftId uuid.UUID) (*models.Flight, error) {
+			return nil, &exceptions.AircraftConflictError{
+				Kind:         exceptions.AircraftConflictOverlap,
+				AircraftID:   aircraftId,
+				FlightID:     clashID,
+				FlightNumber: "CD455",
+			}
+		},
+	}
+	resolver := NewCreateFlightResolver(f)
+
+	req := newRequestWithUserContext(&v1.CreateFlightRequest{
+		Number:        "CD456",
+		Origin:        "LHR",
+		Destination:   "LGW",
+		DepartureTime: timestamppb.New(dep),
+		ArrivalTime:   timestamppb.New(arr),
+		AircraftId:    uuid.NewString(),
+	})
+
+	_, err := resolver.CreateFlightGRPC(context.Background(), req)
+
+	var connectionError *connect.Error
+	if !errors.As(err, &connectionError) {
+		testingHelper.Fatalf("expected connect.Error, got %T", err)
+	}
+	if connectionError.Code() != connect.CodeFailedPrecondition {
+		testingHelper.Errorf("expected CodeFailedPrecondition, got %v", connectionError.Code())
+	}
+	if len(connectionError.Details()) != 1 {
+		testingHelper.Fatalf("expected one error detail, got %d", len(connectionError.Details()))
+	}
+	value, err := connectionError.Details()[0].Value()
+	if err != nil {
+		testingHelper.Fatalf("unexpected error decoding detail: %v", err)
+	}
+	detail, ok := value.(*v1.AircraftConflict)
+	if !ok {
+		testingHelper.Fatalf("expected AircraftConflict detail, got %T", value)
+	}
+	if detail.GetConflictingFlightId() != clashID.String() {
+		testingHelper.Errorf("expected conflicting flight %s, got %s", clashID, detail.GetConflictingFlightId())
+	}
+}
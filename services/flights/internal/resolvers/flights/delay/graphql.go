@@ -0,0 +1,48 @@
+package delay
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) ReportDelay(
+	ctx context.Context,
+	id string,
+	code string,
+	estimatedDepartureTime time.Time,
+	estimatedArrivalTime *time.Time,
+	remarks *string,
+) (*models.DelayResult, error) {
+	logger.Debug("ReportDelay GraphQL request", "id", id, "code", code)
+
+	if r.service == nil {
+		logger.Error("ReportDelay service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, errors.New("invalid flight ID format")
+	}
+
+	result, err := r.service.ReportDelay(ctx, models.DelayReport{
+		FlightID:               flightID,
+		Code:                   code,
+		EstimatedDepartureTime: estimatedDepartureTime,
+		EstimatedArrivalTime:   estimatedArrivalTime,
+		Remarks:                remarks,
+	})
+	if err != nil {
+		logger.Error("Failed to report flight delay", "id", id, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("ReportDelay GraphQL response created", "id", result.Flight.ID, "knock_on_flights", len(result.KnockOnFlights))
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package delay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) ReportDelay(ctx context.Context, report models.DelayReport) (*models.DelayResult, error) {
+	args := m.Called(ctx, report)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DelayResult), args.Error(1)
+}
+
+func TestFlightResolverReportDelay(t *testing.T) {
+	id := uuid.New()
+	departure := time.Date(2025, 4, 1, 10, 30, 0, 0, time.UTC)
+	arrival := departure.Add(8 * time.Hour)
+	remarks := "hydraulic leak"
+	expected := &models.DelayResult{
+		Flight:         &models.Flight{ID: id, Number: "BA117", Status: models.FlightStatusDelayed},
+		KnockOnFlights: []*models.Flight{},
+	}
+
+	tests := []struct {
+		name          string
+		id            string
+		arrival       *time.Time
+		remarks       *string
+		serviceSetup  func(*MockFlightService)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name:    "success",
+			id:      id.String(),
+			arrival: &arrival,
+			remarks: &remarks,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.Anything, models.DelayReport{
+					FlightID:               id,
+					Code:                   "TD",
+					EstimatedDepartureTime: departure,
+					EstimatedArrivalTime:   &arrival,
+					Remarks:                &remarks,
+				}).Return(expected, nil)
+			},
+		},
+		{
+			name: "success without arrival or remarks",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.Anything, models.DelayReport{
+					FlightID:               id,
+					Code:                   "TD",
+					EstimatedDepartureTime: departure,
+				}).Return(expected, nil)
+			},
+		},
+		{
+			name:          "invalid flight id",
+			id:            "fake uuid",
+			serviceSetup:  func(_ *MockFlightService) {},
+			errorContains: "invalid flight ID format",
+		},
+		{
+			name: "invalid delay code",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.Anything, mock.Anything).Return(nil, exceptions.ErrInvalidDelayCode)
+			},
+			expectedError: exceptions.ErrInvalidDelayCode,
+		},
+		{
+			name: "service returns error",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			errorContains: "db error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewReportDelayResolver(mockService)
+
+			result, err := resolver.ReportDelay(context.Background(), tc.id, "TD", departure, tc.arrival, tc.remarks)
+
+			if tc.expectedError != nil || tc.errorContains != "" {
+				assert.Error(t, err)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+				if tc.errorContains != "" {
+					assert.Contains(t, err.Error(), tc.errorContains)
+				}
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, result)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverReportDelayServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	result, err := resolver.ReportDelay(context.Background(), uuid.New().String(), "TD", time.Now(), nil, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service not configured")
+	assert.Nil(t, result)
+}
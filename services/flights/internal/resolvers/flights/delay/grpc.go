@@ -0,0 +1,75 @@
+package delay
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) ReportDelayGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.ReportDelayRequest],
+) (*connect.Response[v1.ReportDelayResponse], error) {
+	logger.Debug("ReportDelay request", "id", req.Msg.GetId(), "code", req.Msg.GetCode())
+
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("ReportDelay service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	flightID, err := uuid.Parse(req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid flight ID format"))
+	}
+
+	if req.Msg.GetEstimatedDepartureTime() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("estimated departure time is required"))
+	}
+
+	report := models.DelayReport{
+		FlightID:               flightID,
+		Code:                   req.Msg.GetCode(),
+		EstimatedDepartureTime: req.Msg.GetEstimatedDepartureTime().AsTime(),
+	}
+	if req.Msg.GetEstimatedArrivalTime() != nil {
+		arrival := req.Msg.GetEstimatedArrivalTime().AsTime()
+		report.EstimatedArrivalTime = &arrival
+	}
+	if remarks := req.Msg.GetRemarks(); remarks != "" {
+		report.Remarks = &remarks
+	}
+
+	result, err := r.service.ReportDelay(ctx, report)
+	if err != nil {
+		logger.Error("Failed to report flight delay", "id", flightID, "err", err)
+		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+	}
+
+	resp := &v1.ReportDelayResponse{
+		Flight:         converters.ToProtoFlight(result.Flight),
+		KnockOnFlights: make([]*v1.Flight, 0, len(result.KnockOnFlights)),
+	}
+	for _, flight := range result.KnockOnFlights {
+		resp.KnockOnFlights = append(resp.KnockOnFlights, converters.ToProtoFlight(flight))
+	}
+
+	logger.Debug("ReportDelay response created", "id", result.Flight.ID, "knock_on_flights", len(result.KnockOnFlights))
+	return connect.NewResponse(resp), nil
+}
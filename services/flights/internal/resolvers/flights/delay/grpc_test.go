@@ -0,0 +1,153 @@
+package delay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testUserID = uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+func newRequestWithUserContext(req *v1.ReportDelayRequest) *connect.Request[v1.ReportDelayRequest] {
+	connectReq := connect.NewRequest(req)
+	connectReq.Header().Set("x-user-sub", testUserID.String())
+	connectReq.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	connectReq.Header().Set("x-org-name", "Test Airline")
+	connectReq.Header().Set("x-user-roles", "dispatcher")
+	return connectReq
+}
+
+func TestFlightGrpcResolverReportDelay(t *testing.T) {
+	id := uuid.New()
+	knockOnID := uuid.New()
+	departure := time.Date(2025, 4, 1, 10, 30, 0, 0, time.UTC)
+	arrival := departure.Add(8 * time.Hour)
+	expected := &models.DelayResult{
+		Flight:         &models.Flight{ID: id, Number: "BA117", Status: models.FlightStatusDelayed},
+		KnockOnFlights: []*models.Flight{{ID: knockOnID, Number: "BA118", Status: models.FlightStatusDelayed}},
+	}
+
+	tests := []struct {
+		name         string
+		request      *v1.ReportDelayRequest
+		serviceSetup func(*MockFlightService)
+		expectedCode connect.Code
+	}{
+		{
+			name: "success",
+			request: &v1.ReportDelayRequest{
+				Id:                     id.String(),
+				Code:                   "41",
+				EstimatedDepartureTime: timestamppb.New(departure),
+				EstimatedArrivalTime:   timestamppb.New(arrival),
+				Remarks:                "hydraulic leak",
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.MatchedBy(func(ctx context.Context) bool {
+					return middleware.GetRequestUserContext(ctx).UserID == testUserID
+				}), mock.MatchedBy(func(report models.DelayReport) bool {
+					return report.FlightID == id && report.Code == "41" &&
+						report.EstimatedDepartureTime.Equal(departure) &&
+						report.EstimatedArrivalTime != nil && report.EstimatedArrivalTime.Equal(arrival) &&
+						report.Remarks != nil && *report.Remarks == "hydraulic leak"
+				})).Return(expected, nil)
+			},
+		},
+		{
+			name: "success without arrival or remarks",
+			request: &v1.ReportDelayRequest{
+				Id:                     id.String(),
+				Code:                   "41",
+				EstimatedDepartureTime: timestamppb.New(departure),
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.Anything, mock.MatchedBy(func(report models.DelayReport) bool {
+					return report.EstimatedArrivalTime == nil && report.Remarks == nil
+				})).Return(expected, nil)
+			},
+		},
+		{
+			name:         "invalid flight id",
+			request:      &v1.ReportDelayRequest{Id: "fake uuid", Code: "41", EstimatedDepartureTime: timestamppb.New(departure)},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "missing estimated departure",
+			request:      &v1.ReportDelayRequest{Id: id.String(), Code: "41"},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "invalid delay code",
+			request: &v1.ReportDelayRequest{Id: id.String(), Code: "ZZ", EstimatedDepartureTime: timestamppb.New(departure)},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.Anything, mock.Anything).Return(nil, exceptions.ErrInvalidDelayCode)
+			},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "departed flight",
+			request: &v1.ReportDelayRequest{Id: id.String(), Code: "41", EstimatedDepartureTime: timestamppb.New(departure)},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.Anything, mock.Anything).
+					Return(nil, exceptions.IllegalStatusTransition(models.FlightStatusDeparted, models.FlightStatusDelayed))
+			},
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:    "service returns error",
+			request: &v1.ReportDelayRequest{Id: id.String(), Code: "41", EstimatedDepartureTime: timestamppb.New(departure)},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ReportDelay", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewReportDelayResolver(mockService)
+
+			resp, err := resolver.ReportDelayGRPC(context.Background(), newRequestWithUserContext(tc.request))
+
+			if tc.expectedCode != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, id.String(), resp.Msg.Flight.Id)
+			assert.Equal(t, v1.FlightStatus_FLIGHT_STATUS_DELAYED, resp.Msg.Flight.Status)
+			require.Len(t, resp.Msg.KnockOnFlights, 1)
+			assert.Equal(t, knockOnID.String(), resp.Msg.KnockOnFlights[0].Id)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightGrpcResolverReportDelayMissingUserContext(t *testing.T) {
+	resolver := NewReportDelayResolver(&MockFlightService{})
+
+	resp, err := resolver.ReportDelayGRPC(context.Background(), connect.NewRequest(&v1.ReportDelayRequest{Id: uuid.New().String()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
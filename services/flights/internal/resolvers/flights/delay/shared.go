@@ -0,0 +1,20 @@
+package delay
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+type FlightDelayReporter interface {
+	ReportDelay(ctx context.Context, report models.DelayReport) (*models.DelayResult, error)
+}
+
+type FlightResolver struct {
+	service FlightDelayReporter
+}
+
+// NewReportDelayResolver returns a FlightResolver that delegates delay reports to the provided FlightDelayReporter.
+func NewReportDelayResolver(service FlightDelayReporter) *FlightResolver {
+	return &FlightResolver{service: service}
+}
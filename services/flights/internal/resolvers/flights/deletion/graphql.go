@@ -0,0 +1,58 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) DeleteFlight(ctx context.Context, id string) (*models.Flight, error) {
+	logger.Debug("DeleteFlight GraphQL request", "id", id)
+
+	if r.service == nil {
+		logger.Error("DeleteFlight service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, errors.New("invalid flight ID format")
+	}
+
+	flight, err := r.service.DeleteFlight(ctx, flightID)
+	if err != nil {
+		logger.Error("Failed to delete flight", "id", id, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("DeleteFlight GraphQL response created", "id", flight.ID)
+	return flight, nil
+}
+
+func (r *FlightResolver) RestoreFlight(ctx context.Context, id string) (*models.Flight, error) {
+	logger.Debug("RestoreFlight GraphQL request", "id", id)
+
+	if r.service == nil {
+		logger.Error("RestoreFlight service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, errors.New("invalid flight ID format")
+	}
+
+	flight, err := r.service.RestoreFlight(ctx, flightID)
+	if err != nil {
+		logger.Error("Failed to restore flight", "id", id, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("RestoreFlight GraphQL response created", "id", flight.ID)
+	return flight, nil
+}
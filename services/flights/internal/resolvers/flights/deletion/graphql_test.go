@@ -0,0 +1,148 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) DeleteFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
+
+func (m *MockFlightService) RestoreFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
+
+func TestFlightResolverDeleteFlight(t *testing.T) {
+	id := uuid.New()
+	deletedAt := time.Now()
+	expectedFlight := &models.Flight{ID: id, Number: "AA123", DeletedAt: &deletedAt}
+
+	tests := []struct {
+		name          string
+		id            string
+		serviceSetup  func(*MockFlightService)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("DeleteFlight", mock.Anything, id).Return(expectedFlight, nil)
+			},
+		},
+		{
+			name:          "invalid flight id",
+			id:            "fake uuid",
+			serviceSetup:  func(_ *MockFlightService) {},
+			errorContains: "invalid flight ID format",
+		},
+		{
+			name: "flight not found",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("DeleteFlight", mock.Anything, id).Return(nil, exceptions.ErrNotFound)
+			},
+			expectedError: exceptions.ErrNotFound,
+		},
+		{
+			name: "service returns error",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("DeleteFlight", mock.Anything, id).Return(nil, errors.New("db error"))
+			},
+			errorContains: "db error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewDeleteFlightResolver(mockService)
+
+			flight, err := resolver.DeleteFlight(context.Background(), tc.id)
+
+			if tc.expectedError != nil || tc.errorContains != "" {
+				assert.Error(t, err)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+				if tc.errorContains != "" {
+					assert.Contains(t, err.Error(), tc.errorContains)
+				}
+				assert.Nil(t, flight)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, expectedFlight, flight)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverRestoreFlight(t *testing.T) {
+	id := uuid.New()
+	expectedFlight := &models.Flight{ID: id, Number: "AA123"}
+
+	t.Run("success", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("RestoreFlight", mock.Anything, id).Return(expectedFlight, nil)
+
+		flight, err := NewDeleteFlightResolver(mockService).RestoreFlight(context.Background(), id.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedFlight, flight)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("duplicate flight", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("RestoreFlight", mock.Anything, id).Return(nil, exceptions.ErrDuplicateFlight)
+
+		flight, err := NewDeleteFlightResolver(mockService).RestoreFlight(context.Background(), id.String())
+
+		assert.ErrorIs(t, err, exceptions.ErrDuplicateFlight)
+		assert.Nil(t, flight)
+	})
+
+	t.Run("invalid flight id", func(t *testing.T) {
+		flight, err := NewDeleteFlightResolver(&MockFlightService{}).RestoreFlight(context.Background(), "fake uuid")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid flight ID format")
+		assert.Nil(t, flight)
+	})
+}
+
+func TestFlightResolverDeleteFlightServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	flight, err := resolver.DeleteFlight(context.Background(), uuid.New().String())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service not configured")
+	assert.Nil(t, flight)
+}
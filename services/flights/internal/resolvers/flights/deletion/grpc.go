@@ -0,0 +1,92 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) DeleteFlightGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.DeleteFlightRequest],
+) (*connect.Response[v1.DeleteFlightResponse], error) {
+	logger.Debug("DeleteFlight request", "id", req.Msg.GetId())
+
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("DeleteFlight service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	flightID, err := uuid.Parse(req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid flight ID format"))
+	}
+
+	flight, err := r.service.DeleteFlight(ctx, flightID)
+	if err != nil {
+		logger.Error("Failed to delete flight", "id", flightID, "err", err)
+		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+	}
+
+	resp := &v1.DeleteFlightResponse{
+		Flight: converters.ToProtoFlight(flight),
+	}
+
+	logger.Debug("DeleteFlight response created", "id", flight.ID)
+	return connect.NewResponse(resp), nil
+}
+
+func (r *FlightResolver) RestoreFlightGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.RestoreFlightRequest],
+) (*connect.Response[v1.RestoreFlightResponse], error) {
+	logger.Debug("RestoreFlight request", "id", req.Msg.GetId())
+
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("RestoreFlight service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	flightID, err := uuid.Parse(req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid flight ID format"))
+	}
+
+	flight, err := r.service.RestoreFlight(ctx, flightID)
+	if err != nil {
+		logger.Error("Failed to restore flight", "id", flightID, "err", err)
+		return nil, converters.ToConnectError(err)
+	}
+
+	resp := &v1.RestoreFlightResponse{
+		Flight: converters.ToProtoFlight(flight),
+	}
+
+	logger.Debug("RestoreFlight response created", "id", flight.ID)
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,129 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testUserID = uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+func withUserHeaders[T any](req *connect.Request[T]) *connect.Request[T] {
+	req.Header().Set("x-user-sub", testUserID.String())
+	req.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	req.Header().Set("x-org-name", "Test Airline")
+	req.Header().Set("x-user-roles", "ADMIN")
+	return req
+}
+
+func TestFlightGrpcResolverDeleteFlight(t *testing.T) {
+	id := uuid.New()
+	deletedAt := time.Now()
+	expectedFlight := &models.Flight{ID: id, Number: "AA123", DeletedAt: &deletedAt}
+
+	tests := []struct {
+		name         string
+		id           string
+		serviceSetup func(*MockFlightService)
+		expectedCode connect.Code
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("DeleteFlight", mock.MatchedBy(func(ctx context.Context) bool {
+					return middleware.GetRequestUserContext(ctx).UserID == testUserID
+				}), id).Return(expectedFlight, nil)
+			},
+		},
+		{
+			name:         "invalid flight id",
+			id:           "fake uuid",
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "flight not found",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("DeleteFlight", mock.Anything, id).Return(nil, exceptions.ErrNotFound)
+			},
+			expectedCode: connect.CodeNotFound,
+		},
+		{
+			name: "service returns error",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("DeleteFlight", mock.Anything, id).Return(nil, errors.New("db error"))
+			},
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewDeleteFlightResolver(mockService)
+
+			resp, err := resolver.DeleteFlightGRPC(context.Background(), withUserHeaders(connect.NewRequest(&v1.DeleteFlightRequest{Id: tc.id})))
+
+			if tc.expectedCode != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, id.String(), resp.Msg.Flight.Id)
+			assert.NotNil(t, resp.Msg.Flight.DeletedAt)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightGrpcResolverRestoreFlight(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("RestoreFlight", mock.Anything, id).Return(&models.Flight{ID: id, Number: "AA123"}, nil)
+
+		resp, err := NewDeleteFlightResolver(mockService).RestoreFlightGRPC(context.Background(), withUserHeaders(connect.NewRequest(&v1.RestoreFlightRequest{Id: id.String()})))
+
+		assert.NoError(t, err)
+		assert.Equal(t, id.String(), resp.Msg.Flight.Id)
+		assert.Nil(t, resp.Msg.Flight.DeletedAt)
+	})
+
+	t.Run("duplicate flight", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("RestoreFlight", mock.Anything, id).Return(nil, exceptions.ErrDuplicateFlight)
+
+		resp, err := NewDeleteFlightResolver(mockService).RestoreFlightGRPC(context.Background(), withUserHeaders(connect.NewRequest(&v1.RestoreFlightRequest{Id: id.String()})))
+
+		assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+		assert.Nil(t, resp)
+	})
+}
+
+func TestFlightGrpcResolverDeleteFlightMissingUserContext(t *testing.T) {
+	resolver := NewDeleteFlightResolver(&MockFlightService{})
+
+	resp, err := resolver.DeleteFlightGRPC(context.Background(), connect.NewRequest(&v1.DeleteFlightRequest{Id: uuid.New().String()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
@@ -0,0 +1,22 @@
+package deletion
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+type FlightDeleter interface {
+	DeleteFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+	RestoreFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+}
+
+type FlightResolver struct {
+	service FlightDeleter
+}
+
+// NewDeleteFlightResolver returns a FlightResolver that delegates deleting and restoring flights to the provided FlightDeleter.
+func NewDeleteFlightResolver(service FlightDeleter) *FlightResolver {
+	return &FlightResolver{service: service}
+}
@@ -0,0 +1,50 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/loaders"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+// FindFlightByID resolves a Flight entity representation for the router. gqlgen
+// resolves the representations of one _entities call concurrently, so inside a
+// GraphQL request they are funnelled through the request's FlightByID loader and
+// fetched together; without loaders it falls back to GetFlightByID.
+func (r *FlightResolver) FindFlightByID(ctx context.Context, id string) (*models.Flight, error) {
+	logger.Debug("FindFlightByID entity request", "id", id)
+
+	if r.service == nil {
+		logger.Error("FindFlightByID service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightId, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, errors.New("invalid flight ID format")
+	}
+
+	var flight *models.Flight
+	if l := loaders.FromContext(ctx); l != nil {
+		flight, err = l.FlightByID.Load(ctx, flightId)
+	} else {
+		flight, err = r.service.GetFlightByID(ctx, flightId, false)
+	}
+
+	if err != nil {
+		if errors.Is(err, exceptions.ErrNotFound) {
+			logger.Debug("Flight entity not found", "id", id)
+			return nil, nil
+		}
+
+		logger.Error("Failed to resolve flight entity", "id", id, "err", err)
+		return nil, err
+	}
+
+	return flight, nil
+}
@@ -0,0 +1,130 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/loaders"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type batchFlightService struct {
+	mu      sync.Mutex
+	batches [][]uuid.UUID
+}
+
+func (s *batchFlightService) GetFlightsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Flight, error) {
+	s.mu.Lock()
+	s.batches = append(s.batches, ids)
+	s.mu.Unlock()
+
+	flights := make(map[uuid.UUID]*models.Flight, len(ids))
+	for _, id := range ids {
+		flights[id] = &models.Flight{ID: id, Number: "AA123"}
+	}
+	return flights, nil
+}
+
+func (s *batchFlightService) ListFlightsForAircraft(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, first int32, after string) (map[uuid.UUID]*models.FlightConnection, error) {
+	return map[uuid.UUID]*models.FlightConnection{}, nil
+}
+
+func TestFlightResolverFindFlightByID(t *testing.T) {
+	id := uuid.New()
+	flight := &models.Flight{ID: id, Number: "AA123"}
+
+	tests := []struct {
+		name           string
+		id             string
+		serviceSetup   func(*MockFlightService)
+		expectedError  string
+		expectedFlight *models.Flight
+	}{
+		{
+			name: "found",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightByID", mock.Anything, id, false).Return(flight, nil)
+			},
+			expectedFlight: flight,
+		},
+		{
+			name: "not found",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightByID", mock.Anything, id, false).Return(nil, exceptions.ErrNotFound)
+			},
+		},
+		{
+			name: "service error",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightByID", mock.Anything, id, false).Return(nil, errors.New("db down"))
+			},
+			expectedError: "db down",
+		},
+		{
+			name:          "invalid id",
+			id:            "not-a-uuid",
+			serviceSetup:  func(m *MockFlightService) {},
+			expectedError: "invalid flight ID format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockFlightService)
+			tt.serviceSetup(service)
+			resolver := NewGetFlightResolver(service)
+
+			result, err := resolver.FindFlightByID(context.Background(), tt.id)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFlight, result)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverFindFlightByIDBatchesWithLoaders(t *testing.T) {
+	service := new(MockFlightService)
+	resolver := NewGetFlightResolver(service)
+
+	batched := &batchFlightService{}
+	ctx := loaders.WithLoaders(context.Background(), loaders.NewLoaders(batched))
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	results := make([]*models.Flight, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			flight, err := resolver.FindFlightByID(ctx, id.String())
+			assert.NoError(t, err)
+			results[i] = flight
+		}()
+	}
+	wg.Wait()
+
+	for i, id := range ids {
+		require.NotNil(t, results[i])
+		assert.Equal(t, id, results[i].ID)
+	}
+	require.Len(t, batched.batches, 1)
+	assert.ElementsMatch(t, ids, batched.batches[0])
+	service.AssertNotCalled(t, "GetFlightByID", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -13,8 +13,9 @@ import (
 func (r *FlightResolver) GetFlightById(
 	ctx context.Context,
 	id string,
+	includeDeleted *bool,
 ) (*models.Flight, error) {
-	logger.Debug("GetFlight GraphQL request", "id", id)
+	logger.Debug("GetFlight GraphQL request", "id", id, "include_deleted", includeDeleted)
 
 	if r.service == nil {
 		logger.Error("GetFlight service not configured")
@@ -27,7 +28,7 @@ func (r *FlightResolver) GetFlightById(
 		return nil, errors.New("invalid flight ID format")
 	}
 
-	flight, err := r.service.GetFlightByID(ctx, flightId)
+	flight, err := r.service.GetFlightByID(ctx, flightId, includeDeleted != nil && *includeDeleted)
 	if err != nil {
 		if errors.Is(err, exceptions.ErrNotFound) {
 			logger.Debug("Flight not found", "id", id)
@@ -20,8 +20,9 @@ type MockFlightService struct {
 func (m *MockFlightService) GetFlightByID(
 	ctx context.Context,
 	id uuid.UUID,
+	includeDeleted bool,
 ) (*models.Flight, error) {
-	args := m.Called(ctx, id)
+	args := m.Called(ctx, id, includeDeleted)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -58,7 +59,7 @@ func TestFlightResolverGetFlight(t *testing.T) {
 			id:   id.String(),
 			serviceSetup: func(m *MockFlightService) {
 				m.On("GetFlightByID",
-					mock.Anything, mock.Anything,
+					mock.Anything, mock.Anything, false,
 				).Return(expectedFlight, nil)
 			},
 			expectErr:      false,
@@ -69,7 +70,7 @@ func TestFlightResolverGetFlight(t *testing.T) {
 			id:   id.String(),
 			serviceSetup: func(m *MockFlightService) {
 				m.On("GetFlightByID",
-					mock.Anything, mock.Anything,
+					mock.Anything, mock.Anything, false,
 				).Return(nil, exceptions.ErrNotFound)
 			},
 			expectErr:      false,
@@ -80,7 +81,7 @@ func TestFlightResolverGetFlight(t *testing.T) {
 			id:   "fake uuid",
 			serviceSetup: func(m *MockFlightService) {
 				m.On("GetFlightByID",
-					mock.Anything, mock.Anything,
+					mock.Anything, mock.Anything, false,
 				).Return(expectedFlight, nil)
 			},
 			expectErr:      true,
@@ -99,7 +100,7 @@ func TestFlightResolverGetFlight(t *testing.T) {
 			name: "service returns error",
 			id:   id.String(),
 			serviceSetup: func(m *MockFlightService) {
-				m.On("GetFlightByID", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+				m.On("GetFlightByID", mock.Anything, mock.Anything, false).Return(nil, errors.New("db error"))
 			},
 			expectErr:     true,
 			expectedError: "db error",
@@ -122,6 +123,7 @@ func TestFlightResolverGetFlight(t *testing.T) {
 			flight, err := resolver.GetFlightById(
 				context.Background(),
 				tc.id,
+				nil,
 			)
 
 			if tc.expectErr {
@@ -137,3 +139,20 @@ func TestFlightResolverGetFlight(t *testing.T) {
 		})
 	}
 }
+
+func TestFlightResolverGetFlightIncludeDeleted(t *testing.T) {
+	id := uuid.New()
+	deletedAt := time.Date(2024, 12, 16, 9, 0, 0, 0, time.UTC)
+	deleted := &models.Flight{ID: id, Number: "AA123", DeletedAt: &deletedAt}
+	includeDeleted := true
+
+	mockService := &MockFlightService{}
+	mockService.On("GetFlightByID", mock.Anything, id, true).Return(deleted, nil)
+	resolver := NewGetFlightResolver(mockService)
+
+	flight, err := resolver.GetFlightById(context.Background(), id.String(), &includeDeleted)
+
+	assert.NoError(t, err)
+	assert.Equal(t, deleted, flight)
+	mockService.AssertExpectations(t)
+}
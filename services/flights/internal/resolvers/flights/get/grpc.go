@@ -8,9 +8,9 @@ import (
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
 	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
 	"github.com/google/uuid"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (r *FlightResolver) GetFlightByIdGRPC(
@@ -18,6 +18,12 @@ func (r *FlightResolver) GetFlightByIdGRPC(
 	req *connect.Request[v1.GetFlightByIdRequest],
 ) (*connect.Response[v1.GetFlightByIdResponse], error) {
 
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
 	if r.service == nil {
 		logger.Error("GetFlight service not configured")
 		return nil, errors.New("service not configured")
@@ -33,7 +39,7 @@ func (r *FlightResolver) GetFlightByIdGRPC(
 		return nil, errors.New("invalid flight ID format")
 	}
 
-	flight, err := r.service.GetFlightByID(ctx, flightId)
+	flight, err := r.service.GetFlightByID(ctx, flightId, req.Msg.GetIncludeDeleted())
 	if err != nil {
 		if errors.Is(err, exceptions.ErrNotFound) {
 			logger.Debug("Flight not found", "id", id)
@@ -44,22 +50,13 @@ func (r *FlightResolver) GetFlightByIdGRPC(
 			return connect.NewResponse(resp), nil
 		}
 		logger.Error("Failed to get flight", "id", id, "err", err)
-		return nil, err
+		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
 	}
 
 	logger.Debug("GetFlight GRPC response retrieved", "id", flight.ID)
 
 	resp := &v1.GetFlightByIdResponse{
-		Flight: &v1.Flight{
-			Id:            flight.ID.String(),
-			Number:        flight.Number,
-			Origin:        flight.Origin,
-			Destination:   flight.Destination,
-			DepartureTime: timestamppb.New(flight.DepartureTime),
-			ArrivalTime:   timestamppb.New(flight.ArrivalTime),
-			Status:        converters.ToProtoStatus(flight.Status),
-			AircraftId:    flight.AircraftID.String(),
-		},
+		Flight: converters.ToProtoFlight(flight),
 	}
 	return connect.NewResponse(resp), nil
 }
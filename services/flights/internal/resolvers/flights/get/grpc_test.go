@@ -15,6 +15,14 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+func newRequestWithUserContext(req *v1.GetFlightByIdRequest) *connect.Request[v1.GetFlightByIdRequest] {
+	connectReq := connect.NewRequest(req)
+	connectReq.Header().Set("x-user-sub", "123e4567-e89b-12d3-a456-426614174000")
+	connectReq.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	connectReq.Header().Set("x-org-name", "Test Airline")
+	return connectReq
+}
+
 func TestFlightGrpcResolverGetFlight(t *testing.T) {
 	id := uuid.New()
 	number := "AA123"
@@ -45,7 +53,7 @@ func TestFlightGrpcResolverGetFlight(t *testing.T) {
 			id:   id.String(),
 			serviceSetup: func(m *MockFlightService) {
 				m.On("GetFlightByID",
-					mock.Anything, id,
+					mock.Anything, id, false,
 				).Return(expectedFlight, nil)
 			},
 			expectErr: false,
@@ -55,7 +63,7 @@ func TestFlightGrpcResolverGetFlight(t *testing.T) {
 			id:   id.String(),
 			serviceSetup: func(m *MockFlightService) {
 				m.On("GetFlightByID",
-					mock.Anything, id,
+					mock.Anything, id, false,
 				).Return(nil, exceptions.ErrNotFound)
 			},
 			expectErr: false,
@@ -78,7 +86,7 @@ func TestFlightGrpcResolverGetFlight(t *testing.T) {
 			name: "service returns error",
 			id:   id.String(),
 			serviceSetup: func(m *MockFlightService) {
-				m.On("GetFlightByID", mock.Anything, id).
+				m.On("GetFlightByID", mock.Anything, id, false).
 					Return(nil, errors.New("db error"))
 			},
 			expectErr:     true,
@@ -98,7 +106,7 @@ func TestFlightGrpcResolverGetFlight(t *testing.T) {
 				resolver = &FlightResolver{service: mockService}
 			}
 
-			req := connect.NewRequest(&v1.GetFlightByIdRequest{Id: tc.id})
+			req := newRequestWithUserContext(&v1.GetFlightByIdRequest{Id: tc.id})
 			resp, err := resolver.GetFlightByIdGRPC(context.Background(), req)
 
 			if tc.expectErr {
@@ -125,3 +133,43 @@ func TestFlightGrpcResolverGetFlight(t *testing.T) {
 		})
 	}
 }
+
+func TestFlightGrpcResolverGetFlightMissingUserContext(t *testing.T) {
+	mockService := &MockFlightService{}
+	resolver := NewGetFlightResolver(mockService)
+
+	resp, err := resolver.GetFlightByIdGRPC(context.Background(), connect.NewRequest(&v1.GetFlightByIdRequest{Id: uuid.NewString()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+	mockService.AssertNotCalled(t, "GetFlightByID")
+}
+
+func TestFlightGrpcResolverGetFlightIncludeDeleted(t *testing.T) {
+	id := uuid.New()
+	deletedAt := time.Date(2024, 12, 16, 9, 0, 0, 0, time.UTC)
+
+	t.Run("returns the deleted flight with its tombstone", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("GetFlightByID", mock.Anything, id, true).
+			Return(&models.Flight{ID: id, Number: "AA123", DeletedAt: &deletedAt}, nil)
+		resolver := NewGetFlightResolver(mockService)
+
+		resp, err := resolver.GetFlightByIdGRPC(context.Background(), newRequestWithUserContext(&v1.GetFlightByIdRequest{Id: id.String(), IncludeDeleted: true}))
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Msg.GetFlight().GetDeletedAt().AsTime().Equal(deletedAt))
+	})
+
+	t.Run("non-admin is refused", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("GetFlightByID", mock.Anything, id, true).Return(nil, exceptions.ErrForbidden)
+		resolver := NewGetFlightResolver(mockService)
+
+		resp, err := resolver.GetFlightByIdGRPC(context.Background(), newRequestWithUserContext(&v1.GetFlightByIdRequest{Id: id.String(), IncludeDeleted: true}))
+
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		assert.Nil(t, resp)
+	})
+}
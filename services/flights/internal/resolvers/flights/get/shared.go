@@ -8,7 +8,7 @@ import (
 )
 
 type FlightGetter interface {
-	GetFlightByID(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+	GetFlightByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Flight, error)
 }
 
 type FlightResolver struct {
@@ -0,0 +1,43 @@
+package history
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+)
+
+// History resolves Flight.history, the audit trail of writes to flight.
+func (r *FlightResolver) History(
+	ctx context.Context,
+	flight *models.Flight,
+	first *int32,
+	after *string,
+) (*models.FlightHistoryConnection, error) {
+	logger.Debug("FlightHistory GraphQL request", "id", flight.ID)
+
+	if r.service == nil {
+		logger.Error("FlightHistory service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	var pageSize int32
+	if first != nil {
+		pageSize = *first
+	}
+
+	var cursor string
+	if after != nil {
+		cursor = *after
+	}
+
+	connection, err := r.service.GetFlightHistory(ctx, flight.ID, pageSize, cursor)
+	if err != nil {
+		logger.Error("Failed to get flight history", "id", flight.ID, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("FlightHistory GraphQL response retrieved", "count", len(connection.Edges))
+	return connection, nil
+}
@@ -0,0 +1,125 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) GetFlightHistory(
+	ctx context.Context,
+	id uuid.UUID,
+	first int32,
+	after string,
+) (*models.FlightHistoryConnection, error) {
+	args := m.Called(ctx, id, first, after)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FlightHistoryConnection), args.Error(1)
+}
+
+func testConnection(flightID uuid.UUID) *models.FlightHistoryConnection {
+	endCursor := "cursor-1"
+	return &models.FlightHistoryConnection{
+		Edges: []*models.FlightHistoryEdge{
+			{
+				Cursor: endCursor,
+				Node: &models.FlightHistoryEntry{
+					ID:        uuid.New(),
+					FlightID:  flightID,
+					Operation: models.FlightHistoryOperationStatusChanged,
+					ActorID:   uuid.New(),
+					Before:    json.RawMessage(`{"status":"SCHEDULED"}`),
+					After:     json.RawMessage(`{"status":"DELAYED"}`),
+					CreatedAt: time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		PageInfo: &models.PageInfo{
+			HasNextPage: true,
+			StartCursor: &endCursor,
+			EndCursor:   &endCursor,
+		},
+	}
+}
+
+func TestFlightResolverHistory(t *testing.T) {
+	flight := &models.Flight{ID: uuid.New()}
+	first := int32(10)
+	after := "cursor-0"
+	connection := testConnection(flight.ID)
+
+	tests := []struct {
+		name         string
+		first        *int32
+		after        *string
+		serviceSetup func(*MockFlightService)
+		expectErr    bool
+	}{
+		{
+			name: "success with defaults",
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightHistory", mock.Anything, flight.ID, int32(0), "").
+					Return(connection, nil)
+			},
+		},
+		{
+			name:  "success with paging",
+			first: &first,
+			after: &after,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightHistory", mock.Anything, flight.ID, first, after).
+					Return(connection, nil)
+			},
+		},
+		{
+			name: "service returns error",
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewFlightHistoryResolver(mockService)
+
+			result, err := resolver.History(context.Background(), flight, tc.first, tc.after)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, connection, result)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverHistoryServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	result, err := resolver.History(context.Background(), &models.Flight{ID: uuid.New()}, nil, nil)
+
+	assert.EqualError(t, err, "service not configured")
+	assert.Nil(t, result)
+}
@@ -0,0 +1,62 @@
+package history
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) GetFlightHistoryGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.GetFlightHistoryRequest],
+) (*connect.Response[v1.GetFlightHistoryResponse], error) {
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("FlightHistory service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	id := req.Msg.GetId()
+
+	logger.Debug("FlightHistory GRPC request", "id", id, "page_size", req.Msg.GetPageSize())
+
+	flightID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid flight ID format"))
+	}
+
+	connection, err := r.service.GetFlightHistory(ctx, flightID, req.Msg.GetPageSize(), req.Msg.GetPageToken())
+	if err != nil {
+		logger.Error("Failed to get flight history", "id", id, "err", err)
+		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+	}
+
+	resp := &v1.GetFlightHistoryResponse{
+		Entries: make([]*v1.FlightHistoryEntry, 0, len(connection.Edges)),
+	}
+	for _, edge := range connection.Edges {
+		resp.Entries = append(resp.Entries, converters.ToProtoFlightHistoryEntry(edge.Node))
+	}
+	if connection.PageInfo.HasNextPage && connection.PageInfo.EndCursor != nil {
+		resp.NextPageToken = *connection.PageInfo.EndCursor
+	}
+
+	logger.Debug("FlightHistory GRPC response retrieved", "count", len(resp.Entries))
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,128 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRequestWithUserContext(req *v1.GetFlightHistoryRequest) *connect.Request[v1.GetFlightHistoryRequest] {
+	connectReq := connect.NewRequest(req)
+	connectReq.Header().Set("x-user-sub", "123e4567-e89b-12d3-a456-426614174000")
+	connectReq.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	connectReq.Header().Set("x-org-name", "Test Airline")
+	return connectReq
+}
+
+func TestFlightGrpcResolverGetFlightHistory(t *testing.T) {
+	flightID := uuid.New()
+	connection := testConnection(flightID)
+
+	tests := []struct {
+		name          string
+		request       *v1.GetFlightHistoryRequest
+		serviceSetup  func(*MockFlightService)
+		expectErr     bool
+		expectedCode  connect.Code
+		expectedToken string
+		expectedCount int
+	}{
+		{
+			name:    "success",
+			request: &v1.GetFlightHistoryRequest{Id: flightID.String(), PageSize: 5, PageToken: "cursor-0"},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightHistory", mock.Anything, flightID, int32(5), "cursor-0").
+					Return(connection, nil)
+			},
+			expectedToken: "cursor-1",
+			expectedCount: 1,
+		},
+		{
+			name:    "last page has no next page token",
+			request: &v1.GetFlightHistoryRequest{Id: flightID.String()},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightHistory", mock.Anything, flightID, int32(0), "").
+					Return(&models.FlightHistoryConnection{PageInfo: &models.PageInfo{}}, nil)
+			},
+		},
+		{
+			name:         "invalid flight id",
+			request:      &v1.GetFlightHistoryRequest{Id: "fake uuid"},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "invalid page token",
+			request: &v1.GetFlightHistoryRequest{Id: flightID.String(), PageToken: "bad"},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightHistory", mock.Anything, flightID, int32(0), "bad").
+					Return(nil, exceptions.ErrInvalidCursor)
+			},
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "service returns error",
+			request: &v1.GetFlightHistoryRequest{Id: flightID.String()},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("GetFlightHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			expectErr:    true,
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewFlightHistoryResolver(mockService)
+
+			resp, err := resolver.GetFlightHistoryGRPC(context.Background(), newRequestWithUserContext(tc.request))
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, resp.Msg.Entries, tc.expectedCount)
+			assert.Equal(t, tc.expectedToken, resp.Msg.NextPageToken)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightGrpcResolverGetFlightHistoryServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	resp, err := resolver.GetFlightHistoryGRPC(context.Background(), newRequestWithUserContext(&v1.GetFlightHistoryRequest{Id: uuid.NewString()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
+
+func TestFlightGrpcResolverGetFlightHistoryMissingUserContext(t *testing.T) {
+	mockService := &MockFlightService{}
+	resolver := NewFlightHistoryResolver(mockService)
+
+	resp, err := resolver.GetFlightHistoryGRPC(context.Background(), connect.NewRequest(&v1.GetFlightHistoryRequest{Id: uuid.NewString()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+	mockService.AssertNotCalled(t, "GetFlightHistory")
+}
@@ -0,0 +1,21 @@
+package history
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+type FlightHistoryGetter interface {
+	GetFlightHistory(ctx context.Context, id uuid.UUID, first int32, after string) (*models.FlightHistoryConnection, error)
+}
+
+type FlightResolver struct {
+	service FlightHistoryGetter
+}
+
+// NewFlightHistoryResolver returns a FlightResolver that delegates history lookups to the provided FlightHistoryGetter.
+func NewFlightHistoryResolver(service FlightHistoryGetter) *FlightResolver {
+	return &FlightResolver{service: service}
+}
@@ -0,0 +1,79 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) ListFlights(
+	ctx context.Context,
+	filter *model.FlightFilterInput,
+	first *int32,
+	after *string,
+) (*models.FlightConnection, error) {
+	logger.Debug("ListFlights GraphQL request")
+
+	if r.service == nil {
+		logger.Error("ListFlights service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightFilter, err := fromGraphQLFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var pageSize int32
+	if first != nil {
+		pageSize = *first
+	}
+
+	var cursor string
+	if after != nil {
+		cursor = *after
+	}
+
+	connection, err := r.service.ListFlights(ctx, flightFilter, pageSize, cursor)
+	if err != nil {
+		logger.Error("Failed to list flights", "err", err)
+		return nil, err
+	}
+
+	logger.Debug("ListFlights GraphQL response retrieved", "count", len(connection.Edges))
+	return connection, nil
+}
+
+func fromGraphQLFilter(filter *model.FlightFilterInput) (models.FlightFilter, error) {
+	if filter == nil {
+		return models.FlightFilter{}, nil
+	}
+
+	result := models.FlightFilter{
+		Origin:        filter.Origin,
+		Destination:   filter.Destination,
+		DepartureFrom: filter.DepartureFrom,
+		DepartureTo:   filter.DepartureTo,
+		Status:        filter.Status,
+		Airline:       filter.Airline,
+	}
+	if filter.IncludeDeleted != nil {
+		result.IncludeDeleted = *filter.IncludeDeleted
+	}
+
+	if filter.AircraftID != nil {
+		aircraftID, err := uuid.Parse(*filter.AircraftID)
+		if err != nil {
+			return result, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+		}
+		result.AircraftID = &aircraftID
+	}
+
+	return result, nil
+}
@@ -0,0 +1,151 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) ListFlights(
+	ctx context.Context,
+	filter models.FlightFilter,
+	first int32,
+	after string,
+) (*models.FlightConnection, error) {
+	args := m.Called(ctx, filter, first, after)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FlightConnection), args.Error(1)
+}
+
+func testConnection() *models.FlightConnection {
+	endCursor := "cursor-1"
+	return &models.FlightConnection{
+		Edges: []*models.FlightEdge{
+			{
+				Cursor: endCursor,
+				Node: &models.Flight{
+					ID:            uuid.New(),
+					Number:        "AA123",
+					Origin:        "LAX",
+					Destination:   "JFK",
+					DepartureTime: time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC),
+					ArrivalTime:   time.Date(2024, 12, 15, 15, 0, 0, 0, time.UTC),
+					Status:        models.FlightStatusScheduled,
+				},
+			},
+		},
+		PageInfo: &models.PageInfo{
+			HasNextPage: true,
+			StartCursor: &endCursor,
+			EndCursor:   &endCursor,
+		},
+	}
+}
+
+func TestFlightResolverListFlights(t *testing.T) {
+	aircraftID := uuid.New()
+	aircraftIDString := aircraftID.String()
+	invalidAircraftID := "fake uuid"
+	origin := "LAX"
+	status := models.FlightStatusScheduled
+	first := int32(10)
+	after := "cursor-0"
+	connection := testConnection()
+
+	tests := []struct {
+		name          string
+		filter        *model.FlightFilterInput
+		first         *int32
+		after         *string
+		serviceSetup  func(*MockFlightService)
+		expectErr     bool
+		expectedError error
+	}{
+		{
+			name: "success without filter",
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlights", mock.Anything, models.FlightFilter{}, int32(0), "").
+					Return(connection, nil)
+			},
+		},
+		{
+			name: "success with filter and paging",
+			filter: &model.FlightFilterInput{
+				Origin:     &origin,
+				Status:     &status,
+				AircraftID: &aircraftIDString,
+			},
+			first: &first,
+			after: &after,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlights", mock.Anything, models.FlightFilter{
+					Origin:     &origin,
+					Status:     &status,
+					AircraftID: &aircraftID,
+				}, first, after).Return(connection, nil)
+			},
+		},
+		{
+			name:          "invalid aircraft id",
+			filter:        &model.FlightFilterInput{AircraftID: &invalidAircraftID},
+			serviceSetup:  func(_ *MockFlightService) {},
+			expectErr:     true,
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name: "service returns error",
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlights", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewListFlightsResolver(mockService)
+
+			result, err := resolver.ListFlights(context.Background(), tc.filter, tc.first, tc.after)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, connection, result)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverListFlightsServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	result, err := resolver.ListFlights(context.Background(), nil, nil, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service not configured")
+	assert.Nil(t, result)
+}
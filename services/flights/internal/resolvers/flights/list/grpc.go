@@ -0,0 +1,104 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) ListFlightsGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.ListFlightsRequest],
+) (*connect.Response[v1.ListFlightsResponse], error) {
+	logger.Debug("ListFlights GRPC request", "page_size", req.Msg.GetPageSize())
+
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("ListFlights service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	filter, err := fromProtoFilter(req.Msg.GetFilter())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	connection, err := r.service.ListFlights(ctx, filter, req.Msg.GetPageSize(), req.Msg.GetPageToken())
+	if err != nil {
+		logger.Error("Failed to list flights", "err", err)
+		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+	}
+
+	resp := &v1.ListFlightsResponse{
+		Flights: make([]*v1.Flight, 0, len(connection.Edges)),
+	}
+	for _, edge := range connection.Edges {
+		resp.Flights = append(resp.Flights, converters.ToProtoFlight(edge.Node))
+	}
+	if connection.PageInfo.HasNextPage && connection.PageInfo.EndCursor != nil {
+		resp.NextPageToken = *connection.PageInfo.EndCursor
+	}
+
+	logger.Debug("ListFlights GRPC response retrieved", "count", len(resp.Flights))
+	return connect.NewResponse(resp), nil
+}
+
+func fromProtoFilter(filter *v1.FlightFilter) (models.FlightFilter, error) {
+	var result models.FlightFilter
+	if filter == nil {
+		return result, nil
+	}
+
+	result.Origin = filter.Origin
+	result.Destination = filter.Destination
+	result.Airline = filter.Airline
+	result.IncludeDeleted = filter.GetIncludeDeleted()
+
+	if filter.DepartureFrom != nil {
+		if err := filter.DepartureFrom.CheckValid(); err != nil {
+			return result, fmt.Errorf("%w: invalid departure_from", exceptions.ErrInvalidInput)
+		}
+		departureFrom := filter.DepartureFrom.AsTime()
+		result.DepartureFrom = &departureFrom
+	}
+
+	if filter.DepartureTo != nil {
+		if err := filter.DepartureTo.CheckValid(); err != nil {
+			return result, fmt.Errorf("%w: invalid departure_to", exceptions.ErrInvalidInput)
+		}
+		departureTo := filter.DepartureTo.AsTime()
+		result.DepartureTo = &departureTo
+	}
+
+	if filter.Status != v1.FlightStatus_FLIGHT_STATUS_UNSPECIFIED {
+		status := converters.FromProtoStatus(filter.Status)
+		result.Status = &status
+	}
+
+	if filter.AircraftId != nil {
+		aircraftID, err := uuid.Parse(filter.GetAircraftId())
+		if err != nil {
+			return result, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+		}
+		result.AircraftID = &aircraftID
+	}
+
+	return result, nil
+}
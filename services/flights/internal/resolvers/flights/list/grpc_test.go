@@ -0,0 +1,160 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newRequestWithUserContext(req *v1.ListFlightsRequest) *connect.Request[v1.ListFlightsRequest] {
+	connectReq := connect.NewRequest(req)
+	connectReq.Header().Set("x-user-sub", "123e4567-e89b-12d3-a456-426614174000")
+	connectReq.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	connectReq.Header().Set("x-org-name", "Test Airline")
+	return connectReq
+}
+
+func TestFlightGrpcResolverListFlights(t *testing.T) {
+	aircraftID := uuid.New()
+	aircraftIDString := aircraftID.String()
+	invalidAircraftID := "fake uuid"
+	origin := "LAX"
+	departureFrom := time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC)
+	status := models.FlightStatusDelayed
+	connection := testConnection()
+
+	tests := []struct {
+		name          string
+		request       *v1.ListFlightsRequest
+		serviceSetup  func(*MockFlightService)
+		expectErr     bool
+		expectedCode  connect.Code
+		expectedToken string
+		expectedCount int
+	}{
+		{
+			name:    "success without filter",
+			request: &v1.ListFlightsRequest{},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlights", mock.Anything, models.FlightFilter{}, int32(0), "").
+					Return(connection, nil)
+			},
+			expectedToken: "cursor-1",
+			expectedCount: 1,
+		},
+		{
+			name: "success with filter and paging",
+			request: &v1.ListFlightsRequest{
+				Filter: &v1.FlightFilter{
+					Origin:        &origin,
+					AircraftId:    &aircraftIDString,
+					DepartureFrom: timestamppb.New(departureFrom),
+					Status:        v1.FlightStatus_FLIGHT_STATUS_DELAYED,
+				},
+				PageSize:  5,
+				PageToken: "cursor-0",
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlights", mock.Anything, models.FlightFilter{
+					Origin:        &origin,
+					AircraftID:    &aircraftID,
+					DepartureFrom: &departureFrom,
+					Status:        &status,
+				}, int32(5), "cursor-0").Return(connection, nil)
+			},
+			expectedToken: "cursor-1",
+			expectedCount: 1,
+		},
+		{
+			name:    "last page has no next page token",
+			request: &v1.ListFlightsRequest{},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlights", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(&models.FlightConnection{PageInfo: &models.PageInfo{}}, nil)
+			},
+		},
+		{
+			name: "invalid aircraft id",
+			request: &v1.ListFlightsRequest{
+				Filter: &v1.FlightFilter{AircraftId: &invalidAircraftID},
+			},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "invalid page token",
+			request: &v1.ListFlightsRequest{PageToken: "bad"},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlights", mock.Anything, mock.Anything, mock.Anything, "bad").
+					Return(nil, exceptions.ErrInvalidCursor)
+			},
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "service returns error",
+			request: &v1.ListFlightsRequest{},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("ListFlights", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			expectErr:    true,
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewListFlightsResolver(mockService)
+
+			resp, err := resolver.ListFlightsGRPC(context.Background(), newRequestWithUserContext(tc.request))
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, resp.Msg.Flights, tc.expectedCount)
+			assert.Equal(t, tc.expectedToken, resp.Msg.NextPageToken)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightGrpcResolverListFlightsServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	resp, err := resolver.ListFlightsGRPC(context.Background(), newRequestWithUserContext(&v1.ListFlightsRequest{}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
+
+func TestFlightGrpcResolverListFlightsMissingUserContext(t *testing.T) {
+	mockService := &MockFlightService{}
+	resolver := NewListFlightsResolver(mockService)
+
+	resp, err := resolver.ListFlightsGRPC(context.Background(), connect.NewRequest(&v1.ListFlightsRequest{}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+	mockService.AssertNotCalled(t, "ListFlights")
+}
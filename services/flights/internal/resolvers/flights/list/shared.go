@@ -0,0 +1,20 @@
+package list
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+type FlightLister interface {
+	ListFlights(ctx context.Context, filter models.FlightFilter, first int32, after string) (*models.FlightConnection, error)
+}
+
+type FlightResolver struct {
+	service FlightLister
+}
+
+// NewListFlightsResolver returns a FlightResolver that delegates flight listing to the provided FlightLister.
+func NewListFlightsResolver(service FlightLister) *FlightResolver {
+	return &FlightResolver{service: service}
+}
@@ -0,0 +1,40 @@
+package movement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) RecordMovement(
+	ctx context.Context,
+	id string,
+	movement models.FlightMovement,
+	at time.Time,
+) (*models.Flight, error) {
+	logger.Debug("RecordMovement GraphQL request", "id", id, "movement", movement)
+
+	if r.service == nil {
+		logger.Error("RecordMovement service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, errors.New("invalid flight ID format")
+	}
+
+	flight, err := r.service.RecordMovement(ctx, flightID, movement, at)
+	if err != nil {
+		logger.Error("Failed to record flight movement", "id", id, "movement", movement, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("RecordMovement GraphQL response created", "id", flight.ID, "status", flight.Status)
+	return flight, nil
+}
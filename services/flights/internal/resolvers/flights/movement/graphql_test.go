@@ -0,0 +1,106 @@
+package movement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) RecordMovement(ctx context.Context, id uuid.UUID, movement models.FlightMovement, at time.Time) (*models.Flight, error) {
+	args := m.Called(ctx, id, movement, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
+
+func TestFlightResolverRecordMovement(t *testing.T) {
+	id := uuid.New()
+	out := time.Date(2025, 4, 1, 10, 35, 0, 0, time.UTC)
+	expected := &models.Flight{ID: id, Number: "BA117", Status: models.FlightStatusDeparted, ActualDepartureTime: &out}
+
+	tests := []struct {
+		name          string
+		id            string
+		serviceSetup  func(*MockFlightService)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("RecordMovement", mock.Anything, id, models.FlightMovementOut, out).Return(expected, nil)
+			},
+		},
+		{
+			name:          "invalid flight id",
+			id:            "fake uuid",
+			serviceSetup:  func(_ *MockFlightService) {},
+			errorContains: "invalid flight ID format",
+		},
+		{
+			name: "movement out of order",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("RecordMovement", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, exceptions.ErrMovementOutOfOrder)
+			},
+			expectedError: exceptions.ErrMovementOutOfOrder,
+		},
+		{
+			name: "service returns error",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("RecordMovement", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			errorContains: "db error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewRecordMovementResolver(mockService)
+
+			result, err := resolver.RecordMovement(context.Background(), tc.id, models.FlightMovementOut, out)
+
+			if tc.expectedError != nil || tc.errorContains != "" {
+				assert.Error(t, err)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+				if tc.errorContains != "" {
+					assert.Contains(t, err.Error(), tc.errorContains)
+				}
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, result)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverRecordMovementServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	result, err := resolver.RecordMovement(context.Background(), uuid.New().String(), models.FlightMovementOut, time.Now())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service not configured")
+	assert.Nil(t, result)
+}
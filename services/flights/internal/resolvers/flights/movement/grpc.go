@@ -0,0 +1,58 @@
+package movement
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) RecordMovementGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.RecordMovementRequest],
+) (*connect.Response[v1.RecordMovementResponse], error) {
+	logger.Debug("RecordMovement request", "id", req.Msg.GetId(), "movement", req.Msg.GetMovement())
+
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("RecordMovement service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	flightID, err := uuid.Parse(req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid flight ID format"))
+	}
+
+	movement, ok := converters.FromProtoMovement(req.Msg.GetMovement())
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("a movement is required"))
+	}
+
+	if req.Msg.GetTime() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("movement time is required"))
+	}
+
+	flight, err := r.service.RecordMovement(ctx, flightID, movement, req.Msg.GetTime().AsTime())
+	if err != nil {
+		logger.Error("Failed to record flight movement", "id", flightID, "movement", movement, "err", err)
+		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+	}
+
+	logger.Debug("RecordMovement response created", "id", flight.ID, "status", flight.Status)
+	return connect.NewResponse(&v1.RecordMovementResponse{Flight: converters.ToProtoFlight(flight)}), nil
+}
@@ -0,0 +1,140 @@
+package movement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testUserID = uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+func newRequestWithUserContext(req *v1.RecordMovementRequest) *connect.Request[v1.RecordMovementRequest] {
+	connectReq := connect.NewRequest(req)
+	connectReq.Header().Set("x-user-sub", testUserID.String())
+	connectReq.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	connectReq.Header().Set("x-org-name", "Test Airline")
+	connectReq.Header().Set("x-user-roles", "dispatcher")
+	return connectReq
+}
+
+func TestFlightGrpcResolverRecordMovement(t *testing.T) {
+	id := uuid.New()
+	out := time.Date(2025, 4, 1, 10, 35, 0, 0, time.UTC)
+	off := out.Add(14 * time.Minute)
+	expected := &models.Flight{
+		ID:                  id,
+		Number:              "BA117",
+		Status:              models.FlightStatusInProgress,
+		ActualDepartureTime: &out,
+		ActualTakeoffTime:   &off,
+	}
+
+	tests := []struct {
+		name         string
+		request      *v1.RecordMovementRequest
+		serviceSetup func(*MockFlightService)
+		expectedCode connect.Code
+	}{
+		{
+			name: "success",
+			request: &v1.RecordMovementRequest{
+				Id:       id.String(),
+				Movement: v1.FlightMovement_FLIGHT_MOVEMENT_OFF,
+				Time:     timestamppb.New(off),
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("RecordMovement", mock.MatchedBy(func(ctx context.Context) bool {
+					return middleware.GetRequestUserContext(ctx).UserID == testUserID
+				}), id, models.FlightMovementOff, mock.MatchedBy(func(at time.Time) bool {
+					return at.Equal(off)
+				})).Return(expected, nil)
+			},
+		},
+		{
+			name:         "invalid flight id",
+			request:      &v1.RecordMovementRequest{Id: "fake uuid", Movement: v1.FlightMovement_FLIGHT_MOVEMENT_OFF, Time: timestamppb.New(off)},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "unspecified movement",
+			request:      &v1.RecordMovementRequest{Id: id.String(), Time: timestamppb.New(off)},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "missing time",
+			request:      &v1.RecordMovementRequest{Id: id.String(), Movement: v1.FlightMovement_FLIGHT_MOVEMENT_OFF},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "movement out of order",
+			request: &v1.RecordMovementRequest{Id: id.String(), Movement: v1.FlightMovement_FLIGHT_MOVEMENT_OFF, Time: timestamppb.New(off)},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("RecordMovement", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, exceptions.ErrMovementOutOfOrder)
+			},
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:    "flight not found",
+			request: &v1.RecordMovementRequest{Id: id.String(), Movement: v1.FlightMovement_FLIGHT_MOVEMENT_OFF, Time: timestamppb.New(off)},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("RecordMovement", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, exceptions.ErrNotFound)
+			},
+			expectedCode: connect.CodeNotFound,
+		},
+		{
+			name:    "service returns error",
+			request: &v1.RecordMovementRequest{Id: id.String(), Movement: v1.FlightMovement_FLIGHT_MOVEMENT_OFF, Time: timestamppb.New(off)},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("RecordMovement", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+			},
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewRecordMovementResolver(mockService)
+
+			resp, err := resolver.RecordMovementGRPC(context.Background(), newRequestWithUserContext(tc.request))
+
+			if tc.expectedCode != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, id.String(), resp.Msg.Flight.Id)
+			assert.Equal(t, v1.FlightStatus_FLIGHT_STATUS_IN_PROGRESS, resp.Msg.Flight.Status)
+			assert.True(t, off.Equal(resp.Msg.Flight.ActualTakeoffTime.AsTime()))
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightGrpcResolverRecordMovementMissingUserContext(t *testing.T) {
+	resolver := NewRecordMovementResolver(&MockFlightService{})
+
+	resp, err := resolver.RecordMovementGRPC(context.Background(), connect.NewRequest(&v1.RecordMovementRequest{Id: uuid.New().String()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
@@ -0,0 +1,22 @@
+package movement
+
+import (
+	"context"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+type FlightMovementRecorder interface {
+	RecordMovement(ctx context.Context, id uuid.UUID, movement models.FlightMovement, at time.Time) (*models.Flight, error)
+}
+
+type FlightResolver struct {
+	service FlightMovementRecorder
+}
+
+// NewRecordMovementResolver returns a FlightResolver that delegates movement times to the provided FlightMovementRecorder.
+func NewRecordMovementResolver(service FlightMovementRecorder) *FlightResolver {
+	return &FlightResolver{service: service}
+}
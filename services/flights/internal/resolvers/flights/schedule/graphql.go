@@ -0,0 +1,166 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+var weekdays = map[model.DayOfWeek]time.Weekday{
+	model.DayOfWeekMonday:    time.Monday,
+	model.DayOfWeekTuesday:   time.Tuesday,
+	model.DayOfWeekWednesday: time.Wednesday,
+	model.DayOfWeekThursday:  time.Thursday,
+	model.DayOfWeekFriday:    time.Friday,
+	model.DayOfWeekSaturday:  time.Saturday,
+	model.DayOfWeekSunday:    time.Sunday,
+}
+
+func (r *FlightResolver) CreateSchedule(ctx context.Context, input model.CreateScheduleInput) (*models.Schedule, error) {
+	logger.Debug("CreateSchedule GraphQL request", "number", input.Number, "start_date", input.StartDate, "end_date", input.EndDate)
+
+	if r.service == nil {
+		logger.Error("CreateSchedule service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	aircraftID, err := uuid.Parse(input.AircraftID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+	}
+
+	startDate, err := parseDate("start date", input.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	endDate, err := parseDate("end date", input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := r.service.CreateSchedule(ctx, models.ScheduleInput{
+		Number:             input.Number,
+		Origin:             input.Origin,
+		Destination:        input.Destination,
+		DepartureLocalTime: input.DepartureLocalTime,
+		TimeZone:           input.TimeZone,
+		DurationMinutes:    input.DurationMinutes,
+		DaysOfWeek:         ToWeekdays(input.DaysOfWeek),
+		StartDate:          startDate,
+		EndDate:            endDate,
+		AircraftID:         aircraftID,
+	})
+	if err != nil {
+		logger.Error("Failed to create schedule", "number", input.Number, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("CreateSchedule GraphQL response created", "id", schedule.ID)
+	return schedule, nil
+}
+
+func (r *FlightResolver) UpdateSchedule(ctx context.Context, id string, input model.UpdateScheduleInput) (*models.Schedule, error) {
+	logger.Debug("UpdateSchedule GraphQL request", "id", id, "version", input.Version)
+
+	if r.service == nil {
+		logger.Error("UpdateSchedule service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	scheduleID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid schedule ID format", "id", id, "err", err)
+		return nil, errors.New("invalid schedule ID format")
+	}
+
+	update := models.ScheduleUpdate{
+		Number:             input.Number,
+		Origin:             input.Origin,
+		Destination:        input.Destination,
+		DepartureLocalTime: input.DepartureLocalTime,
+		TimeZone:           input.TimeZone,
+		DurationMinutes:    input.DurationMinutes,
+		Version:            input.Version,
+	}
+
+	if input.DaysOfWeek != nil {
+		days := ToWeekdays(input.DaysOfWeek)
+		update.DaysOfWeek = &days
+	}
+
+	if input.StartDate != nil {
+		startDate, err := parseDate("start date", *input.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		update.StartDate = &startDate
+	}
+
+	if input.EndDate != nil {
+		endDate, err := parseDate("end date", *input.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		update.EndDate = &endDate
+	}
+
+	if input.AircraftID != nil {
+		aircraftID, err := uuid.Parse(*input.AircraftID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+		}
+		update.AircraftID = &aircraftID
+	}
+
+	schedule, err := r.service.UpdateSchedule(ctx, scheduleID, update)
+	if err != nil {
+		logger.Error("Failed to update schedule", "id", id, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("UpdateSchedule GraphQL response created", "id", schedule.ID, "version", schedule.Version)
+	return schedule, nil
+}
+
+// ToWeekdays converts GraphQL days of the week to a models.Weekdays set.
+func ToWeekdays(days []model.DayOfWeek) models.Weekdays {
+	var set models.Weekdays
+	for _, day := range days {
+		if weekday, ok := weekdays[day]; ok {
+			set |= models.WeekdaysOf(weekday)
+		}
+	}
+	return set
+}
+
+// FromWeekdays lists the days in set as GraphQL days of the week, starting from Monday.
+func FromWeekdays(set models.Weekdays) []model.DayOfWeek {
+	days := make([]model.DayOfWeek, 0, len(model.AllDayOfWeek))
+	for _, day := range model.AllDayOfWeek {
+		if set.Has(weekdays[day]) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// FormatDate formats a schedule calendar date as YYYY-MM-DD.
+func FormatDate(date time.Time) string {
+	return date.Format(time.DateOnly)
+}
+
+func parseDate(field string, value string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s %q must be YYYY-MM-DD", exceptions.ErrInvalidInput, field, value)
+	}
+	return date, nil
+}
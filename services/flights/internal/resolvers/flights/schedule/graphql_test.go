@@ -0,0 +1,267 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) CreateSchedule(ctx context.Context, input models.ScheduleInput) (*models.Schedule, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Schedule), args.Error(1)
+}
+
+func (m *MockFlightService) UpdateSchedule(ctx context.Context, id uuid.UUID, update models.ScheduleUpdate) (*models.Schedule, error) {
+	args := m.Called(ctx, id, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Schedule), args.Error(1)
+}
+
+var weekdaysMonToFri = models.WeekdaysOf(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+
+func TestFlightResolverCreateSchedule(t *testing.T) {
+	aircraftID := uuid.New()
+	schedule := &models.Schedule{ID: uuid.New(), Number: "BA117", Version: 1}
+
+	validInput := func() model.CreateScheduleInput {
+		return model.CreateScheduleInput{
+			Number:             "BA117",
+			Origin:             "LHR",
+			Destination:        "JFK",
+			DepartureLocalTime: "08:25",
+			TimeZone:           "Europe/London",
+			DurationMinutes:    480,
+			DaysOfWeek: []model.DayOfWeek{
+				model.DayOfWeekMonday, model.DayOfWeekTuesday, model.DayOfWeekWednesday,
+				model.DayOfWeekThursday, model.DayOfWeekFriday,
+			},
+			StartDate:  "2025-04-01",
+			EndDate:    "2025-10-26",
+			AircraftID: aircraftID.String(),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		input         func() model.CreateScheduleInput
+		serviceSetup  func(*MockFlightService)
+		expectErr     bool
+		expectedError error
+	}{
+		{
+			name:  "success",
+			input: validInput,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("CreateSchedule", mock.Anything, models.ScheduleInput{
+					Number:             "BA117",
+					Origin:             "LHR",
+					Destination:        "JFK",
+					DepartureLocalTime: "08:25",
+					TimeZone:           "Europe/London",
+					DurationMinutes:    480,
+					DaysOfWeek:         weekdaysMonToFri,
+					StartDate:          time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:            time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC),
+					AircraftID:         aircraftID,
+				}).Return(schedule, nil)
+			},
+		},
+		{
+			name: "invalid aircraft ID",
+			input: func() model.CreateScheduleInput {
+				input := validInput()
+				input.AircraftID = "fake uuid"
+				return input
+			},
+			expectErr:     true,
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name: "invalid start date",
+			input: func() model.CreateScheduleInput {
+				input := validInput()
+				input.StartDate = "01/04/2025"
+				return input
+			},
+			expectErr:     true,
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name: "invalid end date",
+			input: func() model.CreateScheduleInput {
+				input := validInput()
+				input.EndDate = "2025-10-32"
+				return input
+			},
+			expectErr:     true,
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:  "service error",
+			input: validInput,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("CreateSchedule", mock.Anything, mock.Anything).Return(nil, exceptions.ErrSameOriginAndDestination)
+			},
+			expectErr:     true,
+			expectedError: exceptions.ErrSameOriginAndDestination,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFlightService)
+			if tt.serviceSetup != nil {
+				tt.serviceSetup(mockService)
+			}
+
+			resolver := NewScheduleResolver(mockService)
+			result, err := resolver.CreateSchedule(context.Background(), tt.input())
+
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, schedule, result)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverUpdateSchedule(t *testing.T) {
+	scheduleID := uuid.New()
+	aircraftID := uuid.New()
+	aircraftIDString := aircraftID.String()
+	schedule := &models.Schedule{ID: scheduleID, Version: 3}
+	departure := "09:10"
+	startDate := "2025-05-01"
+	badDate := "May 1st"
+	badAircraftID := "fake uuid"
+	saturdays := models.WeekdaysOf(time.Saturday)
+	parsedStartDate := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		id            string
+		input         model.UpdateScheduleInput
+		serviceSetup  func(*MockFlightService)
+		expectErr     bool
+		expectedError error
+	}{
+		{
+			name: "success",
+			id:   scheduleID.String(),
+			input: model.UpdateScheduleInput{
+				DepartureLocalTime: &departure,
+				DaysOfWeek:         []model.DayOfWeek{model.DayOfWeekSaturday},
+				StartDate:          &startDate,
+				AircraftID:         &aircraftIDString,
+				Version:            2,
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateSchedule", mock.Anything, scheduleID, models.ScheduleUpdate{
+					DepartureLocalTime: &departure,
+					DaysOfWeek:         &saturdays,
+					StartDate:          &parsedStartDate,
+					AircraftID:         &aircraftID,
+					Version:            2,
+				}).Return(schedule, nil)
+			},
+		},
+		{
+			name:      "invalid schedule ID",
+			id:        "fake uuid",
+			input:     model.UpdateScheduleInput{Version: 1},
+			expectErr: true,
+		},
+		{
+			name:          "invalid date",
+			id:            scheduleID.String(),
+			input:         model.UpdateScheduleInput{EndDate: &badDate, Version: 1},
+			expectErr:     true,
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:          "invalid aircraft ID",
+			id:            scheduleID.String(),
+			input:         model.UpdateScheduleInput{AircraftID: &badAircraftID, Version: 1},
+			expectErr:     true,
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:  "version conflict",
+			id:    scheduleID.String(),
+			input: model.UpdateScheduleInput{Version: 1},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateSchedule", mock.Anything, scheduleID, models.ScheduleUpdate{Version: 1}).
+					Return(nil, exceptions.ErrVersionConflict)
+			},
+			expectErr:     true,
+			expectedError: exceptions.ErrVersionConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFlightService)
+			if tt.serviceSetup != nil {
+				tt.serviceSetup(mockService)
+			}
+
+			resolver := NewScheduleResolver(mockService)
+			result, err := resolver.UpdateSchedule(context.Background(), tt.id, tt.input)
+
+			if tt.expectErr {
+				assert.Error(t, err)
+				if tt.expectedError != nil {
+					assert.ErrorIs(t, err, tt.expectedError)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, schedule, result)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverNilService(t *testing.T) {
+	resolver := NewScheduleResolver(nil)
+
+	_, err := resolver.CreateSchedule(context.Background(), model.CreateScheduleInput{})
+	assert.EqualError(t, err, "service not configured")
+
+	_, err = resolver.UpdateSchedule(context.Background(), uuid.New().String(), model.UpdateScheduleInput{})
+	assert.EqualError(t, err, "service not configured")
+}
+
+func TestWeekdaysConversion(t *testing.T) {
+	days := []model.DayOfWeek{model.DayOfWeekSunday, model.DayOfWeekMonday, model.DayOfWeekFriday}
+	set := ToWeekdays(days)
+
+	assert.Equal(t, models.WeekdaysOf(time.Sunday, time.Monday, time.Friday), set)
+	assert.Equal(t, []model.DayOfWeek{model.DayOfWeekMonday, model.DayOfWeekFriday, model.DayOfWeekSunday}, FromWeekdays(set))
+	assert.Empty(t, FromWeekdays(0))
+	assert.Equal(t, "2025-04-01", FormatDate(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)))
+}
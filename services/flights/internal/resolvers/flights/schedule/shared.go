@@ -0,0 +1,22 @@
+package schedule
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+type FlightScheduler interface {
+	CreateSchedule(ctx context.Context, input models.ScheduleInput) (*models.Schedule, error)
+	UpdateSchedule(ctx context.Context, id uuid.UUID, update models.ScheduleUpdate) (*models.Schedule, error)
+}
+
+type FlightResolver struct {
+	service FlightScheduler
+}
+
+// NewScheduleResolver returns a FlightResolver that delegates schedule changes to the provided FlightScheduler.
+func NewScheduleResolver(service FlightScheduler) *FlightResolver {
+	return &FlightResolver{service: service}
+}
@@ -0,0 +1,50 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) FlightUpdated(ctx context.Context, id string) (<-chan *models.Flight, error) {
+	logger.Debug("FlightUpdated GraphQL subscription", "id", id)
+
+	if r.service == nil {
+		logger.Error("FlightUpdated service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, errors.New("invalid flight ID format")
+	}
+
+	updates, err := r.service.SubscribeFlight(ctx, flightID)
+	if err != nil {
+		logger.Error("Failed to subscribe to flight", "id", id, "err", err)
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+func (r *FlightResolver) FlightsOnRoute(ctx context.Context, origin string, destination string) (<-chan *models.Flight, error) {
+	logger.Debug("FlightsOnRoute GraphQL subscription", "origin", origin, "destination", destination)
+
+	if r.service == nil {
+		logger.Error("FlightsOnRoute service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	updates, err := r.service.SubscribeRoute(ctx, origin, destination)
+	if err != nil {
+		logger.Error("Failed to subscribe to route", "origin", origin, "destination", destination, "err", err)
+		return nil, err
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,104 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) SubscribeFlight(ctx context.Context, id uuid.UUID) (<-chan *models.Flight, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(<-chan *models.Flight), args.Error(1)
+}
+
+func (m *MockFlightService) SubscribeRoute(ctx context.Context, origin, destination string) (<-chan *models.Flight, error) {
+	args := m.Called(ctx, origin, destination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(<-chan *models.Flight), args.Error(1)
+}
+
+func TestFlightResolverFlightUpdated(t *testing.T) {
+	id := uuid.New()
+	var updates <-chan *models.Flight = make(chan *models.Flight)
+
+	t.Run("success", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("SubscribeFlight", mock.Anything, id).Return(updates, nil)
+
+		result, err := NewSubscriptionResolver(mockService).FlightUpdated(context.Background(), id.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, updates, result)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid flight id", func(t *testing.T) {
+		result, err := NewSubscriptionResolver(&MockFlightService{}).FlightUpdated(context.Background(), "fake uuid")
+
+		assert.ErrorContains(t, err, "invalid flight ID format")
+		assert.Nil(t, result)
+	})
+
+	t.Run("flight not found", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("SubscribeFlight", mock.Anything, id).Return(nil, exceptions.ErrNotFound)
+
+		result, err := NewSubscriptionResolver(mockService).FlightUpdated(context.Background(), id.String())
+
+		assert.ErrorIs(t, err, exceptions.ErrNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("service not configured", func(t *testing.T) {
+		result, err := NewSubscriptionResolver(nil).FlightUpdated(context.Background(), id.String())
+
+		assert.ErrorContains(t, err, "service not configured")
+		assert.Nil(t, result)
+	})
+}
+
+func TestFlightResolverFlightsOnRoute(t *testing.T) {
+	var updates <-chan *models.Flight = make(chan *models.Flight)
+
+	t.Run("success", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("SubscribeRoute", mock.Anything, "LHR", "JFK").Return(updates, nil)
+
+		result, err := NewSubscriptionResolver(mockService).FlightsOnRoute(context.Background(), "LHR", "JFK")
+
+		assert.NoError(t, err)
+		assert.Equal(t, updates, result)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid airport code", func(t *testing.T) {
+		mockService := &MockFlightService{}
+		mockService.On("SubscribeRoute", mock.Anything, "LHR", "J1").Return(nil, exceptions.ErrInvalidIATACode)
+
+		result, err := NewSubscriptionResolver(mockService).FlightsOnRoute(context.Background(), "LHR", "J1")
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidIATACode)
+		assert.Nil(t, result)
+	})
+
+	t.Run("service not configured", func(t *testing.T) {
+		result, err := NewSubscriptionResolver(nil).FlightsOnRoute(context.Background(), "LHR", "JFK")
+
+		assert.ErrorContains(t, err, "service not configured")
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,22 @@
+package subscription
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+type FlightSubscriber interface {
+	SubscribeFlight(ctx context.Context, id uuid.UUID) (<-chan *models.Flight, error)
+	SubscribeRoute(ctx context.Context, origin, destination string) (<-chan *models.Flight, error)
+}
+
+type FlightResolver struct {
+	service FlightSubscriber
+}
+
+// NewSubscriptionResolver returns a FlightResolver that delegates flight subscriptions to the provided FlightSubscriber.
+func NewSubscriptionResolver(service FlightSubscriber) *FlightResolver {
+	return &FlightResolver{service: service}
+}
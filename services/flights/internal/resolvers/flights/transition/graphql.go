@@ -0,0 +1,44 @@
+package transition
+
+import (
+	"context"
+	"errors"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) TransitionFlightStatus(
+	ctx context.Context,
+	id string,
+	status models.FlightStatus,
+	reason *string,
+) (*models.Flight, error) {
+	logger.Debug("TransitionFlightStatus GraphQL request", "id", id, "status", status)
+
+	if r.service == nil {
+		logger.Error("TransitionFlightStatus service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, errors.New("invalid flight ID format")
+	}
+
+	var transitionReason string
+	if reason != nil {
+		transitionReason = *reason
+	}
+
+	flight, err := r.service.TransitionFlightStatus(ctx, flightID, status, transitionReason)
+	if err != nil {
+		logger.Error("Failed to transition flight status", "id", id, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("TransitionFlightStatus GraphQL response created", "id", flight.ID, "status", flight.Status)
+	return flight, nil
+}
@@ -0,0 +1,123 @@
+package transition
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) TransitionFlightStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	status models.FlightStatus,
+	reason string,
+) (*models.Flight, error) {
+	args := m.Called(ctx, id, status, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
+
+func TestFlightResolverTransitionFlightStatus(t *testing.T) {
+	id := uuid.New()
+	reason := "crew shortage"
+	expectedFlight := &models.Flight{ID: id, Number: "AA123", Status: models.FlightStatusCancelled}
+
+	tests := []struct {
+		name          string
+		id            string
+		reason        *string
+		serviceSetup  func(*MockFlightService)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name:   "success with reason",
+			id:     id.String(),
+			reason: &reason,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("TransitionFlightStatus", mock.Anything, id, models.FlightStatusCancelled, reason).
+					Return(expectedFlight, nil)
+			},
+		},
+		{
+			name: "success without reason",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("TransitionFlightStatus", mock.Anything, id, models.FlightStatusCancelled, "").
+					Return(expectedFlight, nil)
+			},
+		},
+		{
+			name:          "invalid flight id",
+			id:            "fake uuid",
+			serviceSetup:  func(_ *MockFlightService) {},
+			errorContains: "invalid flight ID format",
+		},
+		{
+			name: "illegal transition",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("TransitionFlightStatus", mock.Anything, id, models.FlightStatusCancelled, "").
+					Return(nil, exceptions.IllegalStatusTransition(models.FlightStatusArrived, models.FlightStatusCancelled))
+			},
+			expectedError: exceptions.ErrIllegalStatusTransition,
+		},
+		{
+			name: "service returns error",
+			id:   id.String(),
+			serviceSetup: func(m *MockFlightService) {
+				m.On("TransitionFlightStatus", mock.Anything, id, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			errorContains: "db error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewTransitionFlightStatusResolver(mockService)
+
+			flight, err := resolver.TransitionFlightStatus(context.Background(), tc.id, models.FlightStatusCancelled, tc.reason)
+
+			if tc.expectedError != nil || tc.errorContains != "" {
+				assert.Error(t, err)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+				if tc.errorContains != "" {
+					assert.Contains(t, err.Error(), tc.errorContains)
+				}
+				assert.Nil(t, flight)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, expectedFlight, flight)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverTransitionFlightStatusServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	flight, err := resolver.TransitionFlightStatus(context.Background(), uuid.New().String(), models.FlightStatusDelayed, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service not configured")
+	assert.Nil(t, flight)
+}
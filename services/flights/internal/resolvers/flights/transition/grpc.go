@@ -0,0 +1,62 @@
+package transition
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) TransitionFlightStatusGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.TransitionFlightStatusRequest],
+) (*connect.Response[v1.TransitionFlightStatusResponse], error) {
+	logger.Debug("TransitionFlightStatus request", "id", req.Msg.GetId(), "status", req.Msg.GetStatus())
+
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("TransitionFlightStatus service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	flightID, err := uuid.Parse(req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid flight ID format"))
+	}
+
+	if req.Msg.GetStatus() == v1.FlightStatus_FLIGHT_STATUS_UNSPECIFIED {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("status is required"))
+	}
+
+	flight, err := r.service.TransitionFlightStatus(
+		ctx,
+		flightID,
+		converters.FromProtoStatus(req.Msg.GetStatus()),
+		req.Msg.GetReason(),
+	)
+	if err != nil {
+		logger.Error("Failed to transition flight status", "id", flightID, "err", err)
+		return nil, connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+	}
+
+	resp := &v1.TransitionFlightStatusResponse{
+		Flight: converters.ToProtoFlight(flight),
+	}
+
+	logger.Debug("TransitionFlightStatus response created", "id", flight.ID, "status", flight.Status)
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,124 @@
+package transition
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testUserID = uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+func newRequestWithUserContext(req *v1.TransitionFlightStatusRequest) *connect.Request[v1.TransitionFlightStatusRequest] {
+	connectReq := connect.NewRequest(req)
+	connectReq.Header().Set("x-user-sub", testUserID.String())
+	connectReq.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	connectReq.Header().Set("x-org-name", "Test Airline")
+	connectReq.Header().Set("x-user-roles", "user")
+	return connectReq
+}
+
+func TestFlightGrpcResolverTransitionFlightStatus(t *testing.T) {
+	id := uuid.New()
+	expectedFlight := &models.Flight{ID: id, Number: "AA123", Status: models.FlightStatusDeparted}
+
+	tests := []struct {
+		name         string
+		request      *v1.TransitionFlightStatusRequest
+		serviceSetup func(*MockFlightService)
+		expectedCode connect.Code
+	}{
+		{
+			name: "success",
+			request: &v1.TransitionFlightStatusRequest{
+				Id:     id.String(),
+				Status: v1.FlightStatus_FLIGHT_STATUS_DEPARTED,
+				Reason: "pushback",
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("TransitionFlightStatus", mock.MatchedBy(func(ctx context.Context) bool {
+					return middleware.GetRequestUserContext(ctx).UserID == testUserID
+				}), id, models.FlightStatusDeparted, "pushback").Return(expectedFlight, nil)
+			},
+		},
+		{
+			name:         "invalid flight id",
+			request:      &v1.TransitionFlightStatusRequest{Id: "fake uuid", Status: v1.FlightStatus_FLIGHT_STATUS_DEPARTED},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "missing status",
+			request:      &v1.TransitionFlightStatusRequest{Id: id.String()},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "illegal transition",
+			request: &v1.TransitionFlightStatusRequest{Id: id.String(), Status: v1.FlightStatus_FLIGHT_STATUS_SCHEDULED},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("TransitionFlightStatus", mock.Anything, id, models.FlightStatusScheduled, "").
+					Return(nil, exceptions.IllegalStatusTransition(models.FlightStatusArrived, models.FlightStatusScheduled))
+			},
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		{
+			name:    "flight not found",
+			request: &v1.TransitionFlightStatusRequest{Id: id.String(), Status: v1.FlightStatus_FLIGHT_STATUS_DELAYED},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("TransitionFlightStatus", mock.Anything, id, mock.Anything, mock.Anything).
+					Return(nil, exceptions.ErrNotFound)
+			},
+			expectedCode: connect.CodeNotFound,
+		},
+		{
+			name:    "service returns error",
+			request: &v1.TransitionFlightStatusRequest{Id: id.String(), Status: v1.FlightStatus_FLIGHT_STATUS_DELAYED},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("TransitionFlightStatus", mock.Anything, id, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewTransitionFlightStatusResolver(mockService)
+
+			resp, err := resolver.TransitionFlightStatusGRPC(context.Background(), newRequestWithUserContext(tc.request))
+
+			if tc.expectedCode != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, id.String(), resp.Msg.Flight.Id)
+			assert.Equal(t, v1.FlightStatus_FLIGHT_STATUS_DEPARTED, resp.Msg.Flight.Status)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightGrpcResolverTransitionFlightStatusMissingUserContext(t *testing.T) {
+	resolver := NewTransitionFlightStatusResolver(&MockFlightService{})
+
+	resp, err := resolver.TransitionFlightStatusGRPC(context.Background(), connect.NewRequest(&v1.TransitionFlightStatusRequest{Id: uuid.New().String()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
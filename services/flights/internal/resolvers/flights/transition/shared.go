@@ -0,0 +1,21 @@
+package transition
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+type FlightStatusTransitioner interface {
+	TransitionFlightStatus(ctx context.Context, id uuid.UUID, status models.FlightStatus, reason string) (*models.Flight, error)
+}
+
+type FlightResolver struct {
+	service FlightStatusTransitioner
+}
+
+// NewTransitionFlightStatusResolver returns a FlightResolver that delegates status changes to the provided FlightStatusTransitioner.
+func NewTransitionFlightStatusResolver(service FlightStatusTransitioner) *FlightResolver {
+	return &FlightResolver{service: service}
+}
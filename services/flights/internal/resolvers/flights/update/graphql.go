@@ -0,0 +1,58 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) UpdateFlight(
+	ctx context.Context,
+	id string,
+	input model.UpdateFlightInput,
+) (*models.Flight, error) {
+	logger.Debug("UpdateFlight GraphQL request", "id", id, "version", input.Version)
+
+	if r.service == nil {
+		logger.Error("UpdateFlight service not configured")
+		return nil, errors.New("service not configured")
+	}
+
+	flightID, err := uuid.Parse(id)
+	if err != nil {
+		logger.Error("Invalid flight ID format", "id", id, "err", err)
+		return nil, errors.New("invalid flight ID format")
+	}
+
+	update := models.FlightUpdate{
+		Number:        input.Number,
+		Origin:        input.Origin,
+		Destination:   input.Destination,
+		DepartureTime: input.DepartureTime,
+		ArrivalTime:   input.ArrivalTime,
+		Version:       input.Version,
+	}
+
+	if input.AircraftID != nil {
+		aircraftID, err := uuid.Parse(*input.AircraftID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid aircraft ID", exceptions.ErrInvalidInput)
+		}
+		update.AircraftID = &aircraftID
+	}
+
+	flight, err := r.service.UpdateFlight(ctx, flightID, update)
+	if err != nil {
+		logger.Error("Failed to update flight", "id", id, "err", err)
+		return nil, err
+	}
+
+	logger.Debug("UpdateFlight GraphQL response created", "id", flight.ID, "version", flight.Version)
+	return flight, nil
+}
@@ -0,0 +1,149 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) UpdateFlight(
+	ctx context.Context,
+	id uuid.UUID,
+	update models.FlightUpdate,
+) (*models.Flight, error) {
+	args := m.Called(ctx, id, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
+
+func TestFlightResolverUpdateFlight(t *testing.T) {
+	id := uuid.New()
+	aircraftID := uuid.New()
+	aircraftIDString := aircraftID.String()
+	invalidAircraftID := "fake uuid"
+	origin := "LAX"
+	departure := time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC)
+	expectedFlight := &models.Flight{
+		ID:            id,
+		Number:        "AA123",
+		Origin:        origin,
+		Destination:   "JFK",
+		DepartureTime: departure,
+		ArrivalTime:   departure.Add(5 * time.Hour),
+		Status:        models.FlightStatusScheduled,
+		AircraftID:    aircraftID,
+		Version:       3,
+	}
+
+	tests := []struct {
+		name          string
+		id            string
+		input         model.UpdateFlightInput
+		serviceSetup  func(*MockFlightService)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name: "success",
+			id:   id.String(),
+			input: model.UpdateFlightInput{
+				Origin:        &origin,
+				DepartureTime: &departure,
+				AircraftID:    &aircraftIDString,
+				Version:       2,
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateFlight", mock.Anything, id, models.FlightUpdate{
+					Origin:        &origin,
+					DepartureTime: &departure,
+					AircraftID:    &aircraftID,
+					Version:       2,
+				}).Return(expectedFlight, nil)
+			},
+		},
+		{
+			name:          "invalid flight id",
+			id:            "fake uuid",
+			input:         model.UpdateFlightInput{Version: 2},
+			serviceSetup:  func(_ *MockFlightService) {},
+			errorContains: "invalid flight ID format",
+		},
+		{
+			name:          "invalid aircraft id",
+			id:            id.String(),
+			input:         model.UpdateFlightInput{AircraftID: &invalidAircraftID, Version: 2},
+			serviceSetup:  func(_ *MockFlightService) {},
+			expectedError: exceptions.ErrInvalidInput,
+		},
+		{
+			name:  "stale version",
+			id:    id.String(),
+			input: model.UpdateFlightInput{Version: 1},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateFlight", mock.Anything, id, mock.Anything).
+					Return(nil, exceptions.ErrVersionConflict)
+			},
+			expectedError: exceptions.ErrVersionConflict,
+		},
+		{
+			name:  "service returns error",
+			id:    id.String(),
+			input: model.UpdateFlightInput{Version: 2},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateFlight", mock.Anything, id, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			errorContains: "db error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewUpdateFlightResolver(mockService)
+
+			flight, err := resolver.UpdateFlight(context.Background(), tc.id, tc.input)
+
+			if tc.expectedError != nil || tc.errorContains != "" {
+				assert.Error(t, err)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+				if tc.errorContains != "" {
+					assert.Contains(t, err.Error(), tc.errorContains)
+				}
+				assert.Nil(t, flight)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, expectedFlight, flight)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightResolverUpdateFlightServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	flight, err := resolver.UpdateFlight(context.Background(), uuid.New().String(), model.UpdateFlightInput{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service not configured")
+	assert.Nil(t, flight)
+}
@@ -0,0 +1,84 @@
+package update
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+)
+
+func (r *FlightResolver) UpdateFlightGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.UpdateFlightRequest],
+) (*connect.Response[v1.UpdateFlightResponse], error) {
+	logger.Debug("UpdateFlight request", "id", req.Msg.GetId(), "version", req.Msg.GetVersion())
+
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("UpdateFlight service not configured")
+		return nil, connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	flightID, err := uuid.Parse(req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid flight ID format"))
+	}
+
+	update := models.FlightUpdate{
+		Number:      req.Msg.Number,
+		Origin:      req.Msg.Origin,
+		Destination: req.Msg.Destination,
+		Version:     req.Msg.GetVersion(),
+	}
+
+	if departureTS := req.Msg.GetDepartureTime(); departureTS != nil {
+		if err := departureTS.CheckValid(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid departure_time"))
+		}
+		departure := departureTS.AsTime()
+		update.DepartureTime = &departure
+	}
+
+	if arrivalTS := req.Msg.GetArrivalTime(); arrivalTS != nil {
+		if err := arrivalTS.CheckValid(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid arrival_time"))
+		}
+		arrival := arrivalTS.AsTime()
+		update.ArrivalTime = &arrival
+	}
+
+	if req.Msg.AircraftId != nil {
+		aircraftID, err := uuid.Parse(req.Msg.GetAircraftId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid aircraft ID"))
+		}
+		update.AircraftID = &aircraftID
+	}
+
+	flight, err := r.service.UpdateFlight(ctx, flightID, update)
+	if err != nil {
+		logger.Error("Failed to update flight", "id", flightID, "err", err)
+		return nil, converters.ToConnectError(err)
+	}
+
+	resp := &v1.UpdateFlightResponse{
+		Flight: converters.ToProtoFlight(flight),
+	}
+
+	logger.Debug("UpdateFlight response created", "id", flight.ID, "version", flight.Version)
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,169 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testUserID = uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+func newRequestWithUserContext(req *v1.UpdateFlightRequest) *connect.Request[v1.UpdateFlightRequest] {
+	connectReq := connect.NewRequest(req)
+	connectReq.Header().Set("x-user-sub", testUserID.String())
+	connectReq.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+	connectReq.Header().Set("x-org-name", "Test Airline")
+	connectReq.Header().Set("x-user-roles", "user")
+	return connectReq
+}
+
+func TestFlightGrpcResolverUpdateFlight(t *testing.T) {
+	id := uuid.New()
+	aircraftID := uuid.New()
+	aircraftIDString := aircraftID.String()
+	invalidAircraftID := "fake uuid"
+	destination := "SFO"
+	departure := time.Date(2024, 12, 15, 10, 0, 0, 0, time.UTC)
+	expectedFlight := &models.Flight{
+		ID:            id,
+		Number:        "AA123",
+		Origin:        "LAX",
+		Destination:   destination,
+		DepartureTime: departure,
+		ArrivalTime:   departure.Add(2 * time.Hour),
+		Status:        models.FlightStatusScheduled,
+		AircraftID:    aircraftID,
+		Version:       6,
+	}
+
+	tests := []struct {
+		name         string
+		request      *v1.UpdateFlightRequest
+		serviceSetup func(*MockFlightService)
+		expectedCode connect.Code
+	}{
+		{
+			name: "success",
+			request: &v1.UpdateFlightRequest{
+				Id:            id.String(),
+				Destination:   &destination,
+				DepartureTime: timestamppb.New(departure),
+				AircraftId:    &aircraftIDString,
+				Version:       5,
+			},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateFlight", mock.MatchedBy(func(ctx context.Context) bool {
+					return middleware.GetRequestUserContext(ctx).UserID == testUserID
+				}), id, models.FlightUpdate{
+					Destination:   &destination,
+					DepartureTime: &departure,
+					AircraftID:    &aircraftID,
+					Version:       5,
+				}).Return(expectedFlight, nil)
+			},
+		},
+		{
+			name:         "invalid flight id",
+			request:      &v1.UpdateFlightRequest{Id: "fake uuid", Version: 5},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "invalid aircraft id",
+			request:      &v1.UpdateFlightRequest{Id: id.String(), AircraftId: &invalidAircraftID, Version: 5},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid arrival time",
+			request: &v1.UpdateFlightRequest{
+				Id:          id.String(),
+				ArrivalTime: &timestamppb.Timestamp{Seconds: -62135596801},
+				Version:     5,
+			},
+			serviceSetup: func(_ *MockFlightService) {},
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:    "stale version",
+			request: &v1.UpdateFlightRequest{Id: id.String(), Version: 4},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateFlight", mock.Anything, id, mock.Anything).
+					Return(nil, exceptions.ErrVersionConflict)
+			},
+			expectedCode: connect.CodeAborted,
+		},
+		{
+			name:    "flight not found",
+			request: &v1.UpdateFlightRequest{Id: id.String(), Version: 5},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateFlight", mock.Anything, id, mock.Anything).
+					Return(nil, exceptions.ErrNotFound)
+			},
+			expectedCode: connect.CodeNotFound,
+		},
+		{
+			name:    "service returns error",
+			request: &v1.UpdateFlightRequest{Id: id.String(), Version: 5},
+			serviceSetup: func(m *MockFlightService) {
+				m.On("UpdateFlight", mock.Anything, id, mock.Anything).
+					Return(nil, errors.New("db error"))
+			},
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			tc.serviceSetup(mockService)
+			resolver := NewUpdateFlightResolver(mockService)
+
+			resp, err := resolver.UpdateFlightGRPC(context.Background(), newRequestWithUserContext(tc.request))
+
+			if tc.expectedCode != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, id.String(), resp.Msg.Flight.Id)
+			assert.Equal(t, destination, resp.Msg.Flight.Destination)
+			assert.Equal(t, int32(6), resp.Msg.Flight.Version)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFlightGrpcResolverUpdateFlightMissingUserContext(t *testing.T) {
+	resolver := NewUpdateFlightResolver(&MockFlightService{})
+
+	resp, err := resolver.UpdateFlightGRPC(context.Background(), connect.NewRequest(&v1.UpdateFlightRequest{Id: uuid.New().String()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
+
+func TestFlightGrpcResolverUpdateFlightServiceNotConfigured(t *testing.T) {
+	resolver := &FlightResolver{}
+
+	resp, err := resolver.UpdateFlightGRPC(context.Background(), newRequestWithUserContext(&v1.UpdateFlightRequest{Id: uuid.New().String()}))
+
+	assert.Error(t, err)
+	assert.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+	assert.Nil(t, resp)
+}
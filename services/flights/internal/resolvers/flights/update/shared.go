@@ -0,0 +1,21 @@
+package update
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/google/uuid"
+)
+
+type FlightUpdater interface {
+	UpdateFlight(ctx context.Context, id uuid.UUID, update models.FlightUpdate) (*models.Flight, error)
+}
+
+type FlightResolver struct {
+	service FlightUpdater
+}
+
+// NewUpdateFlightResolver returns a FlightResolver that delegates flight updates to the provided FlightUpdater.
+func NewUpdateFlightResolver(service FlightUpdater) *FlightResolver {
+	return &FlightResolver{service: service}
+}
@@ -0,0 +1,81 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models/converters"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/google/uuid"
+)
+
+// WatchFlightsGRPC streams the watched flights to the client until it cancels the call.
+func (r *FlightResolver) WatchFlightsGRPC(
+	ctx context.Context,
+	req *connect.Request[v1.WatchFlightsRequest],
+	stream *connect.ServerStream[v1.FlightEvent],
+) error {
+	logger.Debug("WatchFlights GRPC request", "flight_ids", len(req.Msg.GetFlightIds()), "resume", req.Msg.AfterSequence != nil)
+
+	ctx, err := middleware.UserContextFromHeaders(ctx, req.Header())
+	if err != nil {
+		logger.Error("Failed to extract user context", "err", err)
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if r.service == nil {
+		logger.Error("WatchFlights service not configured")
+		return connect.NewError(
+			connect.CodeInternal,
+			errors.New("service not configured"),
+		)
+	}
+
+	watch, err := fromProtoWatch(req.Msg)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var sendErr error
+	err = r.service.WatchFlights(ctx, watch, func(event *models.FlightEvent) error {
+		sendErr = stream.Send(converters.ToProtoFlightEvent(event))
+		return sendErr
+	})
+	switch {
+	case sendErr != nil:
+		logger.Debug("WatchFlights GRPC client went away", "err", sendErr)
+		return sendErr
+	case errors.Is(err, context.Canceled):
+		logger.Debug("WatchFlights GRPC stream ended by the client")
+		return connect.NewError(connect.CodeCanceled, err)
+	case err != nil:
+		logger.Error("Failed to watch flights", "err", err)
+		return connect.NewError(exceptions.MapErrorToGrpcCode(err), err)
+	}
+	return nil
+}
+
+func fromProtoWatch(req *v1.WatchFlightsRequest) (models.FlightWatch, error) {
+	watch := models.FlightWatch{
+		Origin:        req.Origin,
+		Destination:   req.Destination,
+		Airline:       req.Airline,
+		AfterSequence: req.AfterSequence,
+	}
+
+	for _, id := range req.GetFlightIds() {
+		flightID, err := uuid.Parse(id)
+		if err != nil {
+			return watch, fmt.Errorf("%w: invalid flight ID format", exceptions.ErrInvalidInput)
+		}
+		watch.FlightIDs = append(watch.FlightIDs, flightID)
+	}
+
+	return watch, nil
+}
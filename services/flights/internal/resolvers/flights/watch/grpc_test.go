@@ -0,0 +1,180 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockFlightService struct {
+	mock.Mock
+}
+
+func (m *MockFlightService) WatchFlights(ctx context.Context, watch models.FlightWatch, send func(*models.FlightEvent) error) error {
+	args := m.Called(ctx, watch, send)
+	return args.Error(0)
+}
+
+// watchStream calls the resolver through a real connect handler, since a ServerStream
+// can only be created by connect itself, and collects every event it sends.
+func watchStream(t *testing.T, resolver *FlightResolver, withUser bool, msg *v1.WatchFlightsRequest) ([]*v1.FlightEvent, error) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(flightsv1connect.FlightsServiceWatchFlightsProcedure,
+		connect.NewServerStreamHandler(flightsv1connect.FlightsServiceWatchFlightsProcedure, resolver.WatchFlightsGRPC))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := connect.NewClient[v1.WatchFlightsRequest, v1.FlightEvent](
+		server.Client(), server.URL+flightsv1connect.FlightsServiceWatchFlightsProcedure)
+
+	req := connect.NewRequest(msg)
+	if withUser {
+		req.Header().Set("x-user-sub", "123e4567-e89b-12d3-a456-426614174000")
+		req.Header().Set("x-org-id", "987fcdeb-51a2-43d1-9f87-123456789abc")
+		req.Header().Set("x-org-name", "Test Airline")
+	}
+
+	stream, err := client.CallServerStream(context.Background(), req)
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	var events []*v1.FlightEvent
+	for stream.Receive() {
+		events = append(events, stream.Msg())
+	}
+	return events, stream.Err()
+}
+
+func TestFlightGrpcResolverWatchFlights(t *testing.T) {
+	flightID := uuid.New()
+	origin := "LHR"
+	after := uint64(41)
+	flight := &models.Flight{ID: flightID, Number: "BA117", Origin: origin, Destination: "JFK"}
+
+	tests := []struct {
+		name           string
+		request        *v1.WatchFlightsRequest
+		withUser       bool
+		nilService     bool
+		serviceSetup   func(*MockFlightService)
+		expectedEvents []v1.FlightEventType
+		expectErr      bool
+		expectedCode   connect.Code
+	}{
+		{
+			name:     "streams events",
+			request:  &v1.WatchFlightsRequest{FlightIds: []string{flightID.String()}, Origin: &origin, AfterSequence: &after},
+			withUser: true,
+			serviceSetup: func(m *MockFlightService) {
+				watch := models.FlightWatch{FlightIDs: []uuid.UUID{flightID}, Origin: &origin, AfterSequence: &after}
+				m.On("WatchFlights", mock.Anything, watch, mock.Anything).
+					Run(func(args mock.Arguments) {
+						send := args.Get(2).(func(*models.FlightEvent) error)
+						_ = send(&models.FlightEvent{Type: models.FlightEventUpdated, Sequence: 42, Flight: flight})
+						_ = send(&models.FlightEvent{Type: models.FlightEventDeleted, Sequence: 43, Flight: flight})
+					}).
+					Return(nil)
+			},
+			expectedEvents: []v1.FlightEventType{
+				v1.FlightEventType_FLIGHT_EVENT_TYPE_UPDATED,
+				v1.FlightEventType_FLIGHT_EVENT_TYPE_DELETED,
+			},
+		},
+		{
+			name:     "events sent before an error are delivered",
+			request:  &v1.WatchFlightsRequest{},
+			withUser: true,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("WatchFlights", mock.Anything, models.FlightWatch{}, mock.Anything).
+					Run(func(args mock.Arguments) {
+						send := args.Get(2).(func(*models.FlightEvent) error)
+						_ = send(&models.FlightEvent{Type: models.FlightEventSnapshotComplete, Sequence: 7})
+					}).
+					Return(fmt.Errorf("%w: resume after sequence 7", exceptions.ErrUpdatesInterrupted))
+			},
+			expectedEvents: []v1.FlightEventType{v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT_COMPLETE},
+			expectErr:      true,
+			expectedCode:   connect.CodeUnavailable,
+		},
+		{
+			name:     "resume point no longer retained",
+			request:  &v1.WatchFlightsRequest{AfterSequence: &after},
+			withUser: true,
+			serviceSetup: func(m *MockFlightService) {
+				m.On("WatchFlights", mock.Anything, models.FlightWatch{AfterSequence: &after}, mock.Anything).
+					Return(exceptions.ErrResumeExpired)
+			},
+			expectErr:    true,
+			expectedCode: connect.CodeOutOfRange,
+		},
+		{
+			name:         "invalid flight id",
+			request:      &v1.WatchFlightsRequest{FlightIds: []string{"fake uuid"}},
+			withUser:     true,
+			expectErr:    true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		{
+			name:         "missing user context",
+			request:      &v1.WatchFlightsRequest{},
+			expectErr:    true,
+			expectedCode: connect.CodeUnauthenticated,
+		},
+		{
+			name:         "service not configured",
+			request:      &v1.WatchFlightsRequest{},
+			withUser:     true,
+			nilService:   true,
+			expectErr:    true,
+			expectedCode: connect.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockFlightService{}
+			if tt.serviceSetup != nil {
+				tt.serviceSetup(mockService)
+			}
+
+			resolver := NewWatchFlightsResolver(mockService)
+			if tt.nilService {
+				resolver = NewWatchFlightsResolver(nil)
+			}
+
+			events, err := watchStream(t, resolver, tt.withUser, tt.request)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedCode, connect.CodeOf(err))
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, events, len(tt.expectedEvents))
+			for i, eventType := range tt.expectedEvents {
+				assert.Equal(t, eventType, events[i].GetType())
+			}
+			if len(events) > 0 && events[0].GetFlight() != nil {
+				assert.Equal(t, uint64(42), events[0].GetSequence())
+				assert.Equal(t, flightID.String(), events[0].GetFlight().GetId())
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package watch
+
+import (
+	"context"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+)
+
+type FlightWatcher interface {
+	WatchFlights(ctx context.Context, watch models.FlightWatch, send func(*models.FlightEvent) error) error
+}
+
+type FlightResolver struct {
+	service FlightWatcher
+}
+
+// NewWatchFlightsResolver returns a FlightResolver that delegates flight watches to the provided FlightWatcher.
+func NewWatchFlightsResolver(service FlightWatcher) *FlightResolver {
+	return &FlightResolver{service: service}
+}
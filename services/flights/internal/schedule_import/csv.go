@@ -0,0 +1,102 @@
+package schedule_import
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+)
+
+// csvColumns are the header names a CSV schedule must contain. aircraft_id is optional.
+var csvColumns = []string{"number", "origin", "destination", "departure_time", "arrival_time"}
+
+// ParseCSV reads a CSV schedule. The first record is a header naming the columns, in
+// any order: number, origin, destination, departure_time and arrival_time, plus an
+// optional aircraft_id. Times are RFC 3339.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: schedule is empty", exceptions.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: read CSV header: %v", exceptions.ErrInvalidInput, err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvColumns {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("%w: CSV header is missing the %s column", exceptions.ErrInvalidInput, name)
+		}
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if len(rows) == MaxRows {
+			return nil, errTooManyRows
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, Row{Line: parseErr.Line, Err: rowError("line %d: %v", parseErr.Line, parseErr.Err)})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV schedule: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseCSVRecord(line, record, index))
+	}
+}
+
+func parseCSVRecord(line int, record []string, index map[string]int) Row {
+	field := func(name string) string {
+		i, ok := index[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := Row{
+		Line:        line,
+		Number:      field("number"),
+		Origin:      field("origin"),
+		Destination: field("destination"),
+	}
+
+	var err error
+	if row.DepartureTime, err = time.Parse(time.RFC3339, field("departure_time")); err != nil {
+		row.Err = rowError("line %d: departure_time must be an RFC 3339 time", line)
+		return row
+	}
+	if row.ArrivalTime, err = time.Parse(time.RFC3339, field("arrival_time")); err != nil {
+		row.Err = rowError("line %d: arrival_time must be an RFC 3339 time", line)
+		return row
+	}
+
+	if aircraftID := field("aircraft_id"); aircraftID != "" {
+		if row.AircraftID, err = uuid.Parse(aircraftID); err != nil {
+			row.Err = rowError("line %d: invalid aircraft ID", line)
+			return row
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,70 @@
+package schedule_import
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	aircraftID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		input := "aircraft_id,Number,origin,destination,departure_time,arrival_time\n" +
+			aircraftID.String() + ",BA123,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z\n" +
+			",ba124, jfk,LHR,2025-01-07T10:00:00Z,2025-01-07T18:00:00Z\n"
+
+		rows, err := ParseCSV(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, Row{
+			Line:          2,
+			Number:        "BA123",
+			Origin:        "LHR",
+			Destination:   "JFK",
+			DepartureTime: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC),
+			ArrivalTime:   time.Date(2025, 1, 6, 18, 0, 0, 0, time.UTC),
+			AircraftID:    aircraftID,
+		}, rows[0])
+		assert.Equal(t, 3, rows[1].Line)
+		assert.Equal(t, "ba124", rows[1].Number)
+		assert.Equal(t, "jfk", rows[1].Origin)
+		assert.Equal(t, uuid.Nil, rows[1].AircraftID)
+		assert.NoError(t, rows[1].Err)
+	})
+
+	t.Run("Invalid Rows Are Reported", func(t *testing.T) {
+		input := "number,origin,destination,departure_time,arrival_time,aircraft_id\n" +
+			"BA123,LHR,JFK,tomorrow,2025-01-06T18:00:00Z,\n" +
+			"BA124,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z,not-a-uuid\n" +
+			"BA125,LHR,JFK,2025-01-06T10:00:00Z,2025-01-06T18:00:00Z,\n"
+
+		rows, err := ParseCSV(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.ErrorIs(t, rows[0].Err, exceptions.ErrInvalidInput)
+		assert.ErrorContains(t, rows[0].Err, "line 2: departure_time")
+		assert.ErrorContains(t, rows[1].Err, "line 3: invalid aircraft ID")
+		assert.NoError(t, rows[2].Err)
+	})
+
+	t.Run("Missing Column", func(t *testing.T) {
+		_, err := ParseCSV(strings.NewReader("number,origin,destination,departure_time\n"))
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidInput)
+		assert.ErrorContains(t, err, "arrival_time")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseCSV(strings.NewReader(""))
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidInput)
+	})
+}
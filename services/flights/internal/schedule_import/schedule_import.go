@@ -0,0 +1,56 @@
+package schedule_import
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxFileSize is the largest schedule file accepted, in bytes.
+	MaxFileSize = 10 << 20
+	// MaxRows is the most flights a single import may produce once SSIM periods are expanded.
+	MaxRows = 20000
+)
+
+// Row is one flight read from a schedule file. The fields are as written in the file;
+// validation and normalization are left to the flights service.
+type Row struct {
+	// Line is the 1-based line of the file the row came from.
+	Line          int
+	Number        string
+	Origin        string
+	Destination   string
+	DepartureTime time.Time
+	ArrivalTime   time.Time
+	// AircraftID is uuid.Nil when the file does not name an aircraft.
+	AircraftID uuid.UUID
+	// Err is set when the line could not be read; the other fields may be partial.
+	Err error
+}
+
+// Parse reads every flight in r, which holds a schedule in the given format. Lines that
+// cannot be read are returned as rows with Err set; an error is only returned when the
+// file as a whole is unusable.
+func Parse(format models.ScheduleFormat, r io.Reader) ([]Row, error) {
+	switch format {
+	case models.ScheduleFormatCSV:
+		return ParseCSV(r)
+	case models.ScheduleFormatSSIM:
+		return ParseSSIM(r)
+	default:
+		return nil, fmt.Errorf("%w: unsupported schedule format %q", exceptions.ErrInvalidInput, format)
+	}
+}
+
+// rowError wraps a problem with a single line as invalid input.
+func rowError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", exceptions.ErrInvalidInput, fmt.Sprintf(format, args...))
+}
+
+// errTooManyRows rejects a schedule that would produce more than MaxRows flights.
+var errTooManyRows = fmt.Errorf("%w: schedule contains more than %d flights", exceptions.ErrInvalidInput, MaxRows)
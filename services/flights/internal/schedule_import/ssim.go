@@ -0,0 +1,197 @@
+package schedule_import
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+// ssimRecordLength is the fixed width of an SSIM chapter 7 record.
+const ssimRecordLength = 200
+
+// ParseSSIM reads an IATA SSIM chapter 7 schedule. Only type 3 (flight leg) records
+// are used; each is expanded into one row per operating day in its period. Headers,
+// carrier, segment and trailer records are skipped. SSIM does not identify aircraft, so
+// every row has a nil AircraftID.
+func ParseSSIM(r io.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, ssimRecordLength+2), MaxFileSize)
+
+	var rows []Row
+	line := 0
+	for scanner.Scan() {
+		line++
+		record := strings.TrimRight(scanner.Text(), "\r")
+		if !strings.HasPrefix(record, "3") {
+			continue
+		}
+		if len(record) < ssimRecordLength {
+			record += strings.Repeat(" ", ssimRecordLength-len(record))
+		}
+
+		legRows, err := parseSSIMLeg(line, record, MaxRows-len(rows))
+		if errors.Is(err, errTooManyRows) {
+			return nil, err
+		}
+		if err != nil {
+			rows = append(rows, Row{Line: line, Number: ssimFlightNumber(record), Err: err})
+			continue
+		}
+		rows = append(rows, legRows...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: read SSIM schedule: %v", exceptions.ErrInvalidInput, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: schedule contains no flight leg records", exceptions.ErrInvalidInput)
+	}
+
+	return rows, nil
+}
+
+// parseSSIMLeg expands a type 3 record into its flights, failing with errTooManyRows
+// once more than limit flights would be produced.
+//
+// Record layout (1-based columns): 3-5 airline designator, 6-9 flight number, 15-21 and
+// 22-28 period of operation, 29-35 days of operation, 36 frequency rate, 37-39
+// departure station, 40-43 passenger STD, 48-52 departure UTC variation, 55-57
+// arrival station, 62-65 passenger STA, 66-70 arrival UTC variation, 193-194
+// departure and arrival date variation.
+func parseSSIMLeg(line int, record string, limit int) ([]Row, error) {
+	number := ssimFlightNumber(record)
+
+	from, err := parseSSIMDate(record[14:21])
+	if err != nil {
+		return nil, rowError("line %d: invalid period start %q", line, record[14:21])
+	}
+	to, err := parseSSIMDate(record[21:28])
+	if err != nil {
+		return nil, rowError("line %d: invalid period end %q", line, record[21:28])
+	}
+	if to.Before(from) {
+		return nil, rowError("line %d: period ends before it starts", line)
+	}
+
+	days := record[28:35]
+	frequency := 1
+	if rate := strings.TrimSpace(record[35:36]); rate != "" {
+		if frequency, err = strconv.Atoi(rate); err != nil || frequency < 1 {
+			return nil, rowError("line %d: invalid frequency rate %q", line, rate)
+		}
+	}
+
+	departureClock, err := parseSSIMClock(record[39:43])
+	if err != nil {
+		return nil, rowError("line %d: invalid departure time %q", line, record[39:43])
+	}
+	departureOffset, err := parseSSIMVariation(record[47:52])
+	if err != nil {
+		return nil, rowError("line %d: invalid departure UTC variation %q", line, record[47:52])
+	}
+	arrivalClock, err := parseSSIMClock(record[61:65])
+	if err != nil {
+		return nil, rowError("line %d: invalid arrival time %q", line, record[61:65])
+	}
+	arrivalOffset, err := parseSSIMVariation(record[65:70])
+	if err != nil {
+		return nil, rowError("line %d: invalid arrival UTC variation %q", line, record[65:70])
+	}
+	departureDays, err := parseSSIMDateVariation(record[192])
+	if err != nil {
+		return nil, rowError("line %d: invalid departure date variation %q", line, record[192])
+	}
+	arrivalDays, err := parseSSIMDateVariation(record[193])
+	if err != nil {
+		return nil, rowError("line %d: invalid arrival date variation %q", line, record[193])
+	}
+
+	origin := strings.TrimSpace(record[36:39])
+	destination := strings.TrimSpace(record[54:57])
+
+	var rows []Row
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		weeks := int(day.Sub(from).Hours()/24) / 7
+		if weeks%frequency != 0 || !operatesOn(days, day.Weekday()) {
+			continue
+		}
+		if len(rows) == limit {
+			return nil, errTooManyRows
+		}
+
+		rows = append(rows, Row{
+			Line:          line,
+			Number:        number,
+			Origin:        origin,
+			Destination:   destination,
+			DepartureTime: day.AddDate(0, 0, departureDays).Add(departureClock - departureOffset),
+			ArrivalTime:   day.AddDate(0, 0, arrivalDays).Add(arrivalClock - arrivalOffset),
+		})
+	}
+
+	return rows, nil
+}
+
+// ssimFlightNumber joins the airline designator and flight number, dropping the
+// flight number's leading zeros so "BA 0123" becomes "BA123".
+func ssimFlightNumber(record string) string {
+	airline := strings.TrimSpace(record[2:5])
+	flight := strings.TrimLeft(strings.TrimSpace(record[5:9]), "0")
+	return airline + flight
+}
+
+// parseSSIMDate parses a DDMMMYY date such as 01JAN25 as midnight UTC.
+func parseSSIMDate(value string) (time.Time, error) {
+	return time.Parse("02Jan06", value)
+}
+
+// parseSSIMClock parses an HHMM local time as an offset from midnight.
+func parseSSIMClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("1504", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// parseSSIMVariation parses a +HHMM or -HHMM difference between local time and UTC.
+func parseSSIMVariation(value string) (time.Duration, error) {
+	if len(value) != 5 || (value[0] != '+' && value[0] != '-') {
+		return 0, fmt.Errorf("invalid UTC variation %q", value)
+	}
+	offset, err := parseSSIMClock(value[1:])
+	if err != nil {
+		return 0, err
+	}
+	if value[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// parseSSIMDateVariation parses a date variation: a digit of days after the operating
+// day, or A for the day before. A blank means the operating day itself.
+func parseSSIMDateVariation(value byte) (int, error) {
+	switch {
+	case value == ' ':
+		return 0, nil
+	case value == 'A':
+		return -1, nil
+	case value >= '0' && value <= '9':
+		return int(value - '0'), nil
+	default:
+		return 0, fmt.Errorf("invalid date variation %q", value)
+	}
+}
+
+// operatesOn reports whether the days of operation field, "1234567" with blanks for
+// days without service and Monday as 1, includes weekday.
+func operatesOn(days string, weekday time.Weekday) bool {
+	digit := byte('0' + (int(weekday)+6)%7 + 1)
+	return strings.IndexByte(days, digit) >= 0
+}
@@ -0,0 +1,116 @@
+package schedule_import
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ssimLeg builds a type 3 record, placing each value at its 1-based column.
+func ssimLeg(fields map[int]string) string {
+	record := []byte(strings.Repeat(" ", ssimRecordLength))
+	record[0] = '3'
+	for column, value := range fields {
+		copy(record[column-1:], value)
+	}
+	return string(record)
+}
+
+func baseLeg() map[int]string {
+	return map[int]string{
+		3:   "BA ",
+		6:   "0123",
+		15:  "06JAN25",
+		22:  "12JAN25",
+		29:  "1 3    ",
+		37:  "LHR",
+		40:  "1000",
+		48:  "+0000",
+		55:  "JFK",
+		62:  "1300",
+		66:  "-0500",
+		193: "00",
+	}
+}
+
+func TestParseSSIM(t *testing.T) {
+	t.Run("Expands Days Of Operation", func(t *testing.T) {
+		input := "1AIRLINE STANDARD SCHEDULE DATA SET\n" + ssimLeg(baseLeg()) + "\n5 trailer\n"
+
+		rows, err := ParseSSIM(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, Row{
+			Line:          2,
+			Number:        "BA123",
+			Origin:        "LHR",
+			Destination:   "JFK",
+			DepartureTime: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC),
+			ArrivalTime:   time.Date(2025, 1, 6, 18, 0, 0, 0, time.UTC),
+		}, rows[0])
+		assert.Equal(t, time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC), rows[1].DepartureTime)
+	})
+
+	t.Run("Arrival Date Variation And Frequency", func(t *testing.T) {
+		leg := baseLeg()
+		leg[22] = "26JAN25"
+		leg[29] = "1      "
+		leg[36] = "2"
+		leg[40] = "2200"
+		leg[62] = "0600"
+		leg[66] = "+0000"
+		leg[193] = "01"
+
+		rows, err := ParseSSIM(strings.NewReader(ssimLeg(leg)))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, time.Date(2025, 1, 6, 22, 0, 0, 0, time.UTC), rows[0].DepartureTime)
+		assert.Equal(t, time.Date(2025, 1, 7, 6, 0, 0, 0, time.UTC), rows[0].ArrivalTime)
+		assert.Equal(t, time.Date(2025, 1, 20, 22, 0, 0, 0, time.UTC), rows[1].DepartureTime)
+	})
+
+	t.Run("Invalid Leg Is Reported", func(t *testing.T) {
+		bad := baseLeg()
+		bad[15] = "XXJAN25"
+		input := ssimLeg(bad) + "\n" + ssimLeg(baseLeg())
+
+		rows, err := ParseSSIM(strings.NewReader(input))
+
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, 1, rows[0].Line)
+		assert.Equal(t, "BA123", rows[0].Number)
+		assert.ErrorIs(t, rows[0].Err, exceptions.ErrInvalidInput)
+		assert.NoError(t, rows[1].Err)
+	})
+
+	t.Run("Too Many Flights", func(t *testing.T) {
+		leg := baseLeg()
+		leg[15] = "01JAN00"
+		leg[22] = "31DEC60"
+		leg[29] = "1234567"
+
+		_, err := ParseSSIM(strings.NewReader(ssimLeg(leg)))
+
+		assert.ErrorIs(t, err, errTooManyRows)
+	})
+
+	t.Run("No Legs", func(t *testing.T) {
+		_, err := ParseSSIM(strings.NewReader("1AIRLINE STANDARD SCHEDULE DATA SET\n"))
+
+		assert.ErrorIs(t, err, exceptions.ErrInvalidInput)
+	})
+}
+
+func TestParseUnsupportedFormat(t *testing.T) {
+	_, err := Parse(models.ScheduleFormat("XLSX"), strings.NewReader(""))
+
+	assert.ErrorIs(t, err, exceptions.ErrInvalidInput)
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/clients/aircraft_client"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/config"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/tlsconfig"
+)
+
+// NewAircraftClient connects to the aircraft service as configured, over mutual TLS
+// when any of the aircraft TLS files are set and in plaintext otherwise. One client is
+// shared by the servers and the aircraft events consumer, so the consumer can drop
+// aircraft from the cache the servers read.
+func NewAircraftClient() (*aircraft_client.AircraftClient, error) {
+	options := aircraft_client.Options{CacheTTL: config.App.AircraftCacheTTL}
+
+	if config.App.AircraftTLSEnabled() {
+		tlsConfig, err := tlsconfig.Client(tlsconfig.ClientOptions{
+			CAFile:         config.App.AircraftTLSCAFile,
+			CertFile:       config.App.AircraftTLSCertFile,
+			KeyFile:        config.App.AircraftTLSKeyFile,
+			ServerName:     config.App.AircraftTLSServerName,
+			ReloadInterval: config.App.TLSReloadInterval,
+		})
+		if err != nil {
+			return nil, err
+		}
+		options.TLS = tlsConfig
+	}
+
+	return aircraft_client.NewAircraftClient(config.App.AircraftServiceGrpcUrl, options)
+}
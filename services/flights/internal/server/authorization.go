@@ -0,0 +1,20 @@
+package server
+
+import (
+	userContext "github.com/edinstance/distributed-aviation-system/services/flights/internal/context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1connect "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+)
+
+// procedurePolicies lists the roles allowed to call each restricted gRPC procedure.
+// Keep it in step with the @hasRole directives in schema.graphqls so both transports
+// enforce the same rules.
+var procedurePolicies = middleware.ProcedurePolicies{
+	v1connect.FlightsServiceCreateFlightProcedure:           {userContext.RoleDispatcher, userContext.RoleAdmin},
+	v1connect.FlightsServiceTransitionFlightStatusProcedure: {userContext.RoleDispatcher, userContext.RoleAdmin},
+	v1connect.FlightsServiceDeleteFlightProcedure:           {userContext.RoleDispatcher, userContext.RoleAdmin},
+	v1connect.FlightsServiceRestoreFlightProcedure:          {userContext.RoleAdmin},
+	v1connect.FlightsServiceBulkCreateFlightsProcedure:      {userContext.RoleDispatcher, userContext.RoleAdmin},
+	v1connect.FlightsServiceReportDelayProcedure:            {userContext.RoleDispatcher, userContext.RoleAdmin},
+	v1connect.FlightsServiceRecordMovementProcedure:         {userContext.RoleDispatcher, userContext.RoleAdmin},
+}
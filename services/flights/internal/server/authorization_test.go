@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	v1connect "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateAndTransitionRequireDispatcherOrAdmin(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+	service, alpha, _ := newTenancyFixture()
+
+	graphqlHandler := middleware.UserContextMiddleware(newGraphQLServer(service))
+
+	path, grpcHandler := v1connect.NewFlightsServiceHandler(
+		newGrpcFlightsServer(service),
+		connect.WithInterceptors(middleware.NewAuthorizationInterceptor(procedurePolicies)),
+	)
+	mux := http.NewServeMux()
+	mux.Handle(path, grpcHandler)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := v1connect.NewFlightsServiceClient(server.Client(), server.URL)
+
+	departure := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	const createFlight = `mutation($dep: Time!, $arr: Time!, $aircraft: ID!) {
+		createFlight(number: "BA117", origin: "LHR", destination: "JFK", departureTime: $dep, arrivalTime: $arr, aircraftId: $aircraft) { id }
+	}`
+	const transition = `mutation($id: ID!) { transitionFlightStatus(id: $id, status: CANCELLED) { id } }`
+
+	tests := []struct {
+		roles   string
+		allowed bool
+	}{
+		{roles: "DISPATCHER", allowed: true},
+		{roles: "ADMIN", allowed: true},
+		{roles: "VIEWER", allowed: false},
+		{roles: "", allowed: false},
+	}
+
+	for _, tc := range tests {
+		caller := tenant{orgID: alpha.orgID, roles: tc.roles}
+
+		t.Run("graphql createFlight as "+tc.roles, func(t *testing.T) {
+			resp := postGraphQL(t, graphqlHandler, caller, createFlight, map[string]any{
+				"dep":      departure.Format(time.RFC3339),
+				"arr":      departure.Add(8 * time.Hour).Format(time.RFC3339),
+				"aircraft": uuid.NewString(),
+			})
+			assert.Equal(t, tc.allowed, len(resp.Errors) == 0, "errors: %s", resp.Errors)
+		})
+
+		t.Run("graphql transitionFlightStatus as "+tc.roles, func(t *testing.T) {
+			resp := postGraphQL(t, graphqlHandler, caller, transition, map[string]any{"id": alpha.flight.ID.String()})
+			assert.Equal(t, tc.allowed, len(resp.Errors) == 0, "errors: %s", resp.Errors)
+		})
+
+		t.Run("grpc CreateFlight as "+tc.roles, func(t *testing.T) {
+			req := connect.NewRequest(&v1.CreateFlightRequest{
+				Number:        "BA117",
+				Origin:        "LHR",
+				Destination:   "JFK",
+				DepartureTime: timestamppb.New(departure),
+				ArrivalTime:   timestamppb.New(departure.Add(8 * time.Hour)),
+				AircraftId:    uuid.NewString(),
+			})
+			setTenantHeaders(req.Header(), caller.orgID, caller.roles)
+
+			_, err := client.CreateFlight(context.Background(), req)
+			assertPermitted(t, tc.allowed, err)
+		})
+
+		t.Run("grpc TransitionFlightStatus as "+tc.roles, func(t *testing.T) {
+			req := connect.NewRequest(&v1.TransitionFlightStatusRequest{
+				Id:     alpha.flight.ID.String(),
+				Status: v1.FlightStatus_FLIGHT_STATUS_CANCELLED,
+			})
+			setTenantHeaders(req.Header(), caller.orgID, caller.roles)
+
+			_, err := client.TransitionFlightStatus(context.Background(), req)
+			assertPermitted(t, tc.allowed, err)
+		})
+	}
+}
+
+func assertPermitted(t *testing.T, allowed bool, err error) {
+	t.Helper()
+	if allowed {
+		assert.NoError(t, err)
+		return
+	}
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestDeletedFlightsAreHiddenUntilRestored(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+	service, alpha, _ := newTenancyFixture()
+	handler := middleware.UserContextMiddleware(newGraphQLServer(service))
+
+	const getFlight = `query($id: ID!, $deleted: Boolean) { getFlightById(id: $id, includeDeleted: $deleted) { id } }`
+	const deleteFlight = `mutation($id: ID!) { deleteFlight(id: $id) { id deletedAt } }`
+	const restoreFlight = `mutation($id: ID!) { restoreFlight(id: $id) { id } }`
+
+	dispatcher := tenant{orgID: alpha.orgID, roles: "DISPATCHER"}
+	admin := tenant{orgID: alpha.orgID, roles: "ADMIN"}
+	vars := map[string]any{"id": alpha.flight.ID.String()}
+
+	resp := postGraphQL(t, handler, tenant{orgID: alpha.orgID, roles: "VIEWER"}, deleteFlight, vars)
+	require.NotEmpty(t, resp.Errors)
+
+	resp = postGraphQL(t, handler, dispatcher, deleteFlight, vars)
+	require.Empty(t, resp.Errors)
+
+	resp = postGraphQL(t, handler, dispatcher, getFlight, vars)
+	assert.Nil(t, resp.Data.GetFlightByID)
+	assert.Empty(t, listedIDs(postGraphQL(t, handler, dispatcher, `{ flights { edges { node { id } } } }`, nil)))
+
+	resp = postGraphQL(t, handler, dispatcher, getFlight, map[string]any{"id": alpha.flight.ID.String(), "deleted": true})
+	assert.NotEmpty(t, resp.Errors, "only admins may read deleted flights")
+
+	resp = postGraphQL(t, handler, admin, getFlight, map[string]any{"id": alpha.flight.ID.String(), "deleted": true})
+	require.NotNil(t, resp.Data.GetFlightByID)
+
+	resp = postGraphQL(t, handler, dispatcher, restoreFlight, vars)
+	assert.NotEmpty(t, resp.Errors, "dispatchers cannot restore flights")
+
+	resp = postGraphQL(t, handler, admin, restoreFlight, vars)
+	require.Empty(t, resp.Errors)
+
+	resp = postGraphQL(t, handler, dispatcher, getFlight, vars)
+	require.NotNil(t, resp.Data.GetFlightByID)
+	assert.Equal(t, alpha.flight.ID.String(), resp.Data.GetFlightByID.ID)
+}
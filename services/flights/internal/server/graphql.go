@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/clients/aircraft_client"
 	"net/http"
 	"time"
 
@@ -11,43 +12,78 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	cacheRepository "github.com/edinstance/distributed-aviation-system/services/flights/internal/cache/repositories/flights"
-	"github.com/edinstance/distributed-aviation-system/services/flights/internal/clients/aircraft_client"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/config"
 	flightRepository "github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/flights"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/directives"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/flights"
 	graphqlschema "github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/graphql/resolvers"
-	"github.com/edinstance/distributed-aviation-system/services/flights/internal/kafka"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/loaders"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/aircraft"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/bulk"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/create"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/delay"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/deletion"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/get"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/history"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/list"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/movement"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/schedule"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/subscription"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/transition"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/update"
 	"github.com/gorilla/websocket"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
+	"github.com/vektah/gqlparser/v2/ast"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func newGraphQLHandler(pool *pgxpool.Pool, client *redis.Client, kafkaPublisher *kafka.Publisher) http.Handler {
+func newGraphQLHandler(pool *pgxpool.Pool, client *redis.Client, aircraftClient aircraft_client.AircraftLookup, updates flights.FlightUpdates) http.Handler {
 	logger.Info("Setting up GraphQL Handler")
 	dbRepo := flightRepository.NewFlightRepository(pool)
+	dbRepo.MinTurnaround = config.App.MinTurnaround
 	cacheRepo := cacheRepository.NewRedisFlightRepository(client, config.App.CacheTTL)
-	aircraftClient, aircraftClientErr := aircraft_client.NewAircraftClient(config.App.AircraftServiceGrpcUrl)
-
-	if aircraftClientErr != nil {
-		logger.Error("Failed to create aircraft client", "err", aircraftClientErr)
-		return nil
-	}
+	flightService := flights.NewFlightsService(dbRepo, cacheRepo, aircraftClient)
+	flightService.ScheduleHorizon = config.App.ScheduleHorizon
+	flightService.MinTurnaround = config.App.MinTurnaround
+	flightService.Updates = updates
+	return newGraphQLServer(flightService)
+}
 
-	flightService := flights.NewFlightsService(dbRepo, cacheRepo, aircraftClient, kafkaPublisher)
+// newGraphQLServer builds the GraphQL handler, with per-request loaders, on top of flightService.
+func newGraphQLServer(flightService *flights.Service) http.Handler {
 	graphqlCreateFlightResolver := create.NewCreateFlightResolver(flightService)
 	graphqlGetFlightResolver := get.NewGetFlightResolver(flightService)
+	graphqlListFlightsResolver := list.NewListFlightsResolver(flightService)
+	graphqlUpdateFlightResolver := update.NewUpdateFlightResolver(flightService)
+	graphqlTransitionResolver := transition.NewTransitionFlightStatusResolver(flightService)
+	graphqlAircraftFlightsResolver := aircraft.NewAircraftFlightsResolver(flightService)
+	graphqlDeleteFlightResolver := deletion.NewDeleteFlightResolver(flightService)
+	graphqlFlightHistoryResolver := history.NewFlightHistoryResolver(flightService)
+	graphqlBulkCreateFlightsResolver := bulk.NewBulkCreateFlightsResolver(flightService)
+	graphqlScheduleResolver := schedule.NewScheduleResolver(flightService)
+	graphqlSubscriptionResolver := subscription.NewSubscriptionResolver(flightService)
+	graphqlReportDelayResolver := delay.NewReportDelayResolver(flightService)
+	graphqlRecordMovementResolver := movement.NewRecordMovementResolver(flightService)
 
 	resolver := &resolvers.Resolver{
-		CreateFlightResolver: graphqlCreateFlightResolver,
-		GetFlightResolver:    graphqlGetFlightResolver,
+		CreateFlightResolver:      graphqlCreateFlightResolver,
+		GetFlightResolver:         graphqlGetFlightResolver,
+		ListFlightsResolver:       graphqlListFlightsResolver,
+		UpdateFlightResolver:      graphqlUpdateFlightResolver,
+		TransitionResolver:        graphqlTransitionResolver,
+		AircraftFlightsResolver:   graphqlAircraftFlightsResolver,
+		DeleteFlightResolver:      graphqlDeleteFlightResolver,
+		FlightHistoryResolver:     graphqlFlightHistoryResolver,
+		BulkCreateFlightsResolver: graphqlBulkCreateFlightsResolver,
+		ScheduleResolver:          graphqlScheduleResolver,
+		SubscriptionResolver:      graphqlSubscriptionResolver,
+		ReportDelayResolver:       graphqlReportDelayResolver,
+		RecordMovementResolver:    graphqlRecordMovementResolver,
 	}
 
 	srv := handler.New(
@@ -56,11 +92,14 @@ func newGraphQLHandler(pool *pgxpool.Pool, client *redis.Client, kafkaPublisher
 				Resolvers: resolver,
 				Directives: graphqlschema.DirectiveRoot{
 					Authentication: directives.AuthenticationDirective,
+					HasRole:        directives.HasRoleDirective,
 				},
 			},
 		),
 	)
 
+	srv.SetErrorPresenter(presentGraphQLError)
+
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
@@ -116,6 +155,17 @@ func newGraphQLHandler(pool *pgxpool.Pool, client *redis.Client, kafkaPublisher
 	// Metrics
 	srv.AroundOperations(metrics.GraphQLMetricsInterceptor)
 
+	// A subscription lasts as long as its socket, so each update is resolved with fresh
+	// loaders rather than ones that memoised earlier updates.
+	srv.AroundResponses(func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		if graphql.HasOperationContext(ctx) {
+			if operation := graphql.GetOperationContext(ctx).Operation; operation != nil && operation.Operation == ast.Subscription {
+				ctx = loaders.WithLoaders(ctx, loaders.NewLoaders(flightService))
+			}
+		}
+		return next(ctx)
+	})
+
 	logger.Info("GraphQL Handler setup")
-	return srv
+	return loaders.Middleware(flightService, srv)
 }
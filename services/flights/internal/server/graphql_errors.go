@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// presentGraphQLError extends the default presentation of err with the details of an
+// aircraft conflict, so clients can tell which flight it clashes with without parsing
+// the message.
+func presentGraphQLError(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	var conflict *exceptions.AircraftConflictError
+	if !errors.As(err, &conflict) {
+		return presented
+	}
+
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]any{}
+	}
+	presented.Extensions["code"] = "AIRCRAFT_CONFLICT"
+	presented.Extensions["kind"] = string(conflict.Kind)
+	presented.Extensions["aircraftId"] = conflict.AircraftID.String()
+	presented.Extensions["conflictingFlightId"] = conflict.FlightID.String()
+	presented.Extensions["conflictingFlightNumber"] = conflict.FlightNumber
+	presented.Extensions["departureTime"] = conflict.DepartureTime.UTC().Format(time.RFC3339)
+	presented.Extensions["arrivalTime"] = conflict.ArrivalTime.UTC().Format(time.RFC3339)
+	if conflict.Destination != "" {
+		presented.Extensions["aircraftPosition"] = conflict.Destination
+	}
+	if conflict.Origin != "" {
+		presented.Extensions["requiredPosition"] = conflict.Origin
+	}
+	if conflict.Kind == exceptions.AircraftConflictTurnaround {
+		presented.Extensions["minTurnaroundMinutes"] = int(conflict.MinTurnaround.Minutes())
+	}
+	return presented
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresentGraphQLError(t *testing.T) {
+	t.Run("Aircraft Conflict", func(t *testing.T) {
+		departure := time.Date(2025, 4, 1, 7, 25, 0, 0, time.UTC)
+		conflict := &exceptions.AircraftConflictError{
+			Kind:          exceptions.AircraftConflictTurnaround,
+			AircraftID:    uuid.New(),
+			FlightID:      uuid.New(),
+			FlightNumber:  "BA175",
+			DepartureTime: departure,
+			ArrivalTime:   departure.Add(8 * time.Hour),
+			MinTurnaround: 45 * time.Minute,
+		}
+
+		presented := presentGraphQLError(context.Background(), fmt.Errorf("create flight: %w", conflict))
+
+		assert.Contains(t, presented.Message, "BA175")
+		assert.Equal(t, "AIRCRAFT_CONFLICT", presented.Extensions["code"])
+		assert.Equal(t, "TURNAROUND", presented.Extensions["kind"])
+		assert.Equal(t, conflict.FlightID.String(), presented.Extensions["conflictingFlightId"])
+		assert.Equal(t, "BA175", presented.Extensions["conflictingFlightNumber"])
+		assert.Equal(t, "2025-04-01T07:25:00Z", presented.Extensions["departureTime"])
+		assert.Equal(t, 45, presented.Extensions["minTurnaroundMinutes"])
+		assert.NotContains(t, presented.Extensions, "aircraftPosition")
+		assert.NotContains(t, presented.Extensions, "requiredPosition")
+	})
+
+	t.Run("Next Flight Position", func(t *testing.T) {
+		conflict := &exceptions.AircraftConflictError{
+			Kind:         exceptions.AircraftConflictPosition,
+			AircraftID:   uuid.New(),
+			FlightID:     uuid.New(),
+			FlightNumber: "BA178",
+			Origin:       "JFK",
+		}
+
+		presented := presentGraphQLError(context.Background(), conflict)
+
+		assert.Equal(t, "POSITION", presented.Extensions["kind"])
+		assert.Equal(t, "JFK", presented.Extensions["requiredPosition"])
+		assert.NotContains(t, presented.Extensions, "aircraftPosition")
+	})
+
+	t.Run("Other Errors", func(t *testing.T) {
+		presented := presentGraphQLError(context.Background(), exceptions.ErrVersionConflict)
+
+		assert.Equal(t, exceptions.ErrVersionConflict.Error(), presented.Message)
+		assert.Nil(t, presented.Extensions)
+	})
+}
@@ -9,12 +9,20 @@ import (
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/config"
 	flightRepository "github.com/edinstance/distributed-aviation-system/services/flights/internal/database/repositories/flights"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/flights"
-	"github.com/edinstance/distributed-aviation-system/services/flights/internal/kafka"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
 	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
 	v1connect "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+	bulkCreateFlightsResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/bulk"
 	createFlightsResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/create"
+	reportDelayResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/delay"
+	deleteFlightResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/deletion"
 	getFlightsResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/get"
+	flightHistoryResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/history"
+	listFlightsResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/list"
+	recordMovementResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/movement"
+	transitionFlightResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/transition"
+	updateFlightResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/update"
+	watchFlightsResolver "github.com/edinstance/distributed-aviation-system/services/flights/internal/resolvers/flights/watch"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
@@ -24,24 +32,43 @@ import (
 type GrpcFlightsServer struct {
 	createFlightResolver *createFlightsResolver.FlightResolver
 	getFlightsResolver   *getFlightsResolver.FlightResolver
+	listFlightsResolver  *listFlightsResolver.FlightResolver
+	updateFlightResolver *updateFlightResolver.FlightResolver
+	transitionResolver   *transitionFlightResolver.FlightResolver
+	deleteFlightResolver *deleteFlightResolver.FlightResolver
+	historyResolver      *flightHistoryResolver.FlightResolver
+	bulkCreateResolver   *bulkCreateFlightsResolver.FlightResolver
+	watchResolver        *watchFlightsResolver.FlightResolver
+	delayResolver        *reportDelayResolver.FlightResolver
+	movementResolver     *recordMovementResolver.FlightResolver
 }
 
-func NewGrpcFlightsServer(pool *pgxpool.Pool, client *redis.Client, kafkaPublisher *kafka.Publisher) *GrpcFlightsServer {
+func NewGrpcFlightsServer(pool *pgxpool.Pool, client *redis.Client, aircraftClient aircraft_client.AircraftLookup, updates flights.FlightUpdates) *GrpcFlightsServer {
 	logger.Debug("Creating new FlightsServer")
 	dbRepo := flightRepository.NewFlightRepository(pool)
+	dbRepo.MinTurnaround = config.App.MinTurnaround
 	cacheRepo := cacheRepository.NewRedisFlightRepository(client, config.App.CacheTTL)
-	aircraftClient, aircraftClientErr := aircraft_client.NewAircraftClient(config.App.AircraftServiceGrpcUrl)
-
-	if aircraftClientErr != nil {
-		logger.Error("Failed to create aircraft client", "err", aircraftClientErr)
-		return nil
-	}
-
-	flightService := flights.NewFlightsService(dbRepo, cacheRepo, aircraftClient, kafkaPublisher)
+	flightService := flights.NewFlightsService(dbRepo, cacheRepo, aircraftClient)
+	flightService.ScheduleHorizon = config.App.ScheduleHorizon
+	flightService.MinTurnaround = config.App.MinTurnaround
+	flightService.Updates = updates
+	return newGrpcFlightsServer(flightService)
+}
 
+// newGrpcFlightsServer wires the gRPC resolvers to flightService.
+func newGrpcFlightsServer(flightService *flights.Service) *GrpcFlightsServer {
 	return &GrpcFlightsServer{
 		createFlightResolver: createFlightsResolver.NewCreateFlightResolver(flightService),
 		getFlightsResolver:   getFlightsResolver.NewGetFlightResolver(flightService),
+		listFlightsResolver:  listFlightsResolver.NewListFlightsResolver(flightService),
+		updateFlightResolver: updateFlightResolver.NewUpdateFlightResolver(flightService),
+		transitionResolver:   transitionFlightResolver.NewTransitionFlightStatusResolver(flightService),
+		deleteFlightResolver: deleteFlightResolver.NewDeleteFlightResolver(flightService),
+		historyResolver:      flightHistoryResolver.NewFlightHistoryResolver(flightService),
+		bulkCreateResolver:   bulkCreateFlightsResolver.NewBulkCreateFlightsResolver(flightService),
+		watchResolver:        watchFlightsResolver.NewWatchFlightsResolver(flightService),
+		delayResolver:        reportDelayResolver.NewReportDelayResolver(flightService),
+		movementResolver:     recordMovementResolver.NewRecordMovementResolver(flightService),
 	}
 }
 
@@ -61,3 +88,74 @@ func (s *GrpcFlightsServer) GetFlightById(
 ) (*connect.Response[v1.GetFlightByIdResponse], error) {
 	return s.getFlightsResolver.GetFlightByIdGRPC(ctx, c)
 }
+
+func (s *GrpcFlightsServer) ListFlights(
+	ctx context.Context,
+	req *connect.Request[v1.ListFlightsRequest],
+) (*connect.Response[v1.ListFlightsResponse], error) {
+	return s.listFlightsResolver.ListFlightsGRPC(ctx, req)
+}
+
+func (s *GrpcFlightsServer) UpdateFlight(
+	ctx context.Context,
+	req *connect.Request[v1.UpdateFlightRequest],
+) (*connect.Response[v1.UpdateFlightResponse], error) {
+	return s.updateFlightResolver.UpdateFlightGRPC(ctx, req)
+}
+
+func (s *GrpcFlightsServer) TransitionFlightStatus(
+	ctx context.Context,
+	req *connect.Request[v1.TransitionFlightStatusRequest],
+) (*connect.Response[v1.TransitionFlightStatusResponse], error) {
+	return s.transitionResolver.TransitionFlightStatusGRPC(ctx, req)
+}
+
+func (s *GrpcFlightsServer) DeleteFlight(
+	ctx context.Context,
+	req *connect.Request[v1.DeleteFlightRequest],
+) (*connect.Response[v1.DeleteFlightResponse], error) {
+	return s.deleteFlightResolver.DeleteFlightGRPC(ctx, req)
+}
+
+func (s *GrpcFlightsServer) RestoreFlight(
+	ctx context.Context,
+	req *connect.Request[v1.RestoreFlightRequest],
+) (*connect.Response[v1.RestoreFlightResponse], error) {
+	return s.deleteFlightResolver.RestoreFlightGRPC(ctx, req)
+}
+
+func (s *GrpcFlightsServer) GetFlightHistory(
+	ctx context.Context,
+	req *connect.Request[v1.GetFlightHistoryRequest],
+) (*connect.Response[v1.GetFlightHistoryResponse], error) {
+	return s.historyResolver.GetFlightHistoryGRPC(ctx, req)
+}
+
+func (s *GrpcFlightsServer) BulkCreateFlights(
+	ctx context.Context,
+	stream *connect.ClientStream[v1.BulkCreateFlightsRequest],
+) (*connect.Response[v1.BulkCreateFlightsResponse], error) {
+	return s.bulkCreateResolver.BulkCreateFlightsGRPC(ctx, stream)
+}
+
+func (s *GrpcFlightsServer) WatchFlights(
+	ctx context.Context,
+	req *connect.Request[v1.WatchFlightsRequest],
+	stream *connect.ServerStream[v1.FlightEvent],
+) error {
+	return s.watchResolver.WatchFlightsGRPC(ctx, req, stream)
+}
+
+func (s *GrpcFlightsServer) ReportDelay(
+	ctx context.Context,
+	req *connect.Request[v1.ReportDelayRequest],
+) (*connect.Response[v1.ReportDelayResponse], error) {
+	return s.delayResolver.ReportDelayGRPC(ctx, req)
+}
+
+func (s *GrpcFlightsServer) RecordMovement(
+	ctx context.Context,
+	req *connect.Request[v1.RecordMovementRequest],
+) (*connect.Response[v1.RecordMovementResponse], error) {
+	return s.movementResolver.RecordMovementGRPC(ctx, req)
+}
@@ -1,13 +1,14 @@
 package server
 
 import (
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/clients/aircraft_client"
 	"net/http"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/otelconnect"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/config"
-	"github.com/edinstance/distributed-aviation-system/services/flights/internal/kafka"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/flights"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
@@ -18,7 +19,7 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
-func NewMux(pool *pgxpool.Pool, client *redis.Client, kafkaPublisher *kafka.Publisher) *http.ServeMux {
+func NewMux(pool *pgxpool.Pool, client *redis.Client, aircraftClient aircraft_client.AircraftLookup, updates flights.FlightUpdates) *http.ServeMux {
 	traceInterceptor, err := otelconnect.NewInterceptor(
 		otelconnect.WithTracerProvider(otel.GetTracerProvider()),
 	)
@@ -30,22 +31,25 @@ func NewMux(pool *pgxpool.Pool, client *redis.Client, kafkaPublisher *kafka.Publ
 
 	mux := http.NewServeMux()
 
-	interceptors := []connect.Interceptor{metrics.GrpcMetricsInterceptor{}}
+	interceptors := []connect.Interceptor{
+		metrics.GrpcMetricsInterceptor{},
+		middleware.NewAuthorizationInterceptor(procedurePolicies),
+	}
 	if traceInterceptor != nil {
 		interceptors = append([]connect.Interceptor{traceInterceptor}, interceptors...)
 	}
 
 	// Register Connect/gRPC/gRPC-Web handlers
-	grpcFlightsServer := NewGrpcFlightsServer(pool, client, kafkaPublisher)
+	grpcFlightsServer := NewGrpcFlightsServer(pool, client, aircraftClient, updates)
 	flightPath, flightHandler := v1connect.NewFlightsServiceHandler(
 		grpcFlightsServer,
 		connect.WithInterceptors(interceptors...),
 	)
 
-	mux.Handle(flightPath, flightHandler)
+	mux.Handle(flightPath, withoutStreamDeadline(flightHandler))
 
 	// GraphQL handlers
-	mux.Handle("/graphql", middleware.UserContextMiddleware(newGraphQLHandler(pool, client, kafkaPublisher)))
+	mux.Handle("/graphql", withoutStreamDeadline(middleware.UserContextMiddleware(newGraphQLHandler(pool, client, aircraftClient, updates))))
 
 	if config.App.Environment != "prod" {
 		mux.Handle("/playground", playground.Handler("GraphQL Playground", "/graphql"))
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+	v1connect "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+	"github.com/gorilla/websocket"
+)
+
+// streamingProcedures are the Connect procedures whose responses stay open.
+var streamingProcedures = map[string]bool{
+	v1connect.FlightsServiceWatchFlightsProcedure: true,
+}
+
+// withoutStreamDeadline lifts the server's write timeout for requests that stream,
+// which are calls to streamingProcedures and the websocket upgrades that carry GraphQL
+// subscriptions. The timeout would otherwise reset every stream once it had been open
+// that long. Other requests keep it.
+func withoutStreamDeadline(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if streamingProcedures[r.URL.Path] || websocket.IsWebSocketUpgrade(r) {
+			if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+				logger.WarnContext(r.Context(), "Failed to lift write deadline for stream", "path", r.URL.Path, "err", err)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,300 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/flights"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	v1connect "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pubsub"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// observedUpdates reports when a subscription is registered and when it is released.
+type observedUpdates struct {
+	flights.FlightUpdates
+	subscribed chan struct{}
+	released   chan struct{}
+}
+
+func (o *observedUpdates) Subscribe(ctx context.Context, orgID *uuid.UUID) <-chan *models.FlightChange {
+	updates := o.FlightUpdates.Subscribe(ctx, orgID)
+	go func() {
+		<-ctx.Done()
+		o.released <- struct{}{}
+	}()
+	o.subscribed <- struct{}{}
+	return updates
+}
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// subscribe opens a graphql-transport-ws connection as caller and starts query on it.
+func subscribe(t *testing.T, server *httptest.Server, caller tenant, query string, variables map[string]any) *websocket.Conn {
+	t.Helper()
+
+	header := http.Header{}
+	setTenantHeaders(header, caller.orgID, caller.roles)
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-transport-ws"}}
+	conn, resp, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	t.Cleanup(func() { _ = conn.Close() })
+
+	require.NoError(t, conn.WriteJSON(wsMessage{Type: "connection_init"}))
+	require.Equal(t, "connection_ack", readMessage(t, conn).Type)
+
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteJSON(wsMessage{ID: "1", Type: "subscribe", Payload: payload}))
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	for {
+		var msg wsMessage
+		require.NoError(t, conn.ReadJSON(&msg))
+		if msg.Type != "ping" && msg.Type != "pong" {
+			return msg
+		}
+	}
+}
+
+// assertRefused checks that the subscription on conn failed with an error containing
+// reason and then completed without sending any data.
+func assertRefused(t *testing.T, conn *websocket.Conn, reason string) {
+	t.Helper()
+
+	msg := readMessage(t, conn)
+	var result struct {
+		Data   json.RawMessage   `json:"data"`
+		Errors []json.RawMessage `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(msg.Payload, &result))
+	assert.Equal(t, "null", string(result.Data))
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, string(result.Errors[0]), reason)
+
+	assert.Equal(t, "complete", readMessage(t, conn).Type)
+}
+
+func waitFor(t *testing.T, signal <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-signal:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func TestGraphQLSubscriptionsAreScopedToOrganization(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+	service, alpha, bravo := newTenancyFixture()
+	updates := &observedUpdates{
+		FlightUpdates: pubsub.NewBroker(nil),
+		subscribed:    make(chan struct{}, 1),
+		released:      make(chan struct{}, 1),
+	}
+	service.Updates = updates
+	server := httptest.NewServer(middleware.UserContextMiddleware(newGraphQLServer(service)))
+	defer server.Close()
+
+	const flightUpdated = `subscription($id: ID!) { flightUpdated(id: $id) { id version } }`
+	const flightsOnRoute = `subscription { flightsOnRoute(origin: "LHR", destination: "JFK") { id } }`
+
+	t.Run("Own Flight Updates Are Delivered", func(t *testing.T) {
+		conn := subscribe(t, server, alpha, flightUpdated, map[string]any{"id": alpha.flight.ID.String()})
+		waitFor(t, updates.subscribed, "subscription")
+
+		updated := *alpha.flight
+		updated.Version = 2
+		require.NoError(t, updates.Publish(context.Background(), &updated))
+
+		msg := readMessage(t, conn)
+		require.Equal(t, "next", msg.Type, string(msg.Payload))
+		assert.JSONEq(t, `{"data":{"flightUpdated":{"id":"`+alpha.flight.ID.String()+`","version":2}}}`, string(msg.Payload))
+
+		require.NoError(t, conn.Close())
+		waitFor(t, updates.released, "the subscription to be released after the socket closed")
+	})
+
+	t.Run("Another Organization's Flight Is Refused", func(t *testing.T) {
+		conn := subscribe(t, server, alpha, flightUpdated, map[string]any{"id": bravo.flight.ID.String()})
+		waitFor(t, updates.subscribed, "subscription")
+		waitFor(t, updates.released, "the refused subscription to be released")
+
+		assertRefused(t, conn, "not found")
+	})
+
+	t.Run("Route Updates Stay Within The Organization", func(t *testing.T) {
+		conn := subscribe(t, server, alpha, flightsOnRoute, nil)
+		waitFor(t, updates.subscribed, "subscription")
+
+		require.NoError(t, updates.Publish(context.Background(), bravo.flight, alpha.flight))
+
+		msg := readMessage(t, conn)
+		require.Equal(t, "next", msg.Type, string(msg.Payload))
+		assert.JSONEq(t, `{"data":{"flightsOnRoute":{"id":"`+alpha.flight.ID.String()+`"}}}`, string(msg.Payload))
+
+		require.NoError(t, conn.Close())
+		waitFor(t, updates.released, "the subscription to be released after the socket closed")
+	})
+
+	t.Run("Unauthenticated Callers Are Refused", func(t *testing.T) {
+		conn := subscribe(t, server, tenant{orgID: uuid.Nil}, flightsOnRoute, nil)
+
+		assertRefused(t, conn, "unauthorized")
+	})
+}
+
+func TestGrpcWatchIsScopedToOrganization(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+	service, alpha, bravo := newTenancyFixture()
+	updates := pubsub.NewBroker(nil)
+	service.Updates = updates
+	path, handler := v1connect.NewFlightsServiceHandler(newGrpcFlightsServer(service),
+		connect.WithInterceptors(metrics.GrpcMetricsInterceptor{}, middleware.NewAuthorizationInterceptor(procedurePolicies)))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := v1connect.NewFlightsServiceClient(server.Client(), server.URL)
+
+	watch := func(t *testing.T, caller tenant, msg *v1.WatchFlightsRequest) *connect.ServerStreamForClient[v1.FlightEvent] {
+		t.Helper()
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		req := connect.NewRequest(msg)
+		setTenantHeaders(req.Header(), caller.orgID, caller.roles)
+		stream, err := client.WatchFlights(ctx, req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = stream.Close() })
+		return stream
+	}
+
+	next := func(t *testing.T, stream *connect.ServerStreamForClient[v1.FlightEvent]) *v1.FlightEvent {
+		t.Helper()
+		require.True(t, stream.Receive(), "stream ended: %v", stream.Err())
+		return stream.Msg()
+	}
+
+	updated := *alpha.flight
+	updated.Version = 2
+
+	t.Run("Snapshot And Updates Only Cover Own Flights", func(t *testing.T) {
+		stream := watch(t, alpha, &v1.WatchFlightsRequest{})
+
+		snapshot := next(t, stream)
+		assert.Equal(t, v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT, snapshot.GetType())
+		assert.Equal(t, alpha.flight.ID.String(), snapshot.GetFlight().GetId())
+		assert.Equal(t, v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT_COMPLETE, next(t, stream).GetType())
+
+		require.NoError(t, updates.Publish(context.Background(), bravo.flight, &updated))
+
+		event := next(t, stream)
+		assert.Equal(t, v1.FlightEventType_FLIGHT_EVENT_TYPE_UPDATED, event.GetType())
+		assert.Equal(t, uint64(2), event.GetSequence())
+		assert.Equal(t, int32(2), event.GetFlight().GetVersion())
+	})
+
+	t.Run("Resuming Replays Missed Changes", func(t *testing.T) {
+		after := uint64(0)
+		stream := watch(t, alpha, &v1.WatchFlightsRequest{AfterSequence: &after})
+
+		replayed := next(t, stream)
+		assert.Equal(t, uint64(2), replayed.GetSequence())
+		assert.Equal(t, alpha.flight.ID.String(), replayed.GetFlight().GetId())
+
+		require.NoError(t, updates.Publish(context.Background(), &updated))
+
+		assert.Equal(t, uint64(3), next(t, stream).GetSequence())
+	})
+
+	t.Run("Resuming From An Unknown Sequence Is Out Of Range", func(t *testing.T) {
+		after := uint64(99)
+		stream := watch(t, alpha, &v1.WatchFlightsRequest{AfterSequence: &after})
+
+		assert.False(t, stream.Receive())
+		assert.Equal(t, connect.CodeOutOfRange, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("Callers Without An Organization Are Refused", func(t *testing.T) {
+		stream := watch(t, tenant{orgID: uuid.Nil}, &v1.WatchFlightsRequest{})
+
+		assert.False(t, stream.Receive())
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(stream.Err()))
+	})
+}
+
+func TestGrpcWatchOutlivesWriteTimeout(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+	service, alpha, _ := newTenancyFixture()
+	updates := pubsub.NewBroker(nil)
+	service.Updates = updates
+	path, handler := v1connect.NewFlightsServiceHandler(newGrpcFlightsServer(service),
+		connect.WithInterceptors(metrics.GrpcMetricsInterceptor{}, middleware.NewAuthorizationInterceptor(procedurePolicies)))
+	mux := http.NewServeMux()
+	mux.Handle(path, withoutStreamDeadline(handler))
+
+	// Served over h2c with a short write timeout, as cmd/main.go serves it.
+	const writeTimeout = 200 * time.Millisecond
+	server := httptest.NewUnstartedServer(h2c.NewHandler(mux, &http2.Server{}))
+	server.Config.WriteTimeout = writeTimeout
+	server.Start()
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	client := v1connect.NewFlightsServiceClient(httpClient, server.URL, connect.WithGRPC())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := connect.NewRequest(&v1.WatchFlightsRequest{})
+	setTenantHeaders(req.Header(), alpha.orgID, alpha.roles)
+	stream, err := client.WatchFlights(ctx, req)
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	for range 2 {
+		require.True(t, stream.Receive(), "snapshot ended: %v", stream.Err())
+	}
+	assert.Equal(t, v1.FlightEventType_FLIGHT_EVENT_TYPE_SNAPSHOT_COMPLETE, stream.Msg().GetType())
+
+	time.Sleep(2 * writeTimeout)
+
+	updated := *alpha.flight
+	updated.Version = 2
+	require.NoError(t, updates.Publish(context.Background(), &updated))
+
+	require.True(t, stream.Receive(), "stream ended after the write timeout: %v", stream.Err())
+	assert.Equal(t, v1.FlightEventType_FLIGHT_EVENT_TYPE_UPDATED, stream.Msg().GetType())
+	assert.Equal(t, int32(2), stream.Msg().GetFlight().GetVersion())
+}
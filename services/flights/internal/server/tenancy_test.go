@@ -0,0 +1,356 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	cacheRepository "github.com/edinstance/distributed-aviation-system/services/flights/internal/cache/repositories/flights"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/database/models"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/flights"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/metrics"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/middleware"
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/pagination"
+	v1 "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1"
+	v1connect "github.com/edinstance/distributed-aviation-system/services/flights/internal/protobuf/flights/v1/flightsv1connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantRepo is an in-memory flight store that applies organization scoping the
+// same way the SQL queries do.
+type tenantRepo struct {
+	flights []*models.Flight
+}
+
+func inScope(flight *models.Flight, orgID *uuid.UUID) bool {
+	return orgID == nil || flight.OrganizationID == *orgID
+}
+
+func (r *tenantRepo) GetFlightByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID, includeDeleted bool) (*models.Flight, error) {
+	for _, flight := range r.flights {
+		if flight.ID == id && inScope(flight, orgID) && (includeDeleted || flight.DeletedAt == nil) {
+			return flight, nil
+		}
+	}
+	return nil, exceptions.ErrNotFound
+}
+
+func (r *tenantRepo) GetFlightsByIDs(ctx context.Context, ids []uuid.UUID, orgID *uuid.UUID) ([]*models.Flight, error) {
+	var found []*models.Flight
+	for _, id := range ids {
+		if flight, err := r.GetFlightByID(ctx, id, orgID, false); err == nil {
+			found = append(found, flight)
+		}
+	}
+	return found, nil
+}
+
+func (r *tenantRepo) ListFlights(ctx context.Context, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+	var found []*models.Flight
+	for _, flight := range r.flights {
+		if inScope(flight, filter.OrganizationID) && (filter.IncludeDeleted || flight.DeletedAt == nil) {
+			found = append(found, flight)
+		}
+	}
+	return found, nil
+}
+
+func (r *tenantRepo) ListFlightsForAircraft(ctx context.Context, aircraftIDs []uuid.UUID, filter models.FlightFilter, limit int, after *pagination.Cursor) ([]*models.Flight, error) {
+	return r.ListFlights(ctx, filter, limit, after)
+}
+
+func (r *tenantRepo) CreateFlight(ctx context.Context, f *models.Flight, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	return nil
+}
+
+func (r *tenantRepo) CreateFlights(ctx context.Context, flights []*models.Flight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent) error {
+	return nil
+}
+
+func (r *tenantRepo) FindFlightInstances(ctx context.Context, candidates []*models.Flight) ([]*models.Flight, error) {
+	return nil, nil
+}
+
+func (r *tenantRepo) UpdateFlight(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	return nil
+}
+
+func (r *tenantRepo) TransitionFlightStatus(ctx context.Context, f *models.Flight, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	return nil
+}
+
+func (r *tenantRepo) DeleteFlight(ctx context.Context, f *models.Flight, deletedBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	stored, err := r.GetFlightByID(ctx, f.ID, nil, false)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	stored.DeletedAt = &now
+	f.DeletedAt = &now
+	return nil
+}
+
+func (r *tenantRepo) RestoreFlight(ctx context.Context, f *models.Flight, restoredBy uuid.UUID, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	stored, err := r.GetFlightByID(ctx, f.ID, nil, true)
+	if err != nil {
+		return err
+	}
+	stored.DeletedAt = nil
+	f.DeletedAt = nil
+	return nil
+}
+
+func (r *tenantRepo) BackfillAirline(ctx context.Context, actorID uuid.UUID, traceContext map[string]string) (map[uuid.UUID][]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r *tenantRepo) ListFlightHistory(ctx context.Context, flightID uuid.UUID, orgID *uuid.UUID, limit int, after *pagination.Cursor) ([]*models.FlightHistoryEntry, error) {
+	return nil, nil
+}
+
+func (r *tenantRepo) CreateSchedule(ctx context.Context, s *models.Schedule) error {
+	return nil
+}
+
+func (r *tenantRepo) GetScheduleByID(ctx context.Context, id uuid.UUID, orgID *uuid.UUID) (*models.Schedule, error) {
+	return nil, exceptions.ErrNotFound
+}
+
+func (r *tenantRepo) UpdateSchedule(ctx context.Context, s *models.Schedule, expectedVersion int32) error {
+	return nil
+}
+
+func (r *tenantRepo) ListActiveSchedules(ctx context.Context, from time.Time, limit int, after *uuid.UUID) ([]*models.Schedule, error) {
+	return nil, nil
+}
+
+func (r *tenantRepo) MaterializeSchedule(ctx context.Context, s *models.Schedule, instances []*models.ScheduledFlight, history []*models.FlightHistoryEntry, events []*models.OutboxEvent, through time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *tenantRepo) ListStaleScheduledFlights(ctx context.Context, scheduleID uuid.UUID, scheduleVersion int32, departingAfter time.Time) ([]*models.ScheduledFlight, error) {
+	return nil, nil
+}
+
+func (r *tenantRepo) UpdateScheduledFlight(ctx context.Context, instance *models.ScheduledFlight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	return nil
+}
+
+func (r *tenantRepo) ListUpcomingFlightsForAircraft(ctx context.Context, aircraftID uuid.UUID, departingAfter time.Time, limit int) ([]*models.Flight, error) {
+	return nil, nil
+}
+
+func (r *tenantRepo) UnassignAircraft(ctx context.Context, f *models.Flight, expectedVersion int32, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	return nil
+}
+
+func (r *tenantRepo) UnassignScheduleAircraft(ctx context.Context, aircraftID, actorID uuid.UUID, from time.Time) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r *tenantRepo) ReportDelay(ctx context.Context, f *models.Flight, expectedVersion int32, delay *models.FlightDelay, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	return nil
+}
+
+func (r *tenantRepo) RecordMovement(ctx context.Context, f *models.Flight, expectedVersion int32, transition *models.FlightStatusTransition, history *models.FlightHistoryEntry, events ...*models.OutboxEvent) error {
+	return nil
+}
+
+type noopAircraftLookup struct{}
+
+func (noopAircraftLookup) GetAircraft(ctx context.Context, aircraftID uuid.UUID) (*models.Aircraft, error) {
+	return &models.Aircraft{ID: aircraftID, Capacity: 180, Status: models.AircraftStatusAvailable}, nil
+}
+
+func (noopAircraftLookup) Invalidate(aircraftID uuid.UUID) {}
+
+type tenant struct {
+	orgID  uuid.UUID
+	roles  string
+	flight *models.Flight
+}
+
+func newTenancyFixture() (*flights.Service, tenant, tenant) {
+	departure := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	newFlight := func(number string, orgID uuid.UUID) *models.Flight {
+		return &models.Flight{
+			ID:             uuid.New(),
+			Number:         number,
+			Origin:         "LHR",
+			Destination:    "JFK",
+			DepartureTime:  departure,
+			ArrivalTime:    departure.Add(8 * time.Hour),
+			Status:         models.FlightStatusScheduled,
+			AircraftID:     uuid.New(),
+			OrganizationID: orgID,
+			Version:        1,
+		}
+	}
+
+	alpha := tenant{orgID: uuid.New()}
+	alpha.flight = newFlight("BA117", alpha.orgID)
+	bravo := tenant{orgID: uuid.New()}
+	bravo.flight = newFlight("AA100", bravo.orgID)
+
+	repo := &tenantRepo{flights: []*models.Flight{alpha.flight, bravo.flight}}
+	service := flights.NewFlightsService(repo, cacheRepository.NewNoopFlightRepository(), noopAircraftLookup{})
+	return service, alpha, bravo
+}
+
+func setTenantHeaders(header http.Header, orgID uuid.UUID, roles string) {
+	header.Set("x-user-sub", uuid.NewString())
+	header.Set("x-org-id", orgID.String())
+	header.Set("x-org-name", "Test Airline")
+	if roles != "" {
+		header.Set("x-user-roles", roles)
+	}
+}
+
+type graphQLResponse struct {
+	Data struct {
+		GetFlightByID *struct {
+			ID string `json:"id"`
+		} `json:"getFlightById"`
+		Flights *struct {
+			Edges []struct {
+				Node struct {
+					ID string `json:"id"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"flights"`
+	} `json:"data"`
+	Errors []json.RawMessage `json:"errors"`
+}
+
+func postGraphQL(t *testing.T, handler http.Handler, caller tenant, query string, variables map[string]any) graphQLResponse {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	setTenantHeaders(req.Header, caller.orgID, caller.roles)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp graphQLResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func listedIDs(resp graphQLResponse) []string {
+	var ids []string
+	if resp.Data.Flights != nil {
+		for _, edge := range resp.Data.Flights.Edges {
+			ids = append(ids, edge.Node.ID)
+		}
+	}
+	return ids
+}
+
+func TestGraphQLReadsAreScopedToOrganization(t *testing.T) {
+	require.NoError(t, metrics.InitInstruments())
+	service, alpha, bravo := newTenancyFixture()
+	handler := middleware.UserContextMiddleware(newGraphQLServer(service))
+
+	const getFlight = `query($id: ID!) { getFlightById(id: $id) { id } }`
+	const listFlights = `{ flights { edges { node { id } } } }`
+
+	t.Run("own flight is visible", func(t *testing.T) {
+		resp := postGraphQL(t, handler, alpha, getFlight, map[string]any{"id": alpha.flight.ID.String()})
+
+		require.NotNil(t, resp.Data.GetFlightByID)
+		assert.Equal(t, alpha.flight.ID.String(), resp.Data.GetFlightByID.ID)
+	})
+
+	t.Run("another organization's flight is not found", func(t *testing.T) {
+		resp := postGraphQL(t, handler, alpha, getFlight, map[string]any{"id": bravo.flight.ID.String()})
+
+		assert.Empty(t, resp.Errors)
+		assert.Nil(t, resp.Data.GetFlightByID)
+	})
+
+	t.Run("listing only returns own flights", func(t *testing.T) {
+		assert.Equal(t, []string{alpha.flight.ID.String()}, listedIDs(postGraphQL(t, handler, alpha, listFlights, nil)))
+		assert.Equal(t, []string{bravo.flight.ID.String()}, listedIDs(postGraphQL(t, handler, bravo, listFlights, nil)))
+	})
+
+	t.Run("platform admin reads across organizations", func(t *testing.T) {
+		admin := tenant{orgID: uuid.New(), roles: "PLATFORM_ADMIN"}
+
+		resp := postGraphQL(t, handler, admin, getFlight, map[string]any{"id": bravo.flight.ID.String()})
+		require.NotNil(t, resp.Data.GetFlightByID)
+		assert.Equal(t, bravo.flight.ID.String(), resp.Data.GetFlightByID.ID)
+
+		assert.ElementsMatch(t,
+			[]string{alpha.flight.ID.String(), bravo.flight.ID.String()},
+			listedIDs(postGraphQL(t, handler, admin, listFlights, nil)))
+	})
+}
+
+func TestGrpcReadsAreScopedToOrganization(t *testing.T) {
+	service, alpha, bravo := newTenancyFixture()
+	path, handler := v1connect.NewFlightsServiceHandler(newGrpcFlightsServer(service))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := v1connect.NewFlightsServiceClient(server.Client(), server.URL)
+
+	getFlight := func(caller tenant, id uuid.UUID) *v1.Flight {
+		req := connect.NewRequest(&v1.GetFlightByIdRequest{Id: id.String()})
+		setTenantHeaders(req.Header(), caller.orgID, caller.roles)
+		resp, err := client.GetFlightById(context.Background(), req)
+		require.NoError(t, err)
+		return resp.Msg.GetFlight()
+	}
+
+	listFlights := func(caller tenant) []string {
+		req := connect.NewRequest(&v1.ListFlightsRequest{})
+		setTenantHeaders(req.Header(), caller.orgID, caller.roles)
+		resp, err := client.ListFlights(context.Background(), req)
+		require.NoError(t, err)
+
+		var ids []string
+		for _, flight := range resp.Msg.GetFlights() {
+			ids = append(ids, flight.GetId())
+		}
+		return ids
+	}
+
+	t.Run("own flight is visible", func(t *testing.T) {
+		flight := getFlight(alpha, alpha.flight.ID)
+
+		require.NotNil(t, flight)
+		assert.Equal(t, alpha.flight.ID.String(), flight.GetId())
+	})
+
+	t.Run("another organization's flight is not found", func(t *testing.T) {
+		assert.Nil(t, getFlight(alpha, bravo.flight.ID))
+		assert.Nil(t, getFlight(bravo, alpha.flight.ID))
+	})
+
+	t.Run("listing only returns own flights", func(t *testing.T) {
+		assert.Equal(t, []string{alpha.flight.ID.String()}, listFlights(alpha))
+		assert.Equal(t, []string{bravo.flight.ID.String()}, listFlights(bravo))
+	})
+
+	t.Run("platform admin reads across organizations", func(t *testing.T) {
+		admin := tenant{orgID: uuid.New(), roles: "PLATFORM_ADMIN"}
+
+		require.NotNil(t, getFlight(admin, alpha.flight.ID))
+		assert.ElementsMatch(t, []string{alpha.flight.ID.String(), bravo.flight.ID.String()}, listFlights(admin))
+	})
+}
@@ -0,0 +1,111 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/logger"
+)
+
+// DefaultReloadInterval is how often a CertReloader checks its files when none is given.
+const DefaultReloadInterval = 30 * time.Second
+
+// CertReloader serves a certificate and key pair from disk and reloads it when either
+// file changes, so rotated certificates are picked up without a restart. The files are
+// checked at most once per interval, during a handshake; if a reload fails the
+// previous certificate is kept.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// NewCertReloader loads the pair at certFile and keyFile, returning an error if it
+// cannot be read. A non-positive interval means DefaultReloadInterval.
+func NewCertReloader(certFile, keyFile string, interval time.Duration) (*CertReloader, error) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	r := &CertReloader{certFile: certFile, keyFile: keyFile, interval: interval, now: time.Now}
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	r.checkedAt = r.now()
+	return r, nil
+}
+
+// GetCertificate is a tls.Config GetCertificate callback.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.certificate(), nil
+}
+
+// GetClientCertificate is a tls.Config GetClientCertificate callback.
+func (r *CertReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.certificate(), nil
+}
+
+func (r *CertReloader) certificate() *tls.Certificate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.checkedAt) < r.interval {
+		return r.cert
+	}
+	r.checkedAt = now
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		logger.Warn("Failed to check TLS certificate, keeping the loaded one", "cert_file", r.certFile, "err", err)
+		return r.cert
+	}
+	if modTime.Equal(r.modTime) {
+		return r.cert
+	}
+
+	if err := r.load(modTime); err != nil {
+		logger.Warn("Failed to reload TLS certificate, keeping the loaded one", "cert_file", r.certFile, "err", err)
+		return r.cert
+	}
+	logger.Info("Reloaded TLS certificate", "cert_file", r.certFile)
+	return r.cert
+}
+
+// load reads the pair and records modTime; the caller holds mu or owns r.
+func (r *CertReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load certificate %s: %w", r.certFile, err)
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
+
+func (r *CertReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat %s: %w", name, err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,55 @@
+package tlsconfig
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "Test CA")
+	certFile, keyFile := ca.issue(t, dir, "server", "first")
+
+	now := time.Now()
+	reloader, err := NewCertReloader(certFile, keyFile, time.Minute)
+	require.NoError(t, err)
+	reloader.now = func() time.Time { return now }
+	require.Equal(t, "first", servedCommonName(t, reloader))
+
+	// Rotate the certificate in place, as a secret mount or cert-manager would.
+	ca.issue(t, dir, "server", "second")
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	t.Run("Waits For The Reload Interval", func(t *testing.T) {
+		now = now.Add(30 * time.Second)
+		assert.Equal(t, "first", servedCommonName(t, reloader))
+	})
+
+	t.Run("Reloads A Changed Certificate", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		assert.Equal(t, "second", servedCommonName(t, reloader))
+	})
+
+	t.Run("Keeps The Loaded Certificate If A Reload Fails", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0o600))
+		broken := later.Add(time.Second)
+		require.NoError(t, os.Chtimes(certFile, broken, broken))
+
+		now = now.Add(time.Minute)
+		assert.Equal(t, "second", servedCommonName(t, reloader))
+	})
+}
+
+func TestNewCertReloaderRejectsMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewCertReloader(dir+"/missing.pem", dir+"/missing-key.pem", time.Minute)
+
+	assert.ErrorContains(t, err, "missing.pem")
+}
@@ -0,0 +1,85 @@
+// Package tlsconfig builds the TLS configurations for the flights service's listener
+// and its outbound clients from certificate files on disk.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Server returns a TLS configuration for the HTTP/Connect listener that serves the
+// pair at certFile and keyFile, reloading it when the files change. HTTP/2 is offered
+// through ALPN, so gRPC clients keep working once plaintext h2c is switched off.
+func Server(certFile, keyFile string, reloadInterval time.Duration) (*tls.Config, error) {
+	reloader, err := NewCertReloader(certFile, keyFile, reloadInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     []string{"h2", "http/1.1"},
+		GetCertificate: reloader.GetCertificate,
+	}, nil
+}
+
+// ClientOptions configures an outbound TLS connection.
+type ClientOptions struct {
+	// CAFile is a PEM bundle of the CAs trusted to sign the server's certificate. If
+	// empty, the system roots are used.
+	CAFile string
+	// CertFile and KeyFile are the client certificate presented for mutual TLS. They
+	// must be set together.
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the name verified against the server's certificate.
+	ServerName     string
+	ReloadInterval time.Duration
+}
+
+// Client returns a TLS configuration for an outbound connection. The client
+// certificate, if any, is reloaded when its files change.
+func Client(options ClientOptions) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: options.ServerName,
+	}
+
+	if options.CAFile != "" {
+		pool, err := loadCertPool(options.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	if (options.CertFile == "") != (options.KeyFile == "") {
+		return nil, errors.New("client certificate and key must be set together")
+	}
+	if options.CertFile != "" {
+		reloader, err := NewCertReloader(options.CertFile, options.KeyFile, options.ReloadInterval)
+		if err != nil {
+			return nil, err
+		}
+		config.GetClientCertificate = reloader.GetClientCertificate
+	}
+
+	return config, nil
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	raw, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(raw) {
+		return nil, fmt.Errorf("CA bundle %s contains no certificates", caFile)
+	}
+	return pool, nil
+}
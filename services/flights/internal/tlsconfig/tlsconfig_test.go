@@ -0,0 +1,128 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startTLSServer serves with config and echoes the common name of the client
+// certificate, if any. httptest adds its own certificate for clients that send no
+// server name, so clients must set ServerName to be served config's.
+func startTLSServer(t *testing.T, config *tls.Config) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}
+	}))
+	server.TLS = config
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, config *tls.Config, url string) (*http.Response, error) {
+	t.Helper()
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: config, ForceAttemptHTTP2: true}, Timeout: 5 * time.Second}
+	resp, err := client.Get(url)
+	if err == nil {
+		t.Cleanup(func() { _ = resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "Test CA")
+	certFile, keyFile := ca.issue(t, dir, "server", "flights-service")
+
+	serverConfig, err := Server(certFile, keyFile, time.Minute)
+	require.NoError(t, err)
+	server := startTLSServer(t, serverConfig)
+
+	t.Run("Serves HTTP/2 To Clients Trusting The CA", func(t *testing.T) {
+		clientConfig, err := Client(ClientOptions{CAFile: ca.writeCA(t, dir), ServerName: "localhost"})
+		require.NoError(t, err)
+
+		resp, err := get(t, clientConfig, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, resp.ProtoMajor)
+	})
+
+	t.Run("Is Rejected By Clients Trusting Another CA", func(t *testing.T) {
+		otherDir := t.TempDir()
+		clientConfig, err := Client(ClientOptions{CAFile: newTestCA(t, "Other CA").writeCA(t, otherDir), ServerName: "localhost"})
+		require.NoError(t, err)
+
+		_, err = get(t, clientConfig, server.URL)
+		var unknownAuthority x509.UnknownAuthorityError
+		assert.ErrorAs(t, err, &unknownAuthority)
+	})
+}
+
+func TestClientMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "Test CA")
+	caFile := ca.writeCA(t, dir)
+	serverCert, serverKey := ca.issue(t, dir, "server", "aircraft-service")
+	clientCert, clientKey := ca.issue(t, dir, "client", "flights-service")
+
+	serverConfig, err := Server(serverCert, serverKey, time.Minute)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	serverConfig.ClientCAs = pool
+	server := startTLSServer(t, serverConfig)
+
+	t.Run("Presents The Client Certificate", func(t *testing.T) {
+		clientConfig, err := Client(ClientOptions{CAFile: caFile, CertFile: clientCert, KeyFile: clientKey, ServerName: "localhost"})
+		require.NoError(t, err)
+
+		resp, err := get(t, clientConfig, server.URL)
+		require.NoError(t, err)
+
+		body := make([]byte, 64)
+		n, _ := resp.Body.Read(body)
+		assert.Equal(t, "flights-service", string(body[:n]))
+	})
+
+	t.Run("Is Refused Without A Client Certificate", func(t *testing.T) {
+		clientConfig, err := Client(ClientOptions{CAFile: caFile, ServerName: "localhost"})
+		require.NoError(t, err)
+
+		_, err = get(t, clientConfig, server.URL)
+		assert.Error(t, err)
+	})
+}
+
+func TestClientRejectsInvalidOptions(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "Test CA")
+	certFile, _ := ca.issue(t, dir, "client", "flights-service")
+
+	t.Run("Certificate Without Key", func(t *testing.T) {
+		_, err := Client(ClientOptions{CertFile: certFile})
+		assert.ErrorContains(t, err, "must be set together")
+	})
+
+	t.Run("Missing CA Bundle", func(t *testing.T) {
+		_, err := Client(ClientOptions{CAFile: certFile + ".missing"})
+		assert.ErrorContains(t, err, "read CA bundle")
+	})
+
+	t.Run("CA Bundle Without Certificates", func(t *testing.T) {
+		_, err := Client(ClientOptions{CAFile: dir + "/client-key.pem"})
+		assert.ErrorContains(t, err, "contains no certificates")
+	})
+}
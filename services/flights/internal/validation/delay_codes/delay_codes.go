@@ -0,0 +1,137 @@
+package delay_codes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+// AircraftRotation is the code of a delay caused by the late arrival of the aircraft
+// from its previous flight.
+const AircraftRotation = "93"
+
+// DelayCode is an IATA (AHM 730) delay code. Codes 00 to 05 are left to each airline
+// and have no alphabetic form.
+type DelayCode struct {
+	Code        string
+	Alpha       string
+	Description string
+}
+
+var delayCodes = []DelayCode{
+	{"00", "", "Airline internal code"},
+	{"01", "", "Airline internal code"},
+	{"02", "", "Airline internal code"},
+	{"03", "", "Airline internal code"},
+	{"04", "", "Airline internal code"},
+	{"05", "", "Airline internal code"},
+	{"06", "OA", "No gate or stand available due to own airline activity"},
+	{"09", "SG", "Scheduled ground time less than declared minimum"},
+	{"11", "PD", "Late check-in, acceptance after deadline"},
+	{"12", "PL", "Late check-in, congestion in check-in area"},
+	{"13", "PE", "Check-in error"},
+	{"14", "PO", "Oversales, booking errors"},
+	{"15", "PH", "Boarding, discrepancies and paging"},
+	{"16", "PS", "Commercial publicity, passenger convenience or VIP"},
+	{"17", "PC", "Catering order, late or incorrect"},
+	{"18", "PB", "Baggage processing"},
+	{"19", "PW", "Boarding or deboarding of passengers with reduced mobility"},
+	{"21", "CD", "Cargo documentation errors"},
+	{"22", "CP", "Late positioning of cargo"},
+	{"23", "CC", "Late acceptance of cargo"},
+	{"24", "CI", "Inadequate packing of cargo"},
+	{"25", "CO", "Cargo oversales, booking errors"},
+	{"26", "CU", "Late preparation of cargo in warehouse"},
+	{"27", "CE", "Mail documentation, packing"},
+	{"28", "CL", "Late positioning of mail"},
+	{"29", "CA", "Late acceptance of mail"},
+	{"31", "GD", "Aircraft documentation late or inaccurate"},
+	{"32", "GL", "Loading or unloading, bulky or special load"},
+	{"33", "GE", "Loading equipment, lack of or breakdown"},
+	{"34", "GS", "Servicing equipment, lack of or breakdown"},
+	{"35", "GC", "Aircraft cleaning"},
+	{"36", "GF", "Fuelling or defuelling"},
+	{"37", "GB", "Catering, late delivery or loading"},
+	{"38", "GU", "ULD, containers and pallets"},
+	{"39", "GT", "Technical ground equipment, lack of or breakdown"},
+	{"41", "TD", "Aircraft defects"},
+	{"42", "TM", "Scheduled maintenance, late release"},
+	{"43", "TN", "Non-scheduled maintenance or special checks"},
+	{"44", "TS", "Spares and maintenance equipment"},
+	{"45", "TA", "AOG spares"},
+	{"46", "TC", "Aircraft change for technical reasons"},
+	{"47", "TL", "Standby aircraft, lack of planned standby aircraft"},
+	{"48", "TV", "Scheduled cabin configuration adjustments"},
+	{"51", "DF", "Damage during flight operations"},
+	{"52", "DG", "Damage during ground operations"},
+	{"55", "ED", "Departure control system failure"},
+	{"56", "EC", "Cargo preparation or documentation system failure"},
+	{"57", "EF", "Flight plan system failure"},
+	{"58", "EO", "Other automated system failure"},
+	{"61", "FP", "Flight plan, late completion or change"},
+	{"62", "FF", "Operational requirements, fuel or load alteration"},
+	{"63", "FT", "Late crew boarding or departure procedures"},
+	{"64", "FS", "Flight deck crew shortage"},
+	{"65", "FR", "Flight deck crew special request"},
+	{"66", "FL", "Late cabin crew boarding or departure procedures"},
+	{"67", "FC", "Cabin crew shortage"},
+	{"68", "FA", "Cabin crew error or special request"},
+	{"69", "FB", "Captain request for security check"},
+	{"71", "WO", "Weather at departure station"},
+	{"72", "WT", "Weather at destination station"},
+	{"73", "WR", "Weather en route or at alternate"},
+	{"75", "WI", "De-icing of aircraft"},
+	{"76", "WS", "Removal of snow, ice, water or sand from airport"},
+	{"77", "WG", "Ground handling impaired by adverse weather"},
+	{"81", "AT", "ATFM due to ATC en-route demand or capacity"},
+	{"82", "AX", "ATFM due to ATC staff or equipment en route"},
+	{"83", "AE", "ATFM due to restriction at destination airport"},
+	{"84", "AW", "ATFM due to weather at destination"},
+	{"85", "AS", "Mandatory security"},
+	{"86", "AG", "Immigration, customs or health"},
+	{"87", "AF", "Airport facilities"},
+	{"88", "AD", "Restrictions at airport of destination"},
+	{"89", "AM", "Restrictions at airport of departure"},
+	{"91", "RL", "Load connection, awaiting load from another flight"},
+	{"92", "RT", "Through check-in error"},
+	{"93", "RA", "Aircraft rotation, late arrival from previous sector"},
+	{"94", "RS", "Cabin crew rotation"},
+	{"95", "RC", "Crew rotation, awaiting crew from another flight"},
+	{"96", "RO", "Operations control, re-routing, diversion or aircraft change"},
+	{"97", "MI", "Industrial action within own airline"},
+	{"98", "MO", "Industrial action outside own airline"},
+	{"99", "MX", "Other reason"},
+}
+
+// byCode indexes delayCodes by both their numeric and alphabetic forms.
+var byCode = func() map[string]*DelayCode {
+	index := make(map[string]*DelayCode, 2*len(delayCodes))
+	for i := range delayCodes {
+		code := &delayCodes[i]
+		index[code.Code] = code
+		if code.Alpha != "" {
+			index[code.Alpha] = code
+		}
+	}
+	return index
+}()
+
+// Lookup returns the delay code with the given numeric or alphabetic form, reporting
+// whether there is one.
+func Lookup(code string) (*DelayCode, bool) {
+	delayCode, ok := byCode[code]
+	return delayCode, ok
+}
+
+// ValidateAndNormalizeDelayCode trims and upper-cases code, which may be in either its
+// numeric or alphabetic form, and returns its numeric form. Unknown codes fail with
+// exceptions.ErrInvalidDelayCode.
+func ValidateAndNormalizeDelayCode(code string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	delayCode, ok := Lookup(normalized)
+	if !ok {
+		return "", fmt.Errorf("%w: unknown code %q", exceptions.ErrInvalidDelayCode, code)
+	}
+	return delayCode.Code, nil
+}
@@ -0,0 +1,54 @@
+package delay_codes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
+)
+
+func TestValidateAndNormalizeDelayCode(testHelper *testing.T) {
+	testCases := []struct {
+		code               string
+		expectedNormalized string
+		expectedError      error
+	}{
+		{"93", "93", nil},
+		{"RA", "93", nil},
+		{" ra ", "93", nil},
+		{"41", "41", nil},
+		{"02", "02", nil},
+		{"", "", exceptions.ErrInvalidDelayCode},
+		{"07", "", exceptions.ErrInvalidDelayCode},
+		{"ZZ", "", exceptions.ErrInvalidDelayCode},
+		{"930", "", exceptions.ErrInvalidDelayCode},
+	}
+
+	for _, testCase := range testCases {
+		result, err := ValidateAndNormalizeDelayCode(testCase.code)
+		if result != testCase.expectedNormalized {
+			testHelper.Errorf("Expected normalization of %q to %q, got %q instead", testCase.code, testCase.expectedNormalized, result)
+		}
+		if !errors.Is(err, testCase.expectedError) {
+			testHelper.Errorf("Expected error %v for %q, got %v instead", testCase.expectedError, testCase.code, err)
+		}
+	}
+}
+
+func TestLookup(testHelper *testing.T) {
+	rotation, ok := Lookup(AircraftRotation)
+	if !ok || rotation.Alpha != "RA" {
+		testHelper.Fatalf("Expected %s to be the RA code, got %+v", AircraftRotation, rotation)
+	}
+
+	for _, code := range delayCodes {
+		if len(code.Code) != 2 || code.Description == "" {
+			testHelper.Errorf("Malformed delay code %+v", code)
+		}
+		if code.Alpha != "" {
+			if alpha, ok := Lookup(code.Alpha); !ok || alpha.Code != code.Code {
+				testHelper.Errorf("Expected %s to name code %s", code.Alpha, code.Code)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/edinstance/distributed-aviation-system/services/flights/internal/airports"
 	"github.com/edinstance/distributed-aviation-system/services/flights/internal/exceptions"
 )
 
@@ -14,13 +15,17 @@ var (
 
 // ValidateAndNormalizeIATACode normalises an IATA airport code by trimming
 // surrounding whitespace and converting to upper-case, then validates it must
-// consist of three ASCII letters. On success it returns the normalised code.
-// If the code is not a valid 3‑letter IATA code it returns an empty string and
-// exceptions.ErrInvalidIATACode.
+// consist of three ASCII letters naming an airport in the airports dataset. On
+// success it returns the normalised code. If the code is not a valid 3‑letter IATA
+// code it returns an empty string and exceptions.ErrInvalidIATACode, and if no known
+// airport has the code, exceptions.ErrUnknownAirport.
 func ValidateAndNormalizeIATACode(code string) (string, error) {
 	normalized := strings.ToUpper(strings.TrimSpace(code))
 	if !iataCodeRegex.MatchString(normalized) {
 		return "", exceptions.ErrInvalidIATACode
 	}
+	if _, ok := airports.Lookup(normalized); !ok {
+		return "", exceptions.UnknownAirport(normalized)
+	}
 	return normalized, nil
 }
@@ -19,6 +19,8 @@ func TestValidateAndNormalizeIATACode(testHelper *testing.T) {
 		{"ERROR", "", exceptions.ErrInvalidIATACode},
 		{"123", "", exceptions.ErrInvalidIATACode},
 		{"123456", "", exceptions.ErrInvalidIATACode},
+		{"ZZZ", "", exceptions.ErrUnknownAirport},
+		{" jfk ", "JFK", nil},
 	}
 
 	for _, testCase := range testCases {
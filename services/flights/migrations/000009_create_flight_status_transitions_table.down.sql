@@ -0,0 +1 @@
+DROP TABLE IF EXISTS flight_status_transitions;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS flight_status_transitions (
+    id              UUID PRIMARY KEY NOT NULL,
+    flight_id       UUID        NOT NULL REFERENCES flights (id) ON DELETE CASCADE,
+    from_status     VARCHAR(20) NOT NULL,
+    to_status       VARCHAR(20) NOT NULL,
+    reason          TEXT,
+    changed_by      UUID        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_flight_status_transitions_flight ON flight_status_transitions (flight_id, created_at);
\ No newline at end of file
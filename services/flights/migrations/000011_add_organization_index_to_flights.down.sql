@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_flights_organization_departure;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_flights_organization_departure ON flights (organization_id, departure_time, id);
\ No newline at end of file
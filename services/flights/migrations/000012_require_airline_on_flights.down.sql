@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_flights_organization_airline;
+
+CREATE INDEX IF NOT EXISTS idx_airline ON flights (airline);
+
+ALTER TABLE flights
+    ALTER COLUMN airline SET DEFAULT 'System',
+    ALTER COLUMN airline DROP NOT NULL;
\ No newline at end of file
@@ -0,0 +1,9 @@
+UPDATE flights SET airline = 'System' WHERE airline IS NULL;
+
+ALTER TABLE flights
+    ALTER COLUMN airline SET NOT NULL,
+    ALTER COLUMN airline DROP DEFAULT;
+
+DROP INDEX IF EXISTS idx_airline;
+
+CREATE INDEX IF NOT EXISTS idx_flights_organization_airline ON flights (organization_id, airline);
\ No newline at end of file
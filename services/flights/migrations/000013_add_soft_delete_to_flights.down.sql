@@ -0,0 +1,9 @@
+DELETE FROM flights WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS unique_flight_instance;
+
+ALTER TABLE flights
+    ADD CONSTRAINT unique_flight_instance UNIQUE (number, departure_time);
+
+ALTER TABLE flights
+    DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
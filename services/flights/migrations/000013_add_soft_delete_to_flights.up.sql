@@ -0,0 +1,7 @@
+ALTER TABLE flights
+    ADD COLUMN deleted_at TIMESTAMPTZ;
+
+ALTER TABLE flights
+    DROP CONSTRAINT IF EXISTS unique_flight_instance;
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_flight_instance ON flights (number, departure_time) WHERE deleted_at IS NULL;
\ No newline at end of file
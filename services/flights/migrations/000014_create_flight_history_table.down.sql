@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS flight_history;
+DROP FUNCTION IF EXISTS reject_flight_history_change();
\ No newline at end of file
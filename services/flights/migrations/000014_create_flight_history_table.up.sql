@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS flight_history (
+    id              UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+    flight_id       UUID        NOT NULL REFERENCES flights (id),
+    organization_id UUID        NOT NULL,
+    operation       VARCHAR(30) NOT NULL,
+    actor_id        UUID        NOT NULL,
+    before          JSONB,
+    after           JSONB       NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_flight_history_flight ON flight_history (flight_id, created_at, id);
+
+-- History rows are an audit trail, so they can only ever be inserted.
+CREATE OR REPLACE FUNCTION reject_flight_history_change()
+    RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'flight_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER flight_history_append_only
+    BEFORE UPDATE OR DELETE ON flight_history
+    FOR EACH ROW EXECUTE FUNCTION reject_flight_history_change();
\ No newline at end of file
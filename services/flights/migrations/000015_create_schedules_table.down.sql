@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS unique_schedule_instance;
+
+ALTER TABLE flights
+    DROP COLUMN IF EXISTS schedule_version,
+    DROP COLUMN IF EXISTS schedule_date,
+    DROP COLUMN IF EXISTS schedule_id;
+
+DROP TABLE IF EXISTS schedules;
\ No newline at end of file
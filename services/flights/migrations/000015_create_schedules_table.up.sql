@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS schedules (
+    id                   UUID PRIMARY KEY NOT NULL,
+    number               VARCHAR(20) NOT NULL,
+    origin               VARCHAR(3)  NOT NULL,
+    destination          VARCHAR(3)  NOT NULL,
+    departure_local_time TIME        NOT NULL,
+    time_zone            VARCHAR(64) NOT NULL,
+    duration_minutes     INTEGER     NOT NULL CHECK (duration_minutes > 0),
+    -- Bit n set means the schedule operates on weekday n, counting Sunday as 0.
+    days_of_week         SMALLINT    NOT NULL CHECK (days_of_week BETWEEN 1 AND 127),
+    start_date           DATE        NOT NULL,
+    end_date             DATE        NOT NULL,
+    aircraft_id          UUID        NOT NULL,
+    organization_id      UUID        NOT NULL,
+    airline              VARCHAR(100) NOT NULL,
+    created_by           UUID        NOT NULL,
+    last_updated_by      UUID        NOT NULL,
+    version              INTEGER     NOT NULL DEFAULT 1,
+    materialized_through DATE,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT schedules_dates_ordered CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedules_end_date ON schedules (end_date, id);
+
+CREATE TRIGGER set_updated_date_schedules
+    BEFORE UPDATE ON schedules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_date();
+
+ALTER TABLE flights
+    ADD COLUMN schedule_id      UUID REFERENCES schedules (id),
+    ADD COLUMN schedule_date    DATE,
+    ADD COLUMN schedule_version INTEGER;
+
+-- One instance per schedule and operating day, deleted or not, so the materializer
+-- never recreates an instance that was deleted.
+CREATE UNIQUE INDEX IF NOT EXISTS unique_schedule_instance ON flights (schedule_id, schedule_date) WHERE schedule_id IS NOT NULL;
\ No newline at end of file
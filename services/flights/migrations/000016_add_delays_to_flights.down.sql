@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS flight_delays;
+
+ALTER TABLE flights
+    DROP COLUMN IF EXISTS delay_code,
+    DROP COLUMN IF EXISTS actual_arrival_time,
+    DROP COLUMN IF EXISTS actual_departure_time,
+    DROP COLUMN IF EXISTS estimated_arrival_time,
+    DROP COLUMN IF EXISTS estimated_departure_time;
\ No newline at end of file
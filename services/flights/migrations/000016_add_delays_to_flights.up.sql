@@ -0,0 +1,23 @@
+ALTER TABLE flights
+    ADD COLUMN estimated_departure_time TIMESTAMPTZ,
+    ADD COLUMN estimated_arrival_time   TIMESTAMPTZ,
+    ADD COLUMN actual_departure_time    TIMESTAMPTZ,
+    ADD COLUMN actual_arrival_time      TIMESTAMPTZ,
+    -- The IATA (AHM 730) code of the most recent delay.
+    ADD COLUMN delay_code               VARCHAR(2);
+
+CREATE TABLE IF NOT EXISTS flight_delays (
+    id                       UUID PRIMARY KEY NOT NULL,
+    flight_id                UUID        NOT NULL REFERENCES flights (id) ON DELETE CASCADE,
+    code                     VARCHAR(2)  NOT NULL,
+    minutes                  INTEGER     NOT NULL CHECK (minutes > 0),
+    estimated_departure_time TIMESTAMPTZ NOT NULL,
+    estimated_arrival_time   TIMESTAMPTZ NOT NULL,
+    remarks                  TEXT,
+    -- Set when the delay was carried forward from the late arrival of another flight.
+    caused_by_flight_id      UUID REFERENCES flights (id),
+    reported_by              UUID        NOT NULL,
+    created_at               TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_flight_delays_flight ON flight_delays (flight_id, created_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE flights
+    DROP COLUMN IF EXISTS actual_landing_time,
+    DROP COLUMN IF EXISTS actual_takeoff_time;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- With actual_departure_time and actual_arrival_time these are the Out, Off, On and In
+-- (OOOI) movement times.
+ALTER TABLE flights
+    ADD COLUMN actual_takeoff_time TIMESTAMPTZ,
+    ADD COLUMN actual_landing_time TIMESTAMPTZ;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Set once an event has exhausted its delivery attempts. Dead events are no longer
+-- retried and no longer hold back later events for the same aggregate.
+ALTER TABLE outbox ADD COLUMN dead_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (aggregate_id, id) WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_dead ON outbox (id) WHERE dead_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_schedules_aircraft_id;
+
+-- Fails while any schedule is without an aircraft; assign one first.
+ALTER TABLE schedules ALTER COLUMN aircraft_id SET NOT NULL;
@@ -0,0 +1,5 @@
+-- A schedule loses its aircraft when the aircraft is grounded or retired, and its
+-- flights are then materialized without one until it is given another.
+ALTER TABLE schedules ALTER COLUMN aircraft_id DROP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_schedules_aircraft_id ON schedules (aircraft_id) WHERE aircraft_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outbox_unbroadcast;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS broadcast_at;
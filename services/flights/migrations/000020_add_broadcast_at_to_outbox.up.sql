@@ -0,0 +1,6 @@
+-- Set once an event's flight has been published to subscribers, independently of
+-- delivery to Kafka. Events written before the column existed are not replayed.
+ALTER TABLE outbox ADD COLUMN broadcast_at TIMESTAMPTZ;
+UPDATE outbox SET broadcast_at = NOW();
+
+CREATE INDEX IF NOT EXISTS idx_outbox_unbroadcast ON outbox (id) WHERE broadcast_at IS NULL;
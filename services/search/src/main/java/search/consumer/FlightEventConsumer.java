@@ -27,6 +27,12 @@ public class FlightEventConsumer {
   @KafkaListener(topics = "flights", groupId = "search-service")
   public void handleFlightCreated(GenericRecord flightCreated) {
     try {
+      if (!"FlightCreated".equals(flightCreated.getSchema().getName())) {
+        SearchLogger.info("Skipping {} event, only FlightCreated is indexed",
+                flightCreated.getSchema().getName());
+        return;
+      }
+
       SearchLogger.info("Received FlightCreated event with schema: {} and fields: {}",
               flightCreated.getSchema().getName(),
               flightCreated.getSchema().getFields().stream().map(Schema.Field::name).toList());